@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/matheushermes/wedding_planner_service/configs"
+	_ "github.com/matheushermes/wedding_planner_service/init"
+	"github.com/matheushermes/wedding_planner_service/internal/anonymize"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// anonymize clona a base de produção para o ambiente atual (staging) com todos os dados pessoais
+// substituídos por dados falsos realistas. IDs e chaves estrangeiras são preservados para depuração.
+// O destino é o DATABASE_URL do ambiente e é recriado por completo.
+// Depois da cópia, rode o pii-backfill em staging para criptografar os contatos falsos.
+func main() {
+	source := flag.String("source", os.Getenv("ANONYMIZE_SOURCE_DSN"), "DSN (somente leitura) da base de origem")
+	batchSize := flag.Int("batch", 500, "linhas copiadas por lote")
+	flag.Parse()
+
+	// Segurança: o destino é sempre recriado, nunca rode contra produção
+	if configs.ENV == "production" {
+		log.Fatal("❌ anonymize não pode rodar com ENV=production (o destino seria apagado)")
+	}
+	if *source == "" {
+		log.Fatal("❌ Informe a base de origem com -source ou ANONYMIZE_SOURCE_DSN")
+	}
+	if configs.DATABASE_URL == "" {
+		log.Fatal("❌ DATABASE_URL não configurada")
+	}
+
+	sourceCfg, err := gomysql.ParseDSN(*source)
+	if err != nil {
+		log.Fatalf("❌ DSN de origem inválida: %v", err)
+	}
+	targetCfg, err := gomysql.ParseDSN(configs.DATABASE_URL)
+	if err != nil {
+		log.Fatalf("❌ DATABASE_URL inválida: %v", err)
+	}
+	if sourceCfg.Addr == targetCfg.Addr && sourceCfg.DBName == targetCfg.DBName {
+		log.Fatal("❌ Origem e destino apontam para o mesmo schema")
+	}
+
+	cfg := &gorm.Config{Logger: logger.Default.LogMode(logger.Warn)}
+	sourceDB, err := gorm.Open(mysql.Open(*source), cfg)
+	if err != nil {
+		log.Fatalf("❌ Erro ao conectar na origem: %v", err)
+	}
+	targetDB, err := gorm.Open(mysql.Open(configs.DATABASE_URL), cfg)
+	if err != nil {
+		log.Fatalf("❌ Erro ao conectar no destino: %v", err)
+	}
+
+	log.Printf("🔄 Clonando %s para %s com dados anonimizados...", configs.MaskDSN(*source), configs.MaskDSN(configs.DATABASE_URL))
+	if err := anonymize.Copy(context.Background(), sourceDB, targetDB, anonymize.Options{BatchSize: *batchSize}); err != nil {
+		log.Fatalf("❌ Cópia anonimizada abortada: %v", err)
+	}
+
+	log.Printf("✅ Cópia anonimizada concluída (senha de todas as contas: %q)", anonymize.StagingPassword)
+	log.Println("ℹ️  Rode o pii-backfill para criptografar os contatos dos convidados")
+}
@@ -1,11 +1,26 @@
 package main
 
 import (
+	"context"
 	"log"
 
+	"github.com/matheushermes/wedding_planner_service/configs"
 	_ "github.com/matheushermes/wedding_planner_service/init"
+	"github.com/matheushermes/wedding_planner_service/internal/announcements"
+	"github.com/matheushermes/wedding_planner_service/internal/asyncjobs"
+	"github.com/matheushermes/wedding_planner_service/internal/backup"
 	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/funnel"
+	"github.com/matheushermes/wedding_planner_service/internal/jobs"
+	"github.com/matheushermes/wedding_planner_service/internal/lifecycle"
+	"github.com/matheushermes/wedding_planner_service/internal/mailer"
+	"github.com/matheushermes/wedding_planner_service/internal/payments"
+	"github.com/matheushermes/wedding_planner_service/internal/photos"
+	"github.com/matheushermes/wedding_planner_service/internal/printing"
+	"github.com/matheushermes/wedding_planner_service/internal/routing"
+	"github.com/matheushermes/wedding_planner_service/internal/selfcheck"
 	"github.com/matheushermes/wedding_planner_service/internal/server"
+	"github.com/matheushermes/wedding_planner_service/internal/sms"
 )
 
 func main() {
@@ -14,6 +29,56 @@ func main() {
 	// Inicializa banco de dados
 	database.InitializeDatabase()
 
+	// Registra provedores de pagamento configurados
+	payments.Setup()
+
+	// Registra o provedor de emails (MAIL_PROVIDER)
+	mailer.Setup()
+
+	// Registra o provedor de SMS (SMS_PROVIDER), usado no fallback dos convites por WhatsApp
+	sms.Setup()
+
+	// Verifica schema, credenciais e armazenamento antes de aceitar requests
+	log.Println("🔍 Executando verificações de inicialização...")
+	if err := selfcheck.Run(context.Background()); err != nil {
+		log.Fatalf("❌ Falha nas verificações de inicialização:\n%v", err)
+	}
+
+	// Aplica credenciais rotacionadas no backend de segredos
+	configs.StartSecretsRefresh()
+
+	// Registra a verificação diária de backups
+	backup.Setup()
+
+	// Registra as campanhas de emails de ciclo de vida (LIFECYCLE_EMAILS_ENABLED)
+	lifecycle.Setup()
+
+	// Registra os lembretes anuais de bodas (opt-in por casamento)
+	lifecycle.SetupAnniversaryReminders()
+
+	// Registra a geração dos ZIPs do portal de fotos
+	photos.Setup()
+
+	// Registra os provedores de impressão e o acompanhamento dos lotes de convites impressos
+	printing.Setup()
+
+	// Registra a API de rotas usada no tempo de deslocamento entre cerimônia e recepção
+	routing.Setup()
+
+	// Registra a consolidação diária do funil dos casais (métricas de produto)
+	funnel.Setup()
+
+	// Registra a fila das operações longas pedidas pela API (exportações, importações, envios em massa)
+	asyncjobs.Setup()
+
+	// Registra o envio por email dos comunicados da administração
+	announcements.Setup()
+
+	// Inicia jobs agendados (seguros para múltiplas réplicas)
+	if err := jobs.Start(database.DB); err != nil {
+		log.Fatalf("❌ Erro ao iniciar jobs agendados: %v", err)
+	}
+
 	// Cria servidor
 	appServer := server.NewServer()
 
@@ -21,4 +86,4 @@ func main() {
 	if err := appServer.RunServer(); err != nil {
 		log.Fatalf("❌ Erro fatal: %v", err)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	_ "github.com/matheushermes/wedding_planner_service/init"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+)
+
+// migrate aplica as migrações em modo seguro (produção não migra automaticamente no boot)
+// Deploy blue/green: rode sem flags antes do deploy (expand) e com -allow-destructive
+// apenas depois que todas as réplicas estiverem na versão nova (contract)
+func main() {
+	dryRun := flag.Bool("dry-run", false, "apenas lista as mudanças, sem aplicar")
+	allowDestructive := flag.Bool("allow-destructive", false, "aplica mudanças destrutivas e passos de contract")
+	flag.Parse()
+
+	if err := database.ConnectDB(); err != nil {
+		log.Fatalf("❌ Erro fatal ao conectar ao banco: %v", err)
+	}
+	defer database.CloseDatabase()
+
+	plan, err := database.MigrateSafe(database.SafeMigrateOptions{
+		AllowDestructive: *allowDestructive,
+		DryRun:           *dryRun,
+	}, database.Models()...)
+
+	if plan != nil {
+		for _, c := range plan.Changes {
+			log.Printf("  [%s] %s", c.Kind, c.SQL)
+		}
+		log.Printf("ℹ️  %d mudança(s) planejada(s), %d destrutiva(s)", len(plan.Changes), len(plan.Destructive()))
+	}
+	if err != nil {
+		log.Fatalf("❌ Migração abortada: %v", err)
+	}
+
+	if *dryRun {
+		log.Println("✅ Dry run concluído, nenhuma mudança aplicada")
+		return
+	}
+	log.Println("✅ Migrações concluídas!")
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	_ "github.com/matheushermes/wedding_planner_service/init"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// pii-backfill criptografa telefone/email de convidados gravados em texto puro e recalcula os hashes de busca
+// Também preenche o telefone em E.164 dos convidados cadastrados antes da normalização
+// Também recriptografa com a chave atual após uma rotação de PII_ENCRYPTION_KEY (idempotente)
+func main() {
+	batchSize := flag.Int("batch", 500, "convidados processados por lote")
+	flag.Parse()
+
+	database.InitializeDatabase()
+	defer database.CloseDatabase()
+
+	var updated int
+	var guests []models.Guest
+
+	// Unscoped: convidados removidos (soft delete) também guardam dados pessoais
+	db := database.DB.Unscoped()
+	result := db.FindInBatches(&guests, *batchSize, func(_ *gorm.DB, batch int) error {
+		for i := range guests {
+			g := &guests[i]
+			g.RefreshPIIHashes()
+			// Convidados anteriores à normalização: números inválidos ficam sem E.164 até o casal corrigir
+			if g.PhoneE164 == "" {
+				if e164, err := models.NormalizePhone(g.Phone, models.DefaultPhoneCountry()); err == nil {
+					g.PhoneE164 = e164
+				}
+			}
+
+			// UpdateColumns: não altera updated_at nem dispara hooks
+			err := db.Model(g).
+				Select("phone", "phone_e164", "email", "phone_hash", "email_hash").
+				UpdateColumns(g).Error
+			if err != nil {
+				return err
+			}
+		}
+		updated += len(guests)
+		log.Printf("  ✅ Lote %d: %d convidados processados", batch, len(guests))
+		return nil
+	})
+	if result.Error != nil {
+		log.Fatalf("❌ Erro no backfill de PII após %d convidados: %v", updated, result.Error)
+	}
+
+	log.Printf("✅ Backfill de PII concluído: %d convidados", updated)
+}
@@ -1,11 +1,13 @@
 package configs
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -18,7 +20,65 @@ var (
 	MAX_DB_CONNS       int
 	READ_TIMEOUT_SECS  int
 	WRITE_TIMEOUT_SECS int
-	JWT_SECRET         []byte
+	MIGRATION_MODE     string
+	UPLOAD_DIR         string
+
+	// Administração: token exigido nos endpoints /admin (sem token, desabilitados)
+	ADMIN_TOKEN string
+
+	// Backups lógicos por tenant: destino (file ou s3) e schema descartável para verificação
+	BACKUP_STORE       string
+	BACKUP_DIR         string
+	BACKUP_S3_BUCKET   string
+	BACKUP_S3_REGION   string
+	BACKUP_S3_ENDPOINT string
+	BACKUP_SCRATCH_DSN string
+
+	// Pagamentos: provedor padrão (credenciais em CurrentSecrets)
+	PAYMENT_PROVIDER string
+
+	// Convites impressos: provedor de impressão e postagem e custo por peça (moeda do casamento)
+	PRINT_PROVIDER   string
+	PRINT_UNIT_PRICE float64
+
+	// URL pública do serviço, usada nos links enviados por email
+	PUBLIC_BASE_URL string
+
+	// Emails transacionais: provedor (log, smtp, sendgrid ou ses), remetente e servidor SMTP
+	// Senha SMTP e chave da SendGrid em CurrentSecrets; SES usa AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY
+	MAIL_PROVIDER string
+	MAIL_FROM     string
+	SMTP_HOST     string
+	SMTP_PORT     int
+	SMTP_USERNAME string
+	SES_REGION    string
+
+	// Chave pública (PEM ou base64) que verifica os eventos de entrega da SendGrid; vazia recusa o webhook
+	SENDGRID_WEBHOOK_PUBLIC_KEY string
+
+	// SMS (fallback dos convites por WhatsApp): provedor (log ou twilio), conta e número remetente do serviço
+	// Auth token da Twilio em CurrentSecrets
+	SMS_PROVIDER           string
+	SMS_FROM               string
+	TWILIO_SMS_ACCOUNT_SID string
+
+	// País (ISO 3166-1 alfa-2) dos telefones digitados sem DDI
+	DEFAULT_PHONE_COUNTRY string
+
+	// Emails de ciclo de vida: liga/desliga, limite semanal por usuário e regras desativadas
+	LIFECYCLE_EMAILS_ENABLED      bool
+	LIFECYCLE_MAX_EMAILS_PER_WEEK int
+	LIFECYCLE_DISABLED_RULES      []string
+
+	// Programa de indicação: dias de Pro por indicação convertida e máximo de recompensas por usuário
+	REFERRAL_REWARD_DAYS int
+	REFERRAL_MAX_REWARDS int
+
+	// Tokens JWT por tipo de cliente (audience): validade do token de acesso e do refresh token (0 = sem refresh)
+	JWT_WEB_TTL            time.Duration
+	JWT_MOBILE_TTL         time.Duration
+	JWT_MOBILE_REFRESH_TTL time.Duration
+	JWT_ADMIN_TTL          time.Duration
 )
 
 // LoadEnv carrega e valida variáveis de ambiente
@@ -43,8 +103,20 @@ func LoadEnv() {
 		log.Println("⚠️  GIN_MODE alterado para 'release' em ambiente de produção")
 	}
 
+	// Segredos: variáveis de ambiente, arquivos montados ou Vault (SECRETS_BACKEND)
+	var err error
+	if backend, err = newSecretsBackend(); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	values, err := loadSecrets(ctx)
+	cancel()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
 	// Database URL - CRÍTICO
-	DATABASE_URL = os.Getenv("DATABASE_URL")
+	DATABASE_URL = values["DATABASE_URL"]
 	if DATABASE_URL == "" {
 		log.Fatal("❌ DATABASE_URL não definida")
 	}
@@ -55,17 +127,132 @@ func LoadEnv() {
 	}
 
 	// JWT Secret - CRÍTICO
-	JWT_SECRET = []byte(os.Getenv("JWT_SECRET"))
-	if len(JWT_SECRET) == 0 {
+	if values["JWT_SECRET"] == "" {
 		log.Fatal("❌ JWT_SECRET não definida")
 	}
 
+	// Criptografia de dados pessoais - CRÍTICO em produção
+	piiKey, err := decodePIIKey(values["PII_ENCRYPTION_KEY"])
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if len(piiKey) == 0 {
+		if ENV == "production" {
+			log.Fatal("❌ PII_ENCRYPTION_KEY não definida")
+		}
+		log.Println("⚠️  PII_ENCRYPTION_KEY não definida, dados pessoais serão gravados sem criptografia")
+	}
+
+	// Pagamentos: credenciais de cada PSP
+	secrets.Store(&Secrets{
+		JWTSecret:           []byte(values["JWT_SECRET"]),
+		StripeSecretKey:     values["STRIPE_SECRET_KEY"],
+		StripeWebhookSecret: values["STRIPE_WEBHOOK_SECRET"],
+		LobAPIKey:           values["LOB_API_KEY"],
+		GoogleMapsAPIKey:    values["GOOGLE_MAPS_API_KEY"],
+		SMTPPassword:        values["SMTP_PASSWORD"],
+		SendGridAPIKey:      values["SENDGRID_API_KEY"],
+		TwilioSMSAuthToken:  values["TWILIO_SMS_AUTH_TOKEN"],
+		PIIKey:              piiKey,
+	})
+
 	// Configurações de performance
 	MAX_DB_CONNS = getEnvInt("MAX_DB_CONNS", 100)
 	READ_TIMEOUT_SECS = getEnvInt("READ_TIMEOUT_SECS", 30)
 	WRITE_TIMEOUT_SECS = getEnvInt("WRITE_TIMEOUT_SECS", 30)
 
-	log.Printf("✅ Configurações carregadas: ENV=%s, PORT=%s, GIN_MODE=%s", ENV, PORT, GIN_MODE)
+	// Migrações: "auto" aplica tudo, "safe" recusa mudanças destrutivas (deploys blue/green)
+	MIGRATION_MODE = getEnv("MIGRATION_MODE", "auto")
+	if MIGRATION_MODE != "auto" && MIGRATION_MODE != "safe" {
+		log.Fatalf("❌ MIGRATION_MODE inválido: %s (use auto ou safe)", MIGRATION_MODE)
+	}
+
+	// Configurações recarregáveis em execução (SIGHUP ou POST /admin/config/reload)
+	runtime.Store(loadRuntime())
+
+	// Uploads
+	UPLOAD_DIR = getEnv("UPLOAD_DIR", "./uploads")
+
+	ADMIN_TOKEN = os.Getenv("ADMIN_TOKEN")
+
+	// Backups (credenciais S3 via AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY)
+	BACKUP_STORE = getEnv("BACKUP_STORE", "file")
+	BACKUP_DIR = getEnv("BACKUP_DIR", "./backups")
+	BACKUP_S3_BUCKET = os.Getenv("BACKUP_S3_BUCKET")
+	BACKUP_S3_REGION = getEnv("BACKUP_S3_REGION", "us-east-1")
+	BACKUP_S3_ENDPOINT = os.Getenv("BACKUP_S3_ENDPOINT") // opcional, ex: MinIO
+	BACKUP_SCRATCH_DSN = os.Getenv("BACKUP_SCRATCH_DSN") // sem DSN, a verificação agendada fica desabilitada
+
+	// Pagamentos: provedor padrão
+	PAYMENT_PROVIDER = os.Getenv("PAYMENT_PROVIDER")
+
+	// Convites impressos: o custo por peça vem do contrato com o provedor (a API não informa preço)
+	PRINT_PROVIDER = os.Getenv("PRINT_PROVIDER")
+	PRINT_UNIT_PRICE = getEnvFloat("PRINT_UNIT_PRICE", 0)
+
+	PUBLIC_BASE_URL = strings.TrimRight(getEnv("PUBLIC_BASE_URL", "http://localhost:"+PORT), "/")
+
+	// Emails: sem provedor as mensagens são apenas registradas no log
+	MAIL_PROVIDER = strings.ToLower(getEnv("MAIL_PROVIDER", "log"))
+	switch MAIL_PROVIDER {
+	case "log", "smtp", "sendgrid", "ses":
+	default:
+		log.Fatalf("❌ MAIL_PROVIDER inválido: %s (use log, smtp, sendgrid ou ses)", MAIL_PROVIDER)
+	}
+	MAIL_FROM = os.Getenv("MAIL_FROM") // ex: Casamento <convites@exemplo.com>
+	if MAIL_PROVIDER != "log" && MAIL_FROM == "" {
+		log.Fatal("❌ MAIL_FROM é obrigatório com MAIL_PROVIDER=" + MAIL_PROVIDER)
+	}
+	if MAIL_PROVIDER == "log" && ENV == "production" {
+		log.Println("⚠️  MAIL_PROVIDER não definido, convites por email não serão enviados")
+	}
+	SMTP_HOST = os.Getenv("SMTP_HOST")
+	SMTP_PORT = getEnvInt("SMTP_PORT", 587) // 465 usa TLS implícito, as demais STARTTLS
+	SMTP_USERNAME = os.Getenv("SMTP_USERNAME")
+	SES_REGION = getEnv("SES_REGION", "us-east-1")
+	SENDGRID_WEBHOOK_PUBLIC_KEY = os.Getenv("SENDGRID_WEBHOOK_PUBLIC_KEY")
+
+	// SMS: sem provedor as mensagens são apenas registradas no log
+	SMS_PROVIDER = strings.ToLower(getEnv("SMS_PROVIDER", "log"))
+	switch SMS_PROVIDER {
+	case "log", "twilio":
+	default:
+		log.Fatalf("❌ SMS_PROVIDER inválido: %s (use log ou twilio)", SMS_PROVIDER)
+	}
+	SMS_FROM = os.Getenv("SMS_FROM") // E.164, ex: +5511900000000
+	TWILIO_SMS_ACCOUNT_SID = os.Getenv("TWILIO_SMS_ACCOUNT_SID")
+	if SMS_PROVIDER == "twilio" && (SMS_FROM == "" || TWILIO_SMS_ACCOUNT_SID == "") {
+		log.Fatal("❌ SMS_FROM e TWILIO_SMS_ACCOUNT_SID são obrigatórios com SMS_PROVIDER=twilio")
+	}
+
+	DEFAULT_PHONE_COUNTRY = strings.ToUpper(getEnv("DEFAULT_PHONE_COUNTRY", "BR"))
+	if len(DEFAULT_PHONE_COUNTRY) != 2 {
+		log.Fatal("❌ DEFAULT_PHONE_COUNTRY inválido. Use o código ISO do país (ex: BR)")
+	}
+
+	// Emails de ciclo de vida (desligados por padrão)
+	LIFECYCLE_EMAILS_ENABLED = os.Getenv("LIFECYCLE_EMAILS_ENABLED") == "true"
+	LIFECYCLE_MAX_EMAILS_PER_WEEK = getEnvInt("LIFECYCLE_MAX_EMAILS_PER_WEEK", 2)
+	for _, rule := range strings.Split(os.Getenv("LIFECYCLE_DISABLED_RULES"), ",") {
+		if rule = strings.TrimSpace(rule); rule != "" {
+			LIFECYCLE_DISABLED_RULES = append(LIFECYCLE_DISABLED_RULES, rule)
+		}
+	}
+
+	// Programa de indicação
+	REFERRAL_REWARD_DAYS = getEnvInt("REFERRAL_REWARD_DAYS", 30)
+	REFERRAL_MAX_REWARDS = getEnvInt("REFERRAL_MAX_REWARDS", 12)
+
+	// Tokens: web curto (navegador compartilhado), mobile mais longo com refresh, painel administrativo mais curto
+	JWT_WEB_TTL = time.Duration(getEnvInt("JWT_WEB_TTL_MINUTES", 480)) * time.Minute
+	JWT_MOBILE_TTL = time.Duration(getEnvInt("JWT_MOBILE_TTL_MINUTES", 1440)) * time.Minute
+	JWT_MOBILE_REFRESH_TTL = time.Duration(getEnvInt("JWT_MOBILE_REFRESH_TTL_MINUTES", 43200)) * time.Minute
+	JWT_ADMIN_TTL = time.Duration(getEnvInt("JWT_ADMIN_TTL_MINUTES", 60)) * time.Minute
+	if JWT_WEB_TTL <= 0 || JWT_MOBILE_TTL <= 0 || JWT_ADMIN_TTL <= 0 || JWT_MOBILE_REFRESH_TTL < 0 {
+		log.Fatal("❌ Validade dos tokens JWT inválida (JWT_*_TTL_MINUTES)")
+	}
+
+	log.Printf("✅ Configurações carregadas: ENV=%s, PORT=%s, GIN_MODE=%s, SECRETS=%s", ENV, PORT, GIN_MODE, backend.Name())
 }
 
 // getEnv retorna variável de ambiente ou valor padrão
@@ -87,6 +274,17 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvFloat retorna variável de ambiente float ou valor padrão
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil && floatVal >= 0 {
+			return floatVal
+		}
+		log.Printf("⚠️  %s inválido, usando padrão: %v", key, defaultValue)
+	}
+	return defaultValue
+}
+
 // MaskDSN mascara credenciais da DSN para logs seguros
 func MaskDSN(dsn string) string {
 	if idx := strings.Index(dsn, "@"); idx > 0 {
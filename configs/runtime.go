@@ -0,0 +1,142 @@
+package configs
+
+import (
+	"errors"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/joho/godotenv"
+)
+
+// RuntimeSettings agrupa as configurações não críticas que podem ser recarregadas em execução
+// Configurações críticas (DSN, JWT, porta, credenciais) não mudam por aqui: credenciais seguem a rotação do backend de segredos
+type RuntimeSettings struct {
+	DBQueryTimeoutMS int
+	DBSlowQueryMS    int
+	MetricsToken     string
+	ClamAVAddr       string
+
+	// Limites de body por classe de rota (KB), aplicados pelo BodyLimitMiddleware
+	BodyLimitSmallKB   int // login e cadastro
+	BodyLimitDefaultKB int
+	BodyLimitImportKB  int // importação de agendas (.vcf)
+	BodyLimitUploadKB  int // fotos enviadas em multipart
+
+	// Timeouts por classe de rota (ms), aplicados pelo TimeoutMiddleware; 0 desativa a classe
+	RouteTimeoutDefaultMS int
+	RouteTimeoutLongMS    int // importações de agenda, chamadas a provedores externos e backups
+
+	// Envio de convites em massa: workers por envio e mensagens por segundo em cada provedor
+	InviteBulkWorkers        int
+	InviteEmailRatePerSec    int // compartilhado por todos os casamentos (provedor de email da plataforma)
+	InviteWhatsAppRatePerSec int // por conta de WhatsApp do casal
+}
+
+// Concorrência: snapshot imutável trocado atomicamente, leituras nos handlers não precisam de lock
+var runtime atomic.Pointer[RuntimeSettings]
+
+// Serializa recargas concorrentes (SIGHUP e endpoint administrativo)
+var reloadMu sync.Mutex
+
+// Runtime retorna o snapshot atual das configurações recarregáveis
+func Runtime() RuntimeSettings {
+	if s := runtime.Load(); s != nil {
+		return *s
+	}
+	return RuntimeSettings{}
+}
+
+// loadRuntime lê as configurações recarregáveis das variáveis de ambiente
+func loadRuntime() *RuntimeSettings {
+	return &RuntimeSettings{
+		DBQueryTimeoutMS: getEnvInt("DB_QUERY_TIMEOUT_MS", 5000),
+		DBSlowQueryMS:    getEnvInt("DB_SLOW_QUERY_MS", 200),
+
+		// Métricas: token exigido em /debug/vars (sem token, disponível apenas fora de produção)
+		MetricsToken: os.Getenv("METRICS_TOKEN"),
+
+		// Antivírus opcional para uploads, ex: localhost:3310
+		ClamAVAddr: os.Getenv("CLAMAV_ADDR"),
+
+		// Imports e uploads: limite do arquivo + margem para o envelope multipart
+		BodyLimitSmallKB:   getEnvInt("BODY_LIMIT_SMALL_KB", 16),
+		BodyLimitDefaultKB: getEnvInt("BODY_LIMIT_DEFAULT_KB", 1024),
+		BodyLimitImportKB:  getEnvInt("BODY_LIMIT_IMPORT_KB", 11*1024),
+		BodyLimitUploadKB:  getEnvInt("BODY_LIMIT_UPLOAD_KB", 11*1024),
+
+		// Rotas longas estendem o WRITE_TIMEOUT_SECS do servidor para a própria requisição
+		RouteTimeoutDefaultMS: getEnvInt("ROUTE_TIMEOUT_DEFAULT_MS", 15000),
+		RouteTimeoutLongMS:    getEnvInt("ROUTE_TIMEOUT_LONG_MS", 120000),
+
+		InviteBulkWorkers:        getEnvInt("INVITE_BULK_WORKERS", 4),
+		InviteEmailRatePerSec:    getEnvInt("INVITE_EMAIL_RATE_PER_SEC", 10),
+		InviteWhatsAppRatePerSec: getEnvInt("INVITE_WHATSAPP_RATE_PER_SEC", 1),
+	}
+}
+
+// Reload relê as configurações não críticas e retorna as chaves alteradas
+// Em desenvolvimento o .env é relido; mudanças em configurações críticas são ignoradas com aviso
+func Reload() ([]string, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	if ENV != "production" {
+		if err := godotenv.Overload(".env"); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	if backend.Name() == "env" && (os.Getenv("DATABASE_URL") != DATABASE_URL || os.Getenv("JWT_SECRET") != string(CurrentSecrets().JWTSecret)) {
+		log.Println("[WARN] DATABASE_URL/JWT_SECRET alterados: configurações críticas exigem reinício e foram ignoradas")
+	}
+
+	old := Runtime()
+	next := loadRuntime()
+
+	var changed []string
+	if old.DBQueryTimeoutMS != next.DBQueryTimeoutMS {
+		changed = append(changed, "DB_QUERY_TIMEOUT_MS")
+	}
+	if old.DBSlowQueryMS != next.DBSlowQueryMS {
+		changed = append(changed, "DB_SLOW_QUERY_MS")
+	}
+	if old.MetricsToken != next.MetricsToken {
+		changed = append(changed, "METRICS_TOKEN")
+	}
+	if old.ClamAVAddr != next.ClamAVAddr {
+		changed = append(changed, "CLAMAV_ADDR")
+	}
+	if old.BodyLimitSmallKB != next.BodyLimitSmallKB {
+		changed = append(changed, "BODY_LIMIT_SMALL_KB")
+	}
+	if old.BodyLimitDefaultKB != next.BodyLimitDefaultKB {
+		changed = append(changed, "BODY_LIMIT_DEFAULT_KB")
+	}
+	if old.BodyLimitImportKB != next.BodyLimitImportKB {
+		changed = append(changed, "BODY_LIMIT_IMPORT_KB")
+	}
+	if old.BodyLimitUploadKB != next.BodyLimitUploadKB {
+		changed = append(changed, "BODY_LIMIT_UPLOAD_KB")
+	}
+	if old.RouteTimeoutDefaultMS != next.RouteTimeoutDefaultMS {
+		changed = append(changed, "ROUTE_TIMEOUT_DEFAULT_MS")
+	}
+	if old.RouteTimeoutLongMS != next.RouteTimeoutLongMS {
+		changed = append(changed, "ROUTE_TIMEOUT_LONG_MS")
+	}
+	if old.InviteBulkWorkers != next.InviteBulkWorkers {
+		changed = append(changed, "INVITE_BULK_WORKERS")
+	}
+	if old.InviteEmailRatePerSec != next.InviteEmailRatePerSec {
+		changed = append(changed, "INVITE_EMAIL_RATE_PER_SEC")
+	}
+	if old.InviteWhatsAppRatePerSec != next.InviteWhatsAppRatePerSec {
+		changed = append(changed, "INVITE_WHATSAPP_RATE_PER_SEC")
+	}
+
+	runtime.Store(next)
+	log.Printf("[INFO] Configurações recarregadas, alteradas: %v", changed)
+	return changed, nil
+}
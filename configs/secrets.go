@@ -0,0 +1,317 @@
+package configs
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Secrets agrupa as credenciais carregadas do backend de segredos
+// Mantém a chave JWT anterior para que tokens emitidos antes de uma rotação continuem válidos
+type Secrets struct {
+	JWTSecret           []byte
+	PreviousJWTSecret   []byte
+	StripeSecretKey     string
+	StripeWebhookSecret string
+	LobAPIKey           string
+	GoogleMapsAPIKey    string
+	SMTPPassword        string
+	SendGridAPIKey      string
+	TwilioSMSAuthToken  string
+
+	// Chave AES-256 dos dados pessoais criptografados (anterior mantida para leitura)
+	PIIKey         []byte
+	PreviousPIIKey []byte
+}
+
+// Chaves buscadas no backend de segredos (mesmos nomes das variáveis de ambiente)
+var secretKeys = []string{"DATABASE_URL", "JWT_SECRET", "STRIPE_SECRET_KEY", "STRIPE_WEBHOOK_SECRET", "LOB_API_KEY", "GOOGLE_MAPS_API_KEY", "SMTP_PASSWORD", "SENDGRID_API_KEY", "TWILIO_SMS_AUTH_TOKEN", "PII_ENCRYPTION_KEY"}
+
+// secretsBackend abstrai a origem dos segredos
+type secretsBackend interface {
+	Name() string
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+var (
+	// Concorrência: snapshot trocado atomicamente a cada rotação
+	secrets atomic.Pointer[Secrets]
+	backend secretsBackend
+
+	rotationMu    sync.Mutex
+	rotationHooks []func()
+)
+
+// CurrentSecrets retorna o snapshot atual das credenciais
+func CurrentSecrets() Secrets {
+	if s := secrets.Load(); s != nil {
+		return *s
+	}
+	return Secrets{}
+}
+
+// OnSecretsRotated registra uma função chamada após cada rotação de credenciais
+func OnSecretsRotated(fn func()) {
+	rotationMu.Lock()
+	defer rotationMu.Unlock()
+	rotationHooks = append(rotationHooks, fn)
+}
+
+// newSecretsBackend seleciona o backend via SECRETS_BACKEND
+// AWS Secrets Manager e GCP Secret Manager são suportados via backend "file",
+// montando os segredos como arquivos (Secrets Store CSI driver ou sidecar do provedor)
+func newSecretsBackend() (secretsBackend, error) {
+	switch name := getEnv("SECRETS_BACKEND", "env"); name {
+	case "env":
+		return envBackend{}, nil
+	case "file":
+		return fileBackend{dir: getEnv("SECRETS_DIR", "/run/secrets")}, nil
+	case "vault":
+		addr := strings.TrimSuffix(os.Getenv("VAULT_ADDR"), "/")
+		path := strings.Trim(os.Getenv("VAULT_SECRET_PATH"), "/")
+		if addr == "" || path == "" || os.Getenv("VAULT_TOKEN") == "" {
+			return nil, errors.New("VAULT_ADDR, VAULT_TOKEN e VAULT_SECRET_PATH são obrigatórios com SECRETS_BACKEND=vault")
+		}
+		return &vaultBackend{
+			addr:   addr,
+			path:   path,
+			token:  os.Getenv("VAULT_TOKEN"),
+			client: &http.Client{Timeout: 10 * time.Second},
+		}, nil
+	default:
+		return nil, fmt.Errorf("SECRETS_BACKEND inválido: %s (use env, file ou vault)", name)
+	}
+}
+
+// loadSecrets busca os segredos no backend configurado
+// Chaves ausentes no backend usam a variável de ambiente como fallback
+func loadSecrets(ctx context.Context) (map[string]string, error) {
+	values, err := backend.Fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar segredos (%s): %w", backend.Name(), err)
+	}
+
+	for _, key := range secretKeys {
+		if values[key] == "" {
+			values[key] = os.Getenv(key)
+		}
+	}
+	return values, nil
+}
+
+// StartSecretsRefresh relê periodicamente os segredos para aplicar rotações
+// Sem efeito com SECRETS_BACKEND=env (variáveis de ambiente não mudam em execução)
+func StartSecretsRefresh() {
+	if backend == nil || backend.Name() == "env" {
+		return
+	}
+
+	interval := time.Duration(getEnvInt("SECRETS_REFRESH_SECS", 300)) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			refreshSecrets()
+		}
+	}()
+}
+
+// refreshSecrets aplica credenciais rotacionadas e notifica os interessados
+func refreshSecrets() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	values, err := loadSecrets(ctx)
+	if err != nil {
+		log.Printf("[ERROR] Failed to refresh secrets: %v", err)
+		return
+	}
+
+	piiKey, err := decodePIIKey(values["PII_ENCRYPTION_KEY"])
+	if err != nil {
+		log.Printf("[WARN] %v, rotação ignorada", err)
+		return
+	}
+
+	old := CurrentSecrets()
+	next := &Secrets{
+		JWTSecret:           []byte(values["JWT_SECRET"]),
+		PreviousJWTSecret:   old.PreviousJWTSecret,
+		StripeSecretKey:     values["STRIPE_SECRET_KEY"],
+		StripeWebhookSecret: values["STRIPE_WEBHOOK_SECRET"],
+		LobAPIKey:           values["LOB_API_KEY"],
+		GoogleMapsAPIKey:    values["GOOGLE_MAPS_API_KEY"],
+		SMTPPassword:        values["SMTP_PASSWORD"],
+		SendGridAPIKey:      values["SENDGRID_API_KEY"],
+		TwilioSMSAuthToken:  values["TWILIO_SMS_AUTH_TOKEN"],
+		PIIKey:              piiKey,
+		PreviousPIIKey:      old.PreviousPIIKey,
+	}
+
+	// Segurança: nunca aceita credencial vazia vinda de uma leitura parcial do backend
+	if len(next.JWTSecret) == 0 {
+		log.Println("[WARN] JWT_SECRET vazio no backend de segredos, rotação ignorada")
+		return
+	}
+
+	changed := false
+	if string(next.JWTSecret) != string(old.JWTSecret) {
+		next.PreviousJWTSecret = old.JWTSecret
+		changed = true
+		log.Println("[SECURITY] JWT_SECRET rotacionado (chave anterior aceita apenas para verificação)")
+	}
+	if next.StripeSecretKey != old.StripeSecretKey || next.StripeWebhookSecret != old.StripeWebhookSecret {
+		changed = true
+		log.Println("[SECURITY] Credenciais da Stripe rotacionadas")
+	}
+	if next.LobAPIKey != old.LobAPIKey {
+		changed = true
+		log.Println("[SECURITY] Credenciais da Lob rotacionadas")
+	}
+	if next.GoogleMapsAPIKey != old.GoogleMapsAPIKey {
+		changed = true
+		log.Println("[SECURITY] Credenciais do Google Maps rotacionadas")
+	}
+	if next.SMTPPassword != old.SMTPPassword || next.SendGridAPIKey != old.SendGridAPIKey {
+		changed = true
+		log.Println("[SECURITY] Credenciais de email rotacionadas")
+	}
+	if next.TwilioSMSAuthToken != old.TwilioSMSAuthToken {
+		changed = true
+		log.Println("[SECURITY] Credenciais de SMS rotacionadas")
+	}
+	if len(next.PIIKey) == 0 && len(old.PIIKey) > 0 {
+		log.Println("[WARN] PII_ENCRYPTION_KEY vazio no backend de segredos, rotação ignorada")
+		return
+	}
+	if string(next.PIIKey) != string(old.PIIKey) {
+		next.PreviousPIIKey = old.PIIKey
+		changed = true
+		log.Println("[SECURITY] PII_ENCRYPTION_KEY rotacionada (execute o backfill de PII para recriptografar)")
+	}
+	if values["DATABASE_URL"] != DATABASE_URL {
+		log.Println("[WARN] DATABASE_URL rotacionada no backend de segredos: reinicie o serviço para aplicar")
+	}
+
+	if !changed {
+		return
+	}
+	secrets.Store(next)
+
+	rotationMu.Lock()
+	hooks := append([]func(){}, rotationHooks...)
+	rotationMu.Unlock()
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+// decodePIIKey decodifica a chave de PII (base64 de 32 bytes, AES-256)
+// Chave vazia é aceita: os dados ficam sem criptografia (permitido apenas fora de produção)
+func decodePIIKey(encoded string) ([]byte, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		return nil, errors.New("PII_ENCRYPTION_KEY inválida: esperado base64 de 32 bytes (openssl rand -base64 32)")
+	}
+	return key, nil
+}
+
+// envBackend lê os segredos diretamente das variáveis de ambiente
+type envBackend struct{}
+
+func (envBackend) Name() string {
+	return "env"
+}
+
+func (envBackend) Fetch(context.Context) (map[string]string, error) {
+	values := make(map[string]string, len(secretKeys))
+	for _, key := range secretKeys {
+		values[key] = os.Getenv(key)
+	}
+	return values, nil
+}
+
+// fileBackend lê um arquivo por segredo (ex: /run/secrets/JWT_SECRET)
+type fileBackend struct {
+	dir string
+}
+
+func (b fileBackend) Name() string {
+	return "file"
+}
+
+func (b fileBackend) Fetch(context.Context) (map[string]string, error) {
+	values := make(map[string]string, len(secretKeys))
+	for _, key := range secretKeys {
+		data, err := os.ReadFile(filepath.Join(b.dir, key))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[key] = strings.TrimSpace(string(data))
+	}
+	return values, nil
+}
+
+// vaultBackend lê os segredos de um engine KV v2 do HashiCorp Vault (API REST, sem SDK)
+type vaultBackend struct {
+	addr   string
+	path   string // ex: secret/data/wedding_planner
+	token  string
+	client *http.Client
+}
+
+func (b *vaultBackend) Name() string {
+	return "vault"
+}
+
+func (b *vaultBackend) Fetch(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.addr+"/v1/"+b.path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", b.token)
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault retornou %d", resp.StatusCode)
+	}
+
+	var payload struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("resposta inválida do vault: %w", err)
+	}
+	if payload.Data.Data == nil {
+		return map[string]string{}, nil
+	}
+	return payload.Data.Data, nil
+}
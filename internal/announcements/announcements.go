@@ -0,0 +1,115 @@
+package announcements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/jobs"
+	"github.com/matheushermes/wedding_planner_service/internal/lifecycle"
+	"github.com/matheushermes/wedding_planner_service/internal/mailer"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Máximo de emails enviados por execução (o restante fica para a próxima)
+const maxEmailsPerRun = 200
+
+// Prefixo do assunto por tipo de comunicado
+var subjectPrefixes = map[models.AnnouncementKind]string{
+	models.AnnouncementMaintenance: "[Manutenção] ",
+	models.AnnouncementFeature:     "[Novidade] ",
+}
+
+// Setup registra o job que envia por email os comunicados publicados com send_email (antes de jobs.Start)
+func Setup() {
+	jobs.Register(jobs.Job{
+		Name:     "announcement_emails",
+		Interval: 5 * time.Minute,
+		Timeout:  4 * time.Minute,
+		Run: func(ctx context.Context) error {
+			sent, err := Run(ctx, database.DB.WithContext(ctx), mailer.Default(), time.Now())
+			if sent > 0 {
+				log.Printf("[INFO] Announcement emails sent: %d", sent)
+			}
+			return err
+		},
+	})
+}
+
+// Run envia os emails pendentes dos comunicados, respeitando o opt-out dos emails do serviço
+// O email sai da fila antes de disparar: réplicas e reexecuções nunca enviam em dobro
+// Falhas temporárias devolvem o email à fila; mensagens inválidas são descartadas
+func Run(ctx context.Context, db *gorm.DB, m mailer.Mailer, now time.Time) (int, error) {
+	repo := repository.NewNotificationRepository(db)
+
+	if cleared, err := repo.ClearPendingEmails(); err != nil {
+		return 0, fmt.Errorf("erro ao descartar emails de usuários sem consentimento: %w", err)
+	} else if cleared > 0 {
+		log.Printf("[INFO] Announcement emails dropped after opt-out: %d", cleared)
+	}
+
+	pending, err := repo.FindPendingEmails(maxEmailsPerRun)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao buscar emails de comunicados pendentes: %w", err)
+	}
+
+	var (
+		sent     int
+		problems []error
+	)
+	for _, p := range pending {
+		if ctx.Err() != nil {
+			return sent, errors.Join(append(problems, ctx.Err())...)
+		}
+
+		claimed, err := repo.ClaimEmail(p.ID)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("notificação %d: %w", p.ID, err))
+			continue
+		}
+		if !claimed {
+			continue // enviado por outra execução
+		}
+
+		if _, err := m.Send(ctx, announcementMessage(p)); err != nil {
+			if errors.Is(err, mailer.ErrInvalidMessage) {
+				log.Printf("[WARN] Dropping announcement %d email to user %d: %v", p.AnnouncementID, p.UserID, err)
+				continue
+			}
+			if relErr := repo.ReleaseEmail(p.ID); relErr != nil {
+				log.Printf("[ERROR] Failed to release announcement email %d after send failure: %v", p.ID, relErr)
+			}
+			problems = append(problems, fmt.Errorf("notificação %d: %w", p.ID, err))
+			continue
+		}
+
+		sent++
+		if err := repo.MarkEmailed(p.ID, now); err != nil {
+			problems = append(problems, fmt.Errorf("notificação %d: %w", p.ID, err))
+		}
+	}
+	return sent, errors.Join(problems...)
+}
+
+// announcementMessage monta o email do comunicado com o link de descadastro dos emails do serviço
+func announcementMessage(p repository.PendingNotificationEmail) mailer.Message {
+	unsubscribeURL := configs.PUBLIC_BASE_URL + lifecycle.UnsubscribePath(p.UserID)
+	return mailer.Message{
+		To:      p.Email,
+		Subject: subjectPrefixes[p.Kind] + p.Title,
+		Text: fmt.Sprintf("Olá, %s!\n\n%s\n\n"+
+			"Este comunicado também está na sua caixa de notificações.\n"+
+			"Não quer mais receber emails do serviço? %s\n",
+			p.Name, p.Body, unsubscribeURL),
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}
+}
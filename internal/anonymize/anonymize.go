@@ -0,0 +1,279 @@
+package anonymize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// Senha de todas as contas no ambiente anonimizado (permite logar como qualquer usuário para depuração)
+const StagingPassword = "staging-password"
+
+// Options controla a cópia anonimizada
+type Options struct {
+	BatchSize int
+}
+
+// row é uma linha lida da origem (colunas do banco, sem serializers)
+type row = map[string]interface{}
+
+// rule substitui os dados pessoais de uma linha, mantendo IDs e chaves estrangeiras
+type rule func(r row, f faker)
+
+// Tabelas copiadas sem dados (estado de execução, não de negócio)
+var skippedTables = map[string]bool{"job_leases": true, "maintenance_flags": true, "async_jobs": true, "funnel_snapshots": true}
+
+// rules define a anonimização de cada tabela
+// Segurança: Toda tabela precisa de uma regra explícita; uma tabela nova sem regra interrompe a cópia
+// em vez de levar dados pessoais para staging
+func rules(passwordHash string) map[string]rule {
+	replaceIfSet := func(r row, column, value string) {
+		switch v := r[column].(type) {
+		case string:
+			if v != "" {
+				r[column] = value
+			}
+		case []byte:
+			if len(v) > 0 {
+				r[column] = value
+			}
+		}
+	}
+
+	return map[string]rule{
+		"users": func(r row, f faker) {
+			r["name"] = f.fullName("name")
+			r["partner_name"] = f.fullName("partner")
+			r["email"] = f.email("email")
+			r["password_hash"] = passwordHash
+			r["calendar_token"] = nil
+			r["referral_code"] = nil
+		},
+		"weddings": func(r row, f faker) {
+			r["venue_address"] = f.address("venue")
+			// Endereços públicos e tokens são únicos e apontariam para a produção
+			r["slug"] = nil
+			r["custom_domain"] = nil
+			r["pending_custom_domain"] = nil
+			r["custom_domain_token"] = nil
+			r["custom_domain_verified_at"] = nil
+			r["embed_token"] = nil
+		},
+		"guest_groups": func(r row, f faker) {
+			r["name"] = "Família " + f.lastName("name")
+		},
+		"guests": func(r row, f faker) {
+			r["full_name"] = f.fullName("name")
+			replaceIfSet(r, "email", f.email("email"))
+			replaceIfSet(r, "phone", f.phone("phone"))
+			replaceIfSet(r, "phone_e164", f.phoneE164("phone"))
+			// Hashes de busca dependem da chave de PII de cada ambiente (recalculados pelo pii-backfill)
+			r["email_hash"] = ""
+			r["phone_hash"] = ""
+			// LGPD: restrição alimentar é dado de saúde
+			replaceIfSet(r, "dietary_restrictions", f.restriction("dietary"))
+			// Anotações livres do casal podem citar qualquer dado pessoal
+			replaceIfSet(r, "notes", "")
+			replaceIfSet(r, "decline_message", "")
+			replaceIfSet(r, "address_line1", f.address("address"))
+			replaceIfSet(r, "address_line2", "")
+			replaceIfSet(r, "address_postal_code", "01000-000")
+		},
+		"guest_status_histories": func(r row, f faker) {},
+		"companions": func(r row, f faker) {
+			r["full_name"] = f.fullName("name")
+		},
+		"guest_tags": func(r row, f faker) {
+			// Etiquetas livres podem conter nomes ("amigos do João")
+			r["name"] = "Etiqueta " + f.id
+		},
+		"guest_tag_assignments": func(r row, f faker) {},
+		"rsvp_questions":        func(r row, f faker) {},
+		"rsvp_answers": func(r row, f faker) {
+			// Respostas livres podem conter dados pessoais (a linha não diz o tipo da pergunta,
+			// então só yes/no é mantido)
+			if answer, _ := r["answer"].(string); answer != "yes" && answer != "no" {
+				replaceIfSet(r, "answer", "Resposta "+f.id)
+			}
+		},
+		"invites": func(r row, f faker) {
+			r["subject"] = ""
+			r["template"] = ""
+			// Motivos de bounce citam o endereço do convidado
+			r["delivery_error"] = ""
+		},
+		"invite_deliveries": func(r row, f faker) {
+			// LGPD: o snapshot tem destinatário e texto do convite; motivos de bounce citam o endereço
+			r["payload"] = ""
+			r["reason"] = ""
+		},
+		"invite_templates": func(r row, f faker) {
+			// Textos livres do casal podem citar nomes e endereços
+			r["subject"] = ""
+			r["body"] = "Olá, {{guest_name}}! Template " + f.id
+		},
+		"whats_app_accounts": func(r row, f faker) {
+			// Credenciais e número do casal: staging não deve enviar mensagens reais
+			r["token"] = ""
+			replaceIfSet(r, "from_number", f.phoneE164("whatsapp"))
+		},
+		"budgets": func(r row, f faker) {},
+		"expenses": func(r row, f faker) {
+			replaceIfSet(r, "description", fmt.Sprintf("Despesa %s", f.id))
+		},
+		"fundraisings": func(r row, f faker) {
+			replaceIfSet(r, "donor_name", f.fullName("donor"))
+			replaceIfSet(r, "observation", "")
+			replaceIfSet(r, "refund_reason", "")
+			replaceIfSet(r, "provider_charge_id", "anon_"+f.id)
+		},
+		"ledger_entries": func(r row, f faker) {
+			replaceIfSet(r, "memo", "")
+		},
+		"wedding_themes": func(r row, f faker) {
+			// Snapshot publicado contém nomes do casal; a página precisa ser republicada em staging
+			r["published_snapshot"] = ""
+			r["published_at"] = nil
+		},
+		"vendors": func(r row, f faker) {
+			replaceIfSet(r, "contact_name", f.fullName("contact"))
+			replaceIfSet(r, "email", f.email("email"))
+			replaceIfSet(r, "phone", f.phone("phone"))
+			replaceIfSet(r, "notes", "")
+		},
+		"lifecycle_emails": func(r row, f faker) {},
+		"referrals":        func(r row, f faker) {},
+		"announcements":    func(r row, f faker) {},
+		"notifications": func(r row, f faker) {
+			replaceIfSet(r, "body", "")
+		},
+		"photo_portals": func(r row, f faker) {
+			// Os arquivos não são copiados: link e ZIP precisam ser gerados de novo em staging
+			r["token"] = nil
+			r["archive_file"] = ""
+			r["archive_status"] = ""
+		},
+		"guest_photos": func(r row, f faker) {
+			replaceIfSet(r, "uploader_name", f.fullName("uploader"))
+		},
+		"event_infos": func(r row, f faker) {
+			replaceIfSet(r, "reception_address", f.address("reception"))
+			// O tempo de deslocamento guardado é do endereço real
+			r["travel_minutes"] = nil
+			r["travel_route"] = ""
+		},
+		"print_orders": func(r row, f faker) {},
+		"print_order_items": func(r row, f faker) {
+			r["recipient_name"] = f.fullName("recipient")
+			replaceIfSet(r, "address_line1", f.address("address"))
+			replaceIfSet(r, "address_line2", "")
+			replaceIfSet(r, "address_postal_code", "01000-000")
+		},
+		"wedding_vendors": func(r row, f faker) {
+			replaceIfSet(r, "notes", "")
+		},
+	}
+}
+
+// Copy copia todas as tabelas da origem para o destino substituindo os dados pessoais
+// Os IDs são preservados, então todas as chaves estrangeiras continuam válidas
+// O destino é recriado: todas as tabelas são esvaziadas antes da cópia
+func Copy(ctx context.Context, source, target *gorm.DB, opts Options) error {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(StagingPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	tableRules := rules(string(passwordHash))
+
+	tables, err := tableNames(target)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if _, ok := tableRules[table]; !ok && !skippedTables[table] {
+			return fmt.Errorf("tabela %s sem regra de anonimização", table)
+		}
+	}
+
+	if err := target.AutoMigrate(database.Models()...); err != nil {
+		return fmt.Errorf("erro ao preparar schema de destino: %w", err)
+	}
+
+	// Uma única conexão no destino: FOREIGN_KEY_CHECKS é uma variável de sessão
+	return target.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SET FOREIGN_KEY_CHECKS = 0").Error; err != nil {
+			return err
+		}
+		defer conn.Exec("SET FOREIGN_KEY_CHECKS = 1")
+
+		for _, table := range tables {
+			if err := conn.Exec("DELETE FROM " + conn.Statement.Quote(table)).Error; err != nil {
+				return fmt.Errorf("erro ao limpar %s: %w", table, err)
+			}
+			if skippedTables[table] {
+				continue
+			}
+
+			copied, err := copyTable(source.WithContext(ctx), conn, table, tableRules[table], opts.BatchSize)
+			if err != nil {
+				return fmt.Errorf("erro ao copiar %s: %w", table, err)
+			}
+			log.Printf("  ✅ %s: %d linhas anonimizadas", table, copied)
+		}
+		return nil
+	})
+}
+
+// copyTable copia uma tabela em lotes ordenados por ID (inclui registros com soft delete)
+func copyTable(source, target *gorm.DB, table string, anonymize rule, batchSize int) (int, error) {
+	var (
+		lastID interface{} = 0
+		copied int
+	)
+
+	for {
+		var rows []row
+		err := source.Table(table).Where("id > ?", lastID).Order("id").Limit(batchSize).Find(&rows).Error
+		if err != nil {
+			return copied, err
+		}
+		if len(rows) == 0 {
+			return copied, nil
+		}
+
+		for _, r := range rows {
+			anonymize(r, newFaker(table, r["id"]))
+		}
+		if err := target.Table(table).Create(&rows).Error; err != nil {
+			return copied, err
+		}
+
+		copied += len(rows)
+		lastID = rows[len(rows)-1]["id"]
+		if lastID == nil {
+			return copied, errors.New("linha sem coluna id")
+		}
+	}
+}
+
+// tableNames lista as tabelas dos models na ordem de migração
+func tableNames(db *gorm.DB) ([]string, error) {
+	var names []string
+	for _, model := range database.Models() {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("erro ao analisar %T: %w", model, err)
+		}
+		names = append(names, stmt.Schema.Table)
+	}
+	return names, nil
+}
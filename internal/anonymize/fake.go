@@ -0,0 +1,91 @@
+package anonymize
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+var (
+	firstNames = []string{
+		"Ana", "Beatriz", "Camila", "Daniela", "Eduarda", "Fernanda", "Gabriela", "Helena", "Isabela", "Juliana",
+		"Larissa", "Mariana", "Natália", "Patrícia", "Rafaela", "Sofia", "Tatiana", "Vitória",
+		"André", "Bruno", "Carlos", "Diego", "Eduardo", "Felipe", "Gustavo", "Henrique", "João", "Lucas",
+		"Marcelo", "Nicolas", "Paulo", "Rafael", "Thiago", "Vinícius",
+	}
+	lastNames = []string{
+		"Silva", "Santos", "Oliveira", "Souza", "Rodrigues", "Ferreira", "Alves", "Pereira", "Lima", "Gomes",
+		"Costa", "Ribeiro", "Martins", "Carvalho", "Almeida", "Lopes", "Soares", "Fernandes", "Vieira", "Barbosa",
+	}
+	streets = []string{
+		"Rua das Flores", "Avenida Paulista", "Rua XV de Novembro", "Rua Augusta", "Avenida Atlântica",
+		"Rua da Consolação", "Avenida Brasil", "Rua das Palmeiras",
+	}
+	cities = []string{
+		"São Paulo/SP", "Rio de Janeiro/RJ", "Belo Horizonte/MG", "Curitiba/PR", "Porto Alegre/RS", "Salvador/BA",
+	}
+	restrictions = []string{
+		"Sem glúten", "Sem lactose", "Alergia a frutos do mar", "Alergia a amendoim", "Diabético",
+	}
+)
+
+// faker gera dados falsos determinísticos: a mesma linha de origem sempre vira o mesmo dado falso
+// Mantém a consistência entre execuções sem guardar nenhum mapeamento para o dado real
+type faker struct {
+	table string
+	id    string
+}
+
+func newFaker(table string, id interface{}) faker {
+	return faker{table: table, id: fmt.Sprint(id)}
+}
+
+// pick escolhe um item da lista a partir do hash de (tabela, id, campo)
+func (f faker) pick(field string, options []string) string {
+	return options[f.seed(field)%uint64(len(options))]
+}
+
+func (f faker) seed(field string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(f.table + ":" + f.id + ":" + field))
+	return h.Sum64()
+}
+
+func (f faker) firstName(field string) string {
+	return f.pick(field+".first", firstNames)
+}
+
+func (f faker) lastName(field string) string {
+	return f.pick(field+".last", lastNames)
+}
+
+func (f faker) fullName(field string) string {
+	return f.firstName(field) + " " + f.lastName(field)
+}
+
+// email usa o domínio reservado example.test (RFC 2606): nenhum envio chega a uma caixa real
+func (f faker) email(field string) string {
+	local := strings.ToLower(f.firstName(field) + "." + f.lastName(field))
+	local = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ã", "a", "ç", "c").Replace(local)
+	return fmt.Sprintf("%s.%s%s@example.test", local, f.table, f.id)
+}
+
+// phone gera um celular brasileiro fictício com DDD válido
+func (f faker) phone(field string) string {
+	n := f.seed(field)
+	return fmt.Sprintf("+55 11 9%04d-%04d", n%10000, (n/10000)%10000)
+}
+
+// phoneE164 é o mesmo celular de phone no formato E.164
+func (f faker) phoneE164(field string) string {
+	n := f.seed(field)
+	return fmt.Sprintf("+55119%04d%04d", n%10000, (n/10000)%10000)
+}
+
+func (f faker) address(field string) string {
+	return fmt.Sprintf("%s, %d - %s", f.pick(field+".street", streets), f.seed(field)%2000+1, f.pick(field+".city", cities))
+}
+
+func (f faker) restriction(field string) string {
+	return f.pick(field, restrictions)
+}
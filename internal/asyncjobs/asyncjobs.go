@@ -0,0 +1,287 @@
+package asyncjobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/jobs"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/storage"
+	"gorm.io/gorm"
+)
+
+// Nome do job agendado que executa a fila (acionado por Trigger a cada Enqueue)
+const jobName = "async_jobs"
+
+const (
+	// Máximo de jobs executados por rodada (o restante fica para a próxima)
+	maxJobsPerRun = 20
+
+	// Limite de cada job e de reexecuções após reinícios da réplica
+	jobTimeout  = 10 * time.Minute
+	maxAttempts = 3
+
+	// Resultados expirados removidos por rodada
+	expiredBatchSize = 100
+)
+
+// Retention é o tempo em que resultado e arquivo ficam disponíveis após o fim do job
+// LGPD: exportações contêm dados pessoais dos convidados e não ficam guardadas indefinidamente
+const Retention = 24 * time.Hour
+
+// ErrUnknownKind indica job sem handler registrado
+var ErrUnknownKind = errors.New("unknown async job kind")
+
+// Output é o resultado de um job: resumo em JSON e, nas exportações, o arquivo gerado
+type Output struct {
+	Result       any
+	FileName     string // nome no storage (storage.KindExport)
+	DownloadName string
+	ContentType  string
+}
+
+// Handler executa um job da fila; progress grava o percentual concluído (0 a 100)
+type Handler func(ctx context.Context, job *models.AsyncJob, progress func(percent int)) (*Output, error)
+
+// Failure é uma falha com mensagem que pode ser exibida ao usuário em GET /jobs/:id
+type Failure struct {
+	Message string
+	Err     error
+}
+
+func (f *Failure) Error() string {
+	if f.Err == nil {
+		return f.Message
+	}
+	return f.Message + ": " + f.Err.Error()
+}
+
+func (f *Failure) Unwrap() error {
+	return f.Err
+}
+
+// Fail cria uma falha com mensagem pública; err (opcional) fica apenas no log
+func Fail(message string, err error) error {
+	return &Failure{Message: message, Err: err}
+}
+
+var (
+	mu       sync.RWMutex
+	handlers = map[models.AsyncJobKind]Handler{}
+)
+
+// Handle registra o handler de um tipo de job
+func Handle(kind models.AsyncJobKind, h Handler) {
+	mu.Lock()
+	defer mu.Unlock()
+	handlers[kind] = h
+}
+
+// Setup registra o job que executa a fila (antes de jobs.Start)
+func Setup() {
+	// Intervalo curto: o lease não deve descartar o Trigger de um job enfileirado logo após a rodada anterior
+	jobs.Register(jobs.Job{
+		Name:     jobName,
+		Interval: time.Second,
+		Timeout:  30 * time.Minute,
+		Run: func(ctx context.Context) error {
+			done, err := Run(ctx, database.DB.WithContext(ctx))
+			if done > 0 {
+				log.Printf("[INFO] Async jobs finished: %d", done)
+			}
+			return err
+		},
+	})
+}
+
+// Enqueue grava o job na fila com os parâmetros já validados e aciona o executor desta réplica
+func Enqueue(db *gorm.DB, job *models.AsyncJob, params any) error {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	job.Params = string(encoded)
+
+	if err := repository.NewAsyncJobRepository(db).Create(job); err != nil {
+		return err
+	}
+	jobs.Trigger(jobName)
+	return nil
+}
+
+// DecodeParams lê os parâmetros gravados por Enqueue
+func DecodeParams(job *models.AsyncJob, params any) error {
+	if err := json.Unmarshal([]byte(job.Params), params); err != nil {
+		return Fail("invalid job parameters", err)
+	}
+	return nil
+}
+
+// Run executa os jobs da fila e remove os resultados expirados
+// Falhas de um job são registradas nele (status=failed) sem interromper os demais
+// Concorrência: o lock do scheduler garante uma rodada por vez entre as réplicas
+func Run(ctx context.Context, db *gorm.DB) (int, error) {
+	repo := repository.NewAsyncJobRepository(db)
+
+	// Jobs em execução no início da rodada foram interrompidos por shutdown ou queda da réplica
+	requeued, err := repo.RequeueInterrupted(maxAttempts, time.Now(), Retention)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao recuperar jobs interrompidos: %w", err)
+	}
+	if requeued > 0 {
+		log.Printf("[WARN] Async jobs requeued after interruption: %d", requeued)
+	}
+
+	if err := purgeExpired(repo); err != nil {
+		log.Printf("[ERROR] Failed to purge expired async jobs: %v", err)
+	}
+
+	done := 0
+	for done < maxJobsPerRun {
+		if err := ctx.Err(); err != nil {
+			return done, err
+		}
+
+		queued, err := repo.FindQueued(1)
+		if err != nil {
+			return done, fmt.Errorf("erro ao buscar jobs na fila: %w", err)
+		}
+		if len(queued) == 0 {
+			break
+		}
+
+		job := &queued[0]
+		claimed, err := repo.Claim(job, time.Now())
+		if err != nil {
+			return done, fmt.Errorf("erro ao iniciar job %d: %w", job.ID, err)
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := execute(ctx, repo, job); err != nil {
+			return done, err
+		}
+		done++
+	}
+	return done, nil
+}
+
+// execute roda o handler do job e grava o resultado
+// Interrompido pelo shutdown, o job continua em execução no banco e volta para a fila na próxima rodada
+func execute(ctx context.Context, repo *repository.AsyncJobRepository, job *models.AsyncJob) error {
+	mu.RLock()
+	handler, ok := handlers[job.Kind]
+	mu.RUnlock()
+
+	var out *Output
+	var err error
+	if !ok {
+		err = ErrUnknownKind
+	} else {
+		jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+		out, err = handler(jobCtx, job, progressFunc(repo, job))
+		cancel()
+	}
+
+	if err != nil && ctx.Err() != nil {
+		removeOutput(out)
+		return ctx.Err()
+	}
+
+	finished := time.Now()
+	expiresAt := finished.Add(Retention)
+	job.FinishedAt = &finished
+	job.ExpiresAt = &expiresAt
+
+	if err != nil {
+		log.Printf("[ERROR] Async job %d (%s) of wedding %d failed: %v", job.ID, job.Kind, job.WeddingID, err)
+		removeOutput(out)
+		job.Status = models.AsyncJobFailed
+		job.Error = publicMessage(err)
+	} else {
+		job.Status = models.AsyncJobSucceeded
+		job.Progress = 100
+		if out != nil {
+			if out.Result != nil {
+				encoded, err := json.Marshal(out.Result)
+				if err != nil {
+					return fmt.Errorf("erro ao serializar resultado do job %d: %w", job.ID, err)
+				}
+				job.Result = string(encoded)
+			}
+			job.FileName = out.FileName
+			job.DownloadName = out.DownloadName
+			job.ContentType = out.ContentType
+		}
+	}
+
+	if err := repo.Finish(job); err != nil {
+		removeOutput(out)
+		return fmt.Errorf("erro ao salvar resultado do job %d: %w", job.ID, err)
+	}
+	return nil
+}
+
+// progressFunc grava o percentual apenas quando ele muda (100 fica para o fim do job)
+func progressFunc(repo *repository.AsyncJobRepository, job *models.AsyncJob) func(int) {
+	last := job.Progress
+	return func(percent int) {
+		percent = min(max(percent, 0), 99)
+		if percent == last {
+			return
+		}
+		last = percent
+		if err := repo.UpdateProgress(job.ID, percent); err != nil {
+			log.Printf("[WARN] Failed to update progress of async job %d: %v", job.ID, err)
+		}
+	}
+}
+
+// publicMessage retorna a mensagem exibida ao usuário sem detalhes internos do erro
+func publicMessage(err error) string {
+	var failure *Failure
+	if errors.As(err, &failure) {
+		return failure.Message
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "job timed out"
+	}
+	return "job failed"
+}
+
+// removeOutput apaga o arquivo de um job que não chegou a ser gravado como concluído
+func removeOutput(out *Output) {
+	if out == nil || out.FileName == "" {
+		return
+	}
+	if err := storage.Remove(storage.KindExport, out.FileName); err != nil {
+		log.Printf("[WARN] Failed to remove async job file %s: %v", out.FileName, err)
+	}
+}
+
+// purgeExpired remove os jobs expirados e os arquivos gerados por eles
+func purgeExpired(repo *repository.AsyncJobRepository) error {
+	expired, err := repo.FindExpired(time.Now(), expiredBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range expired {
+		if expired[i].FileName != "" {
+			if err := storage.Remove(storage.KindExport, expired[i].FileName); err != nil {
+				return err
+			}
+		}
+		if err := repo.Delete(expired[i].ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
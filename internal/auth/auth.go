@@ -3,6 +3,7 @@ package auth
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,16 +14,40 @@ import (
 
 // Constantes de configuração para tokens
 const (
-	// TokenExpirationTime define o tempo de vida do token (24h é um bom balanço entre segurança e UX)
-	TokenExpirationTime = 8 * time.Hour
-
-	// RefreshTokenExpirationTime define o tempo de vida do refresh token (7 dias)
-	RefreshTokenExpirationTime = 24 * time.Hour
-
 	// TokenType é o tipo do token (Bearer é o padrão OAuth 2.0)
 	TokenType = "Bearer"
+
+	// tokenIssuer identifica o emissor (importante em microserviços)
+	tokenIssuer = "wedding_planner_service"
+)
+
+// Audience identifica o tipo de cliente para o qual o token foi emitido (claim aud)
+// O tipo de cliente define validade e refresh; não concede privilégios (o /admin segue com ADMIN_TOKEN)
+type Audience string
+
+const (
+	AudienceWeb    Audience = "web"
+	AudienceMobile Audience = "mobile"
+	AudienceAdmin  Audience = "admin"
+)
+
+// Uso do token (claim token_use): refresh tokens não autenticam requisições
+const (
+	tokenUseAccess  = "access"
+	tokenUseRefresh = "refresh"
 )
 
+// TokenPolicy define a validade dos tokens de um tipo de cliente
+type TokenPolicy struct {
+	Lifetime        time.Duration
+	RefreshLifetime time.Duration // 0: o cliente faz login novamente ao expirar
+}
+
+// CanRefresh indica se o tipo de cliente recebe refresh token
+func (p TokenPolicy) CanRefresh() bool {
+	return p.RefreshLifetime > 0
+}
+
 // Erros customizados para melhor tratamento
 var (
 	ErrTokenMissing         = errors.New("authorization token is missing")
@@ -31,35 +56,95 @@ var (
 	ErrTokenNotValidYet     = errors.New("token is not valid yet")
 	ErrInvalidSigningMethod = errors.New("invalid token signing method")
 	ErrJWTSecretNotSet      = errors.New("JWT_SECRET environment variable not set")
+	ErrInvalidAudience      = errors.New("client must be one of: web, mobile, admin")
+	ErrTokenAudience        = errors.New("token is not valid for this client")
+	ErrRefreshNotAllowed    = errors.New("client does not support refresh tokens")
 )
 
 // Claims representa as informações estruturadas contidas no token JWT
 type Claims struct {
-	UserID uint   `json:"user_id"`
-	Email  string `json:"email"`
+	UserID   uint   `json:"user_id"`
+	Email    string `json:"email"`
+	TokenUse string `json:"token_use,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// CreateToken cria um novo token JWT para o usuário com claims estruturadas
+// Audience retorna o tipo de cliente do token
+// Tokens emitidos antes das audiences (sem aud) valem como web até expirar
+func (c *Claims) Audience() Audience {
+	if len(c.RegisteredClaims.Audience) == 0 {
+		return AudienceWeb
+	}
+	return Audience(c.RegisteredClaims.Audience[0])
+}
+
+// ParseAudience valida o tipo de cliente informado no login (vazio = web)
+func ParseAudience(value string) (Audience, error) {
+	audience := Audience(strings.ToLower(strings.TrimSpace(value)))
+	if audience == "" {
+		return AudienceWeb, nil
+	}
+	if _, ok := PolicyFor(audience); !ok {
+		return "", ErrInvalidAudience
+	}
+	return audience, nil
+}
+
+// PolicyFor retorna a validade configurada para o tipo de cliente (JWT_*_TTL_MINUTES)
+func PolicyFor(audience Audience) (TokenPolicy, bool) {
+	switch audience {
+	case AudienceWeb:
+		return TokenPolicy{Lifetime: configs.JWT_WEB_TTL}, true
+	case AudienceMobile:
+		return TokenPolicy{Lifetime: configs.JWT_MOBILE_TTL, RefreshLifetime: configs.JWT_MOBILE_REFRESH_TTL}, true
+	case AudienceAdmin:
+		return TokenPolicy{Lifetime: configs.JWT_ADMIN_TTL}, true
+	}
+	return TokenPolicy{}, false
+}
+
+// CreateToken cria um novo token de acesso para o usuário e o tipo de cliente
 // Retorna o token assinado ou erro caso falhe
-func CreateToken(userID uint, email string) (string, error) {
+func CreateToken(userID uint, email string, audience Audience) (string, error) {
+	policy, ok := PolicyFor(audience)
+	if !ok {
+		return "", ErrInvalidAudience
+	}
+	return signToken(userID, email, audience, tokenUseAccess, policy.Lifetime)
+}
+
+// CreateRefreshToken cria o refresh token do tipo de cliente (apenas clientes com refresh)
+func CreateRefreshToken(userID uint, email string, audience Audience) (string, error) {
+	policy, ok := PolicyFor(audience)
+	if !ok {
+		return "", ErrInvalidAudience
+	}
+	if !policy.CanRefresh() {
+		return "", ErrRefreshNotAllowed
+	}
+	return signToken(userID, email, audience, tokenUseRefresh, policy.RefreshLifetime)
+}
+
+// signToken assina um token com claims estruturadas
+func signToken(userID uint, email string, audience Audience, use string, lifetime time.Duration) (string, error) {
 	now := time.Now()
-	expirationTime := now.Add(TokenExpirationTime)
 
 	claims := &Claims{
-		UserID: userID,
-		Email:  email,
+		UserID:   userID,
+		Email:    email,
+		TokenUse: use,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime), // Tempo de expiração
-			IssuedAt:  jwt.NewNumericDate(now),            // Data de emissão (importante para auditoria)
-			NotBefore: jwt.NewNumericDate(now),            // Token não pode ser usado antes desta data
-			Issuer:    "wedding_planner_service",          // Identifica o emissor (importante em microserviços)
-			Subject:   fmt.Sprintf("%d", userID),          // Subject identifica o usuário
+			ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)), // Tempo de expiração
+			IssuedAt:  jwt.NewNumericDate(now),               // Data de emissão (importante para auditoria)
+			NotBefore: jwt.NewNumericDate(now),               // Token não pode ser usado antes desta data
+			Issuer:    tokenIssuer,                           // Identifica o emissor
+			Subject:   fmt.Sprintf("%d", userID),             // Subject identifica o usuário
+			Audience:  jwt.ClaimStrings{string(audience)},    // Tipo de cliente
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	return token.SignedString([]byte(configs.JWT_SECRET))
+	return token.SignedString(configs.CurrentSecrets().JWTSecret)
 }
 
 // ExtractToken extrai o token JWT do header Authorization
@@ -85,64 +170,124 @@ func returnVerificationKey(token *jwt.Token) (interface{}, error) {
 		return nil, fmt.Errorf("%w: %v", ErrInvalidSigningMethod, token.Header["alg"])
 	}
 
-	return configs.JWT_SECRET, nil
+	// Após uma rotação, tokens assinados com a chave anterior continuam válidos até expirar
+	secrets := configs.CurrentSecrets()
+	if len(secrets.PreviousJWTSecret) == 0 {
+		return secrets.JWTSecret, nil
+	}
+	return jwt.VerificationKeySet{Keys: []jwt.VerificationKey{secrets.JWTSecret, secrets.PreviousJWTSecret}}, nil
 }
 
 // VerifyToken verifica se o token JWT é válido
 // Valida assinatura, expiração e estrutura do token
 func VerifyToken(c *gin.Context) error {
+	_, err := Authenticate(c)
+	return err
+}
+
+// Authenticate valida o token de acesso da requisição e retorna as claims
+// Sem audiences, aceita qualquer tipo de cliente; com audiences, apenas os informados
+// A validade atual do tipo de cliente também vale para tokens já emitidos: reduzir JWT_*_TTL_MINUTES
+// encurta as sessões abertas
+func Authenticate(c *gin.Context, audiences ...Audience) (*Claims, error) {
 	tokenString := ExtractToken(c)
 	if tokenString == "" {
-		return ErrTokenMissing
+		return nil, ErrTokenMissing
 	}
 
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, returnVerificationKey)
+	claims, err := parseClaims(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	// Segurança: refresh tokens (longa duração) só servem para emitir um novo token de acesso
+	if claims.TokenUse == tokenUseRefresh {
+		return nil, ErrTokenInvalid
+	}
+	if err := checkAudience(claims, audiences, false); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// ParseRefreshToken valida um refresh token e retorna as claims
+func ParseRefreshToken(tokenString string) (*Claims, error) {
+	claims, err := parseClaims(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenUse != tokenUseRefresh {
+		return nil, ErrTokenInvalid
+	}
+	if err := checkAudience(claims, nil, true); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// parseClaims valida assinatura, emissor, expiração e estrutura do token
+func parseClaims(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, returnVerificationKey, jwt.WithIssuer(tokenIssuer))
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			return ErrTokenExpired
+			return nil, ErrTokenExpired
 		}
 		if errors.Is(err, jwt.ErrTokenNotValidYet) {
-			return ErrTokenNotValidYet
+			return nil, ErrTokenNotValidYet
 		}
-		return fmt.Errorf("%w: %v", ErrTokenInvalid, err)
+		return nil, fmt.Errorf("%w: %v", ErrTokenInvalid, err)
 	}
 
 	// Verifica se o token está válido (assinatura correta, não expirado, etc)
 	if !token.Valid {
-		return ErrTokenInvalid
+		return nil, ErrTokenInvalid
+	}
+	return claims, nil
+}
+
+// checkAudience confere o tipo de cliente do token e aplica a validade atual da política
+func checkAudience(claims *Claims, allowed []Audience, refresh bool) error {
+	audience := claims.Audience()
+	policy, ok := PolicyFor(audience)
+	if !ok {
+		return ErrTokenAudience
+	}
+	if len(allowed) > 0 && !slices.Contains(allowed, audience) {
+		return ErrTokenAudience
 	}
 
+	lifetime := policy.Lifetime
+	if refresh {
+		if !policy.CanRefresh() {
+			return ErrRefreshNotAllowed
+		}
+		lifetime = policy.RefreshLifetime
+	}
+	if claims.IssuedAt == nil || time.Since(claims.IssuedAt.Time) > lifetime {
+		return ErrTokenExpired
+	}
 	return nil
 }
 
 // ExtractUserID extrai o ID do usuário do token de forma type-safe
 func ExtractUserID(c *gin.Context) (uint, error) {
-	tokenString := ExtractToken(c)
-	if tokenString == "" {
-		return 0, ErrTokenMissing
-	}
-
-	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, returnVerificationKey)
-	if err != nil || !token.Valid {
-		return 0, ErrTokenInvalid
+	claims, err := ExtractTokenMetadata(c)
+	if err != nil {
+		return 0, err
 	}
-
 	return claims.UserID, nil
 }
 
 // ExtractTokenMetadata extrai todas as informações (metadata) do token
 func ExtractTokenMetadata(c *gin.Context) (*Claims, error) {
-	tokenString := ExtractToken(c)
-	if tokenString == "" {
-		return nil, ErrTokenMissing
-	}
-
-	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, returnVerificationKey)
-	if err != nil || !token.Valid {
+	claims, err := Authenticate(c)
+	if err != nil {
+		if errors.Is(err, ErrTokenMissing) {
+			return nil, err
+		}
 		return nil, ErrTokenInvalid
 	}
-
 	return claims, nil
 }
@@ -0,0 +1,138 @@
+// Package authz concentra as decisões de acesso a recursos de outros usuários
+//
+// Política de recursos inacessíveis:
+//   - Recurso de outro usuário responde exatamente como recurso inexistente: 404 "<recurso> not found"
+//   - Nunca respondemos 403 para IDs na URL: o 403 confirmaria que o ID existe (enumeração)
+//   - Recursos filhos são sempre buscados junto com o ID do pai já autorizado (ex: wedding_id)
+package authz
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// NotFoundError representa um recurso inexistente ou inacessível
+// Segurança: Os dois casos são indistinguíveis para não revelar IDs de outros usuários
+type NotFoundError struct {
+	Resource string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Resource + " not found"
+}
+
+// NotFound cria o erro padrão para um recurso inexistente ou inacessível
+func NotFound(resource string) error {
+	return &NotFoundError{Resource: resource}
+}
+
+// Erros dos recursos carregados diretamente por este pacote
+var (
+	ErrWeddingNotFound = NotFound("wedding")
+	ErrVendorNotFound  = NotFound("vendor")
+	ErrJobNotFound     = NotFound("job")
+)
+
+// Action representa uma operação sobre um recurso
+type Action string
+
+const (
+	ActionRead   Action = "read"
+	ActionWrite  Action = "write"
+	ActionDelete Action = "delete"
+)
+
+// ActionForMethod mapeia o método HTTP para a ação correspondente
+func ActionForMethod(method string) Action {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ActionRead
+	case http.MethodDelete:
+		return ActionDelete
+	default:
+		return ActionWrite
+	}
+}
+
+// Subject representa quem está executando a ação
+type Subject struct {
+	UserID uint
+}
+
+// Can é o ponto único de decisão de acesso: can(user, action, resource)
+// Hoje apenas o dono do recurso tem acesso; colaboradores e papéis entram aqui
+func Can(subject Subject, action Action, resource interface{}) bool {
+	if subject.UserID == 0 {
+		return false
+	}
+
+	switch r := resource.(type) {
+	case *models.Wedding:
+		return r.UserID == subject.UserID
+	case *models.Vendor:
+		return r.UserID == subject.UserID
+	case *models.AsyncJob:
+		return r.UserID == subject.UserID
+	default:
+		// Segurança: Recursos sem política explícita são negados por padrão
+		return false
+	}
+}
+
+// LoadWedding busca o casamento pela chave primária e verifica se o usuário pode executar a ação
+// Retorna ErrWeddingNotFound tanto para casamento inexistente quanto para acesso negado
+func LoadWedding(db *gorm.DB, subject Subject, weddingID uint, action Action) (*models.Wedding, error) {
+	var wedding models.Wedding
+	if err := db.First(&wedding, weddingID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWeddingNotFound
+		}
+		return nil, err
+	}
+
+	if !Can(subject, action, &wedding) {
+		return nil, ErrWeddingNotFound
+	}
+	return &wedding, nil
+}
+
+// LoadVendor busca o fornecedor e verifica se o usuário pode executar a ação
+func LoadVendor(db *gorm.DB, subject Subject, vendorID uint, action Action) (*models.Vendor, error) {
+	var vendor models.Vendor
+	if err := db.First(&vendor, vendorID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVendorNotFound
+		}
+		return nil, err
+	}
+
+	if !Can(subject, action, &vendor) {
+		return nil, ErrVendorNotFound
+	}
+	return &vendor, nil
+}
+
+// LoadAsyncJob busca o job em segundo plano e verifica se o usuário pode executar a ação
+func LoadAsyncJob(db *gorm.DB, subject Subject, jobID uint, action Action) (*models.AsyncJob, error) {
+	var job models.AsyncJob
+	if err := db.First(&job, jobID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+
+	if !Can(subject, action, &job) {
+		return nil, ErrJobNotFound
+	}
+	return &job, nil
+}
+
+// IsNotFound indica se o erro representa recurso inexistente ou inacessível
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+	return errors.As(err, &notFound)
+}
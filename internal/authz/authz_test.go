@@ -0,0 +1,264 @@
+package authz
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/database/dbtest"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	userA uint = 1
+	userB uint = 2
+)
+
+func TestCan(t *testing.T) {
+	weddingA := &models.Wedding{ID: 10, UserID: userA}
+	vendorA := &models.Vendor{ID: 20, UserID: userA}
+	jobA := &models.AsyncJob{ID: 30, UserID: userA}
+
+	tests := []struct {
+		name     string
+		subject  Subject
+		resource interface{}
+		want     bool
+	}{
+		{"owner reads wedding", Subject{UserID: userA}, weddingA, true},
+		{"other user reads wedding", Subject{UserID: userB}, weddingA, false},
+		{"anonymous reads wedding", Subject{}, weddingA, false},
+		{"owner reads vendor", Subject{UserID: userA}, vendorA, true},
+		{"other user reads vendor", Subject{UserID: userB}, vendorA, false},
+		{"owner reads job", Subject{UserID: userA}, jobA, true},
+		{"other user reads job", Subject{UserID: userB}, jobA, false},
+		{"resource without policy", Subject{UserID: userA}, &models.Guest{ID: 40, WeddingID: 10}, false},
+		{"nil resource", Subject{UserID: userA}, nil, false},
+	}
+
+	for _, tt := range tests {
+		for _, action := range []Action{ActionRead, ActionWrite, ActionDelete} {
+			t.Run(tt.name+"/"+string(action), func(t *testing.T) {
+				if got := Can(tt.subject, action, tt.resource); got != tt.want {
+					t.Errorf("Can() = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestActionForMethod(t *testing.T) {
+	tests := map[string]Action{
+		"GET":     ActionRead,
+		"HEAD":    ActionRead,
+		"OPTIONS": ActionRead,
+		"POST":    ActionWrite,
+		"PUT":     ActionWrite,
+		"PATCH":   ActionWrite,
+		"DELETE":  ActionDelete,
+	}
+	for method, want := range tests {
+		if got := ActionForMethod(method); got != want {
+			t.Errorf("ActionForMethod(%s) = %s, want %s", method, got, want)
+		}
+	}
+}
+
+// tenantFixture identifica os registros dos dois usuários usados nos testes de isolamento
+type tenantFixture struct {
+	userA, userB uint
+	weddingA     uint
+	vendorA      uint
+	vendorB      uint
+	jobA         uint
+	missing      uint // ID que não existe em nenhuma tabela
+}
+
+// TestLoadCrossTenant garante que o usuário B não acessa recursos do usuário A
+// e que a resposta é idêntica à de um ID inexistente (sem enumeração)
+func TestLoadCrossTenant(t *testing.T) {
+	db, fake := dbtest.Open()
+	// Segurança: no modo estrito um filtro que o driver não avalia falha o teste em vez de ser ignorado
+	fake.Strict()
+	fake.Insert("weddings",
+		dbtest.Row{"id": 10, "user_id": userA, "venue_name": "Espaço A"},
+		dbtest.Row{"id": 11, "user_id": userB, "venue_name": "Espaço B"},
+	)
+	fake.Insert("vendors",
+		dbtest.Row{"id": 20, "user_id": userA, "name": "Buffet A"},
+		dbtest.Row{"id": 21, "user_id": userB, "name": "Buffet B"},
+	)
+	fake.Insert("async_jobs",
+		dbtest.Row{"id": 30, "user_id": userA, "wedding_id": 10, "kind": "guest_export"},
+	)
+
+	assertCrossTenant(t, db, tenantFixture{userA: userA, userB: userB, weddingA: 10, vendorA: 20, vendorB: 21, jobA: 30, missing: 99})
+}
+
+// TestLoadCrossTenantMySQL repete o isolamento contra o MySQL de testes (TEST_DATABASE_URL),
+// com a semântica real do banco (soft delete, tipos e chaves geradas)
+// Segurança: use um banco descartável; o schema é migrado e os dados de teste são removidos ao final
+func TestLoadCrossTenantMySQL(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set: cross-tenant checks against MySQL need a test database")
+	}
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+	if err := database.MigrateDB(database.Models()...); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	tx := db.Session(&gorm.Session{SkipHooks: true})
+	suffix := time.Now().UnixNano()
+	users := []models.User{
+		{Name: "Tenant A", Email: fmt.Sprintf("authz-a-%d@example.com", suffix), PasswordHash: "x"},
+		{Name: "Tenant B", Email: fmt.Sprintf("authz-b-%d@example.com", suffix), PasswordHash: "x"},
+	}
+	if err := tx.Create(&users).Error; err != nil {
+		t.Fatalf("seed users: %v", err)
+	}
+	t.Cleanup(func() {
+		ids := []uint{users[0].ID, users[1].ID}
+		db.Exec("DELETE FROM async_jobs WHERE user_id IN ?", ids)
+		db.Exec("DELETE FROM vendors WHERE user_id IN ?", ids)
+		db.Exec("DELETE FROM weddings WHERE user_id IN ?", ids)
+		db.Exec("DELETE FROM users WHERE id IN ?", ids)
+	})
+
+	day := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	weddings := []models.Wedding{
+		{UserID: users[0].ID, VenueName: "Espaço A", EventDate: day, MaxGuests: 100},
+		{UserID: users[1].ID, VenueName: "Espaço B", EventDate: day, MaxGuests: 100},
+	}
+	vendors := []models.Vendor{
+		{UserID: users[0].ID, Name: "Buffet A", Category: models.ExpenseCategoryFood},
+		{UserID: users[1].ID, Name: "Buffet B", Category: models.ExpenseCategoryFood},
+	}
+	if err := tx.Create(&weddings).Error; err != nil {
+		t.Fatalf("seed weddings: %v", err)
+	}
+	if err := tx.Create(&vendors).Error; err != nil {
+		t.Fatalf("seed vendors: %v", err)
+	}
+	job := models.AsyncJob{UserID: users[0].ID, WeddingID: weddings[0].ID, Kind: models.AsyncJobGuestImport, Status: models.AsyncJobQueued}
+	if err := tx.Create(&job).Error; err != nil {
+		t.Fatalf("seed job: %v", err)
+	}
+
+	// Um casamento excluído (soft delete) também não pode ser carregado pelo dono
+	deleted := models.Wedding{UserID: users[0].ID, VenueName: "Espaço excluído", EventDate: day, MaxGuests: 100}
+	if err := tx.Create(&deleted).Error; err != nil {
+		t.Fatalf("seed deleted wedding: %v", err)
+	}
+	if err := db.Delete(&deleted).Error; err != nil {
+		t.Fatalf("soft delete wedding: %v", err)
+	}
+	for _, action := range []Action{ActionRead, ActionWrite, ActionDelete} {
+		if _, err := LoadWedding(db, Subject{UserID: users[0].ID}, deleted.ID, action); !IsNotFound(err) {
+			t.Errorf("owner loading deleted wedding %d (%s): got %v, want not found", deleted.ID, action, err)
+		}
+	}
+
+	assertCrossTenant(t, db, tenantFixture{
+		userA:    users[0].ID,
+		userB:    users[1].ID,
+		weddingA: weddings[0].ID,
+		vendorA:  vendors[0].ID,
+		vendorB:  vendors[1].ID,
+		jobA:     job.ID,
+		missing:  math.MaxInt32,
+	})
+}
+
+// assertCrossTenant carrega cada recurso como dono, como outro usuário e sem usuário,
+// para todas as ações; fora o dono, a resposta deve ser a mesma de um ID inexistente
+func assertCrossTenant(t *testing.T, db *gorm.DB, f tenantFixture) {
+	t.Helper()
+
+	load := map[string]func(subject Subject, id uint, action Action) (uint, error){
+		"wedding": func(s Subject, id uint, a Action) (uint, error) {
+			w, err := LoadWedding(db, s, id, a)
+			if err != nil {
+				return 0, err
+			}
+			return w.ID, nil
+		},
+		"vendor": func(s Subject, id uint, a Action) (uint, error) {
+			v, err := LoadVendor(db, s, id, a)
+			if err != nil {
+				return 0, err
+			}
+			return v.ID, nil
+		},
+		"job": func(s Subject, id uint, a Action) (uint, error) {
+			j, err := LoadAsyncJob(db, s, id, a)
+			if err != nil {
+				return 0, err
+			}
+			return j.ID, nil
+		},
+	}
+
+	tests := []struct {
+		resource string
+		subject  Subject
+		id       uint
+		wantOK   bool
+	}{
+		{"wedding", Subject{UserID: f.userA}, f.weddingA, true},
+		{"wedding", Subject{UserID: f.userB}, f.weddingA, false},
+		{"wedding", Subject{}, f.weddingA, false},
+		{"wedding", Subject{UserID: f.userB}, f.missing, false},
+		{"vendor", Subject{UserID: f.userA}, f.vendorA, true},
+		{"vendor", Subject{UserID: f.userB}, f.vendorA, false},
+		{"vendor", Subject{UserID: f.userA}, f.vendorB, false},
+		{"vendor", Subject{UserID: f.userB}, f.missing, false},
+		{"job", Subject{UserID: f.userA}, f.jobA, true},
+		{"job", Subject{UserID: f.userB}, f.jobA, false},
+		{"job", Subject{UserID: f.userB}, f.missing, false},
+	}
+
+	for _, tt := range tests {
+		for _, action := range []Action{ActionRead, ActionWrite, ActionDelete} {
+			name := tt.resource + "/" + string(action)
+			t.Run(name, func(t *testing.T) {
+				id, err := load[tt.resource](tt.subject, tt.id, action)
+				if tt.wantOK {
+					if err != nil || id != tt.id {
+						t.Fatalf("user %d loading %s %d: got (%d, %v), want success", tt.subject.UserID, tt.resource, tt.id, id, err)
+					}
+					return
+				}
+
+				if !IsNotFound(err) {
+					t.Fatalf("user %d loading %s %d: got %v, want not found", tt.subject.UserID, tt.resource, tt.id, err)
+				}
+				if want := tt.resource + " not found"; err.Error() != want {
+					t.Errorf("error = %q, want %q (same as a missing ID)", err.Error(), want)
+				}
+			})
+		}
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	if !IsNotFound(NotFound("guest")) {
+		t.Error("NotFound() must be reported by IsNotFound")
+	}
+	if IsNotFound(errors.New("guest not found")) {
+		t.Error("plain errors must not be treated as authorization failures")
+	}
+}
@@ -0,0 +1,55 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/jobs"
+	"github.com/matheushermes/wedding_planner_service/internal/metrics"
+)
+
+// Erros customizados para melhor tratamento
+var (
+	ErrTenantNotFound       = errors.New("tenant not found")
+	ErrVerificationDisabled = errors.New("backup verification disabled (BACKUP_SCRATCH_DSN not set)")
+)
+
+// Setup registra o job diário de verificação de backups (antes de jobs.Start)
+// Sem BACKUP_SCRATCH_DSN o job não é registrado
+func Setup() {
+	if configs.BACKUP_SCRATCH_DSN == "" {
+		return
+	}
+
+	jobs.Register(jobs.Job{
+		Name:     "backup_verification",
+		Interval: 24 * time.Hour,
+		Timeout:  30 * time.Minute,
+		Run:      verifyLatest,
+	})
+}
+
+// verifyLatest restaura o último backup e publica o resultado nas métricas
+func verifyLatest(ctx context.Context) error {
+	store, err := NewStore()
+	if err != nil {
+		return err
+	}
+
+	key, rows, err := Verify(ctx, store)
+	if errors.Is(err, ErrNoBackups) {
+		log.Println("[WARN] Backup verification skipped: no backups found")
+		return nil
+	}
+	metrics.RecordBackupVerification(key, rows, err)
+	if err != nil {
+		log.Printf("[ERROR] Backup verification failed for %s: %v", key, err)
+		return err
+	}
+
+	log.Printf("[INFO] Backup %s verified (%d rows restored)", key, rows)
+	return nil
+}
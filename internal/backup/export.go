@@ -0,0 +1,159 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"gorm.io/gorm"
+)
+
+// Versão do formato do snapshot (incrementar ao mudar a estrutura)
+const snapshotVersion = 1
+
+// Prefixo das chaves de backup por tenant
+const tenantPrefix = "tenants/"
+
+// Formato das colunas de data no snapshot
+const datetimeLayout = "2006-01-02 15:04:05.999999"
+
+// Snapshot é o backup lógico de um tenant (conta de usuário e todos os seus casamentos)
+// Segurança: linhas exportadas como estão no banco, dados pessoais permanecem criptografados
+type Snapshot struct {
+	Version   int                                 `json:"version"`
+	UserID    uint                                `json:"user_id"`
+	CreatedAt time.Time                           `json:"created_at"`
+	Tables    map[string][]map[string]interface{} `json:"tables"`
+	Counts    map[string]int                      `json:"counts"`
+}
+
+// Result resume um backup gravado no destino
+type Result struct {
+	Key    string         `json:"key"`
+	Counts map[string]int `json:"counts"`
+}
+
+// tenantTable descreve como uma tabela se relaciona ao tenant
+type tenantTable struct {
+	name   string
+	column string // user_id, wedding_id ou id (tabela de usuários)
+}
+
+// tenantTables deriva dos models as tabelas pertencentes a um tenant, na ordem de migração
+// Tabelas sem dono (ex: job_leases) ficam de fora
+func tenantTables(db *gorm.DB) ([]tenantTable, error) {
+	var tables []tenantTable
+	for _, model := range database.Models() {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("erro ao analisar %T: %w", model, err)
+		}
+
+		name := stmt.Schema.Table
+		switch {
+		case name == "users":
+			tables = append(tables, tenantTable{name: name, column: "id"})
+		case stmt.Schema.LookUpField("wedding_id") != nil:
+			tables = append(tables, tenantTable{name: name, column: "wedding_id"})
+		case stmt.Schema.LookUpField("user_id") != nil:
+			tables = append(tables, tenantTable{name: name, column: "user_id"})
+		}
+	}
+	return tables, nil
+}
+
+// Export gera o snapshot de um tenant e grava no destino
+// Inclui registros removidos (soft delete) para que a restauração seja fiel
+func Export(ctx context.Context, db *gorm.DB, store Store, userID uint) (*Result, error) {
+	snapshot, err := snapshotTenant(db.WithContext(ctx), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(snapshot); err != nil {
+		return nil, fmt.Errorf("erro ao serializar backup: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%s%s-user%d.json.gz", tenantPrefix, snapshot.CreatedAt.Format("20060102T150405Z"), userID)
+	if err := store.Put(ctx, key, &buf); err != nil {
+		return nil, fmt.Errorf("erro ao gravar backup: %w", err)
+	}
+
+	return &Result{Key: key, Counts: snapshot.Counts}, nil
+}
+
+// normalizeRow converte datas para o formato DATETIME do MySQL (mesmo horário lido do banco)
+// Evita depender da interpretação de RFC3339 pelo servidor na restauração
+func normalizeRow(row map[string]interface{}) {
+	for column, value := range row {
+		if t, ok := value.(time.Time); ok {
+			row[column] = t.Format(datetimeLayout)
+		}
+	}
+}
+
+// snapshotTenant lê as linhas do tenant em uma transação de leitura consistente
+func snapshotTenant(db *gorm.DB, userID uint) (*Snapshot, error) {
+	tables, err := tenantTables(db)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		Version:   snapshotVersion,
+		UserID:    userID,
+		CreatedAt: time.Now().UTC(),
+		Tables:    map[string][]map[string]interface{}{},
+		Counts:    map[string]int{},
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var weddingIDs []uint
+		if err := tx.Table("weddings").Where("user_id = ?", userID).Pluck("id", &weddingIDs).Error; err != nil {
+			return err
+		}
+
+		for _, t := range tables {
+			// Table() sem Model: lê também registros com soft delete e não passa por serializers
+			query := tx.Table(t.name)
+			switch t.column {
+			case "id", "user_id":
+				query = query.Where(t.column+" = ?", userID)
+			case "wedding_id":
+				if len(weddingIDs) == 0 {
+					snapshot.Tables[t.name] = []map[string]interface{}{}
+					continue
+				}
+				query = query.Where("wedding_id IN ?", weddingIDs)
+			}
+
+			var rows []map[string]interface{}
+			if err := query.Order("id").Find(&rows).Error; err != nil {
+				return fmt.Errorf("erro ao exportar %s: %w", t.name, err)
+			}
+			for _, row := range rows {
+				normalizeRow(row)
+			}
+			snapshot.Tables[t.name] = rows
+			snapshot.Counts[t.name] = len(rows)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.Counts["users"] == 0 {
+		return nil, ErrTenantNotFound
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,106 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+)
+
+// ErrNoBackups indica que o destino ainda não possui backups
+var ErrNoBackups = errors.New("no backups found")
+
+// Store abstrai o destino dos arquivos de backup
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// NewStore cria o destino configurado em BACKUP_STORE
+func NewStore() (Store, error) {
+	switch configs.BACKUP_STORE {
+	case "file":
+		return fileStore{dir: configs.BACKUP_DIR}, nil
+	case "s3":
+		return newS3Store()
+	default:
+		return nil, fmt.Errorf("BACKUP_STORE inválido: %s (use file ou s3)", configs.BACKUP_STORE)
+	}
+}
+
+// latest retorna a chave mais recente com o prefixo
+// As chaves começam pelo timestamp UTC, então a ordem lexicográfica é a cronológica
+func latest(ctx context.Context, store Store, prefix string) (string, error) {
+	keys, err := store.List(ctx, prefix)
+	if err != nil {
+		return "", err
+	}
+	if len(keys) == 0 {
+		return "", ErrNoBackups
+	}
+	sort.Strings(keys)
+	return keys[len(keys)-1], nil
+}
+
+// fileStore grava backups em um diretório local (ou volume montado)
+type fileStore struct {
+	dir string
+}
+
+func (s fileStore) Put(_ context.Context, key string, r io.Reader) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	// Grava em arquivo temporário e renomeia: um backup parcial nunca aparece como o mais recente
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".partial-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s fileStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(s.dir, filepath.FromSlash(key)))
+}
+
+func (s fileStore) List(_ context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(s.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	return keys, err
+}
@@ -0,0 +1,132 @@
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	gormmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Linhas inseridas por lote na restauração
+const restoreBatchSize = 200
+
+// Verify restaura o backup mais recente no schema descartável e confere a contagem de linhas
+// A restauração roda em uma transação desfeita ao final: o schema de verificação nunca acumula dados
+func Verify(ctx context.Context, store Store) (key string, rows int, err error) {
+	scratch, err := openScratch()
+	if err != nil {
+		return "", 0, err
+	}
+	if sqlDB, err := scratch.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+	scratch = scratch.WithContext(ctx)
+
+	key, err = latest(ctx, store, tenantPrefix)
+	if err != nil {
+		return "", 0, err
+	}
+
+	snapshot, err := readSnapshot(ctx, store, key)
+	if err != nil {
+		return key, 0, err
+	}
+	if snapshot.Version != snapshotVersion {
+		return key, 0, fmt.Errorf("versão de backup não suportada: %d", snapshot.Version)
+	}
+
+	// Schema atual: um backup que não cabe nas tabelas desta versão falha a verificação
+	if err := scratch.AutoMigrate(database.Models()...); err != nil {
+		return key, 0, fmt.Errorf("erro ao preparar schema de verificação: %w", err)
+	}
+
+	tables, err := tenantTables(scratch)
+	if err != nil {
+		return key, 0, err
+	}
+
+	errRollback := errors.New("rollback")
+	err = scratch.Transaction(func(tx *gorm.DB) error {
+		// Mesma conexão da transação: a ordem de inserção não precisa respeitar as FKs
+		if err := tx.Exec("SET FOREIGN_KEY_CHECKS = 0").Error; err != nil {
+			return err
+		}
+
+		for _, t := range tables {
+			data := snapshot.Tables[t.name]
+			if len(data) > 0 {
+				if err := tx.Table(t.name).CreateInBatches(data, restoreBatchSize).Error; err != nil {
+					return fmt.Errorf("erro ao restaurar %s: %w", t.name, err)
+				}
+			}
+
+			var count int64
+			if err := tx.Table(t.name).Count(&count).Error; err != nil {
+				return err
+			}
+			if int(count) != snapshot.Counts[t.name] {
+				return fmt.Errorf("%s: %d linhas restauradas, %d esperadas", t.name, count, snapshot.Counts[t.name])
+			}
+			rows += int(count)
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		return key, 0, err
+	}
+	return key, rows, nil
+}
+
+// openScratch conecta ao schema descartável configurado em BACKUP_SCRATCH_DSN
+// Segurança: recusa o mesmo schema da aplicação, a verificação nunca escreve no banco real
+func openScratch() (*gorm.DB, error) {
+	if configs.BACKUP_SCRATCH_DSN == "" {
+		return nil, ErrVerificationDisabled
+	}
+
+	scratchCfg, err := mysql.ParseDSN(configs.BACKUP_SCRATCH_DSN)
+	if err != nil {
+		return nil, fmt.Errorf("BACKUP_SCRATCH_DSN inválida: %w", err)
+	}
+	appCfg, err := mysql.ParseDSN(configs.DATABASE_URL)
+	if err == nil && appCfg.Addr == scratchCfg.Addr && appCfg.DBName == scratchCfg.DBName {
+		return nil, errors.New("BACKUP_SCRATCH_DSN aponta para o schema da aplicação")
+	}
+
+	return gorm.Open(gormmysql.Open(configs.BACKUP_SCRATCH_DSN), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+}
+
+// readSnapshot baixa e decodifica um backup
+func readSnapshot(ctx context.Context, store Store, key string) (*Snapshot, error) {
+	r, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler backup %s: %w", key, err)
+	}
+	defer r.Close()
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("backup %s corrompido: %w", key, err)
+	}
+	defer gz.Close()
+
+	// UseNumber: IDs e valores monetários sem perda de precisão por float64
+	decoder := json.NewDecoder(gz)
+	decoder.UseNumber()
+
+	var snapshot Snapshot
+	if err := decoder.Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("backup %s corrompido: %w", key, err)
+	}
+	return &snapshot, nil
+}
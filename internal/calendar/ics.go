@@ -0,0 +1,77 @@
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Event representa um evento de dia inteiro no calendário
+type Event struct {
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+}
+
+// WriteICS escreve um calendário iCalendar (RFC 5545) com eventos de dia inteiro
+func WriteICS(w io.Writer, name string, events []Event) error {
+	var b strings.Builder
+
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:-//wedding_planner_service//payments//EN")
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	writeLine(&b, "X-WR-CALNAME:"+escape(name))
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	for _, e := range events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+e.UID)
+		writeLine(&b, "DTSTAMP:"+stamp)
+		writeLine(&b, "DTSTART;VALUE=DATE:"+e.Date.Format("20060102"))
+		writeLine(&b, "DTEND;VALUE=DATE:"+e.Date.AddDate(0, 0, 1).Format("20060102"))
+		writeLine(&b, "SUMMARY:"+escape(e.Summary))
+		if e.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escape(e.Description))
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeLine escreve uma linha com CRLF, dobrando em 75 octetos conforme a RFC
+func writeLine(b *strings.Builder, line string) {
+	// Linhas de continuação começam com espaço, que conta no limite
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		// Não corta no meio de um caractere UTF-8
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// escape escapa caracteres especiais de valores TEXT
+func escape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// UID gera um identificador estável para um evento
+func UID(kind string, id uint) string {
+	return fmt.Sprintf("%s-%d@wedding-planner-service", kind, id)
+}
@@ -0,0 +1,25 @@
+package contacts
+
+import "errors"
+
+// Limite de contatos lidos por importação (agendas maiores devem ser filtradas antes)
+const MaxContacts = 2000
+
+// Erros customizados para melhor tratamento
+var (
+	ErrInvalidVCard      = errors.New("invalid vcard file")
+	ErrTooManyContacts   = errors.New("contact list exceeds the import limit")
+	ErrInvalidGoogleAuth = errors.New("google access token is invalid or lacks the contacts scope")
+)
+
+// Contact representa um contato da agenda já reduzido aos campos usados no convidado
+type Contact struct {
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+	Phone    string `json:"phone"`
+}
+
+// IsEmpty indica um contato sem nenhum dado aproveitável
+func (c Contact) IsEmpty() bool {
+	return c.FullName == "" && c.Email == "" && c.Phone == ""
+}
@@ -0,0 +1,130 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	googleConnectionsURL = "https://people.googleapis.com/v1/people/me/connections"
+
+	// Maior página aceita pela People API
+	googlePageSize = 1000
+
+	// Limite das respostas da API (proteção contra respostas inesperadamente grandes)
+	maxGoogleResponseSize = 8 << 20 // 8MB
+)
+
+var googleClient = &http.Client{Timeout: 15 * time.Second}
+
+// FetchGoogleContacts lista os contatos da conta Google pela People API
+// O access token vem do OAuth feito no front-end (escopo contacts.readonly) e não é armazenado
+func FetchGoogleContacts(ctx context.Context, accessToken string) ([]Contact, error) {
+	var result []Contact
+	pageToken := ""
+	for {
+		page, err := fetchGooglePage(ctx, accessToken, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range page.Connections {
+			c := p.contact()
+			if c.IsEmpty() {
+				continue
+			}
+			if len(result) == MaxContacts {
+				return nil, ErrTooManyContacts
+			}
+			result = append(result, c)
+		}
+		if page.NextPageToken == "" {
+			return result, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+type googleConnectionsPage struct {
+	Connections   []googlePerson `json:"connections"`
+	NextPageToken string         `json:"nextPageToken"`
+}
+
+type googlePerson struct {
+	Names []struct {
+		DisplayName string `json:"displayName"`
+	} `json:"names"`
+	EmailAddresses []struct {
+		Value string `json:"value"`
+	} `json:"emailAddresses"`
+	PhoneNumbers []struct {
+		Value string `json:"value"`
+		Type  string `json:"type"` // mobile, home, work...
+	} `json:"phoneNumbers"`
+}
+
+// contact usa o nome e o email principais (a API lista o principal primeiro) e prefere o celular
+func (p googlePerson) contact() Contact {
+	var c Contact
+	if len(p.Names) > 0 {
+		c.FullName = strings.Join(strings.Fields(p.Names[0].DisplayName), " ")
+	}
+	if len(p.EmailAddresses) > 0 {
+		c.Email = strings.TrimSpace(p.EmailAddresses[0].Value)
+	}
+	for i, phone := range p.PhoneNumbers {
+		if i == 0 || phone.Type == "mobile" {
+			c.Phone = strings.TrimSpace(phone.Value)
+		}
+		if phone.Type == "mobile" {
+			break
+		}
+	}
+	return c
+}
+
+// fetchGooglePage busca uma página de contatos
+func fetchGooglePage(ctx context.Context, accessToken, pageToken string) (*googleConnectionsPage, error) {
+	query := url.Values{
+		"personFields": {"names,emailAddresses,phoneNumbers"},
+		"pageSize":     {fmt.Sprint(googlePageSize)},
+	}
+	if pageToken != "" {
+		query.Set("pageToken", pageToken)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, googleConnectionsURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := googleClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao chamar google people: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxGoogleResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler resposta do google people: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return nil, ErrInvalidGoogleAuth
+	case resp.StatusCode >= 300:
+		return nil, fmt.Errorf("google people retornou %d", resp.StatusCode)
+	}
+
+	var page googleConnectionsPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, fmt.Errorf("resposta inválida do google people: %w", err)
+	}
+	return &page, nil
+}
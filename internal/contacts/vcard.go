@@ -0,0 +1,218 @@
+package contacts
+
+import (
+	"bufio"
+	"io"
+	"mime/quotedprintable"
+	"strings"
+)
+
+// Limite de uma linha desdobrada do vCard (fotos embutidas em base64 chegam a centenas de KB)
+const maxVCardLine = 1 << 20 // 1MB
+
+// vcardProperty representa uma linha "GRUPO.NOME;PARAM=VALOR:valor" já desdobrada
+type vcardProperty struct {
+	name   string
+	params map[string][]string
+	value  string
+}
+
+// ParseVCard lê um arquivo .vcf (vCard 2.1, 3.0 ou 4.0) exportado da agenda do celular,
+// do Google Contacts ou do iCloud; usa o primeiro email e prefere o celular entre os telefones
+// Contatos sem nenhum dado aproveitável são ignorados
+func ParseVCard(r io.Reader) ([]Contact, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result  []Contact
+		current *vcardContact
+	)
+	for _, line := range lines {
+		prop, ok := parseProperty(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VCARD"):
+			if current != nil {
+				return nil, ErrInvalidVCard
+			}
+			current = &vcardContact{}
+		case prop.name == "END" && strings.EqualFold(prop.value, "VCARD"):
+			if current == nil {
+				return nil, ErrInvalidVCard
+			}
+			if c := current.contact(); !c.IsEmpty() {
+				if len(result) == MaxContacts {
+					return nil, ErrTooManyContacts
+				}
+				result = append(result, c)
+			}
+			current = nil
+		case current != nil:
+			current.add(prop)
+		}
+	}
+	if current != nil {
+		return nil, ErrInvalidVCard
+	}
+	return result, nil
+}
+
+// vcardContact acumula as propriedades de um contato até o END:VCARD
+type vcardContact struct {
+	formatted  string
+	structured string
+	email      string
+	phone      string
+	mobile     string
+}
+
+func (v *vcardContact) add(p vcardProperty) {
+	switch p.name {
+	case "FN":
+		v.formatted = p.value
+	case "N":
+		// Family;Given;Additional;Prefix;Suffix
+		parts := splitEscaped(p.value, ';')
+		for len(parts) < 3 {
+			parts = append(parts, "")
+		}
+		v.structured = strings.Join(strings.Fields(parts[1]+" "+parts[2]+" "+parts[0]), " ")
+	case "EMAIL":
+		if v.email == "" {
+			v.email = p.value
+		}
+	case "TEL":
+		value := strings.TrimPrefix(p.value, "tel:")
+		if v.phone == "" {
+			v.phone = value
+		}
+		if v.mobile == "" && p.hasType("CELL") {
+			v.mobile = value
+		}
+	}
+}
+
+func (v *vcardContact) contact() Contact {
+	c := Contact{FullName: v.formatted, Email: v.email, Phone: v.phone}
+	if c.FullName == "" {
+		c.FullName = v.structured
+	}
+	if v.mobile != "" {
+		c.Phone = v.mobile
+	}
+	c.FullName = strings.Join(strings.Fields(c.FullName), " ")
+	c.Email = strings.TrimSpace(c.Email)
+	c.Phone = strings.TrimSpace(c.Phone)
+	return c
+}
+
+// hasType verifica o parâmetro TYPE nas formas "TYPE=CELL", "TYPE=cell,voice" e "CELL" (vCard 2.1)
+func (p vcardProperty) hasType(t string) bool {
+	for _, v := range p.params["TYPE"] {
+		for _, part := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// unfoldLines junta as linhas continuadas (iniciadas por espaço ou tab) e as soft line breaks
+// do quoted-printable do vCard 2.1 (linha terminada em "=")
+func unfoldLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxVCardLine)
+
+	var lines []string
+	continueQP := false
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case len(lines) > 0 && continueQP:
+			lines[len(lines)-1] += line
+		case len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")):
+			lines[len(lines)-1] += line[1:]
+		default:
+			lines = append(lines, line)
+		}
+		last := lines[len(lines)-1]
+		continueQP = strings.Contains(strings.ToUpper(last), "QUOTED-PRINTABLE") && strings.HasSuffix(last, "=")
+		if continueQP {
+			lines[len(lines)-1] = strings.TrimSuffix(last, "=")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, ErrInvalidVCard
+	}
+	return lines, nil
+}
+
+// parseProperty separa nome, parâmetros e valor de uma linha desdobrada
+func parseProperty(line string) (vcardProperty, bool) {
+	colon := strings.IndexByte(line, ':')
+	if colon <= 0 {
+		return vcardProperty{}, false
+	}
+	head, value := line[:colon], line[colon+1:]
+
+	parts := strings.Split(head, ";")
+	name := strings.ToUpper(parts[0])
+	// Grupos como "item1.EMAIL" (exportação da Apple)
+	if dot := strings.LastIndexByte(name, '.'); dot >= 0 {
+		name = name[dot+1:]
+	}
+
+	prop := vcardProperty{name: name, params: map[string][]string{}}
+	for _, param := range parts[1:] {
+		key, val, found := strings.Cut(param, "=")
+		if !found {
+			// vCard 2.1: "TEL;CELL:..." equivale a TYPE=CELL
+			key, val = "TYPE", param
+		}
+		key = strings.ToUpper(key)
+		prop.params[key] = append(prop.params[key], strings.Trim(val, `"`))
+	}
+
+	if encoding := prop.params["ENCODING"]; len(encoding) > 0 && strings.EqualFold(encoding[0], "QUOTED-PRINTABLE") {
+		decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(value)))
+		if err == nil {
+			value = string(decoded)
+		}
+	}
+	if name != "N" {
+		value = unescapeValue(value)
+	}
+	prop.value = value
+	return prop, true
+}
+
+// splitEscaped divide o valor estruturado respeitando separadores escapados ("\;")
+func splitEscaped(value string, sep byte) []string {
+	var parts []string
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			b.WriteByte(value[i])
+			b.WriteByte(value[i+1])
+			i++
+		case value[i] == sep:
+			parts = append(parts, unescapeValue(b.String()))
+			b.Reset()
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return append(parts, unescapeValue(b.String()))
+}
+
+// unescapeValue remove os escapes de texto do vCard (\, \; \n)
+func unescapeValue(value string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/asyncjobs"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/reports"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// addressExportParams são os filtros da exportação de endereços gravados no job
+type addressExportParams struct {
+	Format    string              `json:"format"`
+	Status    models.InviteStatus `json:"status"`
+	Household bool                `json:"household"`
+}
+
+// ExportGuestAddresses exporta os endereços postais para convites impressos
+// ?format=csv|pdf (etiquetas A4 3x8), ?status= filtra pelo status do convite (padrão: todos menos recusados)
+// ?household=true gera uma etiqueta por família quando os membros do grupo compartilham o endereço
+// O arquivo é gerado em segundo plano: responde 202 e o download fica no job (GET /jobs/:id)
+func ExportGuestAddresses(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "pdf" {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "format must be csv or pdf",
+		})
+		return
+	}
+
+	status := models.InviteStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "invalid status filter",
+		})
+		return
+	}
+
+	enqueueJob(c, wedding, models.AsyncJobAddressExport, addressExportParams{
+		Format:    format,
+		Status:    status,
+		Household: c.Query("household") == "true",
+	})
+}
+
+// runAddressExport gera o CSV ou o PDF de etiquetas de um job de exportação de endereços
+func runAddressExport(ctx context.Context, job *models.AsyncJob, progress func(int)) (*asyncjobs.Output, error) {
+	var params addressExportParams
+	if err := asyncjobs.DecodeParams(job, &params); err != nil {
+		return nil, err
+	}
+	wedding, err := loadJobWedding(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	ctxDB := database.WithContext(ctx)
+
+	guests, err := repository.NewGuestRepository(ctxDB).FindByWeddingID(wedding.ID)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to export addresses", err)
+	}
+
+	groupNames := map[uint]string{}
+	if params.Household {
+		groups, err := repository.NewGuestGroupRepository(ctxDB).FindByWeddingID(wedding.ID)
+		if err != nil {
+			return nil, asyncjobs.Fail("unable to export addresses", err)
+		}
+		for _, g := range groups {
+			groupNames[g.ID] = g.Name
+		}
+	}
+	progress(30)
+
+	labels, missing := buildAddressLabels(guests, params.Status, params.Household, groupNames)
+
+	// Convidados elegíveis sem endereço completo: o casal pode pedir pelo link pessoal do convidado
+	out := &asyncjobs.Output{
+		Result:       gin.H{"labels": len(labels), "guests_without_address": missing},
+		DownloadName: fmt.Sprintf("wedding-%d-addresses.%s", wedding.ID, params.Format),
+	}
+
+	if params.Format == "csv" {
+		out.ContentType = "text/csv; charset=utf-8"
+		out.FileName, err = writeExportFile(".csv", func(w io.Writer) error {
+			return reports.WriteAddressCSV(w, labels)
+		})
+		if err != nil {
+			return nil, asyncjobs.Fail("unable to export addresses", err)
+		}
+		return out, nil
+	}
+
+	pdf, err := reports.BuildAddressLabels(labels)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to export addresses", err)
+	}
+	out.ContentType = "application/pdf"
+	out.FileName, err = writeExportFile(".pdf", func(w io.Writer) error {
+		_, err := w.Write(pdf)
+		return err
+	})
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to export addresses", err)
+	}
+	return out, nil
+}
+
+// buildAddressLabels monta as etiquetas e conta os convidados elegíveis sem endereço completo
+// Com household, membros do mesmo grupo no mesmo endereço viram uma única etiqueta com o nome do grupo
+func buildAddressLabels(guests []models.Guest, status models.InviteStatus, household bool, groupNames map[uint]string) ([]reports.AddressLabel, int) {
+	labels := make([]reports.AddressLabel, 0, len(guests))
+	byHousehold := map[string]int{} // grupo + endereço -> índice em labels
+	missing := 0
+
+	for i := range guests {
+		g := &guests[i]
+		if status != "" && g.InviteStatus != status {
+			continue
+		}
+		if status == "" && g.InviteStatus == models.InviteStatusDeclined {
+			continue
+		}
+		if !g.Address.IsComplete() {
+			missing++
+			continue
+		}
+
+		if household && g.GroupID != nil {
+			key := fmt.Sprintf("%d|%s", *g.GroupID, strings.ToLower(strings.Join(g.Address.Lines(), "|")))
+			if idx, ok := byHousehold[key]; ok {
+				labels[idx].Guests++
+				if name := groupNames[*g.GroupID]; name != "" {
+					labels[idx].Name = name
+				}
+				continue
+			}
+			byHousehold[key] = len(labels)
+		}
+
+		labels = append(labels, reports.AddressLabel{
+			GuestID: g.ID,
+			Name:    g.FullName,
+			Address: g.Address,
+			Guests:  1,
+		})
+	}
+
+	// Ordem de postagem: por CEP, depois por nome
+	sort.SliceStable(labels, func(i, j int) bool {
+		if labels[i].Address.PostalCode != labels[j].Address.PostalCode {
+			return labels[i].Address.PostalCode < labels[j].Address.PostalCode
+		}
+		return labels[i].Name < labels[j].Name
+	})
+	return labels, missing
+}
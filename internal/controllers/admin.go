@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/backup"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/metrics"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// reloadConfigResponse lista as configurações alteradas pela recarga
+type reloadConfigResponse struct {
+	Changed []string `json:"changed"`
+}
+
+// ExportTenantBackup gera o backup lógico de uma conta (usuário e seus casamentos) no destino configurado
+func ExportTenantBackup(c *gin.Context) {
+	userID, err := parseIDParam(c, "userId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	store, err := backup.NewStore()
+	if err != nil {
+		log.Printf("[ERROR] Backup store misconfigured: %v", err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "backup store is not configured"})
+		return
+	}
+
+	result, err := backup.Export(c.Request.Context(), database.DB, store, userID)
+	metrics.RecordBackupExport(err)
+	if err != nil {
+		if errors.Is(err, backup.ErrTenantNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse{Error: "user not found"})
+			return
+		}
+		log.Printf("[ERROR] Failed to export backup of user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "failed to export backup"})
+		return
+	}
+
+	log.Printf("[SECURITY] Backup of user %d exported to %s", userID, result.Key)
+	c.JSON(http.StatusCreated, result)
+}
+
+// ReloadConfig recarrega as configurações não críticas sem reiniciar o servidor
+func ReloadConfig(c *gin.Context) {
+	changed, err := configs.Reload()
+	if err != nil {
+		log.Printf("[ERROR] Failed to reload config: %v", err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "failed to reload config"})
+		return
+	}
+
+	if changed == nil {
+		changed = []string{}
+	}
+	c.JSON(http.StatusOK, reloadConfigResponse{Changed: changed})
+}
+
+// userActivityResponse representa a atividade de um usuário na ferramenta administrativa
+type userActivityResponse struct {
+	ID            uint       `json:"id"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastSeenAt    *time.Time `json:"last_seen_at"`
+	WeddingCount  int64      `json:"wedding_count"`
+	GuestCount    int64      `json:"guest_count"`
+	NextEventDate *time.Time `json:"next_event_date"`
+}
+
+// GetUsersActivity lista usuários com a última atividade (mais recentes primeiro)
+// Filtros: ?inactive_days=N (sem atividade há N dias) e ?no_guests=true (contas sem convidados)
+func GetUsersActivity(c *gin.Context) {
+	page, perPage, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	var filter repository.UserActivityFilter
+	if v := c.Query("inactive_days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 1 {
+			c.JSON(http.StatusBadRequest, errorResponse{Error: "invalid inactive_days parameter"})
+			return
+		}
+		since := now.AddDate(0, 0, -days)
+		filter.InactiveSince = &since
+	}
+	if v := c.Query("no_guests"); v != "" {
+		noGuests, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse{Error: "invalid no_guests parameter"})
+			return
+		}
+		filter.NoGuests = noGuests
+	}
+
+	activity, total, err := repository.NewUserRepository(database.WithContext(c.Request.Context())).
+		FindActivityPage(filter, now, page, perPage)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch user activity: %v", err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "unable to fetch users"})
+		return
+	}
+
+	response := make([]userActivityResponse, len(activity))
+	for i, a := range activity {
+		response[i] = userActivityResponse{
+			ID:            a.ID,
+			Name:          a.Name,
+			Email:         a.Email,
+			CreatedAt:     a.CreatedAt,
+			LastSeenAt:    a.LastSeenAt,
+			WeddingCount:  a.WeddingCount,
+			GuestCount:    a.GuestCount,
+			NextEventDate: a.NextEventDate,
+		}
+	}
+
+	c.JSON(http.StatusOK, paginatedResponse[userActivityResponse]{
+		Items:   response,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	})
+}
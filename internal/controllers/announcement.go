@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// announcementResponse representa um comunicado na ferramenta administrativa
+type announcementResponse struct {
+	ID               uint                    `json:"id"`
+	Kind             models.AnnouncementKind `json:"kind"`
+	Title            string                  `json:"title"`
+	Body             string                  `json:"body"`
+	Plan             models.AnnouncementPlan `json:"plan"`
+	ActiveWithinDays int                     `json:"active_within_days"`
+	SendEmail        bool                    `json:"send_email"`
+	Recipients       int                     `json:"recipients"`
+	CreatedAt        time.Time               `json:"created_at"`
+}
+
+// PublishAnnouncement publica um comunicado na caixa de notificações dos usuários do público
+// Público: plan (pro ou free, vazio para todos) e active_within_days (atividade recente, 0 para todos)
+// Com send_email, o email sai em segundo plano para quem não pediu opt-out
+func PublishAnnouncement(c *gin.Context) {
+	var body struct {
+		Kind             models.AnnouncementKind `json:"kind" binding:"required"`
+		Title            string                  `json:"title" binding:"required"`
+		Body             string                  `json:"body" binding:"required"`
+		Plan             models.AnnouncementPlan `json:"plan"`
+		ActiveWithinDays int                     `json:"active_within_days"`
+		SendEmail        bool                    `json:"send_email"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	announcement := models.Announcement{
+		Kind:             body.Kind,
+		Title:            body.Title,
+		Body:             body.Body,
+		Plan:             body.Plan,
+		ActiveWithinDays: body.ActiveWithinDays,
+		SendEmail:        body.SendEmail,
+	}
+	if err := announcement.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	err := repository.NewAnnouncementRepository(database.WithContext(c.Request.Context())).Publish(&announcement, time.Now())
+	if err != nil {
+		log.Printf("[ERROR] Failed to publish announcement: %v", err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "unable to publish announcement"})
+		return
+	}
+
+	log.Printf("[INFO] Announcement %d published to %d users (email: %t)", announcement.ID, announcement.Recipients, announcement.SendEmail)
+	c.JSON(http.StatusCreated, gin.H{
+		"message":      "announcement published successfully",
+		"announcement": toAnnouncementResponse(&announcement),
+	})
+}
+
+// GetAnnouncements lista os comunicados publicados (mais recentes primeiro)
+func GetAnnouncements(c *gin.Context) {
+	page, perPage, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	announcements, total, err := repository.NewAnnouncementRepository(database.WithContext(c.Request.Context())).FindPage(page, perPage)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch announcements: %v", err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "unable to fetch announcements"})
+		return
+	}
+
+	response := make([]announcementResponse, len(announcements))
+	for i := range announcements {
+		response[i] = toAnnouncementResponse(&announcements[i])
+	}
+
+	c.JSON(http.StatusOK, paginatedResponse[announcementResponse]{
+		Items:   response,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	})
+}
+
+// toAnnouncementResponse converte model para response
+func toAnnouncementResponse(a *models.Announcement) announcementResponse {
+	return announcementResponse{
+		ID:               a.ID,
+		Kind:             a.Kind,
+		Title:            a.Title,
+		Body:             a.Body,
+		Plan:             a.Plan,
+		ActiveWithinDays: a.ActiveWithinDays,
+		SendEmail:        a.SendEmail,
+		Recipients:       a.Recipients,
+		CreatedAt:        a.CreatedAt,
+	}
+}
@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/asyncjobs"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/storage"
+)
+
+func init() {
+	// Operações longas executadas pelo job async_jobs (ver asyncjobs.Setup)
+	asyncjobs.Handle(models.AsyncJobLedgerExport, runLedgerExport)
+	asyncjobs.Handle(models.AsyncJobAddressExport, runAddressExport)
+	asyncjobs.Handle(models.AsyncJobFullReport, runFullReport)
+	asyncjobs.Handle(models.AsyncJobGuestImport, runGuestImport)
+	asyncjobs.Handle(models.AsyncJobRescheduleAnnouncement, runRescheduleAnnouncement)
+	asyncjobs.Handle(models.AsyncJobInviteBulk, runInviteBulk)
+}
+
+// asyncJobResponse representa o andamento de uma operação em segundo plano
+type asyncJobResponse struct {
+	ID          uint                  `json:"id"`
+	Kind        models.AsyncJobKind   `json:"kind"`
+	Status      models.AsyncJobStatus `json:"status"`
+	Progress    int                   `json:"progress"`
+	WeddingID   uint                  `json:"wedding_id"`
+	Result      json.RawMessage       `json:"result,omitempty"`
+	Error       string                `json:"error,omitempty"`
+	DownloadURL string                `json:"download_url,omitempty"`
+	StatusURL   string                `json:"status_url"`
+	CreatedAt   time.Time             `json:"created_at"`
+	StartedAt   *time.Time            `json:"started_at"`
+	FinishedAt  *time.Time            `json:"finished_at"`
+	ExpiresAt   *time.Time            `json:"expires_at"`
+}
+
+// GetJob retorna o progresso, o resultado e o link de download de um job do usuário
+func GetJob(c *gin.Context) {
+	job, ok := loadUserJob(c)
+	if !ok {
+		return
+	}
+
+	// Enquanto o job não termina, o cliente deve consultar de novo
+	if !job.IsFinished() {
+		c.Header("Retry-After", "2")
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job": toAsyncJobResponse(job),
+	})
+}
+
+// DownloadJobResult baixa o arquivo gerado por um job concluído
+func DownloadJobResult(c *gin.Context) {
+	job, ok := loadUserJob(c)
+	if !ok {
+		return
+	}
+
+	if job.Status != models.AsyncJobSucceeded || job.FileName == "" {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "job has no file to download",
+		})
+		return
+	}
+
+	c.Header("Content-Type", job.ContentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, job.DownloadName))
+	servePhotoFile(c, storage.KindExport, job.FileName, "private, no-store")
+}
+
+// loadUserJob carrega o job indicado na rota se ele pertencer ao usuário autenticado
+func loadUserJob(c *gin.Context) (*models.AsyncJob, bool) {
+	jobID, err := parseIDParam(c, "jobId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return nil, false
+	}
+
+	// Segurança: Verifica acesso no ponto central de autorização
+	job, err := authz.LoadAsyncJob(database.WithContext(c.Request.Context()), currentSubject(c), jobID, authz.ActionForMethod(c.Request.Method))
+	if err != nil {
+		if authz.IsNotFound(err) {
+			respondAccessError(c, err)
+			return nil, false
+		}
+		log.Printf("[ERROR] Failed to fetch job %d: %v", jobID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch job",
+		})
+		return nil, false
+	}
+	return job, true
+}
+
+// enqueueJob grava o job na fila e responde 202 com o link de acompanhamento (GET /jobs/:id)
+func enqueueJob(c *gin.Context, wedding *models.Wedding, kind models.AsyncJobKind, params any) {
+	job := &models.AsyncJob{
+		UserID:    c.GetUint("user_id"),
+		WeddingID: wedding.ID,
+		Kind:      kind,
+	}
+
+	if err := asyncjobs.Enqueue(database.WithContext(c.Request.Context()), job, params); err != nil {
+		log.Printf("[ERROR] Failed to enqueue %s job of wedding %d: %v", kind, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to start job",
+		})
+		return
+	}
+
+	c.Header("Location", jobPath(job.ID))
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "job queued",
+		"job":     toAsyncJobResponse(job),
+	})
+}
+
+// loadJobWedding carrega o casamento de um job (a autorização foi feita ao enfileirar)
+func loadJobWedding(ctx context.Context, job *models.AsyncJob) (*models.Wedding, error) {
+	wedding, err := repository.NewWeddingRepository(database.WithContext(ctx)).FindByID(job.WeddingID)
+	if err != nil {
+		return nil, asyncjobs.Fail("wedding not found", err)
+	}
+	return wedding, nil
+}
+
+// writeExportFile grava um arquivo gerado por um job no storage
+// Em caso de falha o arquivo parcial é removido
+func writeExportFile(ext string, write func(w io.Writer) error) (string, error) {
+	f, name, err := storage.Create(storage.KindExport, ext)
+	if err != nil {
+		return "", err
+	}
+
+	err = write(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = storage.Remove(storage.KindExport, name)
+		return "", err
+	}
+	return name, nil
+}
+
+// jobPath retorna o caminho de acompanhamento de um job
+func jobPath(id uint) string {
+	return fmt.Sprintf("/api/v1/jobs/%d", id)
+}
+
+// toAsyncJobResponse converte model para response
+func toAsyncJobResponse(j *models.AsyncJob) asyncJobResponse {
+	response := asyncJobResponse{
+		ID:         j.ID,
+		Kind:       j.Kind,
+		Status:     j.Status,
+		Progress:   j.Progress,
+		WeddingID:  j.WeddingID,
+		Error:      j.Error,
+		StatusURL:  jobPath(j.ID),
+		CreatedAt:  j.CreatedAt,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+		ExpiresAt:  j.ExpiresAt,
+	}
+	if j.Result != "" {
+		response.Result = json.RawMessage(j.Result)
+	}
+	if j.Status == models.AsyncJobSucceeded && j.FileName != "" {
+		response.DownloadURL = jobPath(j.ID) + "/download"
+	}
+	return response
+}
@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/calendar"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+)
+
+// RotatePaymentsFeedToken gera (ou regenera) o link privado do feed iCal de pagamentos
+// Segurança: Regenerar invalida o link anterior, útil se ele vazar
+func RotatePaymentsFeedToken(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, errorResponse{
+			Error: "authentication required",
+		})
+		return
+	}
+
+	repo := repository.NewUserRepository(database.WithContext(c.Request.Context()))
+	user, err := repo.FindByID(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "user not found",
+		})
+		return
+	}
+
+	token, err := security.RandomToken(32)
+	if err != nil {
+		log.Printf("[ERROR] Failed to generate calendar token for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to generate feed link",
+		})
+		return
+	}
+	user.CalendarToken = &token
+
+	if err := repo.Update(user); err != nil {
+		log.Printf("[ERROR] Failed to save calendar token for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to generate feed link",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "payments feed link generated successfully",
+		"feed_path": fmt.Sprintf("/api/v1/public/calendar/%s/payments.ics", token),
+	})
+}
+
+// GetPaymentsFeed retorna o feed iCal com todos os vencimentos de fornecedores e gastos
+// Autenticado pelo token na URL, pois aplicativos de calendário não enviam Authorization
+func GetPaymentsFeed(c *gin.Context) {
+	token := c.Param("token")
+	if len(token) != 64 {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "feed not found",
+		})
+		return
+	}
+
+	user, err := repository.NewUserRepository(database.WithContext(c.Request.Context())).FindByCalendarToken(token)
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "feed not found",
+		})
+		return
+	}
+
+	vendorPayments, err := repository.NewVendorRepository(database.WithContext(c.Request.Context())).FindDueByUserID(user.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch vendor payments for user %d: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to build feed",
+		})
+		return
+	}
+
+	expenses, err := repository.NewBudgetRepository(database.WithContext(c.Request.Context())).FindDueExpensesByUserID(user.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch expenses for user %d: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to build feed",
+		})
+		return
+	}
+
+	events := make([]calendar.Event, 0, len(vendorPayments)+len(expenses))
+	for _, wv := range vendorPayments {
+		events = append(events, calendar.Event{
+			UID:         calendar.UID("wedding-vendor", wv.ID),
+			Date:        *wv.DueDate,
+			Summary:     fmt.Sprintf("Payment due: %s (%.2f)", wv.Vendor.Name, wv.Price),
+			Description: fmt.Sprintf("Wedding at %s on %s", wv.Wedding.VenueName, wv.Wedding.EventDate.Format("02/01/2006")),
+		})
+	}
+	for _, e := range expenses {
+		events = append(events, calendar.Event{
+			UID:         calendar.UID("expense", e.ID),
+			Date:        *e.DueDate,
+			Summary:     fmt.Sprintf("Payment due: %s (%.2f)", e.Category, e.Amount),
+			Description: fmt.Sprintf("%s - wedding at %s", e.Description, e.Wedding.VenueName),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := calendar.WriteICS(&buf, "Wedding payments", events); err != nil {
+		log.Printf("[ERROR] Failed to write payments feed for user %d: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to build feed",
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
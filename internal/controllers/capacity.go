@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// capacityResponse resume a lotação do local para o painel do casal
+type capacityResponse struct {
+	MaxGuests          int `json:"max_guests"`
+	ConfirmedHeadcount int `json:"confirmed_headcount"` // convidados + acompanhantes confirmados
+
+	// Confirmados em cada parte do casamento (quem vai às duas conta nas duas)
+	ByEvent *repository.EventHeadcount `json:"by_event"`
+
+	Enforced     bool   `json:"enforced"`
+	OverCapacity bool   `json:"over_capacity"`
+	Warning      string `json:"warning,omitempty"`
+}
+
+// weddingCapacity calcula a lotação do casamento
+// Falhas são apenas registradas: o aviso é complementar e não deve derrubar a resposta principal
+func weddingCapacity(c *gin.Context, wedding *models.Wedding) *capacityResponse {
+	repo := repository.NewWeddingRepository(database.WithContext(c.Request.Context()))
+
+	headcount, err := repo.ConfirmedHeadcount(wedding)
+	if err != nil {
+		log.Printf("[ERROR] Failed to count confirmed headcount of wedding %d: %v", wedding.ID, err)
+		return nil
+	}
+	byEvent, err := repo.ConfirmedHeadcountByEvent(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to count confirmed headcount by event of wedding %d: %v", wedding.ID, err)
+		return nil
+	}
+
+	capacity := &capacityResponse{
+		MaxGuests:          wedding.MaxGuests,
+		ConfirmedHeadcount: headcount,
+		ByEvent:            byEvent,
+		Enforced:           wedding.EnforceCapacity,
+		OverCapacity:       headcount > wedding.MaxGuests,
+	}
+	if capacity.OverCapacity {
+		capacity.Warning = "confirmed headcount exceeds the venue capacity"
+	}
+	return capacity
+}
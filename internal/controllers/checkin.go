@@ -0,0 +1,228 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/qrcode"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+)
+
+// checkInTokenPurpose separa os tokens de check-in de outros tokens assinados
+const checkInTokenPurpose = "guest-checkin"
+
+// Pixels por módulo do QR Code (boa leitura impresso ou na tela do celular)
+const checkInQRScale = 8
+
+// checkInResponse representa o resultado de um check-in
+type checkInResponse struct {
+	Guest            guestResponse `json:"guest"`
+	AlreadyCheckedIn bool          `json:"already_checked_in"`
+	WalkIn           bool          `json:"walk_in"` // convidado sem confirmação de presença
+}
+
+// checkInStatsResponse representa a situação da portaria em tempo real
+type checkInStatsResponse struct {
+	ExpectedGuests  int64   `json:"expected_guests"`
+	ArrivedGuests   int64   `json:"arrived_guests"`
+	WalkIns         int64   `json:"walk_ins"`
+	ExpectedPeople  int64   `json:"expected_people"` // convidados e acompanhantes confirmados
+	ArrivedPeople   int64   `json:"arrived_people"`
+	ArrivalProgress float64 `json:"arrival_progress"` // arrived_people / expected_people
+}
+
+// GetGuestCheckInQRCode gera o QR Code de check-in de um convidado confirmado
+// O código carrega apenas um token assinado: nenhum dado pessoal fica legível no convite
+func GetGuestCheckInQRCode(c *gin.Context) {
+	_, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return
+	}
+
+	if guest.InviteStatus != models.InviteStatusConfirmed {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "guest has not confirmed attendance",
+		})
+		return
+	}
+
+	code, err := qrcode.Encode(security.SignID(checkInTokenPurpose, guest.ID))
+	if err != nil {
+		log.Printf("[ERROR] Failed to encode check-in QR code for guest %d: %v", guest.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to generate qr code",
+		})
+		return
+	}
+	image, err := code.PNG(checkInQRScale)
+	if err != nil {
+		log.Printf("[ERROR] Failed to render check-in QR code for guest %d: %v", guest.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to generate qr code",
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="checkin-%d.png"`, guest.ID))
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, "image/png", image)
+}
+
+// CheckInGuest registra a chegada de um convidado pelo token do QR Code ou pelo ID (busca manual)
+// Convidados sem confirmação exigem force=true; repetir o check-in mantém o horário original
+func CheckInGuest(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var checkInData struct {
+		Token   string `json:"token"`
+		GuestID uint   `json:"guest_id"`
+		Force   bool   `json:"force"`
+	}
+
+	if err := c.ShouldBindJSON(&checkInData); err != nil || (checkInData.Token == "") == (checkInData.GuestID == 0) {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "provide either token or guest_id",
+		})
+		return
+	}
+
+	guestID := checkInData.GuestID
+	if checkInData.Token != "" {
+		id, err := security.VerifySignedID(checkInTokenPurpose, checkInData.Token)
+		if err != nil {
+			c.JSON(http.StatusNotFound, errorResponse{
+				Error: "invalid check-in code",
+			})
+			return
+		}
+		guestID = id
+	}
+
+	repo := repository.NewGuestRepository(database.WithContext(c.Request.Context()))
+
+	// Segurança: Um QR Code de outro casamento é tratado como inexistente
+	guest, err := repo.FindByIDAndWeddingID(guestID, wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("guest"))
+		return
+	}
+
+	walkIn := guest.InviteStatus != models.InviteStatusConfirmed
+	if walkIn && !checkInData.Force && guest.CheckedInAt == nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "guest has not confirmed attendance",
+			"guest": toGuestResponse(guest),
+		})
+		return
+	}
+
+	created, err := repo.MarkCheckedIn(guest, time.Now())
+	if err != nil {
+		log.Printf("[ERROR] Failed to check in guest %d of wedding %d: %v", guest.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to check in guest",
+		})
+		return
+	}
+	if !created && guest.CheckedInAt == nil {
+		// Check-in simultâneo em outra leitura: recarrega o horário gravado
+		if guest, err = repo.FindByIDAndWeddingID(guest.ID, wedding.ID); err != nil {
+			respondAccessError(c, authz.NotFound("guest"))
+			return
+		}
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, checkInResponse{
+		Guest:            toGuestResponse(guest),
+		AlreadyCheckedIn: !created,
+		WalkIn:           walkIn,
+	})
+}
+
+// UndoCheckIn desfaz o check-in de um convidado (leitura por engano)
+func UndoCheckIn(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	guestID, err := parseIDParam(c, "guestId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	repo := repository.NewGuestRepository(database.WithContext(c.Request.Context()))
+
+	guest, err := repo.FindByIDAndWeddingID(guestID, wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("guest"))
+		return
+	}
+
+	if err := repo.ClearCheckIn(guest); err != nil {
+		log.Printf("[ERROR] Failed to undo check-in of guest %d of wedding %d: %v", guest.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to undo check-in",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "check-in undone successfully",
+		"guest":   toGuestResponse(guest),
+	})
+}
+
+// GetCheckInStats retorna chegadas e esperados do casamento (atualizado a cada chamada)
+func GetCheckInStats(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	stats, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).CheckInStatsByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch check-in stats of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch check-in stats",
+		})
+		return
+	}
+
+	// Painel consultado repetidamente na portaria: nunca servir do cache
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, toCheckInStatsResponse(stats))
+}
+
+// toCheckInStatsResponse converte os totais da portaria, com pessoas (convidados e acompanhantes) e progresso
+func toCheckInStatsResponse(stats *repository.CheckInStats) checkInStatsResponse {
+	response := checkInStatsResponse{
+		ExpectedGuests: stats.ExpectedGuests,
+		ArrivedGuests:  stats.ArrivedGuests,
+		WalkIns:        stats.WalkIns,
+		ExpectedPeople: stats.ExpectedGuests + stats.ExpectedCompanions,
+		ArrivedPeople:  stats.ArrivedGuests + stats.WalkIns + stats.ArrivedCompanions,
+	}
+	if response.ExpectedPeople > 0 {
+		response.ArrivalProgress = math.Round(float64(response.ArrivedPeople)/float64(response.ExpectedPeople)*10000) / 10000
+	}
+	return response
+}
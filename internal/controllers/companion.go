@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// companionResponse representa a resposta padronizada de acompanhante
+type companionResponse struct {
+	ID          uint                     `json:"id"`
+	GuestID     uint                     `json:"guest_id"`
+	FullName    string                   `json:"full_name"`
+	Confirmed   bool                     `json:"confirmed"`
+	ConfirmedAt *time.Time               `json:"confirmed_at"`
+	MealOption  models.MealOption        `json:"meal_option"`
+	AgeGroup    models.CompanionAgeGroup `json:"age_group"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+}
+
+// CreateCompanion registra um acompanhante nomeado do convidado
+func CreateCompanion(c *gin.Context) {
+	wedding, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return
+	}
+
+	var createData struct {
+		FullName   string                   `json:"full_name" binding:"required"`
+		Confirmed  bool                     `json:"confirmed"`
+		MealOption models.MealOption        `json:"meal_option"`
+		AgeGroup   models.CompanionAgeGroup `json:"age_group"`
+	}
+
+	if err := c.ShouldBindJSON(&createData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	companion := models.Companion{
+		GuestID:    guest.ID,
+		WeddingID:  wedding.ID,
+		FullName:   createData.FullName,
+		Confirmed:  createData.Confirmed,
+		MealOption: createData.MealOption,
+		AgeGroup:   createData.AgeGroup,
+	}
+	if companion.Confirmed {
+		now := time.Now()
+		companion.ConfirmedAt = &now
+	}
+
+	if err := companion.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repository.NewCompanionRepository(database.WithContext(c.Request.Context())).Create(&companion); err != nil {
+		if errors.Is(err, repository.ErrCompanionLimit) {
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: "guest has reached the companion limit",
+			})
+			return
+		}
+		log.Printf("[ERROR] Failed to create companion for guest %d of wedding %d: %v", guest.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to create companion",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":   "companion created successfully",
+		"companion": toCompanionResponse(&companion),
+	})
+}
+
+// GetCompanions lista os acompanhantes do convidado
+func GetCompanions(c *gin.Context) {
+	wedding, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return
+	}
+
+	companions, err := repository.NewCompanionRepository(database.WithContext(c.Request.Context())).FindByGuestID(guest.ID, wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch companions of guest %d: %v", guest.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch companions",
+		})
+		return
+	}
+
+	response := make([]companionResponse, len(companions))
+	confirmed := 0
+	for i := range companions {
+		response[i] = toCompanionResponse(&companions[i])
+		if companions[i].Confirmed {
+			confirmed++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"companions": response,
+		"count":      len(response),
+		"confirmed":  confirmed,
+		"limit":      guest.CompanionLimit(),
+	})
+}
+
+// UpdateCompanion atualiza o nome e/ou a confirmação de um acompanhante
+// Confirmações entram no total de acompanhantes confirmados do casamento
+func UpdateCompanion(c *gin.Context) {
+	wedding, companion, ok := loadGuestCompanion(c)
+	if !ok {
+		return
+	}
+
+	var updateData struct {
+		FullName   *string                   `json:"full_name"`
+		Confirmed  *bool                     `json:"confirmed"`
+		MealOption *models.MealOption        `json:"meal_option"`
+		AgeGroup   *models.CompanionAgeGroup `json:"age_group"`
+	}
+
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if updateData.FullName != nil {
+		companion.FullName = *updateData.FullName
+	}
+	if updateData.MealOption != nil {
+		companion.MealOption = *updateData.MealOption
+	}
+	if updateData.AgeGroup != nil {
+		companion.AgeGroup = *updateData.AgeGroup
+	}
+	confirmed := companion.Confirmed
+	if updateData.Confirmed != nil {
+		confirmed = *updateData.Confirmed
+	}
+
+	if err := companion.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repository.NewCompanionRepository(database.WithContext(c.Request.Context())).Update(companion, confirmed); err != nil {
+		log.Printf("[ERROR] Failed to update companion %d of wedding %d: %v", companion.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to update companion",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "companion updated successfully",
+		"companion": toCompanionResponse(companion),
+	})
+}
+
+// DeleteCompanion remove um acompanhante do convidado
+func DeleteCompanion(c *gin.Context) {
+	wedding, companion, ok := loadGuestCompanion(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.NewCompanionRepository(database.WithContext(c.Request.Context())).Delete(companion); err != nil {
+		log.Printf("[ERROR] Failed to delete companion %d of wedding %d: %v", companion.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to delete companion",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "companion deleted successfully",
+	})
+}
+
+// loadGuestCompanion extrai o casamento :id, o convidado :guestId e o acompanhante :companionId
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func loadGuestCompanion(c *gin.Context) (*models.Wedding, *models.Companion, bool) {
+	wedding, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	companionID, err := parseIDParam(c, "companionId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	companion, err := repository.NewCompanionRepository(database.WithContext(c.Request.Context())).FindByIDAndGuestID(companionID, guest.ID, wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("companion"))
+		return nil, nil, false
+	}
+
+	return wedding, companion, true
+}
+
+// toCompanionResponse converte model para response
+func toCompanionResponse(c *models.Companion) companionResponse {
+	return companionResponse{
+		ID:          c.ID,
+		GuestID:     c.GuestID,
+		FullName:    c.FullName,
+		Confirmed:   c.Confirmed,
+		ConfirmedAt: c.ConfirmedAt,
+		MealOption:  c.MealOption,
+		AgeGroup:    c.AgeGroup,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
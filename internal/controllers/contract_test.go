@@ -0,0 +1,373 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/database/dbtest"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+)
+
+// Regrava os arquivos testdata/*.golden.json: go test ./internal/controllers -run Contract -update
+var updateGolden = flag.Bool("update", false, "rewrite golden files")
+
+// Data fixa usada em todos os campos de data dos fixtures
+var fixtureTime = time.Date(2030, 6, 15, 18, 30, 0, 0, time.UTC)
+
+// Campos calculados a partir do relógio: o valor é mascarado, a presença e o nome continuam no contrato
+var volatileFields = map[string]bool{
+	"days_remaining":         true,
+	"days_since":             true,
+	"years_married":          true,
+	"next_anniversary":       true,
+	"days_until_anniversary": true,
+}
+
+// TestResponseContracts fixa o formato JSON das respostas da API (nomes, tipos e omissões)
+// Os clientes gerados do app dependem desses nomes: uma mudança aqui exige versão nova do contrato
+func TestResponseContracts(t *testing.T) {
+	tests := []struct {
+		name  string
+		value func() any
+	}{
+		{"announcement", func() any { return toAnnouncementResponse(fixture[models.Announcement]()) }},
+		{"async_job", func() any {
+			job := fixture[models.AsyncJob]()
+			job.Status = models.AsyncJobSucceeded
+			job.Result = `{"sent":1,"failed":0}`
+			return toAsyncJobResponse(job)
+		}},
+		{"companion", func() any { return toCompanionResponse(fixture[models.Companion]()) }},
+		{"embed_countdown", func() any {
+			// Casamento já realizado: o status não muda com o relógio
+			wedding := fixture[models.Wedding]()
+			wedding.EventDate = time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)
+			return toEmbedCountdownResponse(wedding, fixture[models.User]())
+		}},
+		{"event_info", func() any { return toEventInfoResponse(fixture[models.EventInfo]()) }},
+		{"fundraising", func() any { return toFundraisingResponse(fixture[models.Fundraising](), "BRL") }},
+		{"guest", func() any { return toGuestResponse(fixture[models.Guest]()) }},
+		{"guest_group", func() any { return toGuestGroupResponse(fixture[models.GuestGroup]()) }},
+		{"guest_photo", func() any { return toGuestPhotoResponse(fixture[models.GuestPhoto]()) }},
+		{"guest_tag", func() any { return toGuestTagResponse(fixture[models.GuestTag]()) }},
+		{"invite", func() any { return toInviteResponse(fixture[models.Invite]()) }},
+		{"invite_delivery", func() any {
+			delivery := fixture[models.InviteDelivery]()
+			delivery.Payload = inviteDeliveryPayload("ana@example.com", "Convite", "Olá, Ana!")
+			return toInviteDeliveryResponse(delivery)
+		}},
+		{"invite_template", func() any { return toInviteTemplateResponse(fixture[models.InviteTemplate]()) }},
+		{"ledger_entry", func() any { return toLedgerEntryResponse(fixture[models.LedgerEntry](), "BRL") }},
+		{"print_item", func() any { return toPrintItemResponse(fixture[models.PrintOrderItem]()) }},
+		{"print_order", func() any { return toPrintOrderResponse(fixture[models.PrintOrder]()) }},
+		{"public_address", func() any { return toPublicAddressResponse(fixture[models.Wedding]()) }},
+		{"rsvp_answers", func() any { return toRSVPAnswerResponses([]models.RSVPAnswer{*fixture[models.RSVPAnswer]()}) }},
+		{"rsvp_question", func() any { return toRSVPQuestionResponse(fixture[models.RSVPQuestion]()) }},
+		{"theme", func() any { return toThemeResponse(fixture[models.WeddingTheme]()) }},
+		{"user", func() any { return toUserResponse(fixture[models.User]()) }},
+		{"vendor", func() any { return toVendorResponse(fixture[models.Vendor]()) }},
+		{"wedding", func() any { return toWeddingResponse(fixture[models.Wedding]()) }},
+		{"wedding_vendor", func() any { return toWeddingVendorResponse(fixture[models.WeddingVendor](), "BRL") }},
+		{"whatsapp_account", func() any { return toWhatsAppAccountResponse(fixture[models.WhatsAppAccount]()) }},
+		{"paginated_guests", func() any {
+			return paginatedResponse[guestResponse]{
+				Items:   []guestResponse{toGuestResponse(fixture[models.Guest]())},
+				Total:   1,
+				Page:    1,
+				PerPage: 20,
+			}
+		}},
+		{"error", func() any { return errorResponse{Error: "wedding not found"} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(tt.value())
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			assertGolden(t, tt.name, body)
+		})
+	}
+}
+
+// TestErrorContracts fixa o corpo dos erros respondidos pelos helpers compartilhados
+func TestErrorContracts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		status     int
+		respond    func(c *gin.Context)
+		requestURL string
+	}{
+		{"error_not_found", http.StatusNotFound, func(c *gin.Context) {
+			respondAccessError(c, authz.NotFound("guest"))
+		}, "/"},
+		{"error_bind", http.StatusBadRequest, func(c *gin.Context) {
+			var body struct {
+				Name string `json:"name" binding:"required"`
+			}
+			respondBindError(c, c.ShouldBindJSON(&body))
+		}, "/"},
+		{"error_internal", http.StatusInternalServerError, func(c *gin.Context) {
+			respondAccessError(c, errors.New("connection refused"))
+		}, "/"},
+		{"error_pagination", http.StatusBadRequest, func(c *gin.Context) {
+			parsePagination(c)
+		}, "/?page=abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			c.Request = httptest.NewRequest(http.MethodPost, tt.requestURL, strings.NewReader("{}"))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			tt.respond(c)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.status, rec.Body.String())
+			}
+			assertGolden(t, tt.name, rec.Body.Bytes())
+		})
+	}
+}
+
+// TestHandlerContracts fixa os envelopes montados nos handlers (gin.H), que não passam pelos to*Response
+// Cada caso roda o handler com o banco em memória; nas agregações (GROUP BY), o banco em memória
+// devolve as linhas cadastradas na tabela como o resultado da agregação
+func TestHandlerContracts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	future := time.Date(2099, 6, 15, 0, 0, 0, 0, time.UTC)
+	past := time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)
+	wedding := &models.Wedding{ID: 10, UserID: proUserID, VenueName: "Espaço", Currency: "BRL", EventDate: future, MaxGuests: 100}
+
+	tests := []struct {
+		name    string
+		status  int
+		seed    func(fake *dbtest.DB)
+		handler gin.HandlerFunc
+		url     string
+		body    string
+		params  gin.Params
+		wedding *models.Wedding
+	}{
+		{name: "fundraising_summary", status: http.StatusOK, handler: GetFundraisingSummary, seed: func(fake *dbtest.DB) {
+			fake.Insert("fundraisings",
+				dbtest.Row{"wedding_id": 10, "type": string(models.FundraisingTypeGift), "status": string(models.FundraisingStatusReceived), "total": 350.0, "count": 2},
+				dbtest.Row{"wedding_id": 10, "type": string(models.FundraisingTypeGift), "status": string(models.FundraisingStatusRefunded), "total": 80.0, "count": 1},
+			)
+		}},
+		{name: "countdown", status: http.StatusOK, handler: GetCountdown},
+		{name: "countdown_anniversary", status: http.StatusOK, handler: GetCountdown, wedding: &models.Wedding{ID: 10, UserID: proUserID, EventDate: past}},
+		{name: "guest_stats", status: http.StatusOK, handler: GetGuestStats, seed: func(fake *dbtest.DB) {
+			fake.Insert("guests", dbtest.Row{
+				"wedding_id": 10, "invite_status": string(models.InviteStatusConfirmed), "guests": 3, "seats": 7,
+				"children_age0_to3": 1, "children_age4_to10": 2, "children_age11_plus": 0,
+			})
+		}},
+		{name: "dietary_report", status: http.StatusOK, handler: GetDietaryReport, seed: func(fake *dbtest.DB) {
+			fake.Insert("guests", dbtest.Row{
+				"id": 7, "wedding_id": 10, "full_name": "Ana", "invite_status": string(models.InviteStatusConfirmed),
+				"meal_option": string(models.MealOptionVegan), "count": 1, "children": 0, "dietary_restrictions": "sem lactose",
+			})
+		}},
+		{name: "transport_report", status: http.StatusOK, handler: GetTransportReport, seed: func(fake *dbtest.DB) {
+			fake.Insert("guests", dbtest.Row{
+				"id": 7, "wedding_id": 10, "full_name": "Ana", "invite_status": string(models.InviteStatusConfirmed), "max_guests": 2,
+				"logistics_out_of_town": true, "logistics_needs_hotel": true, "logistics_hotel_name": "Hotel Central",
+				"logistics_needs_shuttle": true, "logistics_pickup_point": "Praça", "logistics_shuttle_seats": 2,
+			})
+		}},
+		{name: "checkin_stats", status: http.StatusOK, handler: GetCheckInStats},
+		{name: "event_day", status: http.StatusOK, handler: GetEventDayDashboard, url: "/?clock=20:00",
+			wedding: &models.Wedding{ID: 10, UserID: proUserID, EventDate: past},
+			seed: func(fake *dbtest.DB) {
+				fake.Insert("event_infos", dbtest.Row{"id": 1, "wedding_id": 10, "highlights": `[{"time":"19:00","title":"Cerimônia","event":"ceremony"}]`})
+			}},
+		{name: "public_rsvp_result", status: http.StatusOK, handler: SubmitPublicRSVP,
+			body:   `{"response":"confirmed","companions":[{"full_name":"Bruno","meal_option":"fish","age_group":"adult"}]}`,
+			params: gin.Params{{Key: "token", Value: security.SignID(rsvpTokenPurpose, rsvpTestGuestID)}},
+			seed: func(fake *dbtest.DB) {
+				fake.Insert("weddings", dbtest.Row{"id": 10, "user_id": proUserID, "venue_name": "Espaço", "max_guests": 100, "event_date": future})
+				fake.Insert("guests", dbtest.Row{"id": rsvpTestGuestID, "wedding_id": 10, "full_name": "Ana", "invite_status": string(models.InviteStatusSent), "max_guests": 2, "events": string(models.WeddingEventBoth)})
+			}},
+		{name: "public_rsvp_closed", status: http.StatusConflict, handler: SubmitPublicRSVP, body: `{"response":"declined"}`,
+			params: gin.Params{{Key: "token", Value: security.SignID(rsvpTokenPurpose, rsvpTestGuestID)}},
+			seed: func(fake *dbtest.DB) {
+				fake.Insert("weddings", dbtest.Row{"id": 10, "user_id": proUserID, "venue_name": "Espaço", "event_date": future, "rsvp_deadline": past})
+				fake.Insert("guests", dbtest.Row{"id": rsvpTestGuestID, "wedding_id": 10, "full_name": "Ana", "invite_status": string(models.InviteStatusSent)})
+			}},
+		{name: "opt_out_confirmation", status: http.StatusOK, handler: GetOptOut,
+			params: gin.Params{{Key: "token", Value: security.SignID(optOutTokenPurpose, rsvpTestGuestID)}},
+			seed: func(fake *dbtest.DB) {
+				fake.Insert("guests", dbtest.Row{"id": rsvpTestGuestID, "wedding_id": 10, "full_name": "Ana"})
+			}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, fake := dbtest.Open()
+			if tt.seed != nil {
+				tt.seed(fake)
+			}
+			previous := database.DB
+			database.DB = db
+			t.Cleanup(func() { database.DB = previous })
+
+			url, method := tt.url, http.MethodGet
+			if url == "" {
+				url = "/"
+			}
+			if tt.body != "" {
+				method = http.MethodPost
+			}
+			current := tt.wedding
+			if current == nil {
+				current = wedding
+			}
+
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			c.Request = httptest.NewRequest(method, url, strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = tt.params
+			c.Set("user_id", proUserID)
+			c.Set("wedding", current)
+			tt.handler(c)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.status, rec.Body.String())
+			}
+			assertGolden(t, tt.name, rec.Body.Bytes())
+		})
+	}
+}
+
+// assertGolden compara o JSON com testdata/<name>.golden.json (indentado, campos mascarados)
+func assertGolden(t *testing.T, name string, body []byte) {
+	t.Helper()
+
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("invalid JSON %s: %v", body, err)
+	}
+	maskVolatile(decoded)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(decoded); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.Bytes()
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if *updateGolden {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("missing golden file (run with -update): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("response contract changed for %s\n--- got\n%s\n--- want\n%s", name, got, want)
+	}
+}
+
+// maskVolatile substitui os campos calculados a partir do relógio
+func maskVolatile(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if volatileFields[key] {
+				v[key] = "<volatile>"
+				continue
+			}
+			maskVolatile(value)
+		}
+	case []any:
+		for _, item := range v {
+			maskVolatile(item)
+		}
+	}
+}
+
+// fixture cria o model com todos os campos preenchidos de forma determinística
+// Strings recebem o nome do campo, números 1, datas fixtureTime e listas um elemento
+func fixture[T any]() *T {
+	var v T
+	fill(reflect.ValueOf(&v).Elem(), "", 0)
+	return &v
+}
+
+// Profundidade máxima das associações preenchidas (User -> Weddings -> User...)
+const maxFixtureDepth = 2
+
+func fill(v reflect.Value, name string, depth int) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(strings.ToLower(name))
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	case reflect.Pointer:
+		if depth > maxFixtureDepth {
+			return
+		}
+		elem := reflect.New(v.Type().Elem())
+		fill(elem.Elem(), name, depth+1)
+		v.Set(elem)
+	case reflect.Slice:
+		if depth > maxFixtureDepth || v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		slice := reflect.MakeSlice(v.Type(), 1, 1)
+		fill(slice.Index(0), name, depth+1)
+		v.Set(slice)
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			v.Set(reflect.ValueOf(fixtureTime))
+			return
+		}
+		if depth > maxFixtureDepth {
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fill(v.Field(i), field.Name, depth+1)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/lifecycle"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+)
+
+// UpdateEmailPreferences liga ou desliga os emails de ciclo de vida (dicas e lembretes) da conta
+func UpdateEmailPreferences(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, errorResponse{
+			Error: "authentication required",
+		})
+		return
+	}
+
+	var requestData struct {
+		LifecycleEmails *bool `json:"lifecycle_emails" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&requestData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	var optOutAt *time.Time
+	if !*requestData.LifecycleEmails {
+		now := time.Now()
+		optOutAt = &now
+	}
+
+	if err := repository.NewUserRepository(database.WithContext(c.Request.Context())).SetLifecycleOptOut(userID.(uint), optOutAt); err != nil {
+		log.Printf("[ERROR] Failed to update email preferences for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to update email preferences",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":          "email preferences updated successfully",
+		"lifecycle_emails": *requestData.LifecycleEmails,
+	})
+}
+
+// UnsubscribeLifecycleEmails descadastra o usuário dos emails de ciclo de vida pelo link do email
+// Aceita GET (clique no link) e POST (one-click unsubscribe, RFC 8058)
+func UnsubscribeLifecycleEmails(c *gin.Context) {
+	userID, err := security.VerifySignedID(lifecycle.UnsubscribeTokenPurpose, c.Param("token"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "invalid unsubscribe link",
+		})
+		return
+	}
+
+	repo := repository.NewUserRepository(database.WithContext(c.Request.Context()))
+	if _, err := repo.FindByID(userID); err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "invalid unsubscribe link",
+		})
+		return
+	}
+
+	now := time.Now()
+	if err := repo.SetLifecycleOptOut(userID, &now); err != nil {
+		log.Printf("[ERROR] Failed to record lifecycle opt-out for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to process unsubscribe",
+		})
+		return
+	}
+
+	log.Printf("[INFO] User %d unsubscribed from lifecycle emails from IP: %s", userID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "you will no longer receive tips and reminder emails",
+	})
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+)
+
+// embedCountdownResponse expõe apenas a contagem regressiva e os nomes do casal
+// Segurança: Nenhum outro dado do casamento é exposto pelo widget
+type embedCountdownResponse struct {
+	CoupleNames   []string  `json:"couple_names"`
+	EventDate     time.Time `json:"event_date"`
+	DaysRemaining int       `json:"days_remaining"`
+	Status        string    `json:"status"`
+}
+
+// RotateEmbedToken gera (ou regenera) o token do widget de contagem regressiva
+// Segurança: Regenerar invalida widgets embutidos com o token anterior
+func RotateEmbedToken(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	token, err := security.RandomToken(32)
+	if err != nil {
+		log.Printf("[ERROR] Failed to generate embed token for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to generate embed token",
+		})
+		return
+	}
+	wedding.EmbedToken = &token
+
+	if err := repository.NewWeddingRepository(database.WithContext(c.Request.Context())).Update(wedding); err != nil {
+		log.Printf("[ERROR] Failed to save embed token for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to generate embed token",
+		})
+		return
+	}
+
+	basePath := "/api/v1/public/embed/" + token
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "embed token generated successfully",
+		"json_path": basePath + "/countdown",
+		"svg_path":  basePath + "/countdown.svg",
+		"iframe":    fmt.Sprintf(`<iframe src="%s/countdown.svg" width="320" height="120" frameborder="0"></iframe>`, basePath),
+	})
+}
+
+// GetEmbedCountdown retorna a contagem regressiva em JSON para sites externos
+func GetEmbedCountdown(c *gin.Context) {
+	countdown, ok := loadEmbedCountdown(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.JSON(http.StatusOK, countdown)
+}
+
+// GetEmbedCountdownSVG retorna a contagem regressiva como imagem SVG (uso em <img> ou <iframe>)
+func GetEmbedCountdownSVG(c *gin.Context) {
+	countdown, ok := loadEmbedCountdown(c)
+	if !ok {
+		return
+	}
+
+	var headline string
+	switch countdown.Status {
+	case "today":
+		headline = "Today is the day!"
+	case "past":
+		headline = "Just married"
+	default:
+		headline = fmt.Sprintf("%d days to go", countdown.DaysRemaining)
+	}
+
+	// Segurança: Nomes escapados para evitar injeção de markup no SVG
+	svg := fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="320" height="120" viewBox="0 0 320 120">
+<rect width="320" height="120" rx="12" fill="#ffffff" stroke="#dddddd"/>
+<text x="160" y="45" font-family="Georgia, serif" font-size="18" text-anchor="middle" fill="#333333">%s</text>
+<text x="160" y="80" font-family="Helvetica, Arial, sans-serif" font-size="24" font-weight="bold" text-anchor="middle" fill="#b5838d">%s</text>
+<text x="160" y="105" font-family="Helvetica, Arial, sans-serif" font-size="12" text-anchor="middle" fill="#777777">%s</text>
+</svg>`,
+		html.EscapeString(strings.Join(countdown.CoupleNames, " & ")),
+		html.EscapeString(headline),
+		countdown.EventDate.Format("02/01/2006"),
+	)
+
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, "image/svg+xml; charset=utf-8", []byte(svg))
+}
+
+// loadEmbedCountdown resolve o token do widget e monta a contagem regressiva
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func loadEmbedCountdown(c *gin.Context) (*embedCountdownResponse, bool) {
+	token := c.Param("token")
+	if len(token) != 64 {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "widget not found",
+		})
+		return nil, false
+	}
+
+	wedding, err := repository.NewWeddingRepository(database.WithContext(c.Request.Context())).FindByEmbedToken(token)
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "widget not found",
+		})
+		return nil, false
+	}
+
+	user, err := repository.NewUserRepository(database.WithContext(c.Request.Context())).FindByID(wedding.UserID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch owner of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to load widget",
+		})
+		return nil, false
+	}
+
+	return toEmbedCountdownResponse(wedding, user), true
+}
+
+// toEmbedCountdownResponse converte model para response
+func toEmbedCountdownResponse(w *models.Wedding, u *models.User) *embedCountdownResponse {
+	daysRemaining := w.DaysRemaining()
+	return &embedCountdownResponse{
+		CoupleNames:   []string{u.Name, u.PartnerName},
+		EventDate:     w.EventDate,
+		DaysRemaining: daysRemaining,
+		Status:        countdownStatus(daysRemaining),
+	}
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// eventDayResponse representa o painel do dia do evento (tablet do cerimonialista)
+type eventDayResponse struct {
+	EventDate  time.Time                `json:"event_date"`
+	IsEventDay bool                     `json:"is_event_day"`
+	Clock      string                   `json:"clock"` // HH:MM usado nas marcações da programação
+	CheckIn    checkInStatsResponse     `json:"checkin"`
+	Timeline   []models.MarkedHighlight `json:"timeline"`
+	Now        *models.MarkedHighlight  `json:"now"`
+	Next       *models.MarkedHighlight  `json:"next"`
+}
+
+// GetEventDayDashboard retorna o painel do dia do evento: progresso da portaria e programação
+// com os momentos em andamento (now) e seguinte (next)
+// O horário segue o fuso de EventDate; ?clock=HH:MM marca a programação pelo relógio do tablet
+// Chegadas de fornecedores ainda não entram: fornecedores não têm horário de chegada registrado
+// Performance: uma query agregada de convidados, uma de acompanhantes e a linha de EventInfo,
+// para suportar a consulta repetida (polling) durante a festa
+func GetEventDayDashboard(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	now := time.Now().In(wedding.EventDate.Location())
+	clock := now.Format("15:04")
+	if param := c.Query("clock"); param != "" {
+		if _, err := time.Parse("15:04", param); err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse{
+				Error: "clock must be in HH:MM format",
+			})
+			return
+		}
+		clock = param
+	}
+
+	db := database.WithContext(c.Request.Context())
+	stats, err := repository.NewGuestRepository(db).CheckInStatsByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch check-in stats of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch event day dashboard",
+		})
+		return
+	}
+	info, err := repository.NewEventInfoRepository(db).FindOrDefault(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch event info for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch event day dashboard",
+		})
+		return
+	}
+
+	// Fora do dia do evento a programação fica toda por vir (antes) ou toda concluída (depois)
+	eventYear, eventMonth, eventDay := wedding.EventDate.Date()
+	eventDate := time.Date(eventYear, eventMonth, eventDay, 0, 0, 0, 0, now.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	response := eventDayResponse{
+		EventDate:  wedding.EventDate,
+		IsEventDay: today.Equal(eventDate),
+		Clock:      clock,
+		CheckIn:    toCheckInStatsResponse(stats),
+	}
+	switch {
+	case today.Before(eventDate):
+		response.Timeline = info.TimelineAt(-1)
+	case today.After(eventDate):
+		response.Timeline = info.TimelineAt(24 * 60)
+	default:
+		at, _ := time.Parse("15:04", clock)
+		response.Timeline = info.TimelineAt(at.Hour()*60 + at.Minute())
+	}
+	for i := range response.Timeline {
+		switch response.Timeline[i].Status {
+		case models.TimelineNow:
+			response.Now = &response.Timeline[i]
+		case models.TimelineNext:
+			response.Next = &response.Timeline[i]
+		}
+	}
+
+	// Painel consultado repetidamente no dia do evento: nunca servir do cache
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response)
+}
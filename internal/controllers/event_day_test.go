@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/database/dbtest"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+)
+
+// callEventDayDashboard consulta o painel do dia do evento com a query informada
+func callEventDayDashboard(t *testing.T, eventDate time.Time, query string) eventDayResponse {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db, fake := dbtest.Open()
+	fake.Insert("event_infos", dbtest.Row{
+		"id": 1, "wedding_id": 10,
+		"highlights": `[{"time":"20:30","title":"Jantar"},{"time":"19:00","title":"Cerimônia"},{"time":"22:00","title":"Festa"}]`,
+	})
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+	c.Set("user_id", proUserID)
+	c.Set("wedding", &models.Wedding{ID: 10, UserID: proUserID, EventDate: eventDate})
+	GetEventDayDashboard(c)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Cache-Control") != "no-store" {
+		t.Error("event day dashboard must not be cached")
+	}
+	var response eventDayResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	return response
+}
+
+// timelineStatuses resume as marcações da programação, na ordem do painel
+func timelineStatuses(response eventDayResponse) []string {
+	statuses := make([]string, len(response.Timeline))
+	for i, h := range response.Timeline {
+		statuses[i] = h.Time + " " + string(h.Status)
+	}
+	return statuses
+}
+
+func TestEventDayDashboardMarksNowAndNext(t *testing.T) {
+	response := callEventDayDashboard(t, time.Now(), "clock=20:45")
+
+	if !response.IsEventDay || response.Clock != "20:45" {
+		t.Errorf("is_event_day = %v, clock = %s, want the event day at 20:45", response.IsEventDay, response.Clock)
+	}
+	want := []string{"19:00 done", "20:30 now", "22:00 next"}
+	if got := timelineStatuses(response); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("timeline = %v, want %v (sorted by time)", got, want)
+	}
+	if response.Now == nil || response.Now.Title != "Jantar" || response.Next == nil || response.Next.Title != "Festa" {
+		t.Errorf("now = %+v, next = %+v, want the dinner now and the party next", response.Now, response.Next)
+	}
+}
+
+func TestEventDayDashboardOutsideTheEventDay(t *testing.T) {
+	before := callEventDayDashboard(t, time.Now().AddDate(0, 0, 3), "")
+	if before.IsEventDay || before.Now != nil || before.Next == nil || before.Next.Time != "19:00" {
+		t.Errorf("before the event: now = %+v, next = %+v, want only the ceremony next", before.Now, before.Next)
+	}
+
+	after := callEventDayDashboard(t, time.Now().AddDate(0, 0, -1), "clock=20:45")
+	if after.Now != nil || after.Next != nil {
+		t.Errorf("after the event: now = %+v, next = %+v, want the whole timeline done", after.Now, after.Next)
+	}
+	for _, h := range after.Timeline {
+		if h.Status != models.TimelineDone {
+			t.Errorf("after the event: %s is %s, want done", h.Time, h.Status)
+		}
+	}
+}
+
+func TestEventDayDashboardInvalidClock(t *testing.T) {
+	rec := callWeddingHandler(func(c *gin.Context) {
+		c.Request = httptest.NewRequest(http.MethodGet, "/?clock=25:00", nil)
+		GetEventDayDashboard(c)
+	}, proUserID, &models.Wedding{ID: 10, UserID: proUserID, EventDate: time.Now()}, "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400 (body %s)", rec.Code, rec.Body.String())
+	}
+}
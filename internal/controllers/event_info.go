@@ -0,0 +1,255 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// eventInfoResponse representa a configuração das informações do evento (visão do casal)
+type eventInfoResponse struct {
+	DressCode         string                     `json:"dress_code"`
+	Highlights        []models.TimelineHighlight `json:"highlights"`
+	ShareVenue        bool                       `json:"share_venue"`
+	ShareDressCode    bool                       `json:"share_dress_code"`
+	ShareTimeline     bool                       `json:"share_timeline"`
+	TablesPublished   bool                       `json:"tables_published"`
+	TablesPublishedAt *time.Time                 `json:"tables_published_at"`
+
+	ReceptionVenueName string `json:"reception_venue_name"`
+	ReceptionAddress   string `json:"reception_address"`
+}
+
+// publicEventInfoResponse expõe ao convidado apenas o que o casal escolheu compartilhar
+// Campos não compartilhados são omitidos (e não enviados vazios) para o front-end esconder a seção
+type publicEventInfoResponse struct {
+	GuestName  string                     `json:"guest_name"`
+	Events     models.WeddingEvent        `json:"events"` // partes do casamento do convite
+	EventDate  time.Time                  `json:"event_date"`
+	EventTime  string                     `json:"event_time"`
+	Venue      *publicEventVenue          `json:"venue,omitempty"`
+	Reception  *publicEventVenue          `json:"reception_venue,omitempty"` // apenas quando em outro endereço
+	DressCode  string                     `json:"dress_code,omitempty"`
+	Highlights []models.TimelineHighlight `json:"highlights,omitempty"`
+	Table      string                     `json:"table,omitempty"`
+
+	// Prazo do RSVP: depois dele a resposta só muda falando com o casal
+	RSVPDeadline *time.Time `json:"rsvp_deadline,omitempty"`
+	RSVPClosed   bool       `json:"rsvp_closed"`
+}
+
+type publicEventVenue struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	MapURL  string `json:"map_url"`
+}
+
+// GetEventInfo retorna as informações do evento e o que está compartilhado com os convidados
+func GetEventInfo(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	info, err := repository.NewEventInfoRepository(database.WithContext(c.Request.Context())).FindOrDefault(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch event info for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch event info",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"event_info": toEventInfoResponse(info),
+		"transfer":   eventTransfer(c, wedding, info),
+	})
+}
+
+// UpdateEventInfo atualiza as informações do evento e as permissões de compartilhamento
+func UpdateEventInfo(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	repo := repository.NewEventInfoRepository(database.WithContext(c.Request.Context()))
+	info, err := repo.FindOrDefault(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch event info for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch event info",
+		})
+		return
+	}
+
+	var updateData struct {
+		DressCode       *string                     `json:"dress_code"`
+		Highlights      *[]models.TimelineHighlight `json:"highlights"` // substitui a programação inteira
+		ShareVenue      *bool                       `json:"share_venue"`
+		ShareDressCode  *bool                       `json:"share_dress_code"`
+		ShareTimeline   *bool                       `json:"share_timeline"`
+		TablesPublished *bool                       `json:"tables_published"`
+
+		ReceptionVenueName *string `json:"reception_venue_name"`
+		ReceptionAddress   *string `json:"reception_address"` // vazio: recepção no local da cerimônia
+	}
+
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	// Atualiza apenas campos fornecidos (PATCH behavior)
+	if updateData.DressCode != nil {
+		info.DressCode = *updateData.DressCode
+	}
+	if updateData.Highlights != nil {
+		info.Highlights = *updateData.Highlights
+	}
+	if updateData.ShareVenue != nil {
+		info.ShareVenue = *updateData.ShareVenue
+	}
+	if updateData.ShareDressCode != nil {
+		info.ShareDressCode = *updateData.ShareDressCode
+	}
+	if updateData.ShareTimeline != nil {
+		info.ShareTimeline = *updateData.ShareTimeline
+	}
+	if updateData.ReceptionVenueName != nil {
+		info.ReceptionVenueName = *updateData.ReceptionVenueName
+	}
+	if updateData.ReceptionAddress != nil {
+		info.ReceptionAddress = *updateData.ReceptionAddress
+	}
+	if updateData.TablesPublished != nil && *updateData.TablesPublished != info.TablesPublished() {
+		if *updateData.TablesPublished {
+			now := time.Now()
+			info.TablesPublishedAt = &now
+		} else {
+			info.TablesPublishedAt = nil
+		}
+	}
+
+	if err := info.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repo.Save(info); err != nil {
+		log.Printf("[ERROR] Failed to save event info for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to update event info",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "event info updated successfully",
+		"event_info": toEventInfoResponse(info),
+		"transfer":   eventTransfer(c, wedding, info),
+	})
+}
+
+// GetPublicRSVPEvent retorna ao convidado as informações do evento pelo link pessoal
+func GetPublicRSVPEvent(c *gin.Context) {
+	guest, ok := loadRSVPGuest(c)
+	if !ok {
+		return
+	}
+
+	db := database.WithContext(c.Request.Context())
+
+	wedding, err := repository.NewWeddingRepository(db).FindByID(guest.WeddingID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "invalid rsvp link",
+		})
+		return
+	}
+
+	info, err := repository.NewEventInfoRepository(db).FindOrDefault(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch event info for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch event info",
+		})
+		return
+	}
+
+	response := publicEventInfoResponse{
+		GuestName: guest.FullName,
+		Events:    guest.Events,
+		EventDate: wedding.EventDate,
+		EventTime: wedding.EventTime,
+
+		RSVPDeadline: wedding.RSVPDeadline,
+		RSVPClosed:   wedding.RSVPClosed(time.Now()),
+	}
+	if info.ShareVenue {
+		response.Venue = &publicEventVenue{
+			Name:    wedding.VenueName,
+			Address: wedding.VenueAddress,
+			MapURL:  mapURL(wedding.VenueAddress),
+		}
+		if info.ReceptionAddress != "" && guest.Events.Includes(models.WeddingEventReception) {
+			response.Reception = &publicEventVenue{
+				Name:    info.ReceptionVenueName,
+				Address: info.ReceptionAddress,
+				MapURL:  mapURL(info.ReceptionAddress),
+			}
+		}
+	}
+	if info.ShareDressCode {
+		response.DressCode = info.DressCode
+	}
+	if info.ShareTimeline {
+		// Cada convidado vê apenas a programação das partes do casamento para as quais foi convidado
+		for _, h := range info.Highlights {
+			if h.Event == "" || guest.Events.Includes(h.Event) {
+				response.Highlights = append(response.Highlights, h)
+			}
+		}
+	}
+	// Convidados que recusaram ou ainda estão na lista de espera não têm lugar reservado;
+	// mesas são da recepção
+	if info.TablesPublished() && guest.InviteStatus == models.InviteStatusConfirmed && guest.Events.Includes(models.WeddingEventReception) {
+		response.Table = guest.TableName
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response)
+}
+
+// mapURL monta o link de busca do endereço no Google Maps (abre o app no celular)
+func mapURL(address string) string {
+	return "https://www.google.com/maps/search/?api=1&query=" + url.QueryEscape(address)
+}
+
+// toEventInfoResponse converte model para response
+func toEventInfoResponse(info *models.EventInfo) eventInfoResponse {
+	highlights := info.Highlights
+	if highlights == nil {
+		highlights = []models.TimelineHighlight{}
+	}
+	return eventInfoResponse{
+		DressCode:         info.DressCode,
+		Highlights:        highlights,
+		ShareVenue:        info.ShareVenue,
+		ShareDressCode:    info.ShareDressCode,
+		ShareTimeline:     info.ShareTimeline,
+		TablesPublished:   info.TablesPublished(),
+		TablesPublishedAt: info.TablesPublishedAt,
+
+		ReceptionVenueName: info.ReceptionVenueName,
+		ReceptionAddress:   info.ReceptionAddress,
+	}
+}
@@ -0,0 +1,303 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/money"
+	"github.com/matheushermes/wedding_planner_service/internal/payments"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// fundraisingResponse representa a resposta padronizada de arrecadação
+type fundraisingResponse struct {
+	ID               uint                     `json:"id"`
+	WeddingID        uint                     `json:"wedding_id"`
+	Type             models.FundraisingType   `json:"type"`
+	Amount           float64                  `json:"amount"`
+	Currency         string                   `json:"currency"`
+	AmountDisplay    string                   `json:"amount_display"`
+	Date             time.Time                `json:"date"`
+	Observation      string                   `json:"observation"`
+	DonorName        string                   `json:"donor_name"`
+	Status           models.FundraisingStatus `json:"status"`
+	RefundedAt       *time.Time               `json:"refunded_at"`
+	RefundReason     string                   `json:"refund_reason"`
+	DisputeDeadline  *time.Time               `json:"dispute_deadline"`
+	DisputeOutcome   models.DisputeOutcome    `json:"dispute_outcome"`
+	DisputeResolved  *time.Time               `json:"dispute_resolved_at"`
+	ProviderName     string                   `json:"provider_name,omitempty"`
+	ProviderChargeID string                   `json:"provider_charge_id,omitempty"`
+	CreatedAt        time.Time                `json:"created_at"`
+	UpdatedAt        time.Time                `json:"updated_at"`
+}
+
+// GetFundraisingSummary retorna os totais arrecadados por tipo
+// Contribuições estornadas ou em disputa ficam fora do total, mas são informadas separadamente
+func GetFundraisingSummary(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	totals, err := repository.NewFundraisingRepository(database.WithContext(c.Request.Context())).TotalsByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to summarize fundraising of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch fundraising summary",
+		})
+		return
+	}
+
+	byType := map[models.FundraisingType]float64{}
+	var total, refunded, disputed float64
+	var count, refundedCount, disputedCount int64
+	for _, t := range totals {
+		switch t.Status {
+		case models.FundraisingStatusRefunded, models.FundraisingStatusChargedBack:
+			refunded += t.Total
+			refundedCount += t.Count
+		case models.FundraisingStatusDisputed:
+			disputed += t.Total
+			disputedCount += t.Count
+		default:
+			byType[t.Type] += t.Total
+			total += t.Total
+			count += t.Count
+		}
+	}
+
+	byTypeDisplay := make(map[models.FundraisingType]string, len(byType))
+	for t, v := range byType {
+		byTypeDisplay[t] = money.Format(v, wedding.Currency)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"wedding_id":             wedding.ID,
+		"currency":               wedding.Currency,
+		"total":                  total,
+		"total_display":          money.Format(total, wedding.Currency),
+		"count":                  count,
+		"by_type":                byType,
+		"by_type_display":        byTypeDisplay,
+		"refunded_total":         refunded,
+		"refunded_total_display": money.Format(refunded, wedding.Currency),
+		"refunded_count":         refundedCount,
+		"disputed_total":         disputed,
+		"disputed_total_display": money.Format(disputed, wedding.Currency),
+		"disputed_count":         disputedCount,
+	})
+}
+
+// RefundFundraising marca uma contribuição como estornada
+// Se a contribuição veio de um PSP, o estorno também é solicitado ao provedor
+// O registro original é mantido para auditoria
+func RefundFundraising(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	fundraisingID, err := parseIDParam(c, "fundraisingId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var refundData struct {
+		Reason string `json:"reason" binding:"max=500"`
+	}
+
+	if err := c.ShouldBindJSON(&refundData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	repo := repository.NewFundraisingRepository(database.WithContext(c.Request.Context()))
+
+	fundraising, err := repo.FindByIDAndWeddingID(fundraisingID, wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("fundraising"))
+		return
+	}
+
+	if !fundraising.CountsTowardTotals() {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "contribution cannot be refunded in its current status",
+		})
+		return
+	}
+
+	if fundraising.ProviderChargeID != "" {
+		provider, err := payments.Get(fundraising.ProviderName)
+		if err != nil {
+			log.Printf("[ERROR] Provider %q of fundraising %d is not available: %v", fundraising.ProviderName, fundraising.ID, err)
+			c.JSON(http.StatusServiceUnavailable, errorResponse{
+				Error: "payment provider is not available",
+			})
+			return
+		}
+
+		if err := provider.Refund(c.Request.Context(), fundraising.ProviderChargeID, 0); err != nil {
+			log.Printf("[ERROR] Failed to refund charge %s of fundraising %d: %v", fundraising.ProviderChargeID, fundraising.ID, err)
+			c.JSON(http.StatusBadGateway, errorResponse{
+				Error: "payment provider refused the refund",
+			})
+			return
+		}
+	}
+
+	reason := strings.TrimSpace(refundData.Reason)
+	markFundraisingRefunded(fundraising, reason)
+
+	entry := models.NewFundraisingLedgerEntry(fundraising, models.LedgerContributionRefunded, reason)
+	if err := repo.UpdateWithLedgerAndNotification(fundraising, entry, fundraisingRefundedNotification(wedding, fundraising)); err != nil {
+		log.Printf("[ERROR] Failed to mark fundraising %d as refunded: %v", fundraising.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to refund contribution",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "contribution refunded successfully",
+		"fundraising": toFundraisingResponse(fundraising, wedding.Currency),
+	})
+}
+
+// ResolveFundraisingDispute registra o resultado de uma disputa (chargeback)
+// Usado quando o casal recebe o resultado fora do webhook do PSP
+func ResolveFundraisingDispute(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	fundraisingID, err := parseIDParam(c, "fundraisingId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var resolveData struct {
+		Outcome models.DisputeOutcome `json:"outcome" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&resolveData); err != nil || !resolveData.Outcome.IsValid() {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "invalid request data",
+		})
+		return
+	}
+
+	repo := repository.NewFundraisingRepository(database.WithContext(c.Request.Context()))
+
+	fundraising, err := repo.FindByIDAndWeddingID(fundraisingID, wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("fundraising"))
+		return
+	}
+
+	if fundraising.Status != models.FundraisingStatusDisputed {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "contribution has no open dispute",
+		})
+		return
+	}
+
+	if err := resolveFundraisingDispute(repo, fundraising, resolveData.Outcome); err != nil {
+		log.Printf("[ERROR] Failed to resolve dispute of fundraising %d: %v", fundraising.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to resolve dispute",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "dispute resolved successfully",
+		"fundraising": toFundraisingResponse(fundraising, wedding.Currency),
+	})
+}
+
+// resolveFundraisingDispute persiste o resultado da disputa
+// Disputa ganha devolve o valor ao livro-razão; perdida não gera lançamento (já foi subtraído na abertura)
+func resolveFundraisingDispute(repo *repository.FundraisingRepository, f *models.Fundraising, outcome models.DisputeOutcome) error {
+	f.ResolveDispute(outcome)
+	if outcome == models.DisputeOutcomeWon {
+		return repo.UpdateWithLedger(f, models.NewFundraisingLedgerEntry(f, models.LedgerDisputeWon, ""))
+	}
+	return repo.Update(f)
+}
+
+// markFundraisingRefunded aplica o estorno ao registro em memória
+func markFundraisingRefunded(f *models.Fundraising, reason string) {
+	now := time.Now()
+	f.Status = models.FundraisingStatusRefunded
+	f.RefundedAt = &now
+	f.RefundReason = reason
+}
+
+// fundraisingRefundedNotification monta o aviso de estorno para o dono do casamento (valor, doador e motivo)
+// Gravado na caixa de entrada junto com o estorno (UpdateWithLedgerAndNotification)
+func fundraisingRefundedNotification(wedding *models.Wedding, f *models.Fundraising) *models.Notification {
+	body := fmt.Sprintf("A contribuição de %s (%s) foi estornada.", fundraisingDonor(f), money.Format(f.Amount, wedding.Currency))
+	if f.RefundReason != "" {
+		body += " Motivo: " + f.RefundReason
+	}
+	return models.NewWeddingNotification(wedding.UserID, wedding.ID, models.NotificationFundraisingRefunded, "Contribuição estornada", body)
+}
+
+// fundraisingDisputedNotification monta o aviso de disputa para o dono do casamento com o prazo de defesa
+// Sem resposta até o prazo, o PSP decide a disputa a favor do doador
+func fundraisingDisputedNotification(wedding *models.Wedding, f *models.Fundraising) *models.Notification {
+	deadline := "o prazo não foi informado pelo provedor de pagamento, confira no painel do provedor"
+	if f.DisputeDeadline != nil {
+		deadline = "envie as provas ao provedor de pagamento até " + f.DisputeDeadline.In(time.UTC).Format("02/01/2006 15:04") + " (UTC)"
+	}
+	body := fmt.Sprintf("A contribuição de %s (%s) foi contestada: %s.", fundraisingDonor(f), money.Format(f.Amount, wedding.Currency), deadline)
+	return models.NewWeddingNotification(wedding.UserID, wedding.ID, models.NotificationFundraisingDisputed, "Contribuição em disputa", body)
+}
+
+// fundraisingDonor retorna o nome do doador para os avisos
+func fundraisingDonor(f *models.Fundraising) string {
+	if f.DonorName == "" {
+		return "um convidado sem nome informado"
+	}
+	return f.DonorName
+}
+
+// toFundraisingResponse converte model para response
+func toFundraisingResponse(f *models.Fundraising, currency string) fundraisingResponse {
+	return fundraisingResponse{
+		ID:               f.ID,
+		WeddingID:        f.WeddingID,
+		Type:             f.Type,
+		Amount:           f.Amount,
+		Currency:         currency,
+		AmountDisplay:    money.Format(f.Amount, currency),
+		Date:             f.Date,
+		Observation:      f.Observation,
+		DonorName:        f.DonorName,
+		Status:           f.Status,
+		RefundedAt:       f.RefundedAt,
+		RefundReason:     f.RefundReason,
+		DisputeDeadline:  f.DisputeDeadline,
+		DisputeOutcome:   f.DisputeOutcome,
+		DisputeResolved:  f.DisputeResolvedAt,
+		ProviderName:     f.ProviderName,
+		ProviderChargeID: f.ProviderChargeID,
+		CreatedAt:        f.CreatedAt,
+		UpdatedAt:        f.UpdatedAt,
+	}
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/database/dbtest"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/payments"
+)
+
+// withFundraisingTestDB liga o banco em memória com o casamento 10 (dono proUserID) e a contribuição 3
+func withFundraisingTestDB(t *testing.T) *dbtest.DB {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db, fake := dbtest.Open()
+	fake.Insert("weddings", dbtest.Row{"id": 10, "user_id": proUserID, "venue_name": "Espaço", "currency": "BRL"})
+	fake.Insert("fundraisings", dbtest.Row{
+		"id": 3, "wedding_id": 10, "type": string(models.FundraisingTypeGift), "amount": 150.0,
+		"donor_name": "Tia Maria", "status": string(models.FundraisingStatusReceived),
+		"provider_name": "stripe", "provider_charge_id": "ch_123",
+	})
+
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+	return fake
+}
+
+// assertWeddingNotification confere o único aviso gravado para o dono do casamento
+func assertWeddingNotification(t *testing.T, fake *dbtest.DB, kind models.NotificationKind, fragments ...string) {
+	t.Helper()
+
+	notifications := fake.Inserted("notifications")
+	if len(notifications) != 1 {
+		t.Fatalf("notifications = %v, want one notice to the couple", notifications)
+	}
+	n := notifications[0]
+	if n["user_id"] != int64(proUserID) || n["wedding_id"] != int64(10) || n["kind"] != string(kind) {
+		t.Errorf("notification = %v, want %s for user %d on wedding 10", n, kind, proUserID)
+	}
+	body, _ := n["body"].(string)
+	for _, fragment := range fragments {
+		if !strings.Contains(body, fragment) {
+			t.Errorf("notification body %q does not mention %q", body, fragment)
+		}
+	}
+}
+
+func TestRefundFundraisingNotifiesCouple(t *testing.T) {
+	fake := withFundraisingTestDB(t)
+
+	wedding := &models.Wedding{ID: 10, UserID: proUserID, Currency: "BRL"}
+	// Contribuição manual (sem cobrança no PSP): o estorno é apenas registrado
+	fake.Insert("fundraisings", dbtest.Row{"id": 4, "wedding_id": 10, "amount": 80.0, "donor_name": "Tio João"})
+
+	rec := callWeddingHandler(RefundFundraising, proUserID, wedding, `{"reason":"valor duplicado"}`, gin.Param{Key: "fundraisingId", Value: "4"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+	}
+	assertWeddingNotification(t, fake, models.NotificationFundraisingRefunded, "Tio João", "80,00", "valor duplicado")
+}
+
+func TestWebhookRefundNotifiesCouple(t *testing.T) {
+	fake := withFundraisingTestDB(t)
+
+	err := applyWebhookRefund(context.Background(), "stripe", &payments.WebhookEvent{Type: payments.EventChargeRefunded, ChargeID: "ch_123"})
+	if err != nil {
+		t.Fatalf("applyWebhookRefund: %v", err)
+	}
+	assertWeddingNotification(t, fake, models.NotificationFundraisingRefunded, "Tia Maria", "150,00", "refunded via stripe")
+}
+
+func TestWebhookDisputeNotifiesCoupleWithDeadline(t *testing.T) {
+	fake := withFundraisingTestDB(t)
+
+	deadline := time.Date(2030, 7, 1, 23, 59, 0, 0, time.UTC)
+	err := applyWebhookDispute(context.Background(), "stripe", &payments.WebhookEvent{
+		Type:            payments.EventDisputeOpened,
+		ChargeID:        "ch_123",
+		DisputeDeadline: &deadline,
+	})
+	if err != nil {
+		t.Fatalf("applyWebhookDispute: %v", err)
+	}
+	assertWeddingNotification(t, fake, models.NotificationFundraisingDisputed, "Tia Maria", "150,00", "01/07/2030 23:59")
+}
+
+func TestWebhookDisputeWithoutDeadline(t *testing.T) {
+	fake := withFundraisingTestDB(t)
+
+	err := applyWebhookDispute(context.Background(), "stripe", &payments.WebhookEvent{Type: payments.EventDisputeOpened, ChargeID: "ch_123"})
+	if err != nil {
+		t.Fatalf("applyWebhookDispute: %v", err)
+	}
+	assertWeddingNotification(t, fake, models.NotificationFundraisingDisputed, "prazo não foi informado")
+}
@@ -0,0 +1,978 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/asyncjobs"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/metrics"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+)
+
+// guestResponse representa a resposta padronizada de convidado
+type guestResponse struct {
+	ID                  uint                  `json:"id"`
+	WeddingID           uint                  `json:"wedding_id"`
+	FullName            string                `json:"full_name"`
+	Phone               string                `json:"phone"`
+	PhoneE164           string                `json:"phone_e164"`
+	Email               string                `json:"email"`
+	InviteStatus        models.InviteStatus   `json:"invite_status"`
+	MaxGuests           int                   `json:"max_guests"`
+	Priority            models.GuestPriority  `json:"priority"`
+	Events              models.WeddingEvent   `json:"events"`
+	GroupID             *uint                 `json:"group_id"`
+	MealOption          models.MealOption     `json:"meal_option"`
+	DietaryRestrictions string                `json:"dietary_restrictions"`
+	IsChild             bool                  `json:"is_child"`
+	Children            models.ChildAgeBands  `json:"children"`
+	Logistics           models.GuestLogistics `json:"logistics"`
+	Locale              string                `json:"locale"`
+	CountryCode         string                `json:"country_code"`
+	OptedOut            bool                  `json:"opted_out"`
+	OptedOutAt          *time.Time            `json:"opted_out_at"`
+	DeclineMessage      string                `json:"decline_message"`
+	CheckedInAt         *time.Time            `json:"checked_in_at"`
+	TableName           string                `json:"table_name"`
+	Relationship        string                `json:"relationship"`
+	Notes               string                `json:"notes"`
+	Address             models.PostalAddress  `json:"address"`
+	AddressUpdatedAt    *time.Time            `json:"address_updated_at"`
+	TagIDs              []uint                `json:"tag_ids,omitempty"` // preenchido apenas na listagem
+	CreatedAt           time.Time             `json:"created_at"`
+	UpdatedAt           time.Time             `json:"updated_at"`
+}
+
+// CreateGuest cadastra um convidado no casamento
+func CreateGuest(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	// Segurança: Campos explícitos impedem definir wedding_id, opt-out ou status pelo body
+	var createData struct {
+		FullName            string `json:"full_name" binding:"required"`
+		Phone               string `json:"phone"`
+		Email               string `json:"email"`
+		MaxGuests           int    `json:"max_guests"`
+		Priority            string `json:"priority"`
+		Events              string `json:"events"` // ceremony, reception ou both (padrão)
+		Locale              string `json:"locale"`
+		CountryCode         string `json:"country_code"`
+		MealOption          string `json:"meal_option"`
+		DietaryRestrictions string `json:"dietary_restrictions"`
+		IsChild             bool   `json:"is_child"`
+		TableName           string `json:"table_name"`
+		Relationship        string `json:"relationship"`
+		Notes               string `json:"notes"`
+
+		Address   models.PostalAddress  `json:"address"`
+		Children  models.ChildAgeBands  `json:"children"`  // crianças do convite por faixa etária
+		Logistics models.GuestLogistics `json:"logistics"` // hotel e transfer
+
+		// Com o casamento lotado, cria o convidado na lista de espera em vez de recusar
+		Waitlist bool `json:"waitlist"`
+	}
+
+	if err := c.ShouldBindJSON(&createData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	guest := models.Guest{
+		WeddingID:           wedding.ID,
+		FullName:            createData.FullName,
+		Phone:               createData.Phone,
+		Email:               createData.Email,
+		MaxGuests:           createData.MaxGuests,
+		Priority:            models.GuestPriority(createData.Priority),
+		Events:              models.WeddingEvent(createData.Events),
+		Locale:              createData.Locale,
+		CountryCode:         createData.CountryCode,
+		MealOption:          models.MealOption(createData.MealOption),
+		DietaryRestrictions: createData.DietaryRestrictions,
+		IsChild:             createData.IsChild,
+		Children:            createData.Children,
+		Logistics:           createData.Logistics,
+		TableName:           createData.TableName,
+		Relationship:        createData.Relationship,
+		Notes:               createData.Notes,
+		InviteStatus:        models.InviteStatusPending,
+		Address:             createData.Address,
+	}
+
+	if err := guest.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	guest.ApplyLocaleDefaults()
+	if !guest.Address.IsEmpty() {
+		now := time.Now()
+		guest.AddressUpdatedAt = &now
+	}
+	// Lista B entra direto na lista de espera: só é chamada quando a lista A libera vagas
+	if guest.Priority == models.GuestPriorityB {
+		guest.InviteStatus = models.InviteStatusWaitlisted
+	}
+
+	if err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).Create(&guest, createData.Waitlist, statusActor(c, models.StatusChannelDashboard)); err != nil {
+		if errors.Is(err, repository.ErrWeddingFull) {
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		log.Printf("[ERROR] Failed to create guest for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to create guest",
+		})
+		return
+	}
+
+	metrics.RecordFunnelStep(metrics.FunnelGuestAdded, wedding.CreatedAt, 1)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "guest created successfully",
+		"guest":      toGuestResponse(&guest),
+		"waitlisted": guest.InviteStatus == models.InviteStatusWaitlisted,
+	})
+}
+
+// GetGuests lista os convidados do casamento ordenados por nome, com paginação e filtros
+// Filtros: ?status=confirmed, ?q= (parte do nome ou email exato), ?relationship=, ?notes= (parte das anotações),
+// ?tag=, ?event= e ?out_of_town=true
+func GetGuests(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	page, perPage, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	var filter repository.GuestFilter
+	if status := models.InviteStatus(c.Query("status")); status != "" {
+		if !status.IsValid() {
+			c.JSON(http.StatusBadRequest, errorResponse{
+				Error: "invalid status filter",
+			})
+			return
+		}
+		filter.Status = status
+	}
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		// Email é criptografado em repouso: a busca é por igualdade via blind index
+		filter.Name = q
+		filter.EmailHashes = security.BlindIndexes(strings.ToLower(q))
+	}
+	if tag := c.Query("tag"); tag != "" {
+		tagID, err := strconv.ParseUint(tag, 10, 32)
+		if err != nil || tagID == 0 {
+			c.JSON(http.StatusBadRequest, errorResponse{
+				Error: "invalid tag filter",
+			})
+			return
+		}
+		filter.TagID = uint(tagID)
+	}
+	if relationship := strings.TrimSpace(c.Query("relationship")); relationship != "" {
+		filter.Relationship = strings.ToLower(strings.Join(strings.Fields(relationship), " "))
+	}
+	if notes := strings.TrimSpace(c.Query("notes")); notes != "" {
+		filter.Notes = notes
+	}
+	if c.Query("out_of_town") == "true" {
+		filter.OutOfTown = true
+	}
+	if event := models.WeddingEvent(c.Query("event")); event != "" {
+		if !event.IsValid() {
+			c.JSON(http.StatusBadRequest, errorResponse{
+				Error: "invalid event filter",
+			})
+			return
+		}
+		filter.Event = event
+	}
+
+	db := database.WithContext(c.Request.Context())
+
+	guests, total, err := repository.NewGuestRepository(db).
+		FindPageByWeddingID(wedding.ID, filter, page, perPage)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch guests of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch guests",
+		})
+		return
+	}
+
+	guestIDs := make([]uint, len(guests))
+	for i := range guests {
+		guestIDs[i] = guests[i].ID
+	}
+	tagIDs, err := repository.NewGuestTagRepository(db).TagIDsByGuestIDs(wedding.ID, guestIDs)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch guest tags of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch guests",
+		})
+		return
+	}
+
+	response := make([]guestResponse, len(guests))
+	for i := range guests {
+		response[i] = toGuestResponse(&guests[i])
+		response[i].TagIDs = tagIDs[guests[i].ID]
+	}
+
+	c.JSON(http.StatusOK, paginatedResponse[guestResponse]{
+		Items:   response,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	})
+}
+
+// dietaryRestrictionResponse representa um convidado com restrição alimentar no relatório do buffet
+type dietaryRestrictionResponse struct {
+	GuestID             uint              `json:"guest_id"`
+	FullName            string            `json:"full_name"`
+	MealOption          models.MealOption `json:"meal_option"`
+	DietaryRestrictions string            `json:"dietary_restrictions"`
+	IsChild             bool              `json:"is_child"`
+}
+
+// parseReportStatus lê o filtro ?status= dos relatórios: confirmados por padrão, "all" para todos
+// Retorna false quando a resposta de erro já foi enviada
+func parseReportStatus(c *gin.Context) (models.InviteStatus, bool) {
+	switch v := models.InviteStatus(c.Query("status")); {
+	case v == "all":
+		return "", true
+	case v == "":
+		return models.InviteStatusConfirmed, true
+	case !v.IsValid():
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "invalid status filter",
+		})
+		return "", false
+	default:
+		return v, true
+	}
+}
+
+// reportStatusLabel descreve o filtro aplicado no relatório
+func reportStatusLabel(status models.InviteStatus) string {
+	if status == "" {
+		return "all"
+	}
+	return string(status)
+}
+
+// GetDietaryReport agrega os convidados por opção de prato para o buffet
+// Por padrão considera apenas confirmados; ?status=all inclui todos
+func GetDietaryReport(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	status, ok := parseReportStatus(c)
+	if !ok {
+		return
+	}
+
+	repo := repository.NewGuestRepository(database.WithContext(c.Request.Context()))
+
+	counts, err := repo.MealCountsByWeddingID(wedding.ID, status)
+	if err != nil {
+		log.Printf("[ERROR] Failed to aggregate meal options of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to build dietary report",
+		})
+		return
+	}
+
+	restricted, err := repo.FindWithDietaryRestrictions(wedding.ID, status)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch dietary restrictions of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to build dietary report",
+		})
+		return
+	}
+
+	// Todas as opções aparecem no relatório, mesmo com zero convidados
+	byOption := make(map[models.MealOption]repository.MealCount, len(counts))
+	var total, children, notChosen int64
+	for _, mc := range counts {
+		byOption[mc.MealOption] = mc
+		total += mc.Count
+		children += mc.Children
+		if mc.MealOption == "" {
+			notChosen = mc.Count
+		}
+	}
+	meals := make([]repository.MealCount, len(models.MealOptions))
+	for i, option := range models.MealOptions {
+		meals[i] = byOption[option]
+		meals[i].MealOption = option
+	}
+
+	restrictions := make([]dietaryRestrictionResponse, len(restricted))
+	for i := range restricted {
+		g := &restricted[i]
+		restrictions[i] = dietaryRestrictionResponse{
+			GuestID:             g.ID,
+			FullName:            g.FullName,
+			MealOption:          g.MealOption,
+			DietaryRestrictions: g.DietaryRestrictions,
+			IsChild:             g.IsChild,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":       reportStatusLabel(status),
+		"total":        total,
+		"children":     children,
+		"not_chosen":   notChosen,
+		"meal_options": meals,
+		"restrictions": restrictions,
+	})
+}
+
+// GetGuest retorna um convidado específico do casamento
+func GetGuest(c *gin.Context) {
+	_, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"guest": toGuestResponse(guest),
+	})
+}
+
+// UpdateGuest atualiza parcialmente os dados de um convidado
+func UpdateGuest(c *gin.Context) {
+	wedding, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return
+	}
+
+	// Estrutura para atualização parcial
+	var updateData struct {
+		FullName            *string              `json:"full_name"`
+		Phone               *string              `json:"phone"`
+		Email               *string              `json:"email"`
+		MaxGuests           *int                 `json:"max_guests"`
+		Priority            *string              `json:"priority"`
+		Events              *string              `json:"events"`
+		InviteStatus        *models.InviteStatus `json:"invite_status"`
+		Locale              *string              `json:"locale"`
+		CountryCode         *string              `json:"country_code"`
+		MealOption          *string              `json:"meal_option"`
+		DietaryRestrictions *string              `json:"dietary_restrictions"`
+		IsChild             *bool                `json:"is_child"`
+		TableName           *string              `json:"table_name"`
+		Relationship        *string              `json:"relationship"`
+		Notes               *string              `json:"notes"`
+
+		// Substitui o endereço inteiro (objeto vazio remove)
+		Address *models.PostalAddress `json:"address"`
+
+		// Substitui todas as faixas de crianças do convite
+		Children *models.ChildAgeBands `json:"children"`
+		// Substitui a logística inteira (hotel e transfer)
+		Logistics *models.GuestLogistics `json:"logistics"`
+	}
+
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if updateData.FullName != nil {
+		guest.FullName = *updateData.FullName
+	}
+	if updateData.Phone != nil {
+		guest.Phone = *updateData.Phone
+	}
+	if updateData.Email != nil {
+		guest.Email = *updateData.Email
+	}
+	if updateData.MaxGuests != nil && *updateData.MaxGuests != guest.MaxGuests {
+		// Não permite reduzir o convite abaixo dos acompanhantes já registrados
+		companions, err := repository.NewCompanionRepository(database.WithContext(c.Request.Context())).CountByGuestID(guest.ID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to count companions of guest %d: %v", guest.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to update guest",
+			})
+			return
+		}
+		guest.MaxGuests = *updateData.MaxGuests
+		if int(companions) > guest.CompanionLimit() {
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: "max guests is lower than the registered companions",
+			})
+			return
+		}
+	}
+	if updateData.Priority != nil {
+		guest.Priority = models.GuestPriority(*updateData.Priority)
+	}
+	if updateData.Events != nil {
+		guest.Events = models.WeddingEvent(*updateData.Events)
+	}
+	previousStatus := guest.InviteStatus
+	if updateData.InviteStatus != nil && *updateData.InviteStatus != guest.InviteStatus {
+		if !guest.InviteStatus.CanTransitionTo(*updateData.InviteStatus) {
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: "waitlisted guests must be promoted through the waitlist endpoint",
+			})
+			return
+		}
+		guest.InviteStatus = *updateData.InviteStatus
+	}
+	if updateData.Locale != nil {
+		guest.Locale = *updateData.Locale
+	}
+	if updateData.CountryCode != nil {
+		guest.CountryCode = *updateData.CountryCode
+	}
+	if updateData.MealOption != nil {
+		guest.MealOption = models.MealOption(*updateData.MealOption)
+	}
+	if updateData.DietaryRestrictions != nil {
+		guest.DietaryRestrictions = *updateData.DietaryRestrictions
+	}
+	if updateData.IsChild != nil {
+		guest.IsChild = *updateData.IsChild
+	}
+	if updateData.Children != nil {
+		guest.Children = *updateData.Children
+	}
+	if updateData.Logistics != nil {
+		guest.Logistics = *updateData.Logistics
+	}
+	if updateData.TableName != nil {
+		guest.TableName = *updateData.TableName
+	}
+	if updateData.Relationship != nil {
+		guest.Relationship = *updateData.Relationship
+	}
+	if updateData.Notes != nil {
+		guest.Notes = *updateData.Notes
+	}
+	previousAddress := guest.Address
+	if updateData.Address != nil {
+		guest.Address = *updateData.Address
+	}
+
+	if err := guest.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	guest.ApplyLocaleDefaults()
+	if guest.Address != previousAddress {
+		now := time.Now()
+		guest.AddressUpdatedAt = &now
+	}
+
+	repo := repository.NewGuestRepository(database.WithContext(c.Request.Context()))
+
+	// Recusar libera a vaga e voltar atrás precisa de vaga livre: o contador é ajustado junto com o status
+	// e toda mudança de status fica no histórico do convidado
+	var promoted *models.Guest
+	var err error
+	if guest.InviteStatus != previousStatus {
+		actor := statusActor(c, models.StatusChannelDashboard)
+		// O casal continua alterando depois do prazo do RSVP (pedido do convidado fora do link)
+		if wedding.RSVPClosed(time.Now()) {
+			actor.Note = "changed by the couple after the rsvp deadline"
+		}
+		promoted, err = repo.UpdateWithStatus(guest, previousStatus, actor)
+	} else {
+		err = repo.Update(guest)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrWeddingFull), errors.Is(err, repository.ErrStatusChanged),
+			errors.Is(err, repository.ErrVenueAtCapacity):
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: err.Error(),
+			})
+		default:
+			log.Printf("[ERROR] Failed to update guest %d of wedding %d: %v", guest.ID, wedding.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to update guest",
+			})
+		}
+		return
+	}
+
+	response := gin.H{
+		"message": "guest updated successfully",
+		"guest":   toGuestResponse(guest),
+	}
+	if promoted != nil {
+		log.Printf("[INFO] Guest %d of wedding %d promoted from the waitlist after guest %d declined", promoted.ID, wedding.ID, guest.ID)
+		invited := []models.Guest{*promoted}
+		response["invites_sent"] = inviteGuests(c.Request.Context(), wedding, invited)
+		response["promoted_guest"] = toGuestResponse(&invited[0])
+	}
+	// Sem a lotação obrigatória a confirmação passa, mas o casal é avisado do excesso
+	if guest.InviteStatus == models.InviteStatusConfirmed && previousStatus != models.InviteStatusConfirmed {
+		if capacity := weddingCapacity(c, wedding); capacity != nil && capacity.OverCapacity {
+			response["capacity_warning"] = capacity
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteGuest remove um convidado e atualiza o contador do casamento
+func DeleteGuest(c *gin.Context) {
+	wedding, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).Delete(guest); err != nil {
+		log.Printf("[ERROR] Failed to delete guest %d of wedding %d: %v", guest.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to delete guest",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "guest deleted successfully",
+	})
+}
+
+// guestImportParams identifica o casamento de origem gravado no job
+type guestImportParams struct {
+	SourceWeddingID uint `json:"source_wedding_id"`
+}
+
+// ImportGuests copia convidados de outro casamento do usuário (ex: lista do noivado)
+// Preserva dados de contato e etiquetas, ignora duplicados e reinicia o status do convite
+// A cópia roda em segundo plano: responde 202 e o resumo fica no job (GET /jobs/:id)
+func ImportGuests(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var importData struct {
+		SourceWeddingID uint `json:"source_wedding_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&importData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if importData.SourceWeddingID == wedding.ID {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "source wedding must be different from the target wedding",
+		})
+		return
+	}
+
+	// Segurança: Casamento de origem também precisa pertencer ao usuário autenticado
+	source, err := authz.LoadWedding(database.WithContext(c.Request.Context()), currentSubject(c), importData.SourceWeddingID, authz.ActionRead)
+	if err != nil {
+		if authz.IsNotFound(err) {
+			err = authz.NotFound("source wedding")
+		}
+		respondAccessError(c, err)
+		return
+	}
+
+	enqueueJob(c, wedding, models.AsyncJobGuestImport, guestImportParams{
+		SourceWeddingID: source.ID,
+	})
+}
+
+// runGuestImport copia os convidados do casamento de origem de um job de importação
+// A autorização do casamento de origem foi conferida ao enfileirar
+func runGuestImport(ctx context.Context, job *models.AsyncJob, progress func(int)) (*asyncjobs.Output, error) {
+	var params guestImportParams
+	if err := asyncjobs.DecodeParams(job, &params); err != nil {
+		return nil, err
+	}
+	wedding, err := loadJobWedding(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := repository.NewGuestRepository(database.WithContext(ctx))
+
+	sourceGuests, err := repo.FindByWeddingID(params.SourceWeddingID)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to import guests", err)
+	}
+
+	existingGuests, err := repo.FindByWeddingID(wedding.ID)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to import guests", err)
+	}
+
+	// Etiquetas da origem: nome e cor são recriados no casamento de destino
+	tagRepo := repository.NewGuestTagRepository(database.WithContext(ctx))
+	sourceTagList, err := tagRepo.FindByWeddingID(params.SourceWeddingID)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to import guests", err)
+	}
+	sourceTags := make(map[uint]models.GuestTag, len(sourceTagList))
+	for _, tag := range sourceTagList {
+		sourceTags[tag.ID] = tag.GuestTag
+	}
+	sourceIDs := make([]uint, len(sourceGuests))
+	for i := range sourceGuests {
+		sourceIDs[i] = sourceGuests[i].ID
+	}
+	tagIDsByGuest, err := tagRepo.TagIDsByGuestIDs(params.SourceWeddingID, sourceIDs)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to import guests", err)
+	}
+	progress(30)
+
+	// De-duplicação por email, telefone ou nome normalizados
+	seen := newGuestDedupIndex()
+	for i := range existingGuests {
+		seen.add(&existingGuests[i])
+	}
+
+	imported := make([]models.Guest, 0, len(sourceGuests))
+	importedTags := make([][]models.GuestTag, 0, len(sourceGuests))
+	skipped := 0
+	for i := range sourceGuests {
+		g := &sourceGuests[i]
+		if seen.contains(g) {
+			skipped++
+			continue
+		}
+		seen.add(g)
+
+		imported = append(imported, models.Guest{
+			WeddingID:   wedding.ID,
+			FullName:    g.FullName,
+			Phone:       g.Phone,
+			PhoneE164:   g.PhoneE164,
+			Email:       g.Email,
+			MaxGuests:   g.MaxGuests,
+			Locale:      g.Locale,
+			CountryCode: g.CountryCode,
+			OptedOutAt:  g.OptedOutAt, // LGPD: a preferência é da pessoa, não do casamento
+			// Restrições alimentares são da pessoa; a opção de prato depende do cardápio de cada evento
+			DietaryRestrictions: g.DietaryRestrictions,
+			IsChild:             g.IsChild,
+			Children:            g.Children,
+			Relationship:        g.Relationship,
+			Notes:               g.Notes,
+			Address:             g.Address,
+			AddressUpdatedAt:    g.AddressUpdatedAt,
+			InviteStatus:        models.InviteStatusPending,
+		})
+		imported[len(imported)-1].ApplyLocaleDefaults()
+
+		var tags []models.GuestTag
+		for _, tagID := range tagIDsByGuest[g.ID] {
+			if tag, ok := sourceTags[tagID]; ok {
+				tags = append(tags, tag)
+			}
+		}
+		importedTags = append(importedTags, tags)
+	}
+
+	tagsCreated, err := repo.ImportMany(wedding.ID, imported, importedTags, models.StatusActor{
+		Channel: models.StatusChannelImport,
+		UserID:  &job.UserID,
+		Note:    fmt.Sprintf("imported from wedding #%d", params.SourceWeddingID),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrWeddingFull) {
+			return nil, asyncjobs.Fail("import would exceed the wedding's max guests", err)
+		}
+		return nil, asyncjobs.Fail("unable to import guests", err)
+	}
+	metrics.RecordFunnelStep(metrics.FunnelGuestAdded, wedding.CreatedAt, len(imported))
+
+	return &asyncjobs.Output{
+		Result: gin.H{
+			"imported":           len(imported),
+			"skipped_duplicates": skipped,
+			"tags_created":       tagsCreated,
+		},
+	}, nil
+}
+
+// PromoteWaitlistedGuest tira o convidado da lista de espera quando há vaga no casamento
+func PromoteWaitlistedGuest(c *gin.Context) {
+	wedding, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).PromoteFromWaitlist(guest, statusActor(c, models.StatusChannelWaitlist)); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrWeddingFull), errors.Is(err, repository.ErrNotWaitlisted):
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: err.Error(),
+			})
+		default:
+			log.Printf("[ERROR] Failed to promote guest %d of wedding %d: %v", guest.ID, wedding.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to promote guest",
+			})
+		}
+		return
+	}
+
+	invited := []models.Guest{*guest}
+	invitesSent := inviteGuests(c.Request.Context(), wedding, invited)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "guest promoted from the waitlist successfully",
+		"guest":        toGuestResponse(&invited[0]),
+		"invites_sent": invitesSent,
+	})
+}
+
+// PromoteWaitlist preenche as vagas livres do casamento com a lista de espera (lista A antes da B)
+// Útil ao aumentar o limite de convidados ou ao ativar a promoção automática depois das recusas
+func PromoteWaitlist(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	promoted, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).PromoteWaitlist(wedding.ID, statusActor(c, models.StatusChannelWaitlist))
+	if err != nil {
+		log.Printf("[ERROR] Failed to promote waitlist of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to promote waitlist",
+		})
+		return
+	}
+
+	invitesSent := inviteGuests(c.Request.Context(), wedding, promoted)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "waitlist promoted successfully",
+		"promoted":     toGuestResponses(promoted),
+		"invites_sent": invitesSent,
+	})
+}
+
+// Limite de convidados por mudança de status em lote
+const maxBulkStatusGuests = 500
+
+// BulkUpdateGuestStatus muda o status de vários convidados de uma vez (ex: família inteira confirmada por telefone)
+// Tudo ou nada: uma transição inválida ou a falta de vagas cancela o lote inteiro
+func BulkUpdateGuestStatus(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var bulkData struct {
+		GuestIDs []uint              `json:"guest_ids" binding:"required,min=1,max=500"`
+		Status   models.InviteStatus `json:"status" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&bulkData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if !bulkData.Status.IsValid() {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "invalid status",
+		})
+		return
+	}
+	if bulkData.Status == models.InviteStatusWaitlisted {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "waitlisted guests must be promoted through the waitlist endpoint",
+		})
+		return
+	}
+
+	// IDs repetidos contam uma vez só
+	slices.Sort(bulkData.GuestIDs)
+	guestIDs := slices.Compact(bulkData.GuestIDs)
+	if len(guestIDs) > maxBulkStatusGuests {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "bulk status updates are limited to 500 guests",
+		})
+		return
+	}
+
+	result, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).
+		UpdateStatusMany(wedding.ID, guestIDs, bulkData.Status, statusActor(c, models.StatusChannelDashboard))
+	if err != nil {
+		switch {
+		case err.Error() == "guest not found":
+			respondAccessError(c, authz.NotFound("guest"))
+		case errors.Is(err, repository.ErrInvalidStatusTransition):
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: "waitlisted guests must be promoted through the waitlist endpoint",
+			})
+		case errors.Is(err, repository.ErrWeddingFull), errors.Is(err, repository.ErrVenueAtCapacity):
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: err.Error(),
+			})
+		default:
+			log.Printf("[ERROR] Failed to bulk update guest status of wedding %d: %v", wedding.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to update guest status",
+			})
+		}
+		return
+	}
+
+	updated := make([]guestResponse, len(result.Updated))
+	for i := range result.Updated {
+		updated[i] = toGuestResponse(&result.Updated[i])
+	}
+	promoted := make([]guestResponse, len(result.Promoted))
+	for i := range result.Promoted {
+		promoted[i] = toGuestResponse(&result.Promoted[i])
+	}
+
+	response := gin.H{
+		"message":      "guest status updated successfully",
+		"updated":      updated,
+		"unchanged":    len(guestIDs) - len(updated),
+		"promoted":     promoted,
+		"invites_sent": inviteGuests(c.Request.Context(), wedding, result.Promoted),
+	}
+	if bulkData.Status == models.InviteStatusConfirmed && len(updated) > 0 {
+		if capacity := weddingCapacity(c, wedding); capacity != nil && capacity.OverCapacity {
+			response["capacity_warning"] = capacity
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// loadWeddingGuest extrai o casamento :id e o convidado :guestId
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func loadWeddingGuest(c *gin.Context) (*models.Wedding, *models.Guest, bool) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	guestID, err := parseIDParam(c, "guestId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	guest, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).FindByIDAndWeddingID(guestID, wedding.ID)
+	if err != nil {
+		// Apenas o registro inexistente vira 404; falhas do banco não podem se passar por convidado ausente
+		if err.Error() == "guest not found" {
+			respondAccessError(c, authz.NotFound("guest"))
+			return nil, nil, false
+		}
+		log.Printf("[ERROR] Failed to load guest %d of wedding %d: %v", guestID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "internal server error",
+		})
+		return nil, nil, false
+	}
+
+	return wedding, guest, true
+}
+
+// guestDedupIndex indexa convidados pelas chaves normalizadas de de-duplicação
+type guestDedupIndex struct {
+	emails map[string]bool
+	phones map[string]bool
+	names  map[string]bool
+}
+
+func newGuestDedupIndex() *guestDedupIndex {
+	return &guestDedupIndex{
+		emails: map[string]bool{},
+		phones: map[string]bool{},
+		names:  map[string]bool{},
+	}
+}
+
+func (idx *guestDedupIndex) add(g *models.Guest) {
+	email, phone, name := g.DedupKeys()
+	if email != "" {
+		idx.emails[email] = true
+	}
+	if phone != "" {
+		idx.phones[phone] = true
+	}
+	if name != "" {
+		idx.names[name] = true
+	}
+}
+
+func (idx *guestDedupIndex) contains(g *models.Guest) bool {
+	email, phone, name := g.DedupKeys()
+	return (email != "" && idx.emails[email]) ||
+		(phone != "" && idx.phones[phone]) ||
+		(name != "" && idx.names[name])
+}
+
+// toGuestResponse converte model para response
+func toGuestResponse(g *models.Guest) guestResponse {
+	return guestResponse{
+		ID:                  g.ID,
+		WeddingID:           g.WeddingID,
+		FullName:            g.FullName,
+		Phone:               g.Phone,
+		PhoneE164:           g.PhoneE164,
+		Email:               g.Email,
+		InviteStatus:        g.InviteStatus,
+		MaxGuests:           g.MaxGuests,
+		Priority:            g.Priority,
+		Events:              g.Events,
+		GroupID:             g.GroupID,
+		MealOption:          g.MealOption,
+		DietaryRestrictions: g.DietaryRestrictions,
+		IsChild:             g.IsChild,
+		Locale:              g.Locale,
+		CountryCode:         g.CountryCode,
+		OptedOut:            !g.CanReceiveMessages(),
+		OptedOutAt:          g.OptedOutAt,
+		DeclineMessage:      g.DeclineMessage,
+		CheckedInAt:         g.CheckedInAt,
+		TableName:           g.TableName,
+		Relationship:        g.Relationship,
+		Notes:               g.Notes,
+		Address:             g.Address,
+		AddressUpdatedAt:    g.AddressUpdatedAt,
+		CreatedAt:           g.CreatedAt,
+		UpdatedAt:           g.UpdatedAt,
+	}
+}
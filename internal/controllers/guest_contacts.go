@@ -0,0 +1,256 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/contacts"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/metrics"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// Tempo máximo da leitura da agenda do Google dentro de um request
+const googleContactsTimeout = 30 * time.Second
+
+// contactPreview representa um contato da agenda como ficaria no cadastro de convidados
+type contactPreview struct {
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone"`
+	PhoneE164 string `json:"phone_e164"`
+
+	// Já cadastrado no casamento (ou repetido na agenda): ignorado na confirmação
+	Duplicate bool `json:"duplicate"`
+	// Dado que impede o cadastro (ex: telefone inválido); o casal corrige antes de confirmar
+	Error string `json:"error,omitempty"`
+}
+
+// PreviewVCardImport lê um arquivo .vcf (multipart, campo "file") e mostra os convidados que seriam criados
+// Nada é gravado: o casal revisa a lista e confirma em POST /guests/import/contacts
+func PreviewVCardImport(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	// Agendas com fotos embutidas passam de 1MB: a rota usa a classe de import do BodyLimitMiddleware
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		if respondBodyTooLarge(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "vcard file is required",
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "unable to read uploaded file",
+		})
+		return
+	}
+	defer file.Close()
+
+	list, err := contacts.ParseVCard(file)
+	if err != nil {
+		if errors.Is(err, contacts.ErrInvalidVCard) || errors.Is(err, contacts.ErrTooManyContacts) {
+			c.JSON(http.StatusBadRequest, errorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		log.Printf("[ERROR] Failed to parse vcard for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to read vcard file",
+		})
+		return
+	}
+
+	respondContactsPreview(c, wedding, list)
+}
+
+// PreviewGoogleContactsImport lê a agenda do Google pela People API e mostra os convidados que seriam criados
+// Segurança: o access token (OAuth no front-end, escopo contacts.readonly) é usado apenas nesta chamada
+func PreviewGoogleContactsImport(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var previewData struct {
+		AccessToken string `json:"access_token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&previewData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), googleContactsTimeout)
+	defer cancel()
+
+	list, err := contacts.FetchGoogleContacts(ctx, previewData.AccessToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, contacts.ErrInvalidGoogleAuth), errors.Is(err, contacts.ErrTooManyContacts):
+			c.JSON(http.StatusBadRequest, errorResponse{
+				Error: err.Error(),
+			})
+		default:
+			log.Printf("[ERROR] Failed to fetch google contacts for wedding %d: %v", wedding.ID, err)
+			c.JSON(http.StatusBadGateway, errorResponse{
+				Error: "unable to fetch google contacts",
+			})
+		}
+		return
+	}
+
+	respondContactsPreview(c, wedding, list)
+}
+
+// ConfirmContactsImport cria os convidados revisados na prévia da agenda
+// Duplicados são ignorados como na importação entre casamentos; contatos inválidos recusam o lote inteiro
+func ConfirmContactsImport(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var confirmData struct {
+		Contacts []contacts.Contact `json:"contacts" binding:"required,min=1,max=2000"`
+	}
+
+	if err := c.ShouldBindJSON(&confirmData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	repo := repository.NewGuestRepository(database.WithContext(c.Request.Context()))
+
+	seen, err := weddingDedupIndex(repo, wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch guests of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to import contacts",
+		})
+		return
+	}
+
+	imported := make([]models.Guest, 0, len(confirmData.Contacts))
+	skipped := 0
+	for i, contact := range confirmData.Contacts {
+		guest := contactGuest(wedding.ID, contact)
+		if err := guest.IsValid(); err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse{
+				Error: fmt.Sprintf("contact %d: %s", i+1, err.Error()),
+			})
+			return
+		}
+		if seen.contains(&guest) {
+			skipped++
+			continue
+		}
+		seen.add(&guest)
+		guest.ApplyLocaleDefaults()
+		imported = append(imported, guest)
+	}
+
+	if err := repo.CreateMany(wedding.ID, imported, models.StatusActor{
+		Channel: models.StatusChannelImport,
+		UserID:  currentUserID(c),
+		Note:    "imported from contacts",
+	}); err != nil {
+		if errors.Is(err, repository.ErrWeddingFull) {
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: "import would exceed the wedding's max guests",
+			})
+			return
+		}
+		log.Printf("[ERROR] Failed to import contacts into wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to import contacts",
+		})
+		return
+	}
+	metrics.RecordFunnelStep(metrics.FunnelGuestAdded, wedding.CreatedAt, len(imported))
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":            "contacts imported successfully",
+		"imported":           len(imported),
+		"skipped_duplicates": skipped,
+		"guests":             toGuestResponses(imported),
+	})
+}
+
+// respondContactsPreview valida os contatos como convidados e marca os já cadastrados
+func respondContactsPreview(c *gin.Context, wedding *models.Wedding, list []contacts.Contact) {
+	seen, err := weddingDedupIndex(repository.NewGuestRepository(database.WithContext(c.Request.Context())), wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch guests of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to preview contacts",
+		})
+		return
+	}
+
+	preview := make([]contactPreview, len(list))
+	invalid, duplicates := 0, 0
+	for i, contact := range list {
+		guest := contactGuest(wedding.ID, contact)
+		p := contactPreview{FullName: contact.FullName, Email: contact.Email, Phone: contact.Phone}
+		if err := guest.IsValid(); err != nil {
+			p.Error = err.Error()
+			invalid++
+		} else {
+			p.FullName, p.Email, p.PhoneE164 = guest.FullName, guest.Email, guest.PhoneE164
+		}
+		if seen.contains(&guest) {
+			p.Duplicate = true
+			duplicates++
+		} else {
+			seen.add(&guest)
+		}
+		preview[i] = p
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"contacts":   preview,
+		"total":      len(preview),
+		"invalid":    invalid,
+		"duplicates": duplicates,
+	})
+}
+
+// weddingDedupIndex indexa os convidados já cadastrados para a detecção de duplicados
+func weddingDedupIndex(repo *repository.GuestRepository, weddingID uint) (*guestDedupIndex, error) {
+	existing, err := repo.FindByWeddingID(weddingID)
+	if err != nil {
+		return nil, err
+	}
+	seen := newGuestDedupIndex()
+	for i := range existing {
+		seen.add(&existing[i])
+	}
+	return seen, nil
+}
+
+// contactGuest converte um contato da agenda em convidado pendente
+func contactGuest(weddingID uint, contact contacts.Contact) models.Guest {
+	return models.Guest{
+		WeddingID:    weddingID,
+		FullName:     contact.FullName,
+		Email:        contact.Email,
+		Phone:        contact.Phone,
+		InviteStatus: models.InviteStatusPending,
+	}
+}
@@ -0,0 +1,297 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// Limite de convidados vinculados por requisição
+const maxGroupAssignment = 200
+
+// guestGroupResponse representa a resposta padronizada de grupo de convidados
+type guestGroupResponse struct {
+	ID        uint      `json:"id"`
+	WeddingID uint      `json:"wedding_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// guestGroupWithGuestsResponse representa um grupo com seus convidados
+type guestGroupWithGuestsResponse struct {
+	guestGroupResponse
+	Guests []guestResponse `json:"guests"`
+
+	// Total de pessoas do grupo (soma de max_guests dos convidados)
+	Headcount int `json:"headcount"`
+}
+
+// CreateGuestGroup cadastra um grupo de convidados no casamento
+func CreateGuestGroup(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var createData struct {
+		Name string `json:"name" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&createData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	group := models.GuestGroup{
+		WeddingID: wedding.ID,
+		Name:      createData.Name,
+	}
+
+	if err := group.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repository.NewGuestGroupRepository(database.WithContext(c.Request.Context())).Create(&group); err != nil {
+		log.Printf("[ERROR] Failed to create guest group for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to create guest group",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "guest group created successfully",
+		"group":   toGuestGroupResponse(&group),
+	})
+}
+
+// GetGuestGroups lista os grupos do casamento com seus convidados
+// Convidados sem grupo são retornados separadamente em "ungrouped"
+func GetGuestGroups(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	db := database.WithContext(c.Request.Context())
+
+	groups, err := repository.NewGuestGroupRepository(db).FindByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch guest groups of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch guest groups",
+		})
+		return
+	}
+
+	guests, err := repository.NewGuestRepository(db).FindByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch guests of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch guest groups",
+		})
+		return
+	}
+
+	// Performance: Uma query por entidade, agrupamento em memória
+	response := make([]guestGroupWithGuestsResponse, len(groups))
+	index := make(map[uint]int, len(groups))
+	for i := range groups {
+		response[i] = guestGroupWithGuestsResponse{
+			guestGroupResponse: toGuestGroupResponse(&groups[i]),
+			Guests:             []guestResponse{},
+		}
+		index[groups[i].ID] = i
+	}
+
+	ungrouped := []guestResponse{}
+	for i := range guests {
+		g := &guests[i]
+		if g.GroupID != nil {
+			if pos, ok := index[*g.GroupID]; ok {
+				response[pos].Guests = append(response[pos].Guests, toGuestResponse(g))
+				response[pos].Headcount += g.MaxGuests
+				continue
+			}
+		}
+		ungrouped = append(ungrouped, toGuestResponse(g))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"groups":    response,
+		"ungrouped": ungrouped,
+		"count":     len(response),
+	})
+}
+
+// UpdateGuestGroup renomeia um grupo
+func UpdateGuestGroup(c *gin.Context) {
+	wedding, group, ok := loadWeddingGuestGroup(c)
+	if !ok {
+		return
+	}
+
+	var updateData struct {
+		Name *string `json:"name"`
+	}
+
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if updateData.Name != nil {
+		group.Name = *updateData.Name
+	}
+
+	if err := group.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repository.NewGuestGroupRepository(database.WithContext(c.Request.Context())).Update(group); err != nil {
+		log.Printf("[ERROR] Failed to update guest group %d of wedding %d: %v", group.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to update guest group",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "guest group updated successfully",
+		"group":   toGuestGroupResponse(group),
+	})
+}
+
+// DeleteGuestGroup remove um grupo (os convidados ficam sem grupo)
+func DeleteGuestGroup(c *gin.Context) {
+	wedding, group, ok := loadWeddingGuestGroup(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.NewGuestGroupRepository(database.WithContext(c.Request.Context())).Delete(group); err != nil {
+		log.Printf("[ERROR] Failed to delete guest group %d of wedding %d: %v", group.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to delete guest group",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "guest group deleted successfully",
+	})
+}
+
+// AssignGuestsToGroup vincula convidados do casamento ao grupo
+// Convidados que já estavam em outro grupo são movidos
+func AssignGuestsToGroup(c *gin.Context) {
+	wedding, group, ok := loadWeddingGuestGroup(c)
+	if !ok {
+		return
+	}
+
+	var assignData struct {
+		GuestIDs []uint `json:"guest_ids" binding:"required,min=1"`
+	}
+
+	if err := c.ShouldBindJSON(&assignData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if len(assignData.GuestIDs) > maxGroupAssignment {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "too many guests in a single request",
+		})
+		return
+	}
+
+	assigned, err := repository.NewGuestGroupRepository(database.WithContext(c.Request.Context())).AssignGuests(group, assignData.GuestIDs)
+	if err != nil {
+		log.Printf("[ERROR] Failed to assign guests to group %d of wedding %d: %v", group.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to assign guests",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "guests assigned successfully",
+		"assigned": assigned,
+	})
+}
+
+// RemoveGuestFromGroup desvincula um convidado do grupo
+func RemoveGuestFromGroup(c *gin.Context) {
+	wedding, group, ok := loadWeddingGuestGroup(c)
+	if !ok {
+		return
+	}
+
+	guestID, err := parseIDParam(c, "guestId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repository.NewGuestGroupRepository(database.WithContext(c.Request.Context())).UnassignGuest(group, guestID); err != nil {
+		log.Printf("[INFO] Guest %d not removed from group %d of wedding %d: %v", guestID, group.ID, wedding.ID, err)
+		respondAccessError(c, authz.NotFound("guest"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "guest removed from group successfully",
+	})
+}
+
+// loadWeddingGuestGroup extrai o casamento :id e o grupo :groupId
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func loadWeddingGuestGroup(c *gin.Context) (*models.Wedding, *models.GuestGroup, bool) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	groupID, err := parseIDParam(c, "groupId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	group, err := repository.NewGuestGroupRepository(database.WithContext(c.Request.Context())).FindByIDAndWeddingID(groupID, wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("guest group"))
+		return nil, nil, false
+	}
+
+	return wedding, group, true
+}
+
+// toGuestGroupResponse converte model para response
+func toGuestGroupResponse(g *models.GuestGroup) guestGroupResponse {
+	return guestGroupResponse{
+		ID:        g.ID,
+		WeddingID: g.WeddingID,
+		Name:      g.Name,
+		CreatedAt: g.CreatedAt,
+		UpdatedAt: g.UpdatedAt,
+	}
+}
@@ -0,0 +1,498 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/mailer"
+	"github.com/matheushermes/wedding_planner_service/internal/metrics"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+	"github.com/matheushermes/wedding_planner_service/internal/sms"
+	"github.com/matheushermes/wedding_planner_service/internal/whatsapp"
+)
+
+var (
+	// errGuestUnreachable indica que o convidado não tem email ou optou por não receber mensagens
+	errGuestUnreachable = errors.New("guest has no email or opted out of messages")
+	// errGuestNoPhone indica que o convidado não tem telefone ou optou por não receber mensagens
+	errGuestNoPhone = errors.New("guest has no phone or opted out of messages")
+	// errWhatsAppNotConfigured indica casamento sem conta de WhatsApp cadastrada
+	errWhatsAppNotConfigured = errors.New("whatsapp is not configured for this wedding")
+)
+
+// Limite de convidados sugeridos por chamada da lista de espera
+const maxWaitlistSuggestions = 50
+
+// Assunto do email de convite
+const inviteEmailSubject = "Você está convidado(a) para o nosso casamento"
+
+// Motivo registrado na auditoria do convite enviado por SMS no lugar do WhatsApp
+const smsFallbackReason = "sms fallback: whatsapp unavailable"
+
+// SendGuestInvite envia (ou reenvia) o convite com o link pessoal de RSVP
+// Canal opcional no body ({"via": "whatsapp"}), email por padrão
+func SendGuestInvite(c *gin.Context) {
+	wedding, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return
+	}
+
+	via, ok := bindInviteVia(c)
+	if !ok {
+		return
+	}
+
+	// Convidados na lista de espera só recebem o convite depois de promovidos para não ocupar vaga sem lugar
+	if guest.InviteStatus == models.InviteStatusWaitlisted {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "waitlisted guests must be promoted before being invited",
+		})
+		return
+	}
+
+	err := deliverInvite(c.Request.Context(), wedding, guest, &models.Invite{SentVia: via}, statusActor(c, models.StatusChannelInvite))
+	if err != nil {
+		respondInviteError(c, wedding, guest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "invite sent successfully",
+		"guest":   toGuestResponse(guest),
+	})
+}
+
+// GetWaitlistSuggestions mostra ao casal quem convidar a seguir: os próximos da lista de espera
+// que cabem nas vagas livres e os promovidos (lista B) que ainda não receberam o convite
+func GetWaitlistSuggestions(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	repo := repository.NewGuestRepository(database.WithContext(c.Request.Context()))
+
+	free := max(wedding.MaxGuests-wedding.CurrentGuestCount, 0)
+	next := []models.Guest{}
+	if free > 0 {
+		var err error
+		next, err = repo.FindWaitlistQueue(wedding.ID, min(free, maxWaitlistSuggestions))
+		if err != nil {
+			log.Printf("[ERROR] Failed to fetch waitlist of wedding %d: %v", wedding.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to fetch waitlist suggestions",
+			})
+			return
+		}
+	}
+
+	uninvited, err := repo.FindPendingByPriority(wedding.ID, models.GuestPriorityB)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch uninvited b-list guests of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch waitlist suggestions",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"free_seats":           free,
+		"next_to_promote":      toGuestResponses(next),
+		"promoted_not_invited": toGuestResponses(uninvited),
+		"auto_promote":         wedding.AutoPromoteGuests,
+		"auto_invite":          wedding.AutoInvitePromoted,
+	})
+}
+
+// inviteGuests envia o convite aos convidados promovidos quando o casamento tem o envio automático ativo
+// Falhas são apenas registradas: a promoção já foi gravada e o casal pode reenviar pelo endpoint de convite
+func inviteGuests(ctx context.Context, wedding *models.Wedding, guests []models.Guest) int {
+	if !wedding.AutoInvitePromoted {
+		return 0
+	}
+
+	sent := 0
+	actor := models.StatusActor{Channel: models.StatusChannelInvite, Note: "sent automatically after waitlist promotion"}
+	for i := range guests {
+		err := sendGuestInvite(ctx, wedding, &guests[i], actor)
+		switch {
+		case err == nil:
+			sent++
+		case errors.Is(err, errGuestUnreachable):
+			// Sem email ou com opt-out: o casal convida por outro canal
+		default:
+			log.Printf("[WARN] Failed to auto-invite promoted guest %d of wedding %d: %v", guests[i].ID, wedding.ID, err)
+		}
+	}
+	return sent
+}
+
+// sendGuestInvite envia o email do convite e registra o envio em um novo convite
+func sendGuestInvite(ctx context.Context, wedding *models.Wedding, guest *models.Guest, actor models.StatusActor) error {
+	return deliverInvite(ctx, wedding, guest, &models.Invite{}, actor)
+}
+
+// deliverInvite envia o convite pelo canal do convite (SentVia, email quando vazio) e grava SentAt/SentVia
+// O convite entra na fila (queued) antes do envio e vira sent ou failed; os webhooks dos provedores
+// completam a entrega (delivered, bounced)
+// Com Wedding.SMSFallback, o convite por WhatsApp de um casamento sem conta sai por SMS (SentVia sms)
+func deliverInvite(ctx context.Context, wedding *models.Wedding, guest *models.Guest, invite *models.Invite, actor models.StatusActor) error {
+	switch invite.SentVia {
+	case "":
+		invite.SentVia = models.InviteViaEmail
+	case models.InviteViaSMS:
+		// Reenvio de um convite que caiu no SMS: o WhatsApp é tentado de novo (com o mesmo fallback)
+		invite.SentVia = models.InviteViaWhatsApp
+	}
+
+	// Sem contato ou com opt-out o convite nem entra na fila
+	if err := checkGuestReachable(guest, invite.SentVia); err != nil {
+		return err
+	}
+
+	// Templates inválidos são recusados antes de registrar o convite
+	for _, text := range []string{invite.Template, invite.Subject} {
+		if err := models.ValidateInviteTemplate(text); err != nil {
+			return err
+		}
+	}
+
+	repo := repository.NewInviteRepository(database.WithContext(ctx))
+	if err := repo.Queue(guest, invite); err != nil {
+		return err
+	}
+
+	// Template renderizado no envio: o convidado recebe os dados atuais do casamento
+	// Por email, o link de RSVP passa pelo registro do clique (precisa do ID do convite)
+	data := inviteTemplateData(wedding, guest)
+	if invite.SentVia == models.InviteViaEmail {
+		data.RSVPLink = configs.PUBLIC_BASE_URL + inviteClickPath(invite.ID)
+	}
+	text, err := inviteText(wedding, guest, invite, data)
+	subject := inviteEmailSubject
+	if err == nil && invite.Subject != "" {
+		subject, err = models.RenderInviteTemplate(invite.Subject, data)
+	}
+
+	// Auditoria: cada tentativa guarda o conteúdo enviado e o ID da mensagem no provedor
+	delivery := &models.InviteDelivery{
+		InviteID:  invite.ID,
+		WeddingID: wedding.ID,
+		Channel:   invite.SentVia,
+		Status:    models.InviteDeliverySent,
+	}
+	if err == nil {
+		var receipt deliveryReceipt
+		switch invite.SentVia {
+		case models.InviteViaWhatsApp:
+			delivery.Payload = inviteDeliveryPayload(guest.PhoneE164, "", text)
+			receipt, err = sendGuestWhatsApp(ctx, wedding, guest, text, configs.PUBLIC_BASE_URL+inviteStatusCallbackPath(invite.ID))
+			if wedding.SMSFallback && errors.Is(err, errWhatsAppNotConfigured) {
+				// Auditoria: a falha do WhatsApp fica no histórico antes da tentativa por SMS
+				recordFallbackCause(ctx, delivery, err)
+				delivery.Channel = models.InviteViaSMS
+				delivery.Reason = smsFallbackReason
+				receipt, err = sendGuestSMS(ctx, guest, text, data.OptOutLink)
+			}
+		default:
+			delivery.Payload = inviteDeliveryPayload(guest.Email, subject, text)
+			receipt, err = sendGuestEmail(ctx, guest, subject, text, &inviteEmailTracking{
+				Metadata: map[string]string{
+					inviteDeliveryArg: security.SignID(inviteDeliveryTokenPurpose, invite.ID),
+				},
+				PixelURL: configs.PUBLIC_BASE_URL + inviteOpenPath(invite.ID),
+			})
+		}
+		delivery.Provider = receipt.Provider
+		delivery.ProviderMessageID = receipt.MessageID
+	}
+	if err != nil {
+		markInviteFailed(ctx, invite, delivery, err)
+		return err
+	}
+
+	now := time.Now()
+	invite.SentAt = &now
+	invite.SentVia = delivery.Channel
+	delivery.OccurredAt = now
+	if err := repo.RecordSent(guest, invite, delivery, actor); err != nil {
+		return err
+	}
+	metrics.RecordFunnelStep(metrics.FunnelInviteSent, wedding.CreatedAt, 1)
+	return nil
+}
+
+// checkGuestReachable confere se o convidado tem contato no canal e aceita mensagens
+// LGPD: convidados com opt-out não recebem mensagens automáticas
+func checkGuestReachable(guest *models.Guest, via string) error {
+	if !guest.CanReceiveMessages() {
+		if via == models.InviteViaWhatsApp {
+			return errGuestNoPhone
+		}
+		return errGuestUnreachable
+	}
+	if via == models.InviteViaWhatsApp && guest.PhoneE164 == "" {
+		return errGuestNoPhone
+	}
+	if via != models.InviteViaWhatsApp && guest.Email == "" {
+		return errGuestUnreachable
+	}
+	return nil
+}
+
+// recordFallbackCause registra na auditoria a falha do canal original antes do fallback
+// Falha ao gravar só vai para o log: o convite ainda pode sair pelo outro canal
+func recordFallbackCause(ctx context.Context, delivery *models.InviteDelivery, cause error) {
+	failed := *delivery
+	failed.Status = models.InviteDeliveryFailed
+	failed.Reason = truncateDeliveryError(cause.Error())
+	failed.OccurredAt = time.Now()
+
+	repo := repository.NewInviteRepository(database.WithContext(context.WithoutCancel(ctx)))
+	if err := repo.RecordDelivery(&failed); err != nil {
+		log.Printf("[ERROR] Failed to record %s failure of invite %d before fallback: %v", failed.Channel, delivery.InviteID, err)
+	}
+}
+
+// markInviteFailed registra a falha do envio no convite (e a tentativa na auditoria) para o casal ver qual convite não saiu
+// Com o contexto cancelado (job interrompido) a falha ainda é gravada
+func markInviteFailed(ctx context.Context, invite *models.Invite, delivery *models.InviteDelivery, cause error) {
+	reason := truncateDeliveryError(cause.Error())
+	event := *delivery
+	event.Status = models.InviteDeliveryFailed
+	event.Reason = reason
+	event.OccurredAt = time.Now()
+
+	repo := repository.NewInviteRepository(database.WithContext(context.WithoutCancel(ctx)))
+	if _, err := repo.UpdateDelivery(invite.ID, event); err != nil {
+		log.Printf("[ERROR] Failed to mark invite %d as failed: %v", invite.ID, err)
+		return
+	}
+	invite.DeliveryStatus = models.InviteDeliveryFailed
+	invite.DeliveryError = reason
+}
+
+// respondInviteError traduz a falha de envio do convite para a resposta HTTP
+func respondInviteError(c *gin.Context, wedding *models.Wedding, guest *models.Guest, err error) {
+	switch {
+	case errors.Is(err, errGuestUnreachable), errors.Is(err, errGuestNoPhone), errors.Is(err, errWhatsAppNotConfigured):
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: err.Error(),
+		})
+	case errors.Is(err, mailer.ErrInvalidMessage):
+		c.JSON(http.StatusUnprocessableEntity, errorResponse{
+			Error: "guest email address cannot receive messages",
+		})
+	case errors.Is(err, models.ErrInvalidInviteTemplate):
+		c.JSON(http.StatusUnprocessableEntity, errorResponse{
+			Error: err.Error(),
+		})
+	case errors.Is(err, whatsapp.ErrInvalidMessage):
+		c.JSON(http.StatusUnprocessableEntity, errorResponse{
+			Error: "guest phone number cannot receive whatsapp messages",
+		})
+	case errors.Is(err, sms.ErrInvalidMessage):
+		c.JSON(http.StatusUnprocessableEntity, errorResponse{
+			Error: "guest phone number cannot receive sms messages",
+		})
+	default:
+		log.Printf("[ERROR] Failed to send invite to guest %d of wedding %d: %v", guest.ID, wedding.ID, err)
+		c.JSON(http.StatusBadGateway, errorResponse{
+			Error: "unable to send invite",
+		})
+	}
+}
+
+// bindInviteVia lê o canal opcional do envio; vazio mantém o canal do convite
+func bindInviteVia(c *gin.Context) (string, bool) {
+	var body struct {
+		Via string `json:"via"`
+	}
+
+	// Corpo opcional: sem canal vale o SentVia do convite
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			respondBindError(c, err)
+			return "", false
+		}
+	}
+
+	via := strings.ToLower(strings.TrimSpace(body.Via))
+	if via != "" && !models.IsValidInviteVia(via) {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "via must be one of: email, whatsapp",
+		})
+		return "", false
+	}
+	return via, true
+}
+
+// deliveryReceipt identifica a mensagem aceita pelo provedor (auditoria dos envios)
+type deliveryReceipt struct {
+	Provider  string
+	MessageID string
+}
+
+// inviteDeliveryPayload monta o snapshot do conteúdo enviado (destinatário, assunto e texto)
+func inviteDeliveryPayload(to, subject, text string) string {
+	payload, _ := json.Marshal(struct {
+		To      string `json:"to"`
+		Subject string `json:"subject,omitempty"`
+		Text    string `json:"text"`
+	}{to, subject, text})
+	return string(payload)
+}
+
+// sendGuestEmail envia uma mensagem do casamento ao convidado com o link de opt-out
+// LGPD: convidados com opt-out não recebem mensagens automáticas
+// Com tracking (convites), o email ganha a versão HTML com o pixel de abertura e o token dos eventos de entrega
+func sendGuestEmail(ctx context.Context, guest *models.Guest, subject, text string, tracking *inviteEmailTracking) (deliveryReceipt, error) {
+	if guest.Email == "" || !guest.CanReceiveMessages() {
+		return deliveryReceipt{}, errGuestUnreachable
+	}
+
+	optOutURL := configs.PUBLIC_BASE_URL + OptOutPath(guest.ID)
+	msg := mailer.Message{
+		To:      guest.Email,
+		Subject: subject,
+		Text:    text + "\nNão quer mais receber mensagens sobre este casamento? " + optOutURL + "\n",
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + optOutURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}
+	if tracking != nil {
+		msg.HTML = inviteEmailHTML(msg.Text, tracking.PixelURL)
+		msg.Metadata = tracking.Metadata
+	}
+	m := mailer.Default()
+	messageID, err := m.Send(ctx, msg)
+	return deliveryReceipt{Provider: m.Name(), MessageID: messageID}, err
+}
+
+// sendGuestWhatsApp envia uma mensagem do casamento pelo WhatsApp do casal
+// Com template aprovado, os parâmetros configurados na conta são preenchidos com os dados do convidado
+// statusCallback recebe as atualizações de entrega do provedor (vazio fora dos convites)
+// LGPD: convidados com opt-out não recebem mensagens automáticas
+func sendGuestWhatsApp(ctx context.Context, wedding *models.Wedding, guest *models.Guest, text, statusCallback string) (deliveryReceipt, error) {
+	if guest.PhoneE164 == "" || !guest.CanReceiveMessages() {
+		return deliveryReceipt{}, errGuestNoPhone
+	}
+
+	account, err := repository.NewWhatsAppRepository(database.WithContext(ctx)).FindByWeddingID(wedding.ID)
+	if err != nil {
+		if err.Error() == "whatsapp account not found" {
+			return deliveryReceipt{}, errWhatsAppNotConfigured
+		}
+		return deliveryReceipt{}, err
+	}
+	sender, err := whatsapp.New(account)
+	if err != nil {
+		return deliveryReceipt{}, err
+	}
+
+	data := inviteTemplateData(wedding, guest)
+	params, err := whatsAppTemplateParams(account, data)
+	if err != nil {
+		return deliveryReceipt{}, err
+	}
+
+	messageID, err := sender.Send(ctx, whatsapp.Message{
+		To:             guest.PhoneE164,
+		Template:       account.TemplateName,
+		Language:       account.TemplateLanguage,
+		Params:         params,
+		Text:           text + "\nNão quer mais receber mensagens sobre este casamento? " + data.OptOutLink + "\n",
+		StatusCallback: statusCallback,
+	})
+	return deliveryReceipt{Provider: sender.Name(), MessageID: messageID}, err
+}
+
+// sendGuestSMS envia o texto do convite por SMS pelo provedor do serviço (SMS_PROVIDER)
+// Usado no fallback do WhatsApp: o texto livre vai no lugar do template aprovado
+// LGPD: convidados com opt-out não recebem mensagens automáticas
+func sendGuestSMS(ctx context.Context, guest *models.Guest, text, optOutLink string) (deliveryReceipt, error) {
+	if guest.PhoneE164 == "" || !guest.CanReceiveMessages() {
+		return deliveryReceipt{}, errGuestNoPhone
+	}
+
+	sender := sms.Default()
+	messageID, err := sender.Send(ctx, sms.Message{
+		To:   guest.PhoneE164,
+		Text: text + "\nNão quer mais receber mensagens sobre este casamento? " + optOutLink + "\n",
+	})
+	return deliveryReceipt{Provider: sender.Name(), MessageID: messageID}, err
+}
+
+// whatsAppTemplateParams renderiza os parâmetros do template aprovado com os dados do convidado
+func whatsAppTemplateParams(account *models.WhatsAppAccount, data models.InviteTemplateData) ([]string, error) {
+	params := make([]string, len(account.TemplateParams))
+	for i, param := range account.TemplateParams {
+		rendered, err := models.RenderInviteTemplate(param, data)
+		if err != nil {
+			return nil, err
+		}
+		params[i] = rendered
+	}
+	return params, nil
+}
+
+// inviteText monta o texto do convite: o template do convite com as variáveis do convidado ou o texto padrão
+func inviteText(wedding *models.Wedding, guest *models.Guest, invite *models.Invite, data models.InviteTemplateData) (string, error) {
+	if invite.Template != "" {
+		return models.RenderInviteTemplate(invite.Template, data)
+	}
+	return inviteEmailText(wedding, guest, data.RSVPLink), nil
+}
+
+// inviteTemplateData retorna os valores das variáveis dos templates de convite para o convidado
+func inviteTemplateData(wedding *models.Wedding, guest *models.Guest) models.InviteTemplateData {
+	return models.InviteTemplateData{
+		GuestName:  guest.FullName,
+		VenueName:  wedding.VenueName,
+		EventDate:  wedding.EventDate.Format("02/01/2006"),
+		EventTime:  wedding.EventTime,
+		RSVPLink:   configs.PUBLIC_BASE_URL + RSVPPath(guest.ID),
+		OptOutLink: configs.PUBLIC_BASE_URL + OptOutPath(guest.ID),
+	}
+}
+
+// inviteEmailText monta o corpo do convite com a data, o local e o link de RSVP
+func inviteEmailText(wedding *models.Wedding, guest *models.Guest, rsvpLink string) string {
+	when := wedding.EventDate.Format("02/01/2006")
+	if wedding.EventTime != "" {
+		when += " às " + wedding.EventTime
+	}
+
+	part := "para o nosso casamento"
+	switch guest.Events {
+	case models.WeddingEventCeremony:
+		part = "para a cerimônia do nosso casamento"
+	case models.WeddingEventReception:
+		part = "para a recepção do nosso casamento"
+	}
+
+	return fmt.Sprintf("Olá, %s!\n\nÉ com muita alegria que convidamos você %s, em %s, no %s.\n\n"+
+		"Confirme sua presença pelo link: %s\n",
+		guest.FullName, part, when, wedding.VenueName, rsvpLink)
+}
+
+// toGuestResponses converte uma lista de models para response
+func toGuestResponses(guests []models.Guest) []guestResponse {
+	response := make([]guestResponse, len(guests))
+	for i := range guests {
+		response[i] = toGuestResponse(&guests[i])
+	}
+	return response
+}
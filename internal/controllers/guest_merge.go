@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// Telefones com menos dígitos não são usados na detecção (ex: ramais, números incompletos)
+const minDedupPhoneDigits = 8
+
+// duplicateGroupResponse representa um conjunto de registros provavelmente do mesmo convidado
+type duplicateGroupResponse struct {
+	MatchedBy []string        `json:"matched_by"` // email, phone e/ou name
+	Guests    []guestResponse `json:"guests"`
+}
+
+// GetDuplicateGuests lista grupos de convidados provavelmente duplicados
+// Dois registros entram no mesmo grupo quando compartilham email, telefone ou nome normalizados
+func GetDuplicateGuests(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	guests, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).FindByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch guests of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch guests",
+		})
+		return
+	}
+
+	groups := findDuplicateGroups(guests)
+
+	c.JSON(http.StatusOK, gin.H{
+		"groups": groups,
+		"total":  len(groups),
+	})
+}
+
+// MergeGuest incorpora outro registro (duplicate_id) no convidado :guestId e remove o duplicado
+// Mantém o status de RSVP mais avançado, os acompanhantes, convites, etiquetas e respostas do RSVP
+func MergeGuest(c *gin.Context) {
+	wedding, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return
+	}
+
+	var mergeData struct {
+		DuplicateID uint `json:"duplicate_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&mergeData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if mergeData.DuplicateID == guest.ID {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "a guest cannot be merged into itself",
+		})
+		return
+	}
+
+	repo := repository.NewGuestRepository(database.WithContext(c.Request.Context()))
+
+	duplicate, err := repo.FindByIDAndWeddingID(mergeData.DuplicateID, wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("duplicate guest"))
+		return
+	}
+
+	if err := repo.Merge(guest, duplicate, models.StatusActor{
+		Channel: models.StatusChannelMerge,
+		UserID:  currentUserID(c),
+		Note:    fmt.Sprintf("merged with guest #%d", duplicate.ID),
+	}); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrMergeCompanionLimit):
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: err.Error(),
+			})
+		case err.Error() == "guest not found":
+			respondAccessError(c, authz.NotFound("guest"))
+		default:
+			log.Printf("[ERROR] Failed to merge guest %d into %d (wedding %d): %v", duplicate.ID, guest.ID, wedding.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to merge guests",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         "guests merged successfully",
+		"guest":           toGuestResponse(guest),
+		"merged_guest_id": duplicate.ID,
+	})
+}
+
+// findDuplicateGroups agrupa convidados que compartilham alguma chave de de-duplicação
+// Performance: Union-find sobre índices por chave, O(n) para a lista inteira do casamento
+func findDuplicateGroups(guests []models.Guest) []duplicateGroupResponse {
+	parent := make([]int, len(guests))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	// Chave -> primeiro convidado com ela; matched registra por qual campo cada raiz foi unida
+	firstByKey := map[string]int{}
+	matched := map[int]map[string]bool{}
+	link := func(i int, field, key string) {
+		first, seen := firstByKey[field+":"+key]
+		if !seen {
+			firstByKey[field+":"+key] = i
+			return
+		}
+		a, b := find(first), find(i)
+		if a != b {
+			parent[b] = a
+			if matched[a] == nil {
+				matched[a] = map[string]bool{}
+			}
+			for f := range matched[b] {
+				matched[a][f] = true
+			}
+			delete(matched, b)
+		}
+		if matched[a] == nil {
+			matched[a] = map[string]bool{}
+		}
+		matched[a][field] = true
+	}
+
+	for i := range guests {
+		email, phone, name := guests[i].DedupKeys()
+		if email != "" {
+			link(i, "email", email)
+		}
+		if len(phone) >= minDedupPhoneDigits {
+			link(i, "phone", phone)
+		}
+		if name != "" {
+			link(i, "name", name)
+		}
+	}
+
+	members := map[int][]int{}
+	for i := range guests {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+
+	groups := make([]duplicateGroupResponse, 0)
+	for root, indexes := range members {
+		if len(indexes) < 2 {
+			continue
+		}
+		group := duplicateGroupResponse{}
+		for _, field := range []string{"email", "phone", "name"} {
+			if matched[root][field] {
+				group.MatchedBy = append(group.MatchedBy, field)
+			}
+		}
+		for _, i := range indexes {
+			group.Guests = append(group.Guests, toGuestResponse(&guests[i]))
+		}
+		// Registro mais antigo primeiro: candidato natural a principal no merge
+		sort.Slice(group.Guests, func(i, j int) bool {
+			return group.Guests[i].ID < group.Guests[j].ID
+		})
+		groups = append(groups, group)
+	}
+
+	// Ordem estável: grupo com o convidado mais antigo primeiro
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Guests[0].ID < groups[j].Guests[0].ID
+	})
+	return groups
+}
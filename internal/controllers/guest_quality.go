@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// Problemas de cadastro apontados no relatório de qualidade
+const (
+	guestIssueMissingEmail = "missing_email"
+	guestIssueInvalidEmail = "invalid_email"
+	guestIssueMissingPhone = "missing_phone"
+	guestIssueInvalidPhone = "invalid_phone"
+	guestIssueNoContact    = "no_contact" // sem email nem telefone válidos: não recebe convite por nenhum canal
+	guestIssueDuplicate    = "duplicate"
+	guestIssueNoGroup      = "no_group"
+	guestIssueNoTable      = "no_table"
+)
+
+// guestQualityIssues lista os problemas de cadastro de um convidado
+type guestQualityIssues struct {
+	GuestID      uint                `json:"guest_id"`
+	FullName     string              `json:"full_name"`
+	InviteStatus models.InviteStatus `json:"invite_status"`
+	Issues       []string            `json:"issues"`
+}
+
+// GetGuestQualityReport aponta convidados com contato ausente ou inválido, duplicados e sem grupo ou mesa
+// para o casal corrigir os dados antes do envio dos convites e da montagem das mesas
+// Grupo e mesa só são cobrados de quem ocupa vaga (recusados e lista de espera ficam de fora)
+func GetGuestQualityReport(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	guests, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).FindByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch guests of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to build guest quality report",
+		})
+		return
+	}
+
+	duplicates := findDuplicateGroups(guests)
+	duplicated := map[uint]bool{}
+	for _, group := range duplicates {
+		for _, g := range group.Guests {
+			duplicated[g.ID] = true
+		}
+	}
+
+	summary := map[string]int{}
+	for _, issue := range []string{
+		guestIssueMissingEmail, guestIssueInvalidEmail, guestIssueMissingPhone, guestIssueInvalidPhone,
+		guestIssueNoContact, guestIssueDuplicate, guestIssueNoGroup, guestIssueNoTable,
+	} {
+		summary[issue] = 0
+	}
+
+	report := []guestQualityIssues{}
+	for i := range guests {
+		issues := guestQualityProblems(&guests[i], duplicated[guests[i].ID])
+		if len(issues) == 0 {
+			continue
+		}
+		for _, issue := range issues {
+			summary[issue]++
+		}
+		report = append(report, guestQualityIssues{
+			GuestID:      guests[i].ID,
+			FullName:     guests[i].FullName,
+			InviteStatus: guests[i].InviteStatus,
+			Issues:       issues,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"total":       len(guests),
+		"with_issues": len(report),
+		"summary":     summary,
+		"guests":      report,
+		"duplicates":  duplicates,
+	})
+}
+
+// guestQualityProblems lista os problemas de cadastro do convidado
+func guestQualityProblems(g *models.Guest, duplicated bool) []string {
+	issues := []string{}
+
+	validEmail := g.Email != "" && g.HasValidEmail()
+	switch {
+	case g.Email == "":
+		issues = append(issues, guestIssueMissingEmail)
+	case !validEmail:
+		issues = append(issues, guestIssueInvalidEmail)
+	}
+
+	validPhone := g.Phone != "" && g.HasValidPhone()
+	switch {
+	case g.Phone == "":
+		issues = append(issues, guestIssueMissingPhone)
+	case !validPhone:
+		issues = append(issues, guestIssueInvalidPhone)
+	}
+
+	if !validEmail && !validPhone {
+		issues = append(issues, guestIssueNoContact)
+	}
+	if duplicated {
+		issues = append(issues, guestIssueDuplicate)
+	}
+
+	if g.InviteStatus.HoldsSeat() {
+		if g.GroupID == nil {
+			issues = append(issues, guestIssueNoGroup)
+		}
+		if g.TableName == "" {
+			issues = append(issues, guestIssueNoTable)
+		}
+	}
+	return issues
+}
@@ -0,0 +1,338 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/metrics"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+)
+
+// rsvpTokenPurpose separa os tokens do link pessoal do convidado de outros tokens assinados
+const rsvpTokenPurpose = "guest-rsvp"
+
+// maxDeclineMessageLength limita o recado do convidado ao recusar (caracteres)
+const maxDeclineMessageLength = 500
+
+// publicRSVPAddressResponse expõe ao convidado apenas o próprio nome e endereço
+type publicRSVPAddressResponse struct {
+	FullName  string               `json:"full_name"`
+	Address   models.PostalAddress `json:"address"`
+	UpdatedAt *time.Time           `json:"updated_at"`
+}
+
+// RSVPPath retorna o link pessoal do convidado (RSVP e dados para o convite)
+func RSVPPath(guestID uint) string {
+	return "/api/v1/public/rsvp/" + security.SignID(rsvpTokenPurpose, guestID)
+}
+
+// GetGuestRSVPLink retorna o link pessoal do convidado para envio junto ao convite
+func GetGuestRSVPLink(c *gin.Context) {
+	_, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"path":         RSVPPath(guest.ID), // POST com a resposta (confirmar ou recusar)
+		"address_path": RSVPPath(guest.ID) + "/address",
+		"event_path":   RSVPPath(guest.ID) + "/event",
+	})
+}
+
+// GetPublicRSVPAddress retorna o endereço já informado para o convidado conferir
+func GetPublicRSVPAddress(c *gin.Context) {
+	guest, ok := loadRSVPGuest(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, publicRSVPAddressResponse{
+		FullName:  guest.FullName,
+		Address:   guest.Address,
+		UpdatedAt: guest.AddressUpdatedAt,
+	})
+}
+
+// SubmitPublicRSVPAddress grava o endereço postal informado pelo próprio convidado
+func SubmitPublicRSVPAddress(c *gin.Context) {
+	guest, ok := loadRSVPGuest(c)
+	if !ok {
+		return
+	}
+
+	var address models.PostalAddress
+
+	if err := c.ShouldBindJSON(&address); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if err := address.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	guest.Address = address
+	guest.AddressUpdatedAt = &now
+
+	if err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).UpdateAddress(guest); err != nil {
+		log.Printf("[ERROR] Failed to save address of guest %d: %v", guest.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to save address",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "address saved successfully",
+		"address": publicRSVPAddressResponse{
+			FullName:  guest.FullName,
+			Address:   guest.Address,
+			UpdatedAt: guest.AddressUpdatedAt,
+		},
+	})
+}
+
+// publicRSVPResponse expõe ao convidado apenas o próprio status de resposta
+type publicRSVPResponse struct {
+	FullName     string               `json:"full_name"`
+	InviteStatus models.InviteStatus  `json:"invite_status"`
+	Events       models.WeddingEvent  `json:"events"`
+	Companions   []rsvpCompanionInput `json:"companions,omitempty"`
+	// Recado da recusa, para o convidado conferir o que o casal recebeu
+	DeclineMessage string `json:"decline_message,omitempty"`
+}
+
+// rsvpCompanionInput descreve um acompanhante informado pelo convidado ao confirmar presença
+type rsvpCompanionInput struct {
+	FullName   string                   `json:"full_name" binding:"required"`
+	MealOption models.MealOption        `json:"meal_option"`
+	AgeGroup   models.CompanionAgeGroup `json:"age_group"`
+}
+
+// SubmitPublicRSVP registra a resposta do próprio convidado (confirmar ou recusar presença)
+// Com a lotação do local atingida, a confirmação é recusada com a oferta da lista de espera;
+// o convidado aceita reenviando com join_waitlist
+// Até o prazo do RSVP o convidado pode mudar a resposta; depois, recebe o aviso para falar com o casal
+// Ao confirmar, companions (nome, prato e faixa etária) substitui os acompanhantes do convite,
+// dentro do limite de MaxGuests; sem o campo, os acompanhantes já registrados são mantidos
+// Ao recusar, message guarda um recado para o casal, que recebe o aviso na caixa de entrada
+func SubmitPublicRSVP(c *gin.Context) {
+	guest, ok := loadRSVPGuest(c)
+	if !ok {
+		return
+	}
+
+	db := database.WithContext(c.Request.Context())
+	wedding, err := repository.NewWeddingRepository(db).FindByID(guest.WeddingID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "invalid rsvp link",
+		})
+		return
+	}
+	if wedding.RSVPClosed(time.Now()) {
+		// Auditoria: a tentativa recusada fica no log; a mudança feita depois pelo casal vai para o histórico
+		log.Printf("[INFO] RSVP of guest %d of wedding %d rejected: deadline %s has passed", guest.ID, wedding.ID, wedding.RSVPDeadline.Format(time.RFC3339))
+		c.Header("Cache-Control", "no-store")
+		c.JSON(http.StatusConflict, gin.H{
+			"error":          "the rsvp deadline has passed, please contact the couple",
+			"contact_couple": true,
+			"rsvp_deadline":  wedding.RSVPDeadline,
+			"invite_status":  guest.InviteStatus,
+		})
+		return
+	}
+
+	var rsvpData struct {
+		Response     models.InviteStatus `json:"response" binding:"required"`
+		JoinWaitlist bool                `json:"join_waitlist"`
+		// nil quando o campo não foi enviado; [] remove os acompanhantes
+		Companions []rsvpCompanionInput `json:"companions" binding:"omitempty,dive"`
+		Message    string               `json:"message"`
+	}
+
+	if err := c.ShouldBindJSON(&rsvpData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if rsvpData.Response != models.InviteStatusConfirmed && rsvpData.Response != models.InviteStatusDeclined {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "response must be confirmed or declined",
+		})
+		return
+	}
+	// Quem está na lista de espera só confirma depois de promovido pelo casal
+	if guest.InviteStatus == models.InviteStatusWaitlisted && rsvpData.Response == models.InviteStatusConfirmed {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "you are on the waitlist and will be contacted if a seat opens",
+		})
+		return
+	}
+
+	companions, err := toRSVPCompanions(rsvpData.Companions)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	if companions != nil && rsvpData.Response != models.InviteStatusConfirmed {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "companions can only be sent when confirming",
+		})
+		return
+	}
+	message := strings.TrimSpace(rsvpData.Message)
+	if message != "" && rsvpData.Response != models.InviteStatusDeclined {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "a message can only be sent when declining",
+		})
+		return
+	}
+	if utf8.RuneCountInString(message) > maxDeclineMessageLength {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: fmt.Sprintf("message must be at most %d characters long", maxDeclineMessageLength),
+		})
+		return
+	}
+	// Resposta rápida para o limite do convite; a checagem definitiva roda sob o bloqueio do casamento
+	if len(companions) > guest.CompanionLimit() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":           "too many companions for this invite",
+			"companion_limit": guest.CompanionLimit(),
+		})
+		return
+	}
+
+	// O recado vale para a recusa atual: uma nova recusa o substitui e a confirmação o apaga
+	if guest.InviteStatus != rsvpData.Response || companions != nil || guest.DeclineMessage != message {
+		repo := repository.NewGuestRepository(db)
+		actor := models.StatusActor{Channel: models.StatusChannelRSVP}
+
+		previous, previousMessage := guest.InviteStatus, guest.DeclineMessage
+		guest.InviteStatus = rsvpData.Response
+		guest.DeclineMessage = message
+
+		update := repository.RSVPUpdate{Companions: companions}
+		if guest.InviteStatus == models.InviteStatusDeclined && (previous != models.InviteStatusDeclined || message != "") {
+			update.Notification = guestDeclinedNotification(wedding, guest)
+		}
+		_, err = repo.UpdateRSVP(guest, previous, actor, update)
+		if errors.Is(err, repository.ErrVenueAtCapacity) && rsvpData.JoinWaitlist && previous != models.InviteStatusConfirmed {
+			// Na lista de espera os acompanhantes não são registrados: o convidado os informa ao ser promovido
+			companions = nil
+			guest.InviteStatus = models.InviteStatusWaitlisted
+			actor.Note = "joined the waitlist at venue capacity"
+			_, err = repo.UpdateWithStatus(guest, previous, actor)
+		}
+		if err != nil {
+			guest.InviteStatus, guest.DeclineMessage = previous, previousMessage
+			switch {
+			case errors.Is(err, repository.ErrVenueAtCapacity):
+				c.JSON(http.StatusConflict, gin.H{
+					"error":          err.Error(),
+					"waitlist_offer": previous != models.InviteStatusConfirmed,
+				})
+			case errors.Is(err, repository.ErrCompanionLimit):
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error":           "too many companions for this invite",
+					"companion_limit": guest.CompanionLimit(),
+				})
+			case errors.Is(err, repository.ErrWeddingFull), errors.Is(err, repository.ErrStatusChanged):
+				c.JSON(http.StatusConflict, errorResponse{
+					Error: err.Error(),
+				})
+			default:
+				log.Printf("[ERROR] Failed to save rsvp of guest %d: %v", guest.ID, err)
+				c.JSON(http.StatusInternalServerError, errorResponse{
+					Error: "unable to save rsvp",
+				})
+			}
+			return
+		}
+
+		// Funil: conta apenas a primeira resposta do convidado (trocas de resposta não são novos RSVPs)
+		firstResponse := previous != models.InviteStatusConfirmed && previous != models.InviteStatusDeclined
+		if firstResponse && guest.InviteStatus == rsvpData.Response {
+			metrics.RecordFunnelStep(metrics.FunnelRSVPReceived, wedding.CreatedAt, 1)
+		}
+	}
+
+	var companionsResponse []rsvpCompanionInput
+	if companions != nil && guest.InviteStatus == models.InviteStatusConfirmed {
+		companionsResponse = make([]rsvpCompanionInput, len(companions))
+		for i, companion := range companions {
+			companionsResponse[i] = rsvpCompanionInput{FullName: companion.FullName, MealOption: companion.MealOption, AgeGroup: companion.AgeGroup}
+		}
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{
+		"message": "rsvp saved successfully",
+		"rsvp": publicRSVPResponse{
+			FullName:       guest.FullName,
+			InviteStatus:   guest.InviteStatus,
+			Events:         guest.Events,
+			Companions:     companionsResponse,
+			DeclineMessage: guest.DeclineMessage,
+		},
+	})
+}
+
+// guestDeclinedNotification monta o aviso ao dono do casamento da recusa pelo link pessoal, com o recado
+// Gravado na caixa de entrada junto com a resposta (UpdateRSVP)
+func guestDeclinedNotification(wedding *models.Wedding, guest *models.Guest) *models.Notification {
+	body := fmt.Sprintf("%s recusou o convite e não poderá comparecer.", guest.FullName)
+	if guest.DeclineMessage != "" {
+		body += " Recado: " + guest.DeclineMessage
+	}
+	return models.NewWeddingNotification(wedding.UserID, wedding.ID, models.NotificationGuestDeclined, "Convidado recusou o convite", body)
+}
+
+// toRSVPCompanions normaliza e valida os acompanhantes enviados no RSVP
+// Retorna nil quando o campo não foi enviado
+func toRSVPCompanions(input []rsvpCompanionInput) ([]models.Companion, error) {
+	if input == nil {
+		return nil, nil
+	}
+
+	companions := make([]models.Companion, len(input))
+	for i, data := range input {
+		companions[i] = models.Companion{FullName: data.FullName, MealOption: data.MealOption, AgeGroup: data.AgeGroup}
+		if err := companions[i].IsValid(); err != nil {
+			return nil, fmt.Errorf("companion %d: %w", i+1, err)
+		}
+	}
+	return companions, nil
+}
+
+// loadRSVPGuest resolve o token do link pessoal do convidado
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func loadRSVPGuest(c *gin.Context) (*models.Guest, bool) {
+	guestID, err := security.VerifySignedID(rsvpTokenPurpose, c.Param("token"))
+	if err == nil {
+		guest, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).FindByID(guestID)
+		if err == nil {
+			return guest, true
+		}
+	}
+
+	c.JSON(http.StatusNotFound, errorResponse{
+		Error: "invalid rsvp link",
+	})
+	return nil, false
+}
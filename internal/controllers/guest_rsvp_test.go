@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/database/dbtest"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+)
+
+const rsvpTestGuestID uint = 7
+
+// withRSVPTestDB liga o banco em memória com o casamento 10 (prazo do RSVP informado) e o convidado 7
+func withRSVPTestDB(t *testing.T, deadline time.Time) *dbtest.DB {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db, fake := dbtest.Open()
+	fake.Insert("weddings", dbtest.Row{
+		"id": 10, "user_id": proUserID, "venue_name": "Espaço", "max_guests": 100,
+		"event_date": time.Now().AddDate(0, 2, 0), "rsvp_deadline": deadline,
+	})
+	fake.Insert("guests", dbtest.Row{
+		"id": rsvpTestGuestID, "wedding_id": 10, "full_name": "Ana", "invite_status": string(models.InviteStatusSent), "max_guests": 3,
+	})
+
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+	return fake
+}
+
+// callPublicRSVP envia a resposta pelo link pessoal do convidado
+func callPublicRSVP(body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "token", Value: security.SignID(rsvpTokenPurpose, rsvpTestGuestID)}}
+	SubmitPublicRSVP(c)
+	return rec
+}
+
+// statusHistory retorna as mudanças de status registradas no histórico do convidado
+func statusHistory(fake *dbtest.DB) []dbtest.Row {
+	return fake.Inserted("guest_status_histories")
+}
+
+func TestSubmitPublicRSVPBeforeDeadline(t *testing.T) {
+	fake := withRSVPTestDB(t, time.Now().Add(24*time.Hour))
+
+	rec := callPublicRSVP(`{"response":"confirmed"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+	}
+
+	history := statusHistory(fake)
+	if len(history) != 1 || history[0]["channel"] != string(models.StatusChannelRSVP) || history[0]["to_status"] != string(models.InviteStatusConfirmed) {
+		t.Errorf("status history = %v, want the guest's rsvp change", history)
+	}
+}
+
+func TestSubmitPublicRSVPAfterDeadline(t *testing.T) {
+	fake := withRSVPTestDB(t, time.Now().Add(-time.Hour))
+
+	rec := callPublicRSVP(`{"response":"declined"}`)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409 (body %s)", rec.Code, rec.Body.String())
+	}
+
+	var body struct {
+		Error         string     `json:"error"`
+		ContactCouple bool       `json:"contact_couple"`
+		RSVPDeadline  *time.Time `json:"rsvp_deadline"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if !body.ContactCouple || body.RSVPDeadline == nil {
+		t.Errorf("body = %s, want the contact the couple state with the deadline", rec.Body.String())
+	}
+	if history := statusHistory(fake); len(history) != 0 {
+		t.Errorf("status changed after the deadline: %v", history)
+	}
+}
+
+func TestSubmitPublicRSVPWithCompanions(t *testing.T) {
+	fake := withRSVPTestDB(t, time.Now().Add(24*time.Hour))
+
+	rec := callPublicRSVP(`{"response":"confirmed","companions":[
+		{"full_name":"Bruno Souza","meal_option":"fish","age_group":"adult"},
+		{"full_name":"Clara Souza","meal_option":"Kids","age_group":"age_4_10"}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+	}
+
+	companions := fake.Inserted("companions")
+	if len(companions) != 2 {
+		t.Fatalf("companions = %v, want the two companions of the rsvp", companions)
+	}
+	clara := companions[1]
+	if clara["full_name"] != "Clara Souza" || clara["meal_option"] != string(models.MealOptionKids) ||
+		clara["age_group"] != string(models.CompanionAge4To10) || clara["confirmed"] != true || clara["wedding_id"] != int64(10) {
+		t.Errorf("companion = %v, want Clara confirmed with the kids meal in the 4-10 group", clara)
+	}
+	if history := statusHistory(fake); len(history) != 1 {
+		t.Errorf("status history = %v, want the guest's confirmation", history)
+	}
+}
+
+func TestSubmitPublicRSVPCompanionsOverLimit(t *testing.T) {
+	fake := withRSVPTestDB(t, time.Now().Add(24*time.Hour))
+
+	// MaxGuests 3 inclui o próprio convidado: no máximo dois acompanhantes
+	rec := callPublicRSVP(`{"response":"confirmed","companions":[
+		{"full_name":"Bruno"},{"full_name":"Clara"},{"full_name":"Davi"}]}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400 (body %s)", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"companion_limit":2`) {
+		t.Errorf("body = %s, want the companion limit", rec.Body.String())
+	}
+	if len(fake.Inserted("companions")) != 0 || len(statusHistory(fake)) != 0 {
+		t.Error("rsvp saved with too many companions")
+	}
+}
+
+func TestSubmitPublicRSVPInvalidCompanion(t *testing.T) {
+	withRSVPTestDB(t, time.Now().Add(24*time.Hour))
+
+	for name, body := range map[string]string{
+		"age group":       `{"response":"confirmed","companions":[{"full_name":"Bruno","age_group":"teen"}]}`,
+		"meal option":     `{"response":"confirmed","companions":[{"full_name":"Bruno","meal_option":"pasta"}]}`,
+		"while declining": `{"response":"declined","companions":[{"full_name":"Bruno"}]}`,
+	} {
+		if rec := callPublicRSVP(body); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400 (body %s)", name, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+// TestSubmitPublicRSVPCompanionsAtVenueCapacity cobre os acompanhantes na lotação do local
+func TestSubmitPublicRSVPCompanionsAtVenueCapacity(t *testing.T) {
+	// Banco próprio com o local para dois lugares; withRSVPTestDB só restaura database.DB no fim
+	withRSVPTestDB(t, time.Now().Add(24*time.Hour))
+	db, capacity := dbtest.Open()
+	database.DB = db
+	capacity.Insert("weddings", dbtest.Row{
+		"id": 10, "user_id": proUserID, "venue_name": "Espaço", "max_guests": 2, "enforce_capacity": true,
+		"event_date": time.Now().AddDate(0, 2, 0),
+	})
+	capacity.Insert("guests", dbtest.Row{
+		"id": rsvpTestGuestID, "wedding_id": 10, "full_name": "Ana", "invite_status": string(models.InviteStatusSent), "max_guests": 3,
+	})
+
+	// O convidado e dois acompanhantes não cabem nos dois lugares do local
+	rec := callPublicRSVP(`{"response":"confirmed","companions":[{"full_name":"Bruno"},{"full_name":"Clara"}]}`)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409 (body %s)", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"waitlist_offer":true`) {
+		t.Errorf("body = %s, want the venue capacity waitlist offer", rec.Body.String())
+	}
+	if len(capacity.Inserted("companions")) != 0 || len(statusHistory(capacity)) != 0 {
+		t.Error("rsvp saved beyond the venue capacity")
+	}
+}
+
+func TestSubmitPublicRSVPDeclineWithMessage(t *testing.T) {
+	fake := withRSVPTestDB(t, time.Now().Add(24*time.Hour))
+
+	rec := callPublicRSVP(`{"response":"declined","message":"  Estarei viajando, felicidades aos noivos!  "}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"decline_message":"Estarei viajando, felicidades aos noivos!"`) {
+		t.Errorf("body = %s, want the trimmed decline message", rec.Body.String())
+	}
+
+	assertWeddingNotification(t, fake, models.NotificationGuestDeclined, "Ana", "Estarei viajando, felicidades aos noivos!")
+	if history := statusHistory(fake); len(history) != 1 || history[0]["to_status"] != string(models.InviteStatusDeclined) {
+		t.Errorf("status history = %v, want the guest's decline", history)
+	}
+}
+
+func TestSubmitPublicRSVPDeclineWithoutMessage(t *testing.T) {
+	fake := withRSVPTestDB(t, time.Now().Add(24*time.Hour))
+
+	if rec := callPublicRSVP(`{"response":"declined"}`); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+	}
+	// O casal é avisado da recusa mesmo sem recado
+	assertWeddingNotification(t, fake, models.NotificationGuestDeclined, "Ana recusou o convite")
+}
+
+func TestSubmitPublicRSVPInvalidDeclineMessage(t *testing.T) {
+	fake := withRSVPTestDB(t, time.Now().Add(24*time.Hour))
+
+	for name, body := range map[string]string{
+		"while confirming": `{"response":"confirmed","message":"Até lá!"}`,
+		"too long":         `{"response":"declined","message":"` + strings.Repeat("á", maxDeclineMessageLength+1) + `"}`,
+	} {
+		if rec := callPublicRSVP(body); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400 (body %s)", name, rec.Code, rec.Body.String())
+		}
+	}
+	if len(fake.Inserted("notifications")) != 0 || len(statusHistory(fake)) != 0 {
+		t.Error("invalid decline message saved")
+	}
+}
+
+// TestBulkInviteSkipsDeclinedGuests garante que convites e lembretes em massa não vão para quem recusou
+func TestBulkInviteSkipsDeclinedGuests(t *testing.T) {
+	fake := withRSVPTestDB(t, time.Now().Add(24*time.Hour))
+
+	wedding := &models.Wedding{ID: 10, UserID: proUserID, VenueName: "Espaço"}
+	rec := callWeddingHandler(BulkSendInvites, proUserID, wedding, `{"status":"declined","template":"Não esqueça de confirmar!"}`)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "declined") {
+		t.Fatalf("status = %d, want 400 for declined guests (body %s)", rec.Code, rec.Body.String())
+	}
+	if jobs := fake.Inserted("async_jobs"); len(jobs) != 0 {
+		t.Errorf("bulk invite queued for declined guests: %v", jobs)
+	}
+}
+
+// TestUpdateGuestAfterRSVPDeadline garante que o casal ainda altera a resposta, com o registro no histórico
+func TestUpdateGuestAfterRSVPDeadline(t *testing.T) {
+	deadline := time.Now().Add(-time.Hour)
+	fake := withRSVPTestDB(t, deadline)
+
+	wedding := &models.Wedding{ID: 10, UserID: proUserID, MaxGuests: 100, RSVPDeadline: &deadline}
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"invite_status":"declined"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "guestId", Value: "7"}}
+	c.Set("user_id", proUserID)
+	c.Set("wedding", wedding)
+	UpdateGuest(c)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+	}
+
+	history := statusHistory(fake)
+	if len(history) != 1 {
+		t.Fatalf("status history = %v, want the couple's override", history)
+	}
+	entry := history[0]
+	if entry["channel"] != string(models.StatusChannelDashboard) || entry["note"] != "changed by the couple after the rsvp deadline" {
+		t.Errorf("status history entry = %v, want a dashboard change noted as after the deadline", entry)
+	}
+}
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// childrenStatsResponse resume as crianças por faixa etária para o orçamento do local e do bufê
+type childrenStatsResponse struct {
+	models.ChildAgeBands
+	Total int `json:"total"`
+}
+
+// GetGuestStats retorna os totais de convites por status e as crianças por faixa etária
+// "expected" considera os convites que ainda podem comparecer (pendentes, enviados e confirmados)
+func GetGuestStats(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	stats, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).GuestStatsByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to aggregate guest stats of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch guest stats",
+		})
+		return
+	}
+
+	// Todos os status aparecem, mesmo com zero convites
+	byStatus := make(map[models.InviteStatus]repository.GuestStatusStats, len(stats))
+	var total int64
+	var confirmed, expected models.ChildAgeBands
+	for _, s := range stats {
+		byStatus[s.InviteStatus] = s
+		total += s.Guests
+		switch s.InviteStatus {
+		case models.InviteStatusConfirmed:
+			confirmed.Add(s.Children)
+			expected.Add(s.Children)
+		case models.InviteStatusPending, models.InviteStatusSent:
+			expected.Add(s.Children)
+		}
+	}
+	statuses := []models.InviteStatus{
+		models.InviteStatusPending, models.InviteStatusSent, models.InviteStatusConfirmed,
+		models.InviteStatusDeclined, models.InviteStatusWaitlisted,
+	}
+	response := make([]repository.GuestStatusStats, len(statuses))
+	for i, status := range statuses {
+		response[i] = byStatus[status]
+		response[i].InviteStatus = status
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"total_guests": total,
+		"by_status":    response,
+		"children": gin.H{
+			"confirmed": childrenStatsResponse{ChildAgeBands: confirmed, Total: confirmed.Total()},
+			"expected":  childrenStatsResponse{ChildAgeBands: expected, Total: expected.Total()},
+		},
+	})
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// guestStatusHistoryResponse representa uma transição de status do convite
+type guestStatusHistoryResponse struct {
+	ID              uint                 `json:"id"`
+	FromStatus      models.InviteStatus  `json:"from_status"`
+	ToStatus        models.InviteStatus  `json:"to_status"`
+	Channel         models.StatusChannel `json:"channel"`
+	ChangedByUserID *uint                `json:"changed_by_user_id"`
+	ChangedByName   string               `json:"changed_by_name"`
+	Note            string               `json:"note"`
+	CreatedAt       time.Time            `json:"created_at"`
+}
+
+// GetGuestStatusHistory lista todas as mudanças de status do convite em ordem cronológica
+// Usado para esclarecer divergências (ex: "eu confirmei") com quem, quando e por onde mudou
+func GetGuestStatusHistory(c *gin.Context) {
+	wedding, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return
+	}
+
+	entries, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).FindStatusHistory(guest.ID, wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch status history of guest %d: %v", guest.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch guest status history",
+		})
+		return
+	}
+
+	response := make([]guestStatusHistoryResponse, len(entries))
+	for i, e := range entries {
+		response[i] = guestStatusHistoryResponse{
+			ID:              e.ID,
+			FromStatus:      e.FromStatus,
+			ToStatus:        e.ToStatus,
+			Channel:         e.Channel,
+			ChangedByUserID: e.ChangedByUserID,
+			ChangedByName:   e.ChangedByName,
+			Note:            e.Note,
+			CreatedAt:       e.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"guest_id":       guest.ID,
+		"current_status": guest.InviteStatus,
+		"history":        response,
+	})
+}
+
+// statusActor identifica o usuário autenticado como autor de uma mudança de status
+func statusActor(c *gin.Context, channel models.StatusChannel) models.StatusActor {
+	return models.StatusActor{Channel: channel, UserID: currentUserID(c)}
+}
+
+// currentUserID retorna o usuário autenticado, ou nil fora de rotas autenticadas
+func currentUserID(c *gin.Context) *uint {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		return nil
+	}
+	return &userID
+}
@@ -0,0 +1,274 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// guestTagResponse representa a resposta padronizada de etiqueta de convidados
+type guestTagResponse struct {
+	ID         uint      `json:"id"`
+	WeddingID  uint      `json:"wedding_id"`
+	Name       string    `json:"name"`
+	Color      string    `json:"color"`
+	GuestCount *int64    `json:"guest_count,omitempty"` // apenas na listagem
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// CreateGuestTag cadastra uma etiqueta de convidados no casamento
+func CreateGuestTag(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var createData struct {
+		Name  string `json:"name" binding:"required"`
+		Color string `json:"color"`
+	}
+
+	if err := c.ShouldBindJSON(&createData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	tag := models.GuestTag{
+		WeddingID: wedding.ID,
+		Name:      createData.Name,
+		Color:     createData.Color,
+	}
+
+	if err := tag.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repository.NewGuestTagRepository(database.WithContext(c.Request.Context())).Create(&tag); err != nil {
+		respondGuestTagSaveError(c, wedding.ID, err, "unable to create guest tag")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "guest tag created successfully",
+		"tag":     toGuestTagResponse(&tag),
+	})
+}
+
+// GetGuestTags lista as etiquetas do casamento com o número de convidados de cada uma
+// Para listar os convidados de uma etiqueta use GET /guests?tag=<id>
+func GetGuestTags(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	tags, err := repository.NewGuestTagRepository(database.WithContext(c.Request.Context())).FindByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch guest tags of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch guest tags",
+		})
+		return
+	}
+
+	response := make([]guestTagResponse, len(tags))
+	for i := range tags {
+		response[i] = toGuestTagResponse(&tags[i].GuestTag)
+		response[i].GuestCount = &tags[i].GuestCount
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tags":  response,
+		"count": len(response),
+	})
+}
+
+// UpdateGuestTag renomeia ou muda a cor de uma etiqueta
+func UpdateGuestTag(c *gin.Context) {
+	wedding, tag, ok := loadWeddingGuestTag(c)
+	if !ok {
+		return
+	}
+
+	var updateData struct {
+		Name  *string `json:"name"`
+		Color *string `json:"color"`
+	}
+
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if updateData.Name != nil {
+		tag.Name = *updateData.Name
+	}
+	if updateData.Color != nil {
+		tag.Color = *updateData.Color
+	}
+
+	if err := tag.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repository.NewGuestTagRepository(database.WithContext(c.Request.Context())).Update(tag); err != nil {
+		respondGuestTagSaveError(c, wedding.ID, err, "unable to update guest tag")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "guest tag updated successfully",
+		"tag":     toGuestTagResponse(tag),
+	})
+}
+
+// DeleteGuestTag remove uma etiqueta (os convidados continuam cadastrados)
+func DeleteGuestTag(c *gin.Context) {
+	wedding, tag, ok := loadWeddingGuestTag(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.NewGuestTagRepository(database.WithContext(c.Request.Context())).Delete(tag); err != nil {
+		log.Printf("[ERROR] Failed to delete guest tag %d of wedding %d: %v", tag.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to delete guest tag",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "guest tag deleted successfully",
+	})
+}
+
+// AssignGuestsToTag aplica a etiqueta aos convidados informados
+// Convidados que já possuem a etiqueta são ignorados
+func AssignGuestsToTag(c *gin.Context) {
+	wedding, tag, ok := loadWeddingGuestTag(c)
+	if !ok {
+		return
+	}
+
+	var assignData struct {
+		GuestIDs []uint `json:"guest_ids" binding:"required,min=1"`
+	}
+
+	if err := c.ShouldBindJSON(&assignData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if len(assignData.GuestIDs) > maxGroupAssignment {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "too many guests in a single request",
+		})
+		return
+	}
+
+	assigned, err := repository.NewGuestTagRepository(database.WithContext(c.Request.Context())).AssignGuests(tag, assignData.GuestIDs)
+	if err != nil {
+		log.Printf("[ERROR] Failed to assign guests to tag %d of wedding %d: %v", tag.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to assign guests",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "guests tagged successfully",
+		"assigned": assigned,
+	})
+}
+
+// RemoveGuestFromTag remove a etiqueta de um convidado
+func RemoveGuestFromTag(c *gin.Context) {
+	wedding, tag, ok := loadWeddingGuestTag(c)
+	if !ok {
+		return
+	}
+
+	guestID, err := parseIDParam(c, "guestId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repository.NewGuestTagRepository(database.WithContext(c.Request.Context())).UnassignGuest(tag, guestID); err != nil {
+		log.Printf("[INFO] Guest %d not removed from tag %d of wedding %d: %v", guestID, tag.ID, wedding.ID, err)
+		respondAccessError(c, authz.NotFound("guest"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "guest removed from tag successfully",
+	})
+}
+
+// respondGuestTagSaveError traduz erros de gravação de etiqueta (nome duplicado vira 409)
+func respondGuestTagSaveError(c *gin.Context, weddingID uint, err error, message string) {
+	if errors.Is(err, repository.ErrDuplicateTagName) {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	log.Printf("[ERROR] Failed to save guest tag for wedding %d: %v", weddingID, err)
+	c.JSON(http.StatusInternalServerError, errorResponse{
+		Error: message,
+	})
+}
+
+// loadWeddingGuestTag extrai o casamento :id e a etiqueta :tagId
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func loadWeddingGuestTag(c *gin.Context) (*models.Wedding, *models.GuestTag, bool) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	tagID, err := parseIDParam(c, "tagId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	tag, err := repository.NewGuestTagRepository(database.WithContext(c.Request.Context())).FindByIDAndWeddingID(tagID, wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("guest tag"))
+		return nil, nil, false
+	}
+
+	return wedding, tag, true
+}
+
+// toGuestTagResponse converte model para response
+func toGuestTagResponse(t *models.GuestTag) guestTagResponse {
+	return guestTagResponse{
+		ID:        t.ID,
+		WeddingID: t.WeddingID,
+		Name:      t.Name,
+		Color:     t.Color,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/database/dbtest"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+)
+
+// withGuestTestDB liga o banco em memória com o convidado 7 (confirmado e já com check-in) do casamento 10
+func withGuestTestDB(t *testing.T) *dbtest.DB {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db, fake := dbtest.Open()
+	fake.Insert("guests", dbtest.Row{
+		"id": 7, "wedding_id": 10, "full_name": "Ana", "invite_status": "confirmed", "max_guests": 1,
+		"checked_in_at": time.Date(2030, 1, 1, 20, 0, 0, 0, time.UTC),
+	})
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+	return fake
+}
+
+// TestUpdateGuestKeepsManagedColumns garante que editar o cadastro sem mudar o status não regrava
+// colunas mantidas por outras operações (check-in, descadastro, RSVP, grupo)
+func TestUpdateGuestKeepsManagedColumns(t *testing.T) {
+	fake := withGuestTestDB(t)
+
+	wedding := &models.Wedding{ID: 10, UserID: 1}
+	rec := callWeddingHandler(UpdateGuest, 1, wedding, `{"notes":"Mesa perto da pista"}`, gin.Param{Key: "guestId", Value: "7"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+	}
+
+	var updates []dbtest.Statement
+	for _, statement := range fake.Statements() {
+		if statement.IsWrite() && strings.Contains(statement.SQL, "`guests`") {
+			updates = append(updates, statement)
+		}
+	}
+	if len(updates) != 1 {
+		t.Fatalf("guest writes = %v, want one update", updates)
+	}
+	if !strings.Contains(updates[0].SQL, "`notes`") {
+		t.Errorf("update = %s, want the edited notes", updates[0].SQL)
+	}
+	for _, column := range []string{"invite_status", "group_id", "opted_out_at", "decline_message", "checked_in_at", "wedding_id", "created_at"} {
+		if strings.Contains(updates[0].SQL, "`"+column+"`=") {
+			t.Errorf("update writes %s: %s", column, updates[0].SQL)
+		}
+	}
+}
+
+// TestLoadWeddingGuestDatabaseError garante que uma falha do banco responde 500, e não 404
+func TestLoadWeddingGuestDatabaseError(t *testing.T) {
+	withGuestTestDB(t)
+	sqlDB, err := database.DB.DB()
+	if err != nil {
+		t.Fatalf("DB: %v", err)
+	}
+	sqlDB.Close()
+
+	wedding := &models.Wedding{ID: 10, UserID: 1}
+	rec := callWeddingHandler(UpdateGuest, 1, wedding, `{"notes":"x"}`, gin.Param{Key: "guestId", Value: "7"})
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500 (body %s)", rec.Code, rec.Body.String())
+	}
+	if want := `{"error":"internal server error"}`; rec.Body.String() != want {
+		t.Errorf("body = %s, want %s", rec.Body.String(), want)
+	}
+}
+
+func TestLoadWeddingGuestNotFound(t *testing.T) {
+	withGuestTestDB(t)
+
+	wedding := &models.Wedding{ID: 10, UserID: 1}
+	rec := callWeddingHandler(UpdateGuest, 1, wedding, `{"notes":"x"}`, gin.Param{Key: "guestId", Value: "8"})
+	if rec.Code != http.StatusNotFound || rec.Body.String() != `{"error":"guest not found"}` {
+		t.Fatalf("got %d %s, want 404 guest not found", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// trashedGuestResponse representa um convidado na lixeira
+type trashedGuestResponse struct {
+	guestResponse
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// GetGuestTrash lista os convidados removidos do casamento que ainda podem ser restaurados
+func GetGuestTrash(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	guests, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).FindDeletedByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch deleted guests of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch deleted guests",
+		})
+		return
+	}
+
+	response := make([]trashedGuestResponse, len(guests))
+	for i := range guests {
+		response[i] = trashedGuestResponse{
+			guestResponse: toGuestResponse(&guests[i]),
+			DeletedAt:     guests[i].DeletedAt.Time,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"guests": response,
+		"total":  len(response),
+	})
+}
+
+// RestoreGuest devolve à lista um convidado removido por engano
+// O convidado volta com o status que tinha e ocupa a vaga de novo se ela estiver disponível
+func RestoreGuest(c *gin.Context) {
+	wedding, guest, ok := loadDeletedGuest(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).Restore(guest); err != nil {
+		if errors.Is(err, repository.ErrWeddingFull) {
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		log.Printf("[ERROR] Failed to restore guest %d of wedding %d: %v", guest.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to restore guest",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "guest restored successfully",
+		"guest":   toGuestResponse(guest),
+	})
+}
+
+// PurgeGuest apaga definitivamente um convidado da lixeira
+func PurgeGuest(c *gin.Context) {
+	wedding, guest, ok := loadDeletedGuest(c)
+	if !ok {
+		return
+	}
+
+	if _, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).Purge(wedding.ID, []uint{guest.ID}); err != nil {
+		log.Printf("[ERROR] Failed to purge guest %d of wedding %d: %v", guest.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to purge guest",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "guest permanently deleted",
+	})
+}
+
+// EmptyGuestTrash apaga definitivamente todos os convidados da lixeira do casamento
+func EmptyGuestTrash(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	repo := repository.NewGuestRepository(database.WithContext(c.Request.Context()))
+
+	guests, err := repo.FindDeletedByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch deleted guests of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to empty guest trash",
+		})
+		return
+	}
+
+	ids := make([]uint, len(guests))
+	for i := range guests {
+		ids[i] = guests[i].ID
+	}
+
+	purged, err := repo.Purge(wedding.ID, ids)
+	if err != nil {
+		log.Printf("[ERROR] Failed to empty guest trash of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to empty guest trash",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "guest trash emptied",
+		"purged":  purged,
+	})
+}
+
+// loadDeletedGuest carrega o casamento autorizado e o convidado da lixeira indicado na rota
+func loadDeletedGuest(c *gin.Context) (*models.Wedding, *models.Guest, bool) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	guestID, err := parseIDParam(c, "guestId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	guest, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).FindDeletedByIDAndWeddingID(guestID, wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("deleted guest"))
+		return nil, nil, false
+	}
+
+	return wedding, guest, true
+}
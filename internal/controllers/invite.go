@@ -0,0 +1,312 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/qrcode"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// Pixels por módulo do QR Code do convite (maior que o do check-in: a imagem vai para a gráfica)
+const inviteQRScale = 12
+
+// inviteResponse representa um convite enviado
+type inviteResponse struct {
+	ID                uint                        `json:"id"`
+	GuestID           uint                        `json:"guest_id"`
+	GuestName         string                      `json:"guest_name,omitempty"`
+	SentAt            *time.Time                  `json:"sent_at"`
+	SentVia           string                      `json:"sent_via"`
+	DeliveryStatus    models.InviteDeliveryStatus `json:"delivery_status"`
+	DeliveryError     string                      `json:"delivery_error,omitempty"`
+	DeliveryUpdatedAt *time.Time                  `json:"delivery_updated_at"`
+	OpenedAt          *time.Time                  `json:"opened_at"`
+	ClickedAt         *time.Time                  `json:"clicked_at"`
+	CreatedAt         time.Time                   `json:"created_at"`
+}
+
+// GetInvites lista os convites do casamento com a entrega informada pelos provedores
+// Filtro opcional ?delivery_status (queued, sent, delivered, bounced, failed)
+func GetInvites(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	page, perPage, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	status := models.InviteDeliveryStatus(strings.ToLower(strings.TrimSpace(c.Query("delivery_status"))))
+	if status != "" && !status.IsValid() {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "delivery_status must be one of: queued, sent, delivered, bounced, failed",
+		})
+		return
+	}
+
+	invites, total, err := repository.NewInviteRepository(database.WithContext(c.Request.Context())).FindByWeddingID(wedding.ID, status, page, perPage)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch invites of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch invites",
+		})
+		return
+	}
+
+	response := make([]inviteResponse, len(invites))
+	for i := range invites {
+		response[i] = toInviteResponse(&invites[i])
+	}
+
+	c.JSON(http.StatusOK, paginatedResponse[inviteResponse]{
+		Items:   response,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	})
+}
+
+// SendInvite envia um convite cadastrado pelo canal do convite (SentVia): email pelo provedor
+// configurado (MAIL_PROVIDER) ou WhatsApp pela conta do casal; {"via": ...} no body troca o canal
+// Grava SentAt/SentVia no convite e move o convidado de pendente para enviado
+func SendInvite(c *gin.Context) {
+	wedding, invite, ok := loadWeddingInvite(c)
+	if !ok {
+		return
+	}
+	guest := &invite.Guest
+
+	via, ok := bindInviteVia(c)
+	if !ok {
+		return
+	}
+	if via != "" {
+		invite.SentVia = via
+	}
+
+	// Convidados na lista de espera só recebem o convite depois de promovidos para não ocupar vaga sem lugar
+	if guest.InviteStatus == models.InviteStatusWaitlisted {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "waitlisted guests must be promoted before being invited",
+		})
+		return
+	}
+
+	err := deliverInvite(c.Request.Context(), wedding, guest, invite, statusActor(c, models.StatusChannelInvite))
+	if err != nil {
+		respondInviteError(c, wedding, guest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "invite sent successfully",
+		"invite":  toInviteResponse(invite),
+		"guest":   toGuestResponse(guest),
+	})
+}
+
+// PreviewInvite mostra o convite renderizado sem enviar nem registrar o envio
+// Sem guest_id, as variáveis são preenchidas com um convidado de exemplo; sem template (texto, template_id
+// ou builtin), vale o texto padrão
+// Com via whatsapp, inclui os parâmetros do template aprovado da conta do casal
+func PreviewInvite(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		inviteTemplateRef
+		GuestID uint   `json:"guest_id"`
+		Via     string `json:"via"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	via := strings.ToLower(strings.TrimSpace(body.Via))
+	if via == "" {
+		via = models.InviteViaEmail
+	}
+	if !models.IsValidInviteVia(via) {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "via must be one of: email, whatsapp",
+		})
+		return
+	}
+
+	subject, text, ok := resolveInviteTemplate(c, wedding, body.inviteTemplateRef)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	guest := &models.Guest{FullName: "Convidado Exemplo", WeddingID: wedding.ID}
+	if body.GuestID != 0 {
+		var err error
+		guest, err = repository.NewGuestRepository(database.WithContext(ctx)).FindByIDAndWeddingID(body.GuestID, wedding.ID)
+		if err != nil {
+			respondAccessError(c, authz.NotFound("guest"))
+			return
+		}
+	}
+
+	data := inviteTemplateData(wedding, guest)
+	if body.GuestID == 0 {
+		// Links de exemplo: o convidado fictício não tem token de RSVP nem de opt-out
+		data.RSVPLink = configs.PUBLIC_BASE_URL + "/api/v1/public/rsvp/exemplo"
+		data.OptOutLink = configs.PUBLIC_BASE_URL + "/api/v1/public/opt-out/exemplo"
+	}
+
+	if text == "" {
+		text = inviteEmailText(wedding, guest, data.RSVPLink)
+	} else {
+		rendered, err := models.RenderInviteTemplate(text, data)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		text = rendered
+	}
+
+	if subject == "" {
+		subject = inviteEmailSubject
+	} else {
+		rendered, err := models.RenderInviteTemplate(subject, data)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		subject = rendered
+	}
+
+	response := gin.H{
+		"via":       via,
+		"text":      text,
+		"variables": models.InviteTemplateVariables,
+	}
+	if via == models.InviteViaEmail {
+		response["subject"] = subject
+	}
+
+	if via == models.InviteViaWhatsApp {
+		account, err := repository.NewWhatsAppRepository(database.WithContext(ctx)).FindByWeddingID(wedding.ID)
+		switch {
+		case err == nil:
+			params, err := whatsAppTemplateParams(account, data)
+			if err != nil {
+				c.JSON(http.StatusUnprocessableEntity, errorResponse{
+					Error: err.Error(),
+				})
+				return
+			}
+			response["whatsapp_template"] = account.TemplateName
+			response["whatsapp_params"] = params
+		case err.Error() == "whatsapp account not found":
+			response["warning"] = errWhatsAppNotConfigured.Error()
+		default:
+			log.Printf("[ERROR] Failed to fetch whatsapp account of wedding %d: %v", wedding.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to preview invite",
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preview": response,
+	})
+}
+
+// GetInviteQRCode gera o QR Code com o link de RSVP do convidado, para impressão no convite físico
+// Segurança: O link carrega apenas o token assinado do convidado, o mesmo dos convites enviados
+func GetInviteQRCode(c *gin.Context) {
+	_, invite, ok := loadWeddingInvite(c)
+	if !ok {
+		return
+	}
+
+	code, err := qrcode.Encode(configs.PUBLIC_BASE_URL + RSVPPath(invite.GuestID))
+	if err != nil {
+		log.Printf("[ERROR] Failed to encode QR code for invite %d: %v", invite.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to generate qr code",
+		})
+		return
+	}
+	image, err := code.PNG(inviteQRScale)
+	if err != nil {
+		log.Printf("[ERROR] Failed to render QR code for invite %d: %v", invite.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to generate qr code",
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="invite-%d.png"`, invite.ID))
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, "image/png", image)
+}
+
+// loadWeddingInvite carrega o casamento autorizado e o convite indicado na rota, com o convidado
+func loadWeddingInvite(c *gin.Context) (*models.Wedding, *models.Invite, bool) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	inviteID, err := parseIDParam(c, "inviteId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	invite, err := repository.NewInviteRepository(database.WithContext(c.Request.Context())).FindByIDAndWeddingID(inviteID, wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("invite"))
+		return nil, nil, false
+	}
+	// Convidado removido (lixeira): o convite não pode mais ser enviado
+	if invite.Guest.ID == 0 {
+		respondAccessError(c, authz.NotFound("guest"))
+		return nil, nil, false
+	}
+
+	return wedding, invite, true
+}
+
+// toInviteResponse converte model para response
+func toInviteResponse(i *models.Invite) inviteResponse {
+	return inviteResponse{
+		ID:                i.ID,
+		GuestID:           i.GuestID,
+		GuestName:         i.Guest.FullName,
+		SentAt:            i.SentAt,
+		SentVia:           i.SentVia,
+		DeliveryStatus:    i.DeliveryStatus,
+		DeliveryError:     i.DeliveryError,
+		DeliveryUpdatedAt: i.DeliveryUpdatedAt,
+		OpenedAt:          i.OpenedAt,
+		ClickedAt:         i.ClickedAt,
+		CreatedAt:         i.CreatedAt,
+	}
+}
@@ -0,0 +1,541 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/asyncjobs"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/mailer"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/whatsapp"
+)
+
+const (
+	// Convites renderizados como amostra no dry-run
+	defaultInviteSampleSize = 3
+	maxInviteSampleSize     = 20
+)
+
+// Problemas que impedem ou prejudicam o envio do convite a um convidado
+const (
+	inviteIssueMissingName      = "missing_name"
+	inviteIssueMissingEmail     = "missing_email"
+	inviteIssueMissingPhone     = "missing_phone"
+	inviteIssueOptedOut         = "opted_out"
+	inviteIssueMissingVariables = "missing_variables"
+)
+
+// inviteSample é o convite renderizado para um convidado da amostra
+type inviteSample struct {
+	GuestID        uint     `json:"guest_id"`
+	FullName       string   `json:"full_name"`
+	Subject        string   `json:"subject,omitempty"`
+	Text           string   `json:"text"`
+	WhatsAppParams []string `json:"whatsapp_params,omitempty"`
+}
+
+// inviteGuestIssues lista os problemas de dados de um convidado antes do envio
+type inviteGuestIssues struct {
+	GuestID          uint     `json:"guest_id"`
+	FullName         string   `json:"full_name"`
+	Issues           []string `json:"issues"`
+	MissingVariables []string `json:"missing_variables,omitempty"`
+}
+
+// inviteBulkRequest define o envio em massa: template opcional, canal e convidados pelo status
+type inviteBulkRequest struct {
+	inviteTemplateRef
+	Via    string `json:"via"`
+	Status string `json:"status"` // vazio: todos fora da lista de espera e que não recusaram
+}
+
+// inviteBulkPlan é o envio em massa validado, com o assunto e o corpo já resolvidos
+type inviteBulkPlan struct {
+	Via     string
+	Status  models.InviteStatus
+	Subject string // vazio: assunto padrão do convite
+	Body    string // vazio: texto padrão do convite
+}
+
+// DryRunInvites simula o envio em massa sem enviar: renderiza o convite para alguns convidados
+// e aponta quem ficaria sem convite ou com variáveis vazias, para o casal corrigir os dados antes
+func DryRunInvites(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		inviteBulkRequest
+		SampleSize int `json:"sample_size"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	plan, ok := validateInviteBulkRequest(c, wedding, &body.inviteBulkRequest)
+	if !ok {
+		return
+	}
+	via := plan.Via
+
+	sampleSize := body.SampleSize
+	if sampleSize <= 0 {
+		sampleSize = defaultInviteSampleSize
+	}
+	if sampleSize > maxInviteSampleSize {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: fmt.Sprintf("sample_size must not exceed %d", maxInviteSampleSize),
+		})
+		return
+	}
+
+	db := database.WithContext(c.Request.Context())
+	guests, err := repository.NewGuestRepository(db).FindInviteRecipients(wedding.ID, plan.Status)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch invite recipients of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to preview invites",
+		})
+		return
+	}
+
+	// Parâmetros do template aprovado do WhatsApp também usam as variáveis do convidado
+	var account *models.WhatsAppAccount
+	warnings := []string{}
+	if via == models.InviteViaWhatsApp {
+		account, err = repository.NewWhatsAppRepository(db).FindByWeddingID(wedding.ID)
+		if err != nil {
+			if err.Error() != "whatsapp account not found" {
+				log.Printf("[ERROR] Failed to fetch whatsapp account of wedding %d: %v", wedding.ID, err)
+				c.JSON(http.StatusInternalServerError, errorResponse{
+					Error: "unable to preview invites",
+				})
+				return
+			}
+			account = nil
+			warnings = append(warnings, errWhatsAppNotConfigured.Error())
+		}
+	}
+
+	samples := []inviteSample{}
+	issues := []inviteGuestIssues{}
+	for i := range guests {
+		guest := &guests[i]
+		data := inviteTemplateData(wedding, guest)
+
+		text := inviteEmailText(wedding, guest, data.RSVPLink)
+		var missing []string
+		if plan.Body != "" {
+			text, missing, err = models.RenderInviteTemplateMissing(plan.Body, data)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, errorResponse{
+					Error: err.Error(),
+				})
+				return
+			}
+		}
+
+		var params []string
+		if account != nil {
+			params = make([]string, len(account.TemplateParams))
+			for j, param := range account.TemplateParams {
+				rendered, paramMissing, err := models.RenderInviteTemplateMissing(param, data)
+				if err != nil {
+					c.JSON(http.StatusUnprocessableEntity, errorResponse{
+						Error: err.Error(),
+					})
+					return
+				}
+				params[j] = rendered
+				missing = appendMissing(missing, paramMissing)
+			}
+		}
+
+		if guestIssues := inviteIssues(guest, via, missing); guestIssues != nil {
+			issues = append(issues, *guestIssues)
+		}
+		if len(samples) < sampleSize {
+			sample := inviteSample{
+				GuestID:        guest.ID,
+				FullName:       guest.FullName,
+				Text:           text,
+				WhatsAppParams: params,
+			}
+			if via == models.InviteViaEmail {
+				sample.Subject = inviteEmailSubject
+				if plan.Subject != "" {
+					sample.Subject, _, err = models.RenderInviteTemplateMissing(plan.Subject, data)
+					if err != nil {
+						c.JSON(http.StatusBadRequest, errorResponse{
+							Error: err.Error(),
+						})
+						return
+					}
+				}
+			}
+			samples = append(samples, sample)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"via":         via,
+		"total":       len(guests),
+		"ready":       len(guests) - len(issues),
+		"with_issues": len(issues),
+		"samples":     samples,
+		"issues":      issues,
+		"warnings":    warnings,
+	})
+}
+
+// validateInviteBulkRequest normaliza o canal e o status do envio em massa e resolve o template
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func validateInviteBulkRequest(c *gin.Context, wedding *models.Wedding, req *inviteBulkRequest) (inviteBulkPlan, bool) {
+	via := strings.ToLower(strings.TrimSpace(req.Via))
+	if via == "" {
+		via = models.InviteViaEmail
+	}
+	if !models.IsValidInviteVia(via) {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "via must be one of: email, whatsapp",
+		})
+		return inviteBulkPlan{}, false
+	}
+
+	status := models.InviteStatus(strings.ToLower(strings.TrimSpace(req.Status)))
+	if status != "" && !status.IsValid() {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "invalid invite status",
+		})
+		return inviteBulkPlan{}, false
+	}
+	if status == models.InviteStatusWaitlisted {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "waitlisted guests must be promoted before being invited",
+		})
+		return inviteBulkPlan{}, false
+	}
+	if status == models.InviteStatusDeclined {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "guests who declined do not receive invites or reminders",
+		})
+		return inviteBulkPlan{}, false
+	}
+
+	if err := models.ValidateInviteTemplate(req.Template); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return inviteBulkPlan{}, false
+	}
+
+	subject, text, ok := resolveInviteTemplate(c, wedding, req.inviteTemplateRef)
+	if !ok {
+		return inviteBulkPlan{}, false
+	}
+	return inviteBulkPlan{Via: via, Status: status, Subject: subject, Body: text}, true
+}
+
+// inviteIssues aponta o que impede o envio ao convidado pelo canal ou deixa o convite incompleto
+// Retorna nil quando o convidado está pronto para receber
+func inviteIssues(guest *models.Guest, via string, missing []string) *inviteGuestIssues {
+	found := []string{}
+	if strings.TrimSpace(guest.FullName) == "" {
+		found = append(found, inviteIssueMissingName)
+	}
+	switch {
+	case via == models.InviteViaEmail && guest.Email == "":
+		found = append(found, inviteIssueMissingEmail)
+	case via == models.InviteViaWhatsApp && guest.PhoneE164 == "":
+		found = append(found, inviteIssueMissingPhone)
+	}
+	if !guest.CanReceiveMessages() {
+		found = append(found, inviteIssueOptedOut)
+	}
+	if len(missing) > 0 {
+		found = append(found, inviteIssueMissingVariables)
+	}
+
+	if len(found) == 0 {
+		return nil
+	}
+	return &inviteGuestIssues{
+		GuestID:          guest.ID,
+		FullName:         guest.FullName,
+		Issues:           found,
+		MissingVariables: missing,
+	}
+}
+
+// appendMissing acrescenta as variáveis vazias sem repetir as já listadas
+func appendMissing(missing, more []string) []string {
+	for _, name := range more {
+		if !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// Falhas individuais detalhadas no resultado do envio em massa (as demais só contam no total)
+const maxInviteBulkFailures = 50
+
+// inviteBulkParams é o envio em massa validado gravado no job
+type inviteBulkParams struct {
+	Via     string              `json:"via"`
+	Status  models.InviteStatus `json:"status"`
+	Subject string              `json:"subject"`
+	Body    string              `json:"body"`
+}
+
+// inviteBulkFailure é um convidado que não recebeu o convite no envio em massa
+type inviteBulkFailure struct {
+	GuestID uint   `json:"guest_id"`
+	Error   string `json:"error"`
+}
+
+// BulkSendInvites cria e envia convites para todos os convidados do filtro (ex: status=pending)
+// O envio roda em segundo plano: responde 202 com o job (batch) e o progresso fica em GET /jobs/:id
+// Convidados sem email/telefone ou com opt-out são contados como ignorados; use o dry-run antes
+func BulkSendInvites(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var body inviteBulkRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	plan, ok := validateInviteBulkRequest(c, wedding, &body)
+	if !ok {
+		return
+	}
+
+	// Sem conta de WhatsApp todos os envios falhariam: recusa antes de enfileirar
+	if plan.Via == models.InviteViaWhatsApp {
+		_, err := repository.NewWhatsAppRepository(database.WithContext(c.Request.Context())).FindByWeddingID(wedding.ID)
+		if err != nil {
+			if err.Error() == "whatsapp account not found" {
+				c.JSON(http.StatusConflict, errorResponse{
+					Error: errWhatsAppNotConfigured.Error(),
+				})
+				return
+			}
+			log.Printf("[ERROR] Failed to fetch whatsapp account of wedding %d: %v", wedding.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to start bulk invite",
+			})
+			return
+		}
+	}
+
+	enqueueJob(c, wedding, models.AsyncJobInviteBulk, inviteBulkParams{
+		Via:     plan.Via,
+		Status:  plan.Status,
+		Subject: plan.Subject,
+		Body:    plan.Body,
+	})
+}
+
+// runInviteBulk envia os convites de um job de envio em massa
+// Concorrência: INVITE_BULK_WORKERS envios em paralelo, limitados por provedor (sendPacer)
+// Na reexecução após reinício, convidados que já receberam o convite neste job não recebem de novo
+func runInviteBulk(ctx context.Context, job *models.AsyncJob, progress func(int)) (*asyncjobs.Output, error) {
+	var params inviteBulkParams
+	if err := asyncjobs.DecodeParams(job, &params); err != nil {
+		return nil, err
+	}
+	wedding, err := loadJobWedding(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	db := database.WithContext(ctx)
+	guests, err := repository.NewGuestRepository(db).FindInviteRecipients(wedding.ID, params.Status)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to send invites", err)
+	}
+
+	alreadySent := map[uint]bool{}
+	if job.Attempts > 1 {
+		alreadySent, err = repository.NewInviteRepository(db).SentGuestIDsSince(wedding.ID, job.CreatedAt)
+		if err != nil {
+			return nil, asyncjobs.Fail("unable to send invites", err)
+		}
+	}
+
+	settings := configs.Runtime()
+	pacerKey, rate := "email:"+mailer.Default().Name(), settings.InviteEmailRatePerSec
+	if params.Via == models.InviteViaWhatsApp {
+		account, err := repository.NewWhatsAppRepository(db).FindByWeddingID(wedding.ID)
+		if err != nil {
+			return nil, asyncjobs.Fail(errWhatsAppNotConfigured.Error(), err)
+		}
+		pacerKey, rate = "whatsapp:"+string(account.Provider)+":"+account.AccountID, settings.InviteWhatsAppRatePerSec
+	}
+	pacer := providerPacer(pacerKey)
+
+	pending := make([]int, 0, len(guests))
+	for i := range guests {
+		if !alreadySent[guests[i].ID] {
+			pending = append(pending, i)
+		}
+	}
+
+	type outcome struct {
+		index int
+		err   error
+	}
+	work := make(chan int)
+	results := make(chan outcome)
+	actor := models.StatusActor{
+		Channel: models.StatusChannelInvite,
+		UserID:  &job.UserID,
+		Note:    fmt.Sprintf("bulk invite job #%d", job.ID),
+	}
+
+	// Com o contexto cancelado os workers apenas devolvem o erro, esvaziando a fila sem enviar
+	for range max(settings.InviteBulkWorkers, 1) {
+		go func() {
+			for i := range work {
+				err := pacer.Wait(ctx, rate)
+				if err == nil {
+					err = deliverInvite(ctx, wedding, &guests[i], &models.Invite{
+						SentVia:  params.Via,
+						Subject:  params.Subject,
+						Template: params.Body,
+					}, actor)
+				}
+				results <- outcome{index: i, err: err}
+			}
+		}()
+	}
+	go func() {
+		for _, i := range pending {
+			work <- i
+		}
+		close(work)
+	}()
+
+	sent, skipped, failed := 0, 0, 0
+	failures := []inviteBulkFailure{}
+	for n := range pending {
+		r := <-results
+		guest := &guests[r.index]
+		switch {
+		case r.err == nil:
+			sent++
+		case errors.Is(r.err, errGuestUnreachable), errors.Is(r.err, errGuestNoPhone):
+			skipped++
+		case ctx.Err() != nil:
+			failed++
+		default:
+			failed++
+			log.Printf("[WARN] Failed to send bulk invite to guest %d of wedding %d: %v", guest.ID, wedding.ID, r.err)
+			if len(failures) < maxInviteBulkFailures {
+				failures = append(failures, inviteBulkFailure{GuestID: guest.ID, Error: inviteBulkError(r.err)})
+			}
+		}
+		progress((n + 1) * 100 / len(pending))
+	}
+	if err := ctx.Err(); err != nil {
+		// Os convites já enviados ficam gravados: com status=pending, um novo envio continua de onde parou
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, asyncjobs.Fail(fmt.Sprintf("bulk invite timed out after %d invites, start it again to invite the remaining guests", sent), err)
+		}
+		return nil, err
+	}
+
+	return &asyncjobs.Output{
+		Result: gin.H{
+			"total":        len(guests),
+			"sent":         sent,
+			"already_sent": len(guests) - len(pending),
+			"skipped":      skipped,
+			"failed":       failed,
+			"failures":     failures,
+		},
+	}, nil
+}
+
+// inviteBulkError traduz a falha de um envio para a mensagem exibida ao casal
+// Erros de provedor ficam apenas no log
+func inviteBulkError(err error) string {
+	switch {
+	case errors.Is(err, models.ErrInvalidInviteTemplate), errors.Is(err, errWhatsAppNotConfigured):
+		return err.Error()
+	case errors.Is(err, mailer.ErrInvalidMessage):
+		return "guest email address cannot receive messages"
+	case errors.Is(err, whatsapp.ErrInvalidMessage):
+		return "guest phone number cannot receive whatsapp messages"
+	}
+	return "unable to send invite"
+}
+
+// sendPacer espaça os envios de um provedor para respeitar o limite de mensagens por segundo
+// Concorrência: cada chamada reserva o próximo horário livre; workers de envios diferentes compartilham o limite
+type sendPacer struct {
+	mu   sync.Mutex
+	next time.Time
+}
+
+var (
+	sendPacersMu sync.Mutex
+	sendPacers   = map[string]*sendPacer{}
+)
+
+// providerPacer retorna o limitador do provedor (email da plataforma ou conta de WhatsApp do casal)
+func providerPacer(key string) *sendPacer {
+	sendPacersMu.Lock()
+	defer sendPacersMu.Unlock()
+
+	p, ok := sendPacers[key]
+	if !ok {
+		p = &sendPacer{}
+		sendPacers[key] = p
+	}
+	return p
+}
+
+// Wait bloqueia até o horário reservado para o próximo envio; perSec <= 0 desativa o limite
+func (p *sendPacer) Wait(ctx context.Context, perSec int) error {
+	if perSec <= 0 {
+		return ctx.Err()
+	}
+	interval := time.Second / time.Duration(perSec)
+
+	p.mu.Lock()
+	slot := p.next
+	if now := time.Now(); slot.Before(now) {
+		slot = now
+	}
+	p.next = slot.Add(interval)
+	p.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(slot))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
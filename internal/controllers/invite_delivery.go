@@ -0,0 +1,282 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/mailer"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+	"github.com/matheushermes/wedding_planner_service/internal/whatsapp"
+)
+
+const (
+	// Finalidade do token que identifica o convite nos eventos de entrega
+	inviteDeliveryTokenPurpose = "invite-delivery"
+
+	// Campo do email (SendGrid custom_args) com o token do convite
+	inviteDeliveryArg = "invite_token"
+
+	// Limite do motivo da falha de entrega (tamanho da coluna)
+	maxDeliveryErrorLength = 255
+
+	twilioStatusWebhookPath = "/api/v1/webhooks/whatsapp/twilio/"
+)
+
+// inviteStatusCallbackPath retorna o caminho assinado do StatusCallback da Twilio para o convite
+func inviteStatusCallbackPath(inviteID uint) string {
+	return twilioStatusWebhookPath + security.SignID(inviteDeliveryTokenPurpose, inviteID)
+}
+
+// HandleSendGridEvents recebe o lote do Event Webhook da SendGrid e atualiza a entrega dos convites
+// Eventos de emails que não são convites (sem invite_token) são confirmados sem efeito
+// Segurança: lote assinado com a chave da conta (SENDGRID_WEBHOOK_PUBLIC_KEY) e convite identificado por token assinado
+func HandleSendGridEvents(c *gin.Context) {
+	events, err := mailer.ParseSendGridEvents(c.Request, configs.SENDGRID_WEBHOOK_PUBLIC_KEY, inviteDeliveryArg)
+	if err != nil {
+		log.Printf("[SECURITY] Rejected sendgrid events webhook from IP: %s: %v", c.ClientIP(), err)
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "invalid webhook",
+		})
+		return
+	}
+
+	repo := repository.NewInviteRepository(database.WithContext(c.Request.Context()))
+	for _, event := range events {
+		token := event.Metadata[inviteDeliveryArg]
+		if token == "" {
+			continue
+		}
+		inviteID, err := security.VerifySignedID(inviteDeliveryTokenPurpose, token)
+		if err != nil {
+			log.Printf("[SECURITY] Ignored sendgrid event with invalid invite token from IP: %s", c.ClientIP())
+			continue
+		}
+
+		_, err = repo.UpdateDelivery(inviteID, models.InviteDelivery{
+			Channel:           models.InviteViaEmail,
+			Provider:          "sendgrid",
+			ProviderMessageID: event.MessageID,
+			Status:            event.Status,
+			Reason:            truncateDeliveryError(event.Reason),
+			OccurredAt:        event.At,
+		})
+		if err != nil {
+			log.Printf("[ERROR] Failed to apply sendgrid %s event to invite %d: %v", event.Status, inviteID, err)
+			// Erro 5xx faz a SendGrid reenviar o lote; eventos já aplicados são ignorados no reenvio
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to process webhook",
+			})
+			return
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// HandleTwilioStatus recebe o StatusCallback de um convite enviado por WhatsApp pela Twilio
+// Segurança: o token da rota identifica o convite e a assinatura é conferida com o auth token da conta do casal
+func HandleTwilioStatus(c *gin.Context) {
+	token := c.Param("token")
+	inviteID, err := security.VerifySignedID(inviteDeliveryTokenPurpose, token)
+	if err != nil {
+		log.Printf("[SECURITY] Rejected twilio status webhook from IP: %s: invalid invite token", c.ClientIP())
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "invalid webhook",
+		})
+		return
+	}
+
+	db := database.WithContext(c.Request.Context())
+	invite, err := repository.NewInviteRepository(db).FindByID(inviteID)
+	if err != nil {
+		// Convite removido: confirmado para a Twilio não reenviar
+		if err.Error() == "invite not found" {
+			c.Status(http.StatusNoContent)
+			return
+		}
+		log.Printf("[ERROR] Failed to fetch invite %d for twilio status webhook: %v", inviteID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to process webhook",
+		})
+		return
+	}
+
+	account, err := repository.NewWhatsAppRepository(db).FindByWeddingID(invite.WeddingID)
+	if err != nil && err.Error() != "whatsapp account not found" {
+		log.Printf("[ERROR] Failed to fetch whatsapp account of wedding %d: %v", invite.WeddingID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to process webhook",
+		})
+		return
+	}
+	// Conta removida ou trocada de provedor após o envio: sem como conferir a assinatura
+	if err != nil || account.Provider != models.WhatsAppProviderTwilio {
+		log.Printf("[WARN] Ignored twilio status webhook for invite %d: wedding %d has no twilio account", invite.ID, invite.WeddingID)
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	event, err := whatsapp.ParseTwilioStatus(c.Request, account.Token, configs.PUBLIC_BASE_URL+twilioStatusWebhookPath+token)
+	if err != nil {
+		if errors.Is(err, whatsapp.ErrIgnoredStatus) {
+			c.Status(http.StatusNoContent)
+			return
+		}
+		log.Printf("[SECURITY] Rejected twilio status webhook from IP: %s: %v", c.ClientIP(), err)
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "invalid webhook",
+		})
+		return
+	}
+
+	updated, err := repository.NewInviteRepository(db).UpdateDelivery(invite.ID, models.InviteDelivery{
+		Channel:           models.InviteViaWhatsApp,
+		Provider:          "twilio",
+		ProviderMessageID: event.MessageID,
+		Status:            event.Status,
+		Reason:            truncateDeliveryError(event.Reason),
+		OccurredAt:        time.Now(),
+	})
+	if err != nil {
+		log.Printf("[ERROR] Failed to apply twilio %s status to invite %d: %v", event.Status, invite.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to process webhook",
+		})
+		return
+	}
+
+	// Número sem WhatsApp: o convite sai por SMS quando o casamento ativou o fallback
+	// Só na transição aplicada, para o reenvio do mesmo callback não disparar outro SMS
+	if updated && event.RecipientUnavailable {
+		fallBackToSMS(c.Request.Context(), invite)
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// fallBackToSMS reenvia por SMS o convite que o WhatsApp não entregou
+// Falhas vão para a auditoria e o log: o webhook já foi aplicado e não deve ser reenviado pela Twilio
+func fallBackToSMS(ctx context.Context, invite *models.Invite) {
+	db := database.WithContext(context.WithoutCancel(ctx))
+	wedding, err := repository.NewWeddingRepository(db).FindByID(invite.WeddingID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch wedding %d for sms fallback of invite %d: %v", invite.WeddingID, invite.ID, err)
+		return
+	}
+	if !wedding.SMSFallback {
+		return
+	}
+	guest, err := repository.NewGuestRepository(db).FindByIDAndWeddingID(invite.GuestID, wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch guest %d for sms fallback of invite %d: %v", invite.GuestID, invite.ID, err)
+		return
+	}
+
+	data := inviteTemplateData(wedding, guest)
+	delivery := &models.InviteDelivery{
+		InviteID:  invite.ID,
+		WeddingID: wedding.ID,
+		Channel:   models.InviteViaSMS,
+		Status:    models.InviteDeliverySent,
+		Reason:    smsFallbackReason,
+	}
+	text, err := inviteText(wedding, guest, invite, data)
+	if err == nil {
+		delivery.Payload = inviteDeliveryPayload(guest.PhoneE164, "", text)
+		var receipt deliveryReceipt
+		receipt, err = sendGuestSMS(ctx, guest, text, data.OptOutLink)
+		delivery.Provider = receipt.Provider
+		delivery.ProviderMessageID = receipt.MessageID
+	}
+
+	repo := repository.NewInviteRepository(db)
+	if err != nil {
+		log.Printf("[WARN] SMS fallback of invite %d failed: %v", invite.ID, err)
+		delivery.Status = models.InviteDeliveryFailed
+		delivery.Reason = truncateDeliveryError("sms fallback: " + err.Error())
+		if err := repo.RecordDelivery(delivery); err != nil {
+			log.Printf("[ERROR] Failed to record sms fallback failure of invite %d: %v", invite.ID, err)
+		}
+		return
+	}
+	if err := repo.RecordFallbackSent(invite, delivery); err != nil {
+		log.Printf("[ERROR] Failed to record sms fallback of invite %d: %v", invite.ID, err)
+	}
+}
+
+// inviteDeliveryResponse representa um passo da entrega do convite
+type inviteDeliveryResponse struct {
+	ID                uint                        `json:"id"`
+	Channel           string                      `json:"channel"`
+	Provider          string                      `json:"provider"`
+	ProviderMessageID string                      `json:"provider_message_id"`
+	Status            models.InviteDeliveryStatus `json:"status"`
+	Reason            string                      `json:"reason"`
+	Payload           json.RawMessage             `json:"payload,omitempty"`
+	OccurredAt        time.Time                   `json:"occurred_at"`
+	CreatedAt         time.Time                   `json:"created_at"`
+}
+
+// GetInviteDeliveries lista a auditoria de envios do convite em ordem cronológica
+// Cada tentativa traz o conteúdo enviado e o ID da mensagem no provedor, seguida das mudanças de status
+// dos webhooks: permite rastrear um "não recebi o convite" de ponta a ponta
+func GetInviteDeliveries(c *gin.Context) {
+	wedding, invite, ok := loadWeddingInvite(c)
+	if !ok {
+		return
+	}
+
+	deliveries, err := repository.NewInviteRepository(database.WithContext(c.Request.Context())).FindDeliveries(invite.ID, wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch deliveries of invite %d: %v", invite.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch invite deliveries",
+		})
+		return
+	}
+
+	response := make([]inviteDeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		response[i] = toInviteDeliveryResponse(&deliveries[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invite":     toInviteResponse(invite),
+		"deliveries": response,
+	})
+}
+
+// toInviteDeliveryResponse converte model para response
+func toInviteDeliveryResponse(d *models.InviteDelivery) inviteDeliveryResponse {
+	response := inviteDeliveryResponse{
+		ID:                d.ID,
+		Channel:           d.Channel,
+		Provider:          d.Provider,
+		ProviderMessageID: d.ProviderMessageID,
+		Status:            d.Status,
+		Reason:            d.Reason,
+		OccurredAt:        d.OccurredAt,
+		CreatedAt:         d.CreatedAt,
+	}
+	if json.Valid([]byte(d.Payload)) {
+		response.Payload = json.RawMessage(d.Payload)
+	}
+	return response
+}
+
+// truncateDeliveryError corta o motivo da falha no limite da coluna
+func truncateDeliveryError(reason string) string {
+	if len(reason) <= maxDeliveryErrorLength {
+		return reason
+	}
+	return reason[:maxDeliveryErrorLength]
+}
@@ -0,0 +1,319 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// Limite de templates salvos por casamento
+const maxInviteTemplates = 50
+
+// inviteTemplateResponse representa um template de mensagem do casal ou padrão (builtin, sem ID)
+type inviteTemplateResponse struct {
+	ID        uint                      `json:"id,omitempty"`
+	Builtin   bool                      `json:"builtin"`
+	Name      string                    `json:"name"`
+	Kind      models.InviteTemplateKind `json:"kind"`
+	Subject   string                    `json:"subject"`
+	Body      string                    `json:"body"`
+	CreatedAt *time.Time                `json:"created_at,omitempty"`
+	UpdatedAt *time.Time                `json:"updated_at,omitempty"`
+}
+
+// inviteTemplateRef indica o texto de um envio: template digitado, salvo (template_id) ou padrão (builtin)
+type inviteTemplateRef struct {
+	Template   string                    `json:"template"`
+	TemplateID uint                      `json:"template_id"`
+	Builtin    models.InviteTemplateKind `json:"builtin"`
+}
+
+// CreateInviteTemplate salva um template de mensagem no casamento
+// Com "from_builtin", parte do template padrão do tipo; os campos enviados substituem os do padrão
+func CreateInviteTemplate(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var createData struct {
+		FromBuiltin models.InviteTemplateKind `json:"from_builtin"`
+		Name        string                    `json:"name"`
+		Kind        models.InviteTemplateKind `json:"kind"`
+		Subject     *string                   `json:"subject"`
+		Body        string                    `json:"body"`
+	}
+
+	if err := c.ShouldBindJSON(&createData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	template := models.InviteTemplate{}
+	if createData.FromBuiltin != "" {
+		builtin, found := models.BuiltinInviteTemplate(createData.FromBuiltin)
+		if !found {
+			respondAccessError(c, authz.NotFound("builtin template"))
+			return
+		}
+		template = *builtin
+	}
+	template.WeddingID = wedding.ID
+	if createData.Name != "" {
+		template.Name = createData.Name
+	}
+	if createData.Kind != "" {
+		template.Kind = createData.Kind
+	}
+	if createData.Subject != nil {
+		template.Subject = *createData.Subject
+	}
+	if createData.Body != "" {
+		template.Body = createData.Body
+	}
+
+	if err := template.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	repo := repository.NewInviteTemplateRepository(database.WithContext(c.Request.Context()))
+
+	count, err := repo.CountByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to count invite templates of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to create invite template",
+		})
+		return
+	}
+	if count >= maxInviteTemplates {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "invite template limit reached for this wedding",
+		})
+		return
+	}
+
+	if err := repo.Create(&template); err != nil {
+		log.Printf("[ERROR] Failed to create invite template for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to create invite template",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "invite template created successfully",
+		"template": toInviteTemplateResponse(&template),
+	})
+}
+
+// GetInviteTemplates lista os templates do casal e os templates padrão
+func GetInviteTemplates(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	templates, err := repository.NewInviteTemplateRepository(database.WithContext(c.Request.Context())).FindByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch invite templates of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch invite templates",
+		})
+		return
+	}
+
+	response := make([]inviteTemplateResponse, len(templates))
+	for i := range templates {
+		response[i] = toInviteTemplateResponse(&templates[i])
+	}
+	builtins := make([]inviteTemplateResponse, len(models.BuiltinInviteTemplates))
+	for i := range models.BuiltinInviteTemplates {
+		builtins[i] = toInviteTemplateResponse(&models.BuiltinInviteTemplates[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"templates": response,
+		"builtin":   builtins,
+		"variables": models.InviteTemplateVariables,
+		"count":     len(response),
+	})
+}
+
+// GetInviteTemplate retorna um template do casal
+func GetInviteTemplate(c *gin.Context) {
+	_, template, ok := loadWeddingInviteTemplate(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"template": toInviteTemplateResponse(template),
+	})
+}
+
+// UpdateInviteTemplate atualiza parcialmente um template do casal
+func UpdateInviteTemplate(c *gin.Context) {
+	wedding, template, ok := loadWeddingInviteTemplate(c)
+	if !ok {
+		return
+	}
+
+	var updateData struct {
+		Name    *string                    `json:"name"`
+		Kind    *models.InviteTemplateKind `json:"kind"`
+		Subject *string                    `json:"subject"`
+		Body    *string                    `json:"body"`
+	}
+
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if updateData.Name != nil {
+		template.Name = *updateData.Name
+	}
+	if updateData.Kind != nil {
+		template.Kind = *updateData.Kind
+	}
+	if updateData.Subject != nil {
+		template.Subject = *updateData.Subject
+	}
+	if updateData.Body != nil {
+		template.Body = *updateData.Body
+	}
+
+	if err := template.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repository.NewInviteTemplateRepository(database.WithContext(c.Request.Context())).Update(template); err != nil {
+		log.Printf("[ERROR] Failed to update invite template %d of wedding %d: %v", template.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to update invite template",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "invite template updated successfully",
+		"template": toInviteTemplateResponse(template),
+	})
+}
+
+// DeleteInviteTemplate remove um template do casal
+func DeleteInviteTemplate(c *gin.Context) {
+	wedding, template, ok := loadWeddingInviteTemplate(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.NewInviteTemplateRepository(database.WithContext(c.Request.Context())).Delete(template); err != nil {
+		log.Printf("[ERROR] Failed to delete invite template %d of wedding %d: %v", template.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to delete invite template",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "invite template deleted successfully",
+	})
+}
+
+// resolveInviteTemplate retorna o assunto e o corpo indicados no envio (vazios: texto padrão do convite)
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func resolveInviteTemplate(c *gin.Context, wedding *models.Wedding, ref inviteTemplateRef) (string, string, bool) {
+	set := 0
+	for _, given := range []bool{ref.Template != "", ref.TemplateID != 0, ref.Builtin != ""} {
+		if given {
+			set++
+		}
+	}
+	if set > 1 {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "use only one of: template, template_id, builtin",
+		})
+		return "", "", false
+	}
+
+	switch {
+	case ref.TemplateID != 0:
+		template, err := repository.NewInviteTemplateRepository(database.WithContext(c.Request.Context())).FindByIDAndWeddingID(ref.TemplateID, wedding.ID)
+		if err != nil {
+			if err.Error() == "invite template not found" {
+				respondAccessError(c, authz.NotFound("invite template"))
+				return "", "", false
+			}
+			log.Printf("[ERROR] Failed to fetch invite template %d of wedding %d: %v", ref.TemplateID, wedding.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to fetch invite template",
+			})
+			return "", "", false
+		}
+		return template.Subject, template.Body, true
+	case ref.Builtin != "":
+		builtin, found := models.BuiltinInviteTemplate(ref.Builtin)
+		if !found {
+			respondAccessError(c, authz.NotFound("builtin template"))
+			return "", "", false
+		}
+		return builtin.Subject, builtin.Body, true
+	}
+	return "", ref.Template, true
+}
+
+// loadWeddingInviteTemplate extrai o casamento :id e o template :templateId
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func loadWeddingInviteTemplate(c *gin.Context) (*models.Wedding, *models.InviteTemplate, bool) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	templateID, err := parseIDParam(c, "templateId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	template, err := repository.NewInviteTemplateRepository(database.WithContext(c.Request.Context())).FindByIDAndWeddingID(templateID, wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("invite template"))
+		return nil, nil, false
+	}
+
+	return wedding, template, true
+}
+
+// toInviteTemplateResponse converte model para response
+func toInviteTemplateResponse(t *models.InviteTemplate) inviteTemplateResponse {
+	response := inviteTemplateResponse{
+		ID:      t.ID,
+		Builtin: t.ID == 0,
+		Name:    t.Name,
+		Kind:    t.Kind,
+		Subject: t.Subject,
+		Body:    t.Body,
+	}
+	if t.ID != 0 {
+		response.CreatedAt = &t.CreatedAt
+		response.UpdatedAt = &t.UpdatedAt
+	}
+	return response
+}
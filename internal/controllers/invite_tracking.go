@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"html"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+)
+
+// inviteTrackingTokenPurpose separa os tokens de abertura e clique de outros tokens assinados
+const inviteTrackingTokenPurpose = "invite-tracking"
+
+// GIF transparente de 1x1 devolvido pelo pixel de abertura
+var trackingPixelGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// Links no texto do email que viram <a> na versão HTML
+var emailLinkPattern = regexp.MustCompile(`https?://[^\s<>"]+`)
+
+// inviteEmailTracking acompanha o email do convite: token dos eventos de entrega e pixel de abertura
+type inviteEmailTracking struct {
+	Metadata map[string]string
+	PixelURL string
+}
+
+// inviteEngagementGuest representa um convidado que recebeu o convite por email e nunca o abriu
+type inviteEngagementGuest struct {
+	GuestID    uint      `json:"guest_id"`
+	FullName   string    `json:"full_name"`
+	Invites    int64     `json:"invites"`
+	LastSentAt time.Time `json:"last_sent_at"`
+}
+
+// inviteEngagementResponse resume a abertura e o clique dos convites por email por convidado
+type inviteEngagementResponse struct {
+	Guests      int                     `json:"guests"` // convidados com convite por email enviado
+	Opened      int                     `json:"opened"`
+	Clicked     int                     `json:"clicked"`
+	OpenRate    float64                 `json:"open_rate"`  // abertos / convidados
+	ClickRate   float64                 `json:"click_rate"` // clicados / convidados
+	NeverOpened []inviteEngagementGuest `json:"never_opened"`
+}
+
+// inviteOpenPath retorna o caminho assinado do pixel de abertura do convite
+func inviteOpenPath(inviteID uint) string {
+	return "/api/v1/public/invites/" + security.SignID(inviteTrackingTokenPurpose, inviteID) + "/open.gif"
+}
+
+// inviteClickPath retorna o caminho assinado que registra o clique e redireciona para o RSVP
+func inviteClickPath(inviteID uint) string {
+	return "/api/v1/public/invites/" + security.SignID(inviteTrackingTokenPurpose, inviteID) + "/click"
+}
+
+// TrackInviteOpen registra a primeira abertura do convite e devolve o pixel
+// O pixel é devolvido mesmo com token inválido ou falha no registro: o email do convidado não quebra
+// LGPD: registra só o horário da abertura no convite, sem IP nem user agent
+func TrackInviteOpen(c *gin.Context) {
+	if inviteID, err := security.VerifySignedID(inviteTrackingTokenPurpose, c.Param("token")); err == nil {
+		if err := repository.NewInviteRepository(database.WithContext(c.Request.Context())).RecordOpened(inviteID, time.Now()); err != nil {
+			log.Printf("[ERROR] Failed to record open of invite %d: %v", inviteID, err)
+		}
+	}
+
+	c.Header("Cache-Control", "no-store, private")
+	c.Data(http.StatusOK, "image/gif", trackingPixelGIF)
+}
+
+// TrackInviteClick registra o primeiro clique no link de RSVP do convite e redireciona para o RSVP
+// Segurança: o destino vem do convite do token assinado, nunca da URL (sem open redirect)
+func TrackInviteClick(c *gin.Context) {
+	inviteID, err := security.VerifySignedID(inviteTrackingTokenPurpose, c.Param("token"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "invalid invite link",
+		})
+		return
+	}
+
+	repo := repository.NewInviteRepository(database.WithContext(c.Request.Context()))
+	invite, err := repo.FindByID(inviteID)
+	if err != nil {
+		if err.Error() == "invite not found" {
+			c.JSON(http.StatusNotFound, errorResponse{
+				Error: "invalid invite link",
+			})
+			return
+		}
+		log.Printf("[ERROR] Failed to fetch invite %d for click tracking: %v", inviteID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to open invite link",
+		})
+		return
+	}
+
+	// Falha no registro não impede o convidado de responder
+	if err := repo.RecordClicked(invite.ID, time.Now()); err != nil {
+		log.Printf("[ERROR] Failed to record click of invite %d: %v", invite.ID, err)
+	}
+
+	c.Redirect(http.StatusFound, configs.PUBLIC_BASE_URL+RSVPPath(invite.GuestID))
+}
+
+// GetInviteEngagement mostra quantos convidados abriram o convite por email e clicaram no link de RSVP
+// Lista quem recebeu e nunca abriu para o casal tentar outro canal
+// Aberturas são aproximadas: clientes que bloqueiam imagens só contam ao clicar e proxies que
+// pré-carregam imagens contam como abertura
+func GetInviteEngagement(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	rows, err := repository.NewInviteRepository(database.WithContext(c.Request.Context())).EngagementByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch invite engagement of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch invite engagement",
+		})
+		return
+	}
+
+	response := inviteEngagementResponse{
+		Guests:      len(rows),
+		NeverOpened: []inviteEngagementGuest{},
+	}
+	for _, row := range rows {
+		if row.ClickedAt != nil {
+			response.Clicked++
+		}
+		if row.OpenedAt != nil {
+			response.Opened++
+			continue
+		}
+		response.NeverOpened = append(response.NeverOpened, inviteEngagementGuest{
+			GuestID:    row.GuestID,
+			FullName:   row.FullName,
+			Invites:    row.Invites,
+			LastSentAt: row.LastSentAt,
+		})
+	}
+	if response.Guests > 0 {
+		response.OpenRate = float64(response.Opened) / float64(response.Guests)
+		response.ClickRate = float64(response.Clicked) / float64(response.Guests)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"engagement": response,
+	})
+}
+
+// inviteEmailHTML converte o texto do email em HTML com os links clicáveis e o pixel de abertura
+// Segurança: o texto (nomes e templates do casal) é escapado; só os links viram tags
+func inviteEmailHTML(text, pixelURL string) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html><body>\n<p>")
+
+	writeText := func(s string) {
+		b.WriteString(strings.ReplaceAll(html.EscapeString(s), "\n", "<br>\n"))
+	}
+	last := 0
+	for _, loc := range emailLinkPattern.FindAllStringIndex(text, -1) {
+		writeText(text[last:loc[0]])
+		link := html.EscapeString(text[loc[0]:loc[1]])
+		b.WriteString(`<a href="` + link + `">` + link + `</a>`)
+		last = loc[1]
+	}
+	writeText(text[last:])
+
+	b.WriteString("</p>\n")
+	if pixelURL != "" {
+		b.WriteString(`<img src="` + html.EscapeString(pixelURL) + `" width="1" height="1" alt="" style="display:none">` + "\n")
+	}
+	b.WriteString("</body></html>\n")
+	return b.String()
+}
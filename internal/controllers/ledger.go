@@ -0,0 +1,279 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/asyncjobs"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/money"
+	"github.com/matheushermes/wedding_planner_service/internal/reports"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// ledgerEntryResponse representa um lançamento do livro-razão
+type ledgerEntryResponse struct {
+	ID            uint                   `json:"id"`
+	Kind          models.LedgerEntryKind `json:"kind"`
+	Account       models.LedgerAccount   `json:"account"`
+	Amount        float64                `json:"amount"`
+	Currency      string                 `json:"currency"`
+	AmountDisplay string                 `json:"amount_display"`
+	SourceType    string                 `json:"source_type"`
+	SourceID      uint                   `json:"source_id"`
+	Memo          string                 `json:"memo"`
+	OccurredAt    time.Time              `json:"occurred_at"`
+}
+
+// GetLedger retorna os lançamentos financeiros do casamento e os saldos derivados
+// Filtros opcionais: ?from=YYYY-MM-DD&to=YYYY-MM-DD (to é inclusivo)
+func GetLedger(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	repo := repository.NewLedgerRepository(database.WithContext(c.Request.Context()))
+
+	entries, err := repo.FindByWeddingID(wedding.ID, from, to)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch ledger of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch ledger",
+		})
+		return
+	}
+
+	// Saldos sempre consideram o histórico completo, independente do filtro
+	balances, err := repo.BalancesByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to compute ledger balances of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch ledger",
+		})
+		return
+	}
+
+	totals := map[models.LedgerAccount]float64{
+		models.LedgerAccountExpenses:    0,
+		models.LedgerAccountFundraising: 0,
+	}
+	for _, b := range balances {
+		totals[b.Account] = b.Total
+	}
+
+	totalsDisplay := make(map[models.LedgerAccount]string, len(totals))
+	for account, total := range totals {
+		totalsDisplay[account] = money.Format(total, wedding.Currency)
+	}
+
+	response := make([]ledgerEntryResponse, len(entries))
+	for i := range entries {
+		response[i] = toLedgerEntryResponse(&entries[i], wedding.Currency)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"wedding_id":       wedding.ID,
+		"currency":         wedding.Currency,
+		"entries":          response,
+		"count":            len(response),
+		"balances":         totals,
+		"balances_display": totalsDisplay,
+	})
+}
+
+// ledgerExportParams são os filtros da exportação gravados no job
+type ledgerExportParams struct {
+	Format     string            `json:"format"`
+	From       *time.Time        `json:"from"`
+	To         *time.Time        `json:"to"`
+	Categories map[string]string `json:"categories"`
+}
+
+// ExportLedger exporta os lançamentos para softwares de contabilidade
+// Query: ?format=csv|ofx&from=YYYY-MM-DD&to=YYYY-MM-DD&category[<origem ou tipo>]=<conta contábil>
+// O mapeamento aceita a categoria de origem (ex: food, gift) ou o tipo do lançamento (ex: contribution_refunded)
+// O arquivo é gerado em segundo plano: responde 202 e o download fica no job (GET /jobs/:id)
+func ExportLedger(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "ofx" {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "format must be csv or ofx",
+		})
+		return
+	}
+
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	enqueueJob(c, wedding, models.AsyncJobLedgerExport, ledgerExportParams{
+		Format:     format,
+		From:       from,
+		To:         to,
+		Categories: c.QueryMap("category"),
+	})
+}
+
+// runLedgerExport gera o CSV ou OFX do livro-razão de um job de exportação
+func runLedgerExport(ctx context.Context, job *models.AsyncJob, progress func(int)) (*asyncjobs.Output, error) {
+	var params ledgerExportParams
+	if err := asyncjobs.DecodeParams(job, &params); err != nil {
+		return nil, err
+	}
+	wedding, err := loadJobWedding(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := repository.NewLedgerRepository(database.WithContext(ctx))
+
+	entries, err := repo.FindByWeddingID(wedding.ID, params.From, params.To)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to export ledger", err)
+	}
+
+	sourceCategories, err := repo.SourceCategories(wedding.ID)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to export ledger", err)
+	}
+	progress(50)
+
+	rows := make([]reports.LedgerExportRow, len(entries))
+	for i, e := range entries {
+		// Gastos são saídas de caixa; arrecadações, entradas
+		amount := e.Amount
+		if e.Account == models.LedgerAccountExpenses {
+			amount = -amount
+		}
+
+		source := sourceCategories[fmt.Sprintf("%s:%d", e.SourceType, e.SourceID)]
+		category := params.Categories[source]
+		if category == "" {
+			category = params.Categories[string(e.Kind)]
+		}
+		if category == "" {
+			category = source
+		}
+		if category == "" {
+			category = string(e.Kind)
+		}
+
+		rows[i] = reports.LedgerExportRow{
+			EntryID:    e.ID,
+			Date:       e.OccurredAt,
+			Kind:       string(e.Kind),
+			Category:   category,
+			Memo:       e.Memo,
+			Amount:     amount,
+			SourceType: e.SourceType,
+			SourceID:   e.SourceID,
+		}
+	}
+
+	out := &asyncjobs.Output{
+		Result:       gin.H{"entries": len(rows)},
+		DownloadName: fmt.Sprintf("wedding-%d-ledger.%s", wedding.ID, params.Format),
+	}
+
+	if params.Format == "csv" {
+		out.ContentType = "text/csv; charset=utf-8"
+		out.FileName, err = writeExportFile(".csv", func(w io.Writer) error {
+			return reports.WriteLedgerCSV(w, wedding.Currency, rows)
+		})
+		if err != nil {
+			return nil, asyncjobs.Fail("unable to export ledger", err)
+		}
+		return out, nil
+	}
+
+	// Período do extrato: filtro informado ou intervalo coberto pelos lançamentos
+	start, end := time.Now(), time.Now()
+	if len(rows) > 0 {
+		start, end = rows[0].Date, rows[len(rows)-1].Date
+	}
+	if params.From != nil {
+		start = *params.From
+	}
+	if params.To != nil {
+		end = *params.To
+	}
+
+	out.ContentType = "application/x-ofx"
+	out.FileName, err = writeExportFile(".ofx", func(w io.Writer) error {
+		return reports.WriteLedgerOFX(w, fmt.Sprintf("WEDDING%d", wedding.ID), wedding.Currency, start, end, rows)
+	})
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to export ledger", err)
+	}
+	return out, nil
+}
+
+// parseDateRange lê os filtros ?from e ?to no formato YYYY-MM-DD
+// Retorna to como o início do dia seguinte para o filtro ser inclusivo
+func parseDateRange(c *gin.Context) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, nil, errors.New("invalid date range, expected YYYY-MM-DD")
+		}
+		from = &t
+	}
+
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, nil, errors.New("invalid date range, expected YYYY-MM-DD")
+		}
+		t = t.AddDate(0, 0, 1)
+		to = &t
+	}
+
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, errors.New("invalid date range, expected YYYY-MM-DD")
+	}
+
+	return from, to, nil
+}
+
+// toLedgerEntryResponse converte model para response
+func toLedgerEntryResponse(e *models.LedgerEntry, currency string) ledgerEntryResponse {
+	return ledgerEntryResponse{
+		ID:            e.ID,
+		Kind:          e.Kind,
+		Account:       e.Account,
+		Amount:        e.Amount,
+		Currency:      currency,
+		AmountDisplay: money.Format(e.Amount, currency),
+		SourceType:    e.SourceType,
+		SourceID:      e.SourceID,
+		Memo:          e.Memo,
+		OccurredAt:    e.OccurredAt,
+	}
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/maintenance"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// GetMaintenanceFlags lista as áreas da API em manutenção (admin)
+func GetMaintenanceFlags(c *gin.Context) {
+	flags, err := repository.NewMaintenanceRepository(database.WithContext(c.Request.Context())).FindAll()
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch maintenance flags: %v", err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "unable to fetch maintenance flags"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"flags": flags})
+}
+
+// SetMaintenanceFlag coloca uma área (ou a API toda, com "all") em manutenção (admin)
+// As outras réplicas passam a responder 503 na próxima leitura da tabela
+func SetMaintenanceFlag(c *gin.Context) {
+	var body struct {
+		Message           string `json:"message"`
+		RetryAfterSeconds int    `json:"retry_after_seconds"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	flag := models.MaintenanceFlag{
+		Feature:           models.MaintenanceFeature(c.Param("feature")),
+		Message:           body.Message,
+		RetryAfterSeconds: body.RetryAfterSeconds,
+	}
+	if err := flag.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := repository.NewMaintenanceRepository(database.WithContext(c.Request.Context())).Save(&flag); err != nil {
+		log.Printf("[ERROR] Failed to save maintenance flag %s: %v", flag.Feature, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "unable to save maintenance flag"})
+		return
+	}
+	maintenance.Invalidate()
+
+	log.Printf("[INFO] Maintenance enabled for %s", flag.Feature)
+	c.JSON(http.StatusOK, gin.H{"flag": flag})
+}
+
+// ClearMaintenanceFlag encerra a manutenção de uma área (admin)
+func ClearMaintenanceFlag(c *gin.Context) {
+	feature := models.MaintenanceFeature(c.Param("feature"))
+	if !feature.IsValid() {
+		c.JSON(http.StatusBadRequest, errorResponse{Error: "invalid maintenance feature"})
+		return
+	}
+
+	if err := repository.NewMaintenanceRepository(database.WithContext(c.Request.Context())).Delete(feature); err != nil {
+		if err.Error() == "maintenance flag not found" {
+			c.JSON(http.StatusNotFound, errorResponse{Error: err.Error()})
+			return
+		}
+		log.Printf("[ERROR] Failed to clear maintenance flag %s: %v", feature, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "unable to clear maintenance flag"})
+		return
+	}
+	maintenance.Invalidate()
+
+	log.Printf("[INFO] Maintenance disabled for %s", feature)
+	c.JSON(http.StatusOK, gin.H{"message": "maintenance disabled"})
+}
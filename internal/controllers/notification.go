@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// notificationResponse representa um comunicado na caixa de notificações do usuário
+type notificationResponse struct {
+	ID        uint       `json:"id"`
+	Kind      string     `json:"kind"` // tipo do comunicado ou do aviso do casamento
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	WeddingID *uint      `json:"wedding_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ReadAt    *time.Time `json:"read_at"`
+}
+
+// notificationPageResponse é uma página da caixa de notificações com o total de não lidas
+type notificationPageResponse struct {
+	paginatedResponse[notificationResponse]
+	Unread int64 `json:"unread"`
+}
+
+// GetNotifications lista a caixa de notificações do usuário (mais recentes primeiro)
+// Filtro: ?unread=true (apenas as não lidas)
+func GetNotifications(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, errorResponse{Error: "authentication required"})
+		return
+	}
+
+	page, perPage, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	var unreadOnly bool
+	if v := c.Query("unread"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse{Error: "invalid unread parameter"})
+			return
+		}
+		unreadOnly = parsed
+	}
+
+	repo := repository.NewNotificationRepository(database.WithContext(c.Request.Context()))
+
+	notifications, total, err := repo.FindByUserID(userID.(uint), unreadOnly, page, perPage)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch notifications of user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "unable to fetch notifications"})
+		return
+	}
+	unread, err := repo.CountUnread(userID.(uint))
+	if err != nil {
+		log.Printf("[ERROR] Failed to count unread notifications of user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "unable to fetch notifications"})
+		return
+	}
+
+	response := make([]notificationResponse, len(notifications))
+	for i := range notifications {
+		response[i] = toNotificationResponse(&notifications[i])
+	}
+
+	c.JSON(http.StatusOK, notificationPageResponse{
+		paginatedResponse: paginatedResponse[notificationResponse]{
+			Items:   response,
+			Total:   total,
+			Page:    page,
+			PerPage: perPage,
+		},
+		Unread: unread,
+	})
+}
+
+// MarkNotificationRead marca uma notificação do usuário como lida
+func MarkNotificationRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, errorResponse{Error: "authentication required"})
+		return
+	}
+
+	notificationID, err := parseIDParam(c, "notificationId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	err = repository.NewNotificationRepository(database.WithContext(c.Request.Context())).MarkRead(notificationID, userID.(uint), time.Now())
+	if err != nil {
+		if err.Error() == "notification not found" {
+			respondAccessError(c, authz.NotFound("notification"))
+			return
+		}
+		log.Printf("[ERROR] Failed to mark notification %d of user %d as read: %v", notificationID, userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "unable to update notification"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "notification marked as read",
+	})
+}
+
+// MarkAllNotificationsRead marca como lidas todas as notificações do usuário
+func MarkAllNotificationsRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, errorResponse{Error: "authentication required"})
+		return
+	}
+
+	marked, err := repository.NewNotificationRepository(database.WithContext(c.Request.Context())).MarkAllRead(userID.(uint), time.Now())
+	if err != nil {
+		log.Printf("[ERROR] Failed to mark notifications of user %d as read: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "unable to update notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "notifications marked as read",
+		"marked":  marked,
+	})
+}
+
+// toNotificationResponse converte a notificação: comunicados trazem o texto do comunicado
+func toNotificationResponse(n *models.Notification) notificationResponse {
+	response := notificationResponse{
+		ID:        n.ID,
+		Kind:      string(n.Kind),
+		Title:     n.Title,
+		Body:      n.Body,
+		WeddingID: n.WeddingID,
+		CreatedAt: n.CreatedAt,
+		ReadAt:    n.ReadAt,
+	}
+	if n.Announcement != nil {
+		response.Kind = string(n.Announcement.Kind)
+		response.Title = n.Announcement.Title
+		response.Body = n.Announcement.Body
+	}
+	return response
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+)
+
+// optOutTokenPurpose separa os tokens de opt-out de outros tokens assinados
+const optOutTokenPurpose = "guest-opt-out"
+
+// OptOutPath retorna o link de descadastro que deve acompanhar toda mensagem ao convidado
+func OptOutPath(guestID uint) string {
+	return "/api/v1/public/opt-out/" + security.SignID(optOutTokenPurpose, guestID)
+}
+
+// GetOptOut confere o link de descadastro e informa o que a confirmação faz, sem gravar nada
+// Segurança: GET não tem efeito colateral; scanners de link e pré-visualizações de email
+// abrem o link sem o convidado pedir o descadastro
+func GetOptOut(c *gin.Context) {
+	guest, ok := loadOptOutGuest(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{
+		"full_name":    guest.FullName,
+		"opted_out":    !guest.CanReceiveMessages(),
+		"opted_out_at": guest.OptedOutAt,
+		"confirm":      "send a POST to this link to stop receiving automated messages about this wedding",
+	})
+}
+
+// OptOutGuest registra que o convidado não deseja mais receber mensagens automáticas
+// Apenas POST: confirmação da página de descadastro e one-click unsubscribe (RFC 8058)
+func OptOutGuest(c *gin.Context) {
+	guest, ok := loadOptOutGuest(c)
+	if !ok {
+		return
+	}
+
+	repo := repository.NewGuestRepository(database.WithContext(c.Request.Context()))
+	if err := repo.MarkOptedOut(guest.ID, time.Now()); err != nil {
+		log.Printf("[ERROR] Failed to record opt-out for guest %d: %v", guest.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to process opt-out",
+		})
+		return
+	}
+
+	log.Printf("[INFO] Guest %d opted out of automated messages from IP: %s", guest.ID, c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "you will no longer receive automated messages about this wedding",
+	})
+}
+
+// loadOptOutGuest resolve o token do link de descadastro
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func loadOptOutGuest(c *gin.Context) (*models.Guest, bool) {
+	guestID, err := security.VerifySignedID(optOutTokenPurpose, c.Param("token"))
+	if err == nil {
+		guest, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).FindByID(guestID)
+		if err == nil {
+			return guest, true
+		}
+	}
+
+	c.JSON(http.StatusNotFound, errorResponse{
+		Error: "invalid opt-out link",
+	})
+	return nil, false
+}
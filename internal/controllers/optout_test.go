@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/database/dbtest"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+)
+
+// callOptOut abre o link de descadastro do convidado 7 com o método informado
+func callOptOut(t *testing.T, method string, handler gin.HandlerFunc) (*httptest.ResponseRecorder, *dbtest.DB) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db, fake := dbtest.Open()
+	fake.Insert("guests", dbtest.Row{"id": 7, "wedding_id": 10, "full_name": "Ana", "opted_out_at": nil})
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(method, "/", nil)
+	c.Params = gin.Params{{Key: "token", Value: security.SignID(optOutTokenPurpose, 7)}}
+	handler(c)
+	return rec, fake
+}
+
+// optOutWrites retorna as escritas que gravam opted_out_at
+func optOutWrites(fake *dbtest.DB) []dbtest.Statement {
+	var writes []dbtest.Statement
+	for _, statement := range fake.Statements() {
+		if statement.IsWrite() && strings.Contains(statement.SQL, "opted_out_at") {
+			writes = append(writes, statement)
+		}
+	}
+	return writes
+}
+
+// TestGetOptOutHasNoSideEffects garante que abrir o link (scanner de email, pré-visualização) não descadastra
+func TestGetOptOutHasNoSideEffects(t *testing.T) {
+	rec, fake := callOptOut(t, http.MethodGet, GetOptOut)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"opted_out":false`) || !strings.Contains(rec.Body.String(), `"opted_out_at":null`) {
+		t.Errorf("body = %s, want the guest still subscribed", rec.Body.String())
+	}
+	for _, statement := range fake.Statements() {
+		if statement.IsWrite() {
+			t.Errorf("GET wrote to the database: %s", statement.SQL)
+		}
+	}
+}
+
+func TestOptOutGuestRecordsOptOut(t *testing.T) {
+	rec, fake := callOptOut(t, http.MethodPost, OptOutGuest)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+	}
+	if writes := optOutWrites(fake); len(writes) != 1 {
+		t.Errorf("opt-out writes = %v, want the opt-out recorded once", writes)
+	}
+}
+
+func TestGetOptOutInvalidToken(t *testing.T) {
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Params = gin.Params{{Key: "token", Value: security.SignID(rsvpTokenPurpose, 7)}}
+	GetOptOut(c)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404 for a token of another purpose", rec.Code)
+	}
+}
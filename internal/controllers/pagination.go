@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Limites de paginação das listagens
+const (
+	defaultPerPage = 50
+	maxPerPage     = 100
+)
+
+// paginatedResponse é o envelope padronizado das listagens paginadas
+type paginatedResponse[T any] struct {
+	Items   []T   `json:"items"`
+	Total   int64 `json:"total"`
+	Page    int   `json:"page"`
+	PerPage int   `json:"per_page"`
+}
+
+// parsePagination lê ?page e ?per_page (padrão 1 e 50, máximo 100 por página)
+// Responde 400 e retorna ok=false para valores inválidos
+func parsePagination(c *gin.Context) (page, perPage int, ok bool) {
+	page, perPage = 1, defaultPerPage
+
+	if v := c.Query("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, errorResponse{Error: "invalid page parameter"})
+			return 0, 0, false
+		}
+		page = n
+	}
+
+	if v := c.Query("per_page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPerPage {
+			c.JSON(http.StatusBadRequest, errorResponse{Error: "per_page must be between 1 and 100"})
+			return 0, 0, false
+		}
+		perPage = n
+	}
+
+	return page, perPage, true
+}
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/payments"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// HandlePaymentWebhook recebe webhooks de qualquer provedor registrado
+// Segurança: Cada provedor valida a assinatura do payload em ParseWebhook
+func HandlePaymentWebhook(c *gin.Context) {
+	provider, err := payments.Get(c.Param("provider"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	event, err := provider.ParseWebhook(c.Request)
+	if err != nil {
+		// Eventos não tratados são confirmados para o PSP não reenviar
+		if errors.Is(err, payments.ErrIgnoredEvent) {
+			c.Status(http.StatusNoContent)
+			return
+		}
+
+		log.Printf("[SECURITY] Rejected %s webhook from IP: %s: %v", provider.Name(), c.ClientIP(), err)
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "invalid webhook",
+		})
+		return
+	}
+
+	log.Printf("[INFO] Received %s webhook %s (%s) for charge %s", provider.Name(), event.ID, event.Type, event.ChargeID)
+
+	var applyErr error
+	switch event.Type {
+	case payments.EventChargeRefunded:
+		applyErr = applyWebhookRefund(c.Request.Context(), provider.Name(), event)
+	case payments.EventDisputeOpened, payments.EventDisputeClosed:
+		applyErr = applyWebhookDispute(c.Request.Context(), provider.Name(), event)
+	}
+
+	if applyErr != nil {
+		log.Printf("[ERROR] Failed to apply %s webhook %s for charge %s: %v", provider.Name(), event.ID, event.ChargeID, applyErr)
+		// Erro 5xx faz o PSP reenviar o webhook
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to process webhook",
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// applyWebhookRefund marca como estornada a contribuição associada à cobrança
+// Idempotente: reenvios do mesmo webhook não alteram um registro já estornado
+func applyWebhookRefund(ctx context.Context, providerName string, event *payments.WebhookEvent) error {
+	repo := repository.NewFundraisingRepository(database.WithContext(ctx))
+
+	fundraising, err := repo.FindByProviderCharge(providerName, event.ChargeID)
+	if err != nil {
+		// Cobrança sem contribuição associada (ex: criada fora do sistema)
+		log.Printf("[WARN] No contribution found for %s charge %s", providerName, event.ChargeID)
+		return nil
+	}
+
+	if !fundraising.CountsTowardTotals() {
+		return nil
+	}
+
+	wedding, err := repository.NewWeddingRepository(database.WithContext(ctx)).FindByID(fundraising.WeddingID)
+	if err != nil {
+		return err
+	}
+
+	reason := "refunded via " + providerName
+	markFundraisingRefunded(fundraising, reason)
+	entry := models.NewFundraisingLedgerEntry(fundraising, models.LedgerContributionRefunded, reason)
+	return repo.UpdateWithLedgerAndNotification(fundraising, entry, fundraisingRefundedNotification(wedding, fundraising))
+}
+
+// applyWebhookDispute abre ou encerra a disputa da contribuição associada à cobrança
+func applyWebhookDispute(ctx context.Context, providerName string, event *payments.WebhookEvent) error {
+	repo := repository.NewFundraisingRepository(database.WithContext(ctx))
+
+	fundraising, err := repo.FindByProviderCharge(providerName, event.ChargeID)
+	if err != nil {
+		log.Printf("[WARN] No contribution found for %s charge %s", providerName, event.ChargeID)
+		return nil
+	}
+
+	if event.Type == payments.EventDisputeOpened {
+		if fundraising.Status == models.FundraisingStatusDisputed {
+			return nil
+		}
+		wedding, err := repository.NewWeddingRepository(database.WithContext(ctx)).FindByID(fundraising.WeddingID)
+		if err != nil {
+			return err
+		}
+		fundraising.OpenDispute(event.DisputeDeadline)
+		entry := models.NewFundraisingLedgerEntry(fundraising, models.LedgerContributionDisputed, "dispute opened via "+providerName)
+		return repo.UpdateWithLedgerAndNotification(fundraising, entry, fundraisingDisputedNotification(wedding, fundraising))
+	}
+
+	// Idempotente: disputa já resolvida (manualmente ou por reenvio) é ignorada
+	if fundraising.Status != models.FundraisingStatusDisputed {
+		return nil
+	}
+
+	outcome := models.DisputeOutcomeLost
+	if event.DisputeWon {
+		outcome = models.DisputeOutcomeWon
+	}
+	return resolveFundraisingDispute(repo, fundraising, outcome)
+}
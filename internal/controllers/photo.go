@@ -0,0 +1,623 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+	"github.com/matheushermes/wedding_planner_service/internal/storage"
+)
+
+const (
+	// Validade padrão e máxima do link do portal de fotos
+	defaultPhotoPortalDays = 30
+	maxPhotoPortalDays     = 180
+
+	// Proteção contra abuso do link público: limite de fotos por casamento
+	maxPhotosPerWedding = 2000
+)
+
+// photoPortalResponse representa o portal de fotos para o casal
+type photoPortalResponse struct {
+	Open               bool                   `json:"open"`
+	Path               string                 `json:"path,omitempty"` // link para os convidados (apenas com o portal aberto)
+	ExpiresAt          time.Time              `json:"expires_at"`
+	RequireApproval    bool                   `json:"require_approval"`
+	Photos             repository.PhotoCounts `json:"photos"`
+	ArchiveStatus      models.ArchiveStatus   `json:"archive_status"`
+	ArchiveRequestedAt *time.Time             `json:"archive_requested_at"`
+	ArchiveReadyAt     *time.Time             `json:"archive_ready_at"`
+	ArchivePhotos      int                    `json:"archive_photos"`
+}
+
+// guestPhotoResponse representa uma foto para o casal (moderação)
+type guestPhotoResponse struct {
+	ID           uint               `json:"id"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UploaderName string             `json:"uploader_name"`
+	ContentType  string             `json:"content_type"`
+	Size         int64              `json:"size"`
+	Status       models.PhotoStatus `json:"status"`
+	ModeratedAt  *time.Time         `json:"moderated_at"`
+}
+
+// publicPhotoResponse representa uma foto aprovada na galeria dos convidados
+// Segurança: Sem IDs internos nem status de moderação
+type publicPhotoResponse struct {
+	URL          string    `json:"url"`
+	UploaderName string    `json:"uploader_name"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// OpenPhotoPortal abre (ou regenera) o link de fotos dos convidados
+// Disponível a partir do dia do evento; regenerar invalida o link anterior
+func OpenPhotoPortal(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var portalData struct {
+		ExpiresInDays   int   `json:"expires_in_days"`
+		RequireApproval *bool `json:"require_approval"`
+	}
+
+	// Corpo opcional: sem JSON usa a validade padrão e mantém a moderação atual
+	if err := c.ShouldBindJSON(&portalData); err != nil && !errors.Is(err, io.EOF) {
+		respondBindError(c, err)
+		return
+	}
+	if portalData.ExpiresInDays == 0 {
+		portalData.ExpiresInDays = defaultPhotoPortalDays
+	}
+	if portalData.ExpiresInDays < 1 || portalData.ExpiresInDays > maxPhotoPortalDays {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: fmt.Sprintf("expires_in_days must be between 1 and %d", maxPhotoPortalDays),
+		})
+		return
+	}
+
+	now := time.Now()
+	y, m, d := wedding.EventDate.Date()
+	if now.Before(time.Date(y, m, d, 0, 0, 0, 0, wedding.EventDate.Location())) {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "photo portal opens on the event day",
+		})
+		return
+	}
+
+	repo := repository.NewPhotoRepository(database.WithContext(c.Request.Context()))
+
+	portal, err := repo.FindPortalByWeddingID(wedding.ID)
+	if err != nil {
+		portal = &models.PhotoPortal{WeddingID: wedding.ID, RequireApproval: true}
+	}
+
+	token, err := security.RandomToken(32)
+	if err != nil {
+		log.Printf("[ERROR] Failed to generate photo portal token for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to open photo portal",
+		})
+		return
+	}
+	portal.Token = &token
+	portal.ExpiresAt = now.AddDate(0, 0, portalData.ExpiresInDays)
+	if portalData.RequireApproval != nil {
+		portal.RequireApproval = *portalData.RequireApproval
+	}
+
+	if err := repo.SavePortal(portal); err != nil {
+		log.Printf("[ERROR] Failed to save photo portal for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to open photo portal",
+		})
+		return
+	}
+
+	response, ok := buildPhotoPortalResponse(c, repo, portal)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "photo portal opened successfully",
+		"portal":  response,
+	})
+}
+
+// GetPhotoPortal retorna o link, a validade e os totais do portal de fotos
+func GetPhotoPortal(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	repo := repository.NewPhotoRepository(database.WithContext(c.Request.Context()))
+
+	portal, err := repo.FindPortalByWeddingID(wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("photo portal"))
+		return
+	}
+
+	response, ok := buildPhotoPortalResponse(c, repo, portal)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ClosePhotoPortal invalida o link dos convidados (as fotos enviadas são mantidas)
+func ClosePhotoPortal(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	repo := repository.NewPhotoRepository(database.WithContext(c.Request.Context()))
+
+	portal, err := repo.FindPortalByWeddingID(wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("photo portal"))
+		return
+	}
+
+	portal.Token = nil
+	if err := repo.SavePortal(portal); err != nil {
+		log.Printf("[ERROR] Failed to close photo portal for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to close photo portal",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "photo portal closed successfully",
+	})
+}
+
+// GetGuestPhotos lista as fotos enviadas para moderação (?status=pending|approved|rejected)
+func GetGuestPhotos(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	status := models.PhotoStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "invalid status filter",
+		})
+		return
+	}
+
+	page, perPage, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	photos, total, err := repository.NewPhotoRepository(database.WithContext(c.Request.Context())).FindByWeddingID(wedding.ID, status, page, perPage)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch photos of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch photos",
+		})
+		return
+	}
+
+	items := make([]guestPhotoResponse, 0, len(photos))
+	for i := range photos {
+		items = append(items, toGuestPhotoResponse(&photos[i]))
+	}
+
+	c.JSON(http.StatusOK, paginatedResponse[guestPhotoResponse]{
+		Items:   items,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	})
+}
+
+// ModerateGuestPhoto aprova ou rejeita uma foto enviada
+func ModerateGuestPhoto(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	photo, ok := loadWeddingPhoto(c, wedding.ID)
+	if !ok {
+		return
+	}
+
+	var moderationData struct {
+		Status models.PhotoStatus `json:"status" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&moderationData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if moderationData.Status != models.PhotoStatusApproved && moderationData.Status != models.PhotoStatusRejected {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "status must be approved or rejected",
+		})
+		return
+	}
+
+	if err := repository.NewPhotoRepository(database.WithContext(c.Request.Context())).UpdateStatus(photo, moderationData.Status, time.Now()); err != nil {
+		log.Printf("[ERROR] Failed to moderate photo %d of wedding %d: %v", photo.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to update photo",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "photo updated successfully",
+		"photo":   toGuestPhotoResponse(photo),
+	})
+}
+
+// DeleteGuestPhoto remove a foto e o arquivo
+func DeleteGuestPhoto(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	photo, ok := loadWeddingPhoto(c, wedding.ID)
+	if !ok {
+		return
+	}
+
+	if err := repository.NewPhotoRepository(database.WithContext(c.Request.Context())).Delete(photo); err != nil {
+		log.Printf("[ERROR] Failed to delete photo %d of wedding %d: %v", photo.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to delete photo",
+		})
+		return
+	}
+
+	if err := storage.Remove(storage.KindGuestPhoto, photo.FileName); err != nil {
+		log.Printf("[WARN] Failed to remove photo file %s: %v", photo.FileName, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "photo deleted successfully",
+	})
+}
+
+// GetGuestPhotoFile serve o arquivo de uma foto ao casal (qualquer status)
+func GetGuestPhotoFile(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	photo, ok := loadWeddingPhoto(c, wedding.ID)
+	if !ok {
+		return
+	}
+
+	servePhotoFile(c, storage.KindGuestPhoto, photo.FileName, "private, max-age=3600")
+}
+
+// RequestPhotoArchive enfileira a geração do ZIP com as fotos aprovadas
+// O ZIP é montado em segundo plano pelo job photo_archives
+func RequestPhotoArchive(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	repo := repository.NewPhotoRepository(database.WithContext(c.Request.Context()))
+
+	portal, err := repo.FindPortalByWeddingID(wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("photo portal"))
+		return
+	}
+
+	if _, err := repo.RequestArchive(portal, time.Now()); err != nil {
+		log.Printf("[ERROR] Failed to request photo archive for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to request archive",
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":              "archive requested successfully",
+		"archive_status":       portal.ArchiveStatus,
+		"archive_requested_at": portal.ArchiveRequestedAt,
+	})
+}
+
+// DownloadPhotoArchive baixa o último ZIP gerado
+func DownloadPhotoArchive(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	portal, err := repository.NewPhotoRepository(database.WithContext(c.Request.Context())).FindPortalByWeddingID(wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("photo portal"))
+		return
+	}
+
+	// Um novo pedido pendente não impede baixar o ZIP anterior
+	if portal.ArchiveFile == "" {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "archive is not ready yet",
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="wedding-%d-photos.zip"`, wedding.ID))
+	servePhotoFile(c, storage.KindArchive, portal.ArchiveFile, "private, no-store")
+}
+
+// GetPublicPhotoPortal retorna a galeria de fotos aprovadas para os convidados
+func GetPublicPhotoPortal(c *gin.Context) {
+	portal, ok := loadOpenPhotoPortal(c)
+	if !ok {
+		return
+	}
+
+	page, perPage, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	photos, total, err := repository.NewPhotoRepository(database.WithContext(c.Request.Context())).FindByWeddingID(portal.WeddingID, models.PhotoStatusApproved, page, perPage)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch public photos of wedding %d: %v", portal.WeddingID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch photos",
+		})
+		return
+	}
+
+	basePath := publicPhotoPortalPath(*portal.Token)
+	items := make([]publicPhotoResponse, 0, len(photos))
+	for _, photo := range photos {
+		items = append(items, publicPhotoResponse{
+			URL:          basePath + "/files/" + photo.FileName,
+			UploaderName: photo.UploaderName,
+			CreatedAt:    photo.CreatedAt,
+		})
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{
+		"expires_at":       portal.ExpiresAt,
+		"require_approval": portal.RequireApproval,
+		"photos": paginatedResponse[publicPhotoResponse]{
+			Items:   items,
+			Total:   total,
+			Page:    page,
+			PerPage: perPage,
+		},
+	})
+}
+
+// UploadPublicPhoto recebe a foto de um convidado (multipart, campos "photo" e "name" opcional)
+func UploadPublicPhoto(c *gin.Context) {
+	portal, ok := loadOpenPhotoPortal(c)
+	if !ok {
+		return
+	}
+
+	fileHeader, err := c.FormFile("photo")
+	if err != nil {
+		if respondBodyTooLarge(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "photo file is required",
+		})
+		return
+	}
+
+	photo := models.GuestPhoto{
+		WeddingID:    portal.WeddingID,
+		UploaderName: c.PostForm("name"),
+		Status:       models.PhotoStatusApproved,
+	}
+	if portal.RequireApproval {
+		photo.Status = models.PhotoStatusPending
+	}
+	if err := photo.NormalizeUploader(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	repo := repository.NewPhotoRepository(database.WithContext(c.Request.Context()))
+
+	counts, err := repo.CountByWeddingID(portal.WeddingID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to count photos of wedding %d: %v", portal.WeddingID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to upload photo",
+		})
+		return
+	}
+	if counts.Total() >= maxPhotosPerWedding {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "photo limit reached for this wedding",
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "unable to read uploaded file",
+		})
+		return
+	}
+	defer file.Close()
+
+	stored, err := storage.Save(storage.KindGuestPhoto, file)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	photo.FileName = stored.Name
+	photo.ContentType = stored.ContentType
+	photo.Size = stored.Size
+
+	if err := repo.Create(&photo); err != nil {
+		log.Printf("[ERROR] Failed to save photo for wedding %d: %v", portal.WeddingID, err)
+		_ = storage.Remove(storage.KindGuestPhoto, stored.Name)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to upload photo",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":        "photo uploaded successfully",
+		"pending_review": photo.Status == models.PhotoStatusPending,
+	})
+}
+
+// GetPublicPhotoFile serve uma foto aprovada aos convidados
+func GetPublicPhotoFile(c *gin.Context) {
+	portal, ok := loadOpenPhotoPortal(c)
+	if !ok {
+		return
+	}
+
+	// Segurança: Fotos pendentes ou rejeitadas não são servidas mesmo com o nome do arquivo
+	photo, err := repository.NewPhotoRepository(database.WithContext(c.Request.Context())).FindByFileNameAndWeddingID(c.Param("name"), portal.WeddingID)
+	if err != nil || photo.Status != models.PhotoStatusApproved {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "file not found",
+		})
+		return
+	}
+
+	servePhotoFile(c, storage.KindGuestPhoto, photo.FileName, "private, max-age=3600")
+}
+
+// loadWeddingPhoto resolve o :photoId garantindo que pertence ao casamento
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func loadWeddingPhoto(c *gin.Context, weddingID uint) (*models.GuestPhoto, bool) {
+	photoID, err := parseIDParam(c, "photoId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return nil, false
+	}
+
+	photo, err := repository.NewPhotoRepository(database.WithContext(c.Request.Context())).FindByIDAndWeddingID(photoID, weddingID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("photo"))
+		return nil, false
+	}
+	return photo, true
+}
+
+// loadOpenPhotoPortal resolve o token do link público e confere a validade
+// Segurança: Token inexistente, fechado ou expirado respondem igual (404)
+func loadOpenPhotoPortal(c *gin.Context) (*models.PhotoPortal, bool) {
+	token := c.Param("token")
+	if len(token) == 64 {
+		portal, err := repository.NewPhotoRepository(database.WithContext(c.Request.Context())).FindPortalByToken(token)
+		if err == nil && portal.IsOpen(time.Now()) {
+			return portal, true
+		}
+	}
+
+	c.JSON(http.StatusNotFound, errorResponse{
+		Error: "photo portal not found or expired",
+	})
+	return nil, false
+}
+
+// servePhotoFile serve um arquivo do storage com suporte a Range/If-Modified-Since
+func servePhotoFile(c *gin.Context, kind storage.Kind, name, cacheControl string) {
+	file, err := storage.Open(kind, name)
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "file not found",
+		})
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "file not found",
+		})
+		return
+	}
+
+	c.Header("Cache-Control", cacheControl)
+	c.Header("X-Content-Type-Options", "nosniff")
+	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), file)
+}
+
+// buildPhotoPortalResponse monta a resposta do portal com os totais por status
+func buildPhotoPortalResponse(c *gin.Context, repo *repository.PhotoRepository, portal *models.PhotoPortal) (*photoPortalResponse, bool) {
+	counts, err := repo.CountByWeddingID(portal.WeddingID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to count photos of wedding %d: %v", portal.WeddingID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch photo portal",
+		})
+		return nil, false
+	}
+
+	response := &photoPortalResponse{
+		Open:               portal.IsOpen(time.Now()),
+		ExpiresAt:          portal.ExpiresAt,
+		RequireApproval:    portal.RequireApproval,
+		Photos:             counts,
+		ArchiveStatus:      portal.ArchiveStatus,
+		ArchiveRequestedAt: portal.ArchiveRequestedAt,
+		ArchiveReadyAt:     portal.ArchiveReadyAt,
+		ArchivePhotos:      portal.ArchivePhotos,
+	}
+	if response.Open {
+		response.Path = publicPhotoPortalPath(*portal.Token)
+	}
+	return response, true
+}
+
+// publicPhotoPortalPath retorna o caminho público do portal de fotos
+func publicPhotoPortalPath(token string) string {
+	return "/api/v1/public/photos/" + token
+}
+
+// toGuestPhotoResponse converte model para response
+func toGuestPhotoResponse(p *models.GuestPhoto) guestPhotoResponse {
+	return guestPhotoResponse{
+		ID:           p.ID,
+		CreatedAt:    p.CreatedAt,
+		UploaderName: p.UploaderName,
+		ContentType:  p.ContentType,
+		Size:         p.Size,
+		Status:       p.Status,
+		ModeratedAt:  p.ModeratedAt,
+	}
+}
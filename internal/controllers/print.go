@@ -0,0 +1,344 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/printing"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// Limite de peças por lote (pedidos maiores devem ser divididos)
+const maxPrintPieces = 1000
+
+// printOrderResponse representa a resposta padronizada de um lote de impressão
+type printOrderResponse struct {
+	ID              uint                    `json:"id"`
+	Provider        string                  `json:"provider"`
+	FrontTemplateID string                  `json:"front_template_id"`
+	BackTemplateID  string                  `json:"back_template_id"`
+	Status          models.PrintOrderStatus `json:"status"`
+	Pieces          int                     `json:"pieces"`
+	PiecesSubmitted int                     `json:"pieces_submitted"`
+	PiecesDelivered int                     `json:"pieces_delivered"`
+	PiecesFailed    int                     `json:"pieces_failed"`
+	ExpenseID       *uint                   `json:"expense_id"`
+	SubmittedAt     *time.Time              `json:"submitted_at"`
+	CompletedAt     *time.Time              `json:"completed_at"`
+	CreatedAt       time.Time               `json:"created_at"`
+}
+
+// printItemResponse representa uma peça do lote
+type printItemResponse struct {
+	ID              uint                   `json:"id"`
+	GuestID         uint                   `json:"guest_id"`
+	RecipientName   string                 `json:"recipient_name"`
+	Guests          int                    `json:"guests"`
+	Address         models.PostalAddress   `json:"address"`
+	Status          models.PrintItemStatus `json:"status"`
+	ProviderPieceID string                 `json:"provider_piece_id"`
+	Error           string                 `json:"error"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+// GetPrintTemplates lista os templates de convite disponíveis no provedor de impressão
+func GetPrintTemplates(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	provider, ok := printProvider(c)
+	if !ok {
+		return
+	}
+
+	templates, err := provider.Templates(c.Request.Context())
+	if err != nil {
+		log.Printf("[ERROR] Failed to list print templates for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusBadGateway, errorResponse{
+			Error: "unable to fetch templates from the print provider",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"provider":  provider.Name(),
+		"templates": templates,
+	})
+}
+
+// CreatePrintOrder cria um lote de convites impressos com os endereços dos convidados
+// As peças são enviadas ao provedor em segundo plano; o lote é acompanhado por GET /print/orders/:orderId
+func CreatePrintOrder(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var createData struct {
+		FrontTemplateID string              `json:"front_template_id" binding:"required,max=100"`
+		BackTemplateID  string              `json:"back_template_id" binding:"required,max=100"`
+		Status          models.InviteStatus `json:"status"`    // padrão: todos menos recusados
+		Household       bool                `json:"household"` // uma peça por família no mesmo endereço
+		GuestIDs        []uint              `json:"guest_ids"` // opcional: apenas estes convidados
+		Event           models.WeddingEvent `json:"event"`     // opcional: convite só da cerimônia ou da recepção
+	}
+
+	if err := c.ShouldBindJSON(&createData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if createData.Status != "" && !createData.Status.IsValid() {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "invalid status filter",
+		})
+		return
+	}
+	if createData.Event != "" && !createData.Event.IsValid() {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "invalid event filter",
+		})
+		return
+	}
+
+	provider, ok := printProvider(c)
+	if !ok {
+		return
+	}
+
+	// Segurança: o template precisa existir no catálogo da conta antes de gerar cobranças no provedor
+	templates, err := provider.Templates(c.Request.Context())
+	if err != nil {
+		log.Printf("[ERROR] Failed to list print templates for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusBadGateway, errorResponse{
+			Error: "unable to fetch templates from the print provider",
+		})
+		return
+	}
+	for _, id := range []string{createData.FrontTemplateID, createData.BackTemplateID} {
+		if !slices.ContainsFunc(templates, func(t printing.Template) bool { return t.ID == id }) {
+			c.JSON(http.StatusBadRequest, errorResponse{
+				Error: "template not found at the print provider",
+			})
+			return
+		}
+	}
+
+	ctxDB := database.WithContext(c.Request.Context())
+
+	guests, err := repository.NewGuestRepository(ctxDB).FindByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch guests of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to create print order",
+		})
+		return
+	}
+	if len(createData.GuestIDs) > 0 {
+		guests = slices.DeleteFunc(guests, func(g models.Guest) bool {
+			return !slices.Contains(createData.GuestIDs, g.ID)
+		})
+	}
+	if createData.Event != "" {
+		guests = slices.DeleteFunc(guests, func(g models.Guest) bool {
+			return !g.Events.Includes(createData.Event)
+		})
+	}
+
+	groupNames := map[uint]string{}
+	if createData.Household {
+		groups, err := repository.NewGuestGroupRepository(ctxDB).FindByWeddingID(wedding.ID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to fetch guest groups of wedding %d: %v", wedding.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to create print order",
+			})
+			return
+		}
+		for _, g := range groups {
+			groupNames[g.ID] = g.Name
+		}
+	}
+
+	labels, missing := buildAddressLabels(guests, createData.Status, createData.Household, groupNames)
+	if len(labels) == 0 {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "no guests with a complete address to print",
+		})
+		return
+	}
+	if len(labels) > maxPrintPieces {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "print orders are limited to 1000 pieces",
+		})
+		return
+	}
+
+	order := models.PrintOrder{
+		WeddingID:       wedding.ID,
+		Provider:        provider.Name(),
+		FrontTemplateID: createData.FrontTemplateID,
+		BackTemplateID:  createData.BackTemplateID,
+		Status:          models.PrintOrderPending,
+	}
+	items := make([]models.PrintOrderItem, len(labels))
+	for i, label := range labels {
+		items[i] = models.PrintOrderItem{
+			GuestID:       label.GuestID,
+			RecipientName: label.Name,
+			Guests:        label.Guests,
+			Address:       label.Address,
+			Status:        models.PrintItemPending,
+		}
+	}
+
+	if err := repository.NewPrintRepository(ctxDB).CreateOrder(&order, items); err != nil {
+		log.Printf("[ERROR] Failed to create print order for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to create print order",
+		})
+		return
+	}
+
+	log.Printf("[INFO] Print order %d created for wedding %d: %d pieces via %s", order.ID, wedding.ID, order.Pieces, order.Provider)
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":                "print order created successfully",
+		"order":                  toPrintOrderResponse(&order),
+		"guests_without_address": missing,
+	})
+}
+
+// GetPrintOrders lista os lotes de impressão do casamento com paginação
+func GetPrintOrders(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	page, perPage, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	orders, total, err := repository.NewPrintRepository(database.WithContext(c.Request.Context())).FindOrdersByWeddingID(wedding.ID, page, perPage)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch print orders of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch print orders",
+		})
+		return
+	}
+
+	items := make([]printOrderResponse, 0, len(orders))
+	for i := range orders {
+		items = append(items, toPrintOrderResponse(&orders[i]))
+	}
+
+	c.JSON(http.StatusOK, paginatedResponse[printOrderResponse]{
+		Items:   items,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	})
+}
+
+// GetPrintOrder retorna um lote com o status de cada peça
+func GetPrintOrder(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	orderID, err := parseIDParam(c, "orderId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	repo := repository.NewPrintRepository(database.WithContext(c.Request.Context()))
+	order, err := repo.FindOrderByIDAndWeddingID(orderID, wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("print order"))
+		return
+	}
+
+	items, err := repo.FindItemsByOrderID(order.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch items of print order %d: %v", order.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch print order",
+		})
+		return
+	}
+
+	response := make([]printItemResponse, len(items))
+	for i := range items {
+		response[i] = toPrintItemResponse(&items[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"order": toPrintOrderResponse(order),
+		"items": response,
+	})
+}
+
+// printProvider resolve o provedor de impressão configurado
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func printProvider(c *gin.Context) (printing.Provider, bool) {
+	provider, err := printing.Default()
+	if err != nil {
+		if !errors.Is(err, printing.ErrNoProviderConfigured) {
+			log.Printf("[ERROR] Print provider unavailable: %v", err)
+		}
+		c.JSON(http.StatusServiceUnavailable, errorResponse{
+			Error: "invitation printing is not available",
+		})
+		return nil, false
+	}
+	return provider, true
+}
+
+// toPrintOrderResponse converte model para response
+func toPrintOrderResponse(o *models.PrintOrder) printOrderResponse {
+	return printOrderResponse{
+		ID:              o.ID,
+		Provider:        o.Provider,
+		FrontTemplateID: o.FrontTemplateID,
+		BackTemplateID:  o.BackTemplateID,
+		Status:          o.Status,
+		Pieces:          o.Pieces,
+		PiecesSubmitted: o.PiecesSubmitted,
+		PiecesDelivered: o.PiecesDelivered,
+		PiecesFailed:    o.PiecesFailed,
+		ExpenseID:       o.ExpenseID,
+		SubmittedAt:     o.SubmittedAt,
+		CompletedAt:     o.CompletedAt,
+		CreatedAt:       o.CreatedAt,
+	}
+}
+
+// toPrintItemResponse converte model para response
+func toPrintItemResponse(i *models.PrintOrderItem) printItemResponse {
+	return printItemResponse{
+		ID:              i.ID,
+		GuestID:         i.GuestID,
+		RecipientName:   i.RecipientName,
+		Guests:          i.Guests,
+		Address:         i.Address,
+		Status:          i.Status,
+		ProviderPieceID: i.ProviderPieceID,
+		Error:           i.Error,
+		UpdatedAt:       i.UpdatedAt,
+	}
+}
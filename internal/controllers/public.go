@@ -0,0 +1,339 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+)
+
+// Tempo máximo da consulta DNS na verificação do domínio customizado
+const customDomainLookupTimeout = 5 * time.Second
+
+// lookupTXT consulta os registros TXT (substituível nos testes)
+var lookupTXT = net.DefaultResolver.LookupTXT
+
+// publicAddressResponse representa os endereços públicos de um casamento
+type publicAddressResponse struct {
+	Slug                   *string                     `json:"slug"`
+	CustomDomain           *string                     `json:"custom_domain"`
+	CustomDomainVerifiedAt *time.Time                  `json:"custom_domain_verified_at"`
+	PendingCustomDomain    *string                     `json:"pending_custom_domain"`
+	Verification           *domainVerificationResponse `json:"verification,omitempty"`
+	PublicPath             string                      `json:"public_path"`
+}
+
+// domainVerificationResponse é o registro DNS que o casal publica para comprovar a posse do domínio
+type domainVerificationResponse struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// UpdatePublicAddress define o slug e pede o domínio customizado da página pública
+// O domínio só passa a responder depois de POST /public-address/verify (registro TXT) e exige o plano Pro
+func UpdatePublicAddress(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var updateData struct {
+		Slug         *string `json:"slug"`
+		CustomDomain *string `json:"custom_domain"`
+	}
+
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	// String vazia remove o endereço (volta para NULL)
+	if updateData.Slug != nil {
+		wedding.Slug = nilIfBlank(updateData.Slug)
+	}
+	previousPending := wedding.PendingCustomDomain
+	requestedDomain := false
+	if updateData.CustomDomain != nil {
+		if domain := nilIfBlank(updateData.CustomDomain); domain != nil {
+			wedding.PendingCustomDomain = domain
+			requestedDomain = true
+		} else {
+			// Remove o domínio ativo e o pedido pendente
+			wedding.CustomDomain = nil
+			wedding.CustomDomainVerifiedAt = nil
+			wedding.PendingCustomDomain = nil
+			wedding.CustomDomainToken = nil
+		}
+	}
+
+	if err := wedding.ValidateSlug(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := wedding.ValidateCustomDomain(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if requestedDomain && !requireProAccount(c) {
+		return
+	}
+
+	repo := repository.NewWeddingRepository(database.WithContext(c.Request.Context()))
+
+	if wedding.Slug != nil {
+		taken, err := repo.IsSlugTaken(*wedding.Slug, wedding.ID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to check slug for wedding %d: %v", wedding.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to update public address",
+			})
+			return
+		}
+		if taken {
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: "slug is already in use",
+			})
+			return
+		}
+	}
+
+	if requestedDomain {
+		domain := *wedding.PendingCustomDomain
+		// Segurança: Pedidos pendentes não reservam o domínio; apenas domínios verificados de outro casamento conflitam
+		taken, err := repo.IsCustomDomainTaken(domain, wedding.ID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to check custom domain for wedding %d: %v", wedding.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to update public address",
+			})
+			return
+		}
+		if taken {
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: "custom domain is already in use",
+			})
+			return
+		}
+
+		switch {
+		case wedding.CustomDomain != nil && *wedding.CustomDomain == domain:
+			// Domínio já verificado: nada pendente
+			wedding.PendingCustomDomain = nil
+			wedding.CustomDomainToken = nil
+		case previousPending == nil || *previousPending != domain || wedding.CustomDomainToken == nil:
+			// Novo pedido: novo token (o registro publicado para outro domínio não vale)
+			token, err := security.RandomToken(16)
+			if err != nil {
+				log.Printf("[ERROR] Failed to generate custom domain token for wedding %d: %v", wedding.ID, err)
+				c.JSON(http.StatusInternalServerError, errorResponse{
+					Error: "unable to update public address",
+				})
+				return
+			}
+			wedding.CustomDomainToken = &token
+		}
+	}
+
+	if err := repo.Update(wedding); err != nil {
+		// Tratamento de erro de duplicação (race condition entre check e update)
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: "public address is already in use",
+			})
+			return
+		}
+
+		log.Printf("[ERROR] Failed to update public address for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to update public address",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "public address updated successfully",
+		"address": toPublicAddressResponse(wedding),
+	})
+}
+
+// VerifyCustomDomain confere o registro TXT do domínio pendente e o ativa na página pública
+func VerifyCustomDomain(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	name, value, pending := wedding.CustomDomainVerificationRecord()
+	if !pending {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "no custom domain pending verification",
+		})
+		return
+	}
+
+	if !requireProAccount(c) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), customDomainLookupTimeout)
+	defer cancel()
+	records, err := lookupTXT(ctx, name)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			log.Printf("[WARN] Failed to look up TXT record %s for wedding %d: %v", name, wedding.ID, err)
+			c.JSON(http.StatusBadGateway, errorResponse{
+				Error: "unable to verify custom domain, try again later",
+			})
+			return
+		}
+	}
+	if !slices.Contains(records, value) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":        "verification record not found",
+			"verification": toPublicAddressResponse(wedding).Verification,
+		})
+		return
+	}
+
+	repo := repository.NewWeddingRepository(database.WithContext(c.Request.Context()))
+
+	taken, err := repo.IsCustomDomainTaken(*wedding.PendingCustomDomain, wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to check custom domain for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to verify custom domain",
+		})
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "custom domain is already in use",
+		})
+		return
+	}
+
+	if err := repo.VerifyCustomDomain(wedding, time.Now()); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrCustomDomainChanged):
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: err.Error(),
+			})
+		case strings.Contains(err.Error(), "Duplicate entry"):
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: "custom domain is already in use",
+			})
+		default:
+			log.Printf("[ERROR] Failed to activate custom domain for wedding %d: %v", wedding.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to verify custom domain",
+			})
+		}
+		return
+	}
+
+	log.Printf("[INFO] Custom domain %s verified for wedding %d", *wedding.CustomDomain, wedding.ID)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "custom domain verified successfully",
+		"address": toPublicAddressResponse(wedding),
+	})
+}
+
+// requireProAccount responde 402 quando o usuário autenticado não tem o plano Pro ativo
+func requireProAccount(c *gin.Context) bool {
+	user, err := repository.NewUserRepository(database.WithContext(c.Request.Context())).FindByID(c.GetUint("user_id"))
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch user %d: %v", c.GetUint("user_id"), err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to check account plan",
+		})
+		return false
+	}
+	if !user.IsPro(time.Now()) {
+		c.JSON(http.StatusPaymentRequired, errorResponse{
+			Error: "custom domains require a pro account",
+		})
+		return false
+	}
+	return true
+}
+
+// GetPublicPage retorna a página pública publicada (resolvida pelo PublicWeddingMiddleware)
+func GetPublicPage(c *gin.Context) {
+	value, exists := c.Get("public_wedding")
+	if !exists {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "page not found",
+		})
+		return
+	}
+	wedding := value.(*models.Wedding)
+
+	theme, err := repository.NewThemeRepository(database.WithContext(c.Request.Context())).FindByWeddingID(wedding.ID)
+	if err != nil || !theme.IsPublished() {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "page not found",
+		})
+		return
+	}
+
+	var page publicPageResponse
+	if err := json.Unmarshal([]byte(theme.PublishedSnapshot), &page); err != nil {
+		log.Printf("[ERROR] Corrupted public page snapshot for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to load page",
+		})
+		return
+	}
+
+	// Contagem regressiva é sempre recalculada (o snapshot envelhece)
+	page.DaysRemaining = wedding.DaysRemaining()
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{
+		"page": page,
+	})
+}
+
+// toPublicAddressResponse converte model para response
+func toPublicAddressResponse(w *models.Wedding) publicAddressResponse {
+	response := publicAddressResponse{
+		Slug:                   w.Slug,
+		CustomDomain:           w.CustomDomain,
+		CustomDomainVerifiedAt: w.CustomDomainVerifiedAt,
+		PendingCustomDomain:    w.PendingCustomDomain,
+	}
+	if name, value, ok := w.CustomDomainVerificationRecord(); ok {
+		response.Verification = &domainVerificationResponse{Type: "TXT", Name: name, Value: value}
+	}
+	if w.Slug != nil {
+		response.PublicPath = "/w/" + *w.Slug
+	}
+	return response
+}
+
+// nilIfBlank converte strings vazias em nil
+func nilIfBlank(s *string) *string {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil
+	}
+	return s
+}
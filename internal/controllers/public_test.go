@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/database/dbtest"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+)
+
+const (
+	proUserID  uint = 1
+	freeUserID uint = 2
+)
+
+// withDomainTestDB liga o banco em memória com um usuário Pro e um usuário no plano gratuito
+func withDomainTestDB(t *testing.T) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db, fake := dbtest.Open()
+	fake.Insert("users",
+		dbtest.Row{"id": proUserID, "name": "Pro", "email": "pro@example.com", "pro_until": time.Now().AddDate(0, 1, 0)},
+		dbtest.Row{"id": freeUserID, "name": "Free", "email": "free@example.com", "pro_until": time.Now().AddDate(0, -1, 0)},
+	)
+
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+}
+
+// withTXTRecords substitui a consulta DNS pelos registros informados
+func withTXTRecords(t *testing.T, records []string, err error) {
+	t.Helper()
+	previous := lookupTXT
+	lookupTXT = func(context.Context, string) ([]string, error) { return records, err }
+	t.Cleanup(func() { lookupTXT = previous })
+}
+
+// callWeddingHandler executa o handler como uma rota aninhada (casamento já carregado pelo middleware)
+// params preenche os demais parâmetros da rota (ex: fundraisingId)
+func callWeddingHandler(handler gin.HandlerFunc, userID uint, wedding *models.Wedding, body string, params ...gin.Param) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	c.Set("user_id", userID)
+	c.Set("wedding", wedding)
+	handler(c)
+	return rec
+}
+
+func pendingWedding(userID uint) *models.Wedding {
+	domain, token := "casamento.example.com", "abc123"
+	return &models.Wedding{ID: 10, UserID: userID, PendingCustomDomain: &domain, CustomDomainToken: &token}
+}
+
+func TestUpdatePublicAddressCustomDomain(t *testing.T) {
+	withDomainTestDB(t)
+
+	t.Run("free account", func(t *testing.T) {
+		rec := callWeddingHandler(UpdatePublicAddress, freeUserID, &models.Wedding{ID: 10, UserID: freeUserID}, `{"custom_domain":"casamento.example.com"}`)
+		if rec.Code != http.StatusPaymentRequired {
+			t.Fatalf("status = %d, want 402 (body %s)", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("pro account gets a pending domain", func(t *testing.T) {
+		wedding := &models.Wedding{ID: 10, UserID: proUserID}
+		rec := callWeddingHandler(UpdatePublicAddress, proUserID, wedding, `{"custom_domain":"Casamento.Example.com."}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+		}
+
+		var body struct {
+			Address publicAddressResponse `json:"address"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatal(err)
+		}
+		if body.Address.CustomDomain != nil {
+			t.Errorf("custom domain active before verification: %s", *body.Address.CustomDomain)
+		}
+		if body.Address.PendingCustomDomain == nil || *body.Address.PendingCustomDomain != "casamento.example.com" {
+			t.Errorf("pending domain = %v, want casamento.example.com", body.Address.PendingCustomDomain)
+		}
+		v := body.Address.Verification
+		if v == nil || v.Type != "TXT" || v.Name != "_wedding-verify.casamento.example.com" || !strings.HasPrefix(v.Value, "wedding-verify=") {
+			t.Errorf("verification record = %+v", v)
+		}
+	})
+
+	t.Run("removing the domain clears the pending request", func(t *testing.T) {
+		wedding := pendingWedding(proUserID)
+		rec := callWeddingHandler(UpdatePublicAddress, proUserID, wedding, `{"custom_domain":""}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+		}
+		if wedding.PendingCustomDomain != nil || wedding.CustomDomainToken != nil || wedding.CustomDomain != nil {
+			t.Errorf("domain fields not cleared: %+v", wedding)
+		}
+	})
+}
+
+func TestVerifyCustomDomain(t *testing.T) {
+	withDomainTestDB(t)
+
+	tests := []struct {
+		name    string
+		userID  uint
+		wedding *models.Wedding
+		records []string
+		dnsErr  error
+		status  int
+	}{
+		{"nothing pending", proUserID, &models.Wedding{ID: 10, UserID: proUserID}, nil, nil, http.StatusBadRequest},
+		{"free account", freeUserID, pendingWedding(freeUserID), []string{"wedding-verify=abc123"}, nil, http.StatusPaymentRequired},
+		{"record missing", proUserID, pendingWedding(proUserID), nil, &net.DNSError{Err: "no such host", IsNotFound: true}, http.StatusUnprocessableEntity},
+		{"record of another claim", proUserID, pendingWedding(proUserID), []string{"wedding-verify=other"}, nil, http.StatusUnprocessableEntity},
+		{"dns failure", proUserID, pendingWedding(proUserID), nil, errors.New("i/o timeout"), http.StatusBadGateway},
+		{"verified", proUserID, pendingWedding(proUserID), []string{"v=spf1 -all", "wedding-verify=abc123"}, nil, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTXTRecords(t, tt.records, tt.dnsErr)
+
+			rec := callWeddingHandler(VerifyCustomDomain, tt.userID, tt.wedding, "")
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.status, rec.Body.String())
+			}
+
+			active := tt.wedding.CustomDomain != nil
+			if active != (tt.status == http.StatusOK) {
+				t.Errorf("custom domain active = %v after status %d", active, rec.Code)
+			}
+			if active && (tt.wedding.CustomDomainVerifiedAt == nil || tt.wedding.PendingCustomDomain != nil) {
+				t.Errorf("verified wedding = %+v, want verified_at set and nothing pending", tt.wedding)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// Quantidade de indicadores no relatório administrativo
+const topReferrersLimit = 20
+
+// referredSignupResponse representa uma indicação vista por quem indicou
+// LGPD: Apenas o primeiro nome do indicado é exposto
+type referredSignupResponse struct {
+	ID          uint                  `json:"id"`
+	FirstName   string                `json:"first_name"`
+	Status      models.ReferralStatus `json:"status"`
+	CreatedAt   time.Time             `json:"created_at"`
+	ConvertedAt *time.Time            `json:"converted_at"`
+	RewardedAt  *time.Time            `json:"rewarded_at"`
+}
+
+// referralStatusResponse representa o código e o andamento das indicações do usuário
+type referralStatusResponse struct {
+	Code       string                   `json:"code"`
+	RewardDays int                      `json:"reward_days"`
+	ProUntil   *time.Time               `json:"pro_until"`
+	Signups    int                      `json:"signups"`
+	Converted  int                      `json:"converted"`
+	Rewarded   int                      `json:"rewarded"`
+	Referrals  []referredSignupResponse `json:"referrals"`
+}
+
+// topReferrerResponse representa um indicador no relatório administrativo
+type topReferrerResponse struct {
+	UserID    uint   `json:"user_id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Signups   int64  `json:"signups"`
+	Converted int64  `json:"converted"`
+	Rewarded  int64  `json:"rewarded"`
+}
+
+// referralReportResponse representa o relatório de conversão do programa de indicação
+type referralReportResponse struct {
+	Signups        int64                 `json:"signups"`
+	Converted      int64                 `json:"converted"`
+	Rewarded       int64                 `json:"rewarded"`
+	ConversionRate float64               `json:"conversion_rate"` // convertidas / cadastros
+	TopReferrers   []topReferrerResponse `json:"top_referrers"`
+}
+
+// GetMyReferral retorna o código de indicação do usuário (gerado no primeiro acesso) e suas indicações
+func GetMyReferral(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, errorResponse{
+			Error: "authentication required",
+		})
+		return
+	}
+
+	db := database.WithContext(c.Request.Context())
+
+	user, err := repository.NewUserRepository(db).FindByID(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "user not found",
+		})
+		return
+	}
+
+	repo := repository.NewReferralRepository(db)
+
+	code, err := repo.EnsureCode(user)
+	if err != nil {
+		log.Printf("[ERROR] Failed to generate referral code for user %d: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch referral status",
+		})
+		return
+	}
+
+	signups, err := repo.FindByReferrerID(user.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch referrals of user %d: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch referral status",
+		})
+		return
+	}
+
+	response := referralStatusResponse{
+		Code:       code,
+		RewardDays: configs.REFERRAL_REWARD_DAYS,
+		ProUntil:   user.ProUntil,
+		Signups:    len(signups),
+		Referrals:  make([]referredSignupResponse, len(signups)),
+	}
+	for i, s := range signups {
+		firstName, _, _ := strings.Cut(s.Name, " ")
+		response.Referrals[i] = referredSignupResponse{
+			ID:          s.ID,
+			FirstName:   firstName,
+			Status:      s.Status,
+			CreatedAt:   s.CreatedAt,
+			ConvertedAt: s.ConvertedAt,
+			RewardedAt:  s.RewardedAt,
+		}
+		if s.Status != models.ReferralStatusSignedUp {
+			response.Converted++
+		}
+		if s.Status == models.ReferralStatusRewarded {
+			response.Rewarded++
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetReferralReport resume a conversão do programa de indicação (admin)
+// ?since=YYYY-MM-DD restringe às indicações a partir da data
+func GetReferralReport(c *gin.Context) {
+	var since *time.Time
+	if v := c.Query("since"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse{Error: "invalid since parameter, expected YYYY-MM-DD"})
+			return
+		}
+		since = &t
+	}
+
+	repo := repository.NewReferralRepository(database.WithContext(c.Request.Context()))
+
+	totals, err := repo.Totals(since)
+	if err != nil {
+		log.Printf("[ERROR] Failed to aggregate referrals: %v", err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "unable to build referral report"})
+		return
+	}
+
+	top, err := repo.TopReferrers(since, topReferrersLimit)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch top referrers: %v", err)
+		c.JSON(http.StatusInternalServerError, errorResponse{Error: "unable to build referral report"})
+		return
+	}
+
+	response := referralReportResponse{
+		Signups:      totals.SignedUp,
+		Converted:    totals.Converted,
+		Rewarded:     totals.Rewarded,
+		TopReferrers: make([]topReferrerResponse, len(top)),
+	}
+	if totals.SignedUp > 0 {
+		response.ConversionRate = float64(totals.Converted) / float64(totals.SignedUp)
+	}
+	for i, t := range top {
+		response.TopReferrers[i] = topReferrerResponse{
+			UserID:    t.UserID,
+			Name:      t.Name,
+			Email:     t.Email,
+			Signups:   t.Signups,
+			Converted: t.Converted,
+			Rewarded:  t.Rewarded,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// convertReferral converte a indicação pendente do usuário após o primeiro casamento
+// Falhas não interrompem o request: a conversão é repetida no próximo casamento criado
+func convertReferral(c *gin.Context, userID uint) {
+	referral, err := repository.NewReferralRepository(database.WithContext(c.Request.Context())).
+		ConvertReferral(userID, configs.REFERRAL_REWARD_DAYS, configs.REFERRAL_MAX_REWARDS, time.Now())
+	if err != nil {
+		log.Printf("[WARN] Failed to convert referral of user %d: %v", userID, err)
+		return
+	}
+	if referral != nil {
+		log.Printf("[INFO] Referral %d converted (user %d referred by %d, status %s)", referral.ID, userID, referral.UserID, referral.Status)
+	}
+}
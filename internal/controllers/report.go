@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/asyncjobs"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/reports"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// GetFullReportPDF gera o relatório completo do casamento em PDF
+// O PDF é gerado em segundo plano: responde 202 e o download fica no job (GET /jobs/:id)
+func GetFullReportPDF(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	enqueueJob(c, wedding, models.AsyncJobFullReport, struct{}{})
+}
+
+// runFullReport monta o PDF do relatório completo de um job
+func runFullReport(ctx context.Context, job *models.AsyncJob, progress func(int)) (*asyncjobs.Output, error) {
+	wedding, err := loadJobWedding(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := repository.NewUserRepository(database.WithContext(ctx)).FindByID(wedding.UserID)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to generate report", err)
+	}
+
+	guests, err := repository.NewGuestRepository(database.WithContext(ctx)).FindByWeddingID(wedding.ID)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to generate report", err)
+	}
+
+	info, err := repository.NewEventInfoRepository(database.WithContext(ctx)).FindOrDefault(wedding.ID)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to generate report", err)
+	}
+
+	budgetRepo := repository.NewBudgetRepository(database.WithContext(ctx))
+
+	// Orçamento é opcional: relatório é gerado mesmo sem ele
+	var budget *models.Budget
+	if b, err := budgetRepo.FindByWeddingID(wedding.ID); err == nil {
+		budget = b
+	}
+
+	totals, err := budgetRepo.ExpenseTotalsByCategory(wedding.ID)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to generate report", err)
+	}
+	progress(40)
+
+	expenses := make([]reports.ExpenseLine, len(totals))
+	for i, t := range totals {
+		expenses[i] = reports.ExpenseLine{
+			Category: t.Category,
+			Status:   t.Status,
+			Total:    t.Total,
+		}
+	}
+
+	pdf, err := reports.BuildFullReport(reports.FullReportData{
+		CoupleNames:     []string{user.Name, user.PartnerName},
+		Wedding:         wedding,
+		CountdownStatus: countdownStatus(wedding.DaysRemaining()),
+		Guests:          guests,
+		Timeline:        info.Highlights,
+		Budget:          budget,
+		Expenses:        expenses,
+		GeneratedAt:     time.Now(),
+	})
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to generate report", err)
+	}
+
+	name, err := writeExportFile(".pdf", func(w io.Writer) error {
+		_, err := w.Write(pdf)
+		return err
+	})
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to generate report", err)
+	}
+
+	return &asyncjobs.Output{
+		Result:       gin.H{"guests": len(guests)},
+		FileName:     name,
+		DownloadName: fmt.Sprintf("wedding-%d-report.pdf", wedding.ID),
+		ContentType:  "application/pdf",
+	}, nil
+}
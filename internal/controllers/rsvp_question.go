@@ -0,0 +1,465 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// Limite de perguntas personalizadas por casamento
+const maxRSVPQuestions = 30
+
+// rsvpQuestionResponse representa a resposta padronizada de pergunta de RSVP
+type rsvpQuestionResponse struct {
+	ID        uint                    `json:"id"`
+	WeddingID uint                    `json:"wedding_id"`
+	Prompt    string                  `json:"prompt"`
+	Type      models.RSVPQuestionType `json:"type"`
+	Options   []string                `json:"options"`
+	Position  int                     `json:"position"`
+	Event     models.WeddingEvent     `json:"event"`
+	CreatedAt time.Time               `json:"created_at"`
+	UpdatedAt time.Time               `json:"updated_at"`
+}
+
+// rsvpAnswerResponse representa a resposta de um convidado a uma pergunta
+type rsvpAnswerResponse struct {
+	QuestionID uint      `json:"question_id"`
+	Answer     string    `json:"answer"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// rsvpGuestAnswer representa a resposta de um convidado no relatório
+type rsvpGuestAnswer struct {
+	GuestID  uint   `json:"guest_id"`
+	FullName string `json:"full_name"`
+	Answer   string `json:"answer"`
+}
+
+// rsvpQuestionReport resume as respostas de uma pergunta
+type rsvpQuestionReport struct {
+	Question     rsvpQuestionResponse `json:"question"`
+	TotalAnswers int                  `json:"total_answers"`
+	Counts       map[string]int       `json:"counts,omitempty"` // por opção (single_choice e yes_no)
+	Answers      []rsvpGuestAnswer    `json:"answers"`
+}
+
+// CreateRSVPQuestion cadastra uma pergunta personalizada no RSVP do casamento
+func CreateRSVPQuestion(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var createData struct {
+		Prompt   string                  `json:"prompt" binding:"required"`
+		Type     models.RSVPQuestionType `json:"type" binding:"required"`
+		Options  []string                `json:"options"`
+		Position int                     `json:"position"`
+		Event    models.WeddingEvent     `json:"event"`
+	}
+
+	if err := c.ShouldBindJSON(&createData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	question := models.RSVPQuestion{
+		WeddingID: wedding.ID,
+		Prompt:    createData.Prompt,
+		Type:      createData.Type,
+		Options:   createData.Options,
+		Position:  createData.Position,
+		Event:     createData.Event,
+	}
+
+	if err := question.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	repo := repository.NewRSVPQuestionRepository(database.WithContext(c.Request.Context()))
+
+	count, err := repo.CountByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to count rsvp questions of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to create rsvp question",
+		})
+		return
+	}
+	if count >= maxRSVPQuestions {
+		c.JSON(http.StatusConflict, errorResponse{
+			Error: "rsvp question limit reached",
+		})
+		return
+	}
+
+	if err := repo.Create(&question); err != nil {
+		log.Printf("[ERROR] Failed to create rsvp question for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to create rsvp question",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "rsvp question created successfully",
+		"question": toRSVPQuestionResponse(&question),
+	})
+}
+
+// GetRSVPQuestions lista as perguntas personalizadas do casamento na ordem de exibição
+func GetRSVPQuestions(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	questions, err := repository.NewRSVPQuestionRepository(database.WithContext(c.Request.Context())).FindByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch rsvp questions of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch rsvp questions",
+		})
+		return
+	}
+
+	response := make([]rsvpQuestionResponse, len(questions))
+	for i := range questions {
+		response[i] = toRSVPQuestionResponse(&questions[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"questions": response,
+		"count":     len(response),
+	})
+}
+
+// UpdateRSVPQuestion atualiza uma pergunta
+// Respostas incompatíveis com o novo tipo ou com as novas opções são removidas
+func UpdateRSVPQuestion(c *gin.Context) {
+	wedding, question, ok := loadWeddingRSVPQuestion(c)
+	if !ok {
+		return
+	}
+
+	var updateData struct {
+		Prompt   *string                  `json:"prompt"`
+		Type     *models.RSVPQuestionType `json:"type"`
+		Options  *[]string                `json:"options"`
+		Position *int                     `json:"position"`
+		Event    *models.WeddingEvent     `json:"event"`
+	}
+
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if updateData.Prompt != nil {
+		question.Prompt = *updateData.Prompt
+	}
+	if updateData.Type != nil {
+		question.Type = *updateData.Type
+	}
+	if updateData.Options != nil {
+		question.Options = *updateData.Options
+	}
+	if updateData.Position != nil {
+		question.Position = *updateData.Position
+	}
+	if updateData.Event != nil {
+		question.Event = *updateData.Event
+	}
+
+	if err := question.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repository.NewRSVPQuestionRepository(database.WithContext(c.Request.Context())).Update(question); err != nil {
+		log.Printf("[ERROR] Failed to update rsvp question %d of wedding %d: %v", question.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to update rsvp question",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "rsvp question updated successfully",
+		"question": toRSVPQuestionResponse(question),
+	})
+}
+
+// DeleteRSVPQuestion remove uma pergunta e suas respostas
+func DeleteRSVPQuestion(c *gin.Context) {
+	wedding, question, ok := loadWeddingRSVPQuestion(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.NewRSVPQuestionRepository(database.WithContext(c.Request.Context())).Delete(question); err != nil {
+		log.Printf("[ERROR] Failed to delete rsvp question %d of wedding %d: %v", question.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to delete rsvp question",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "rsvp question deleted successfully",
+	})
+}
+
+// GetRSVPAnswers lista as respostas de um convidado
+func GetRSVPAnswers(c *gin.Context) {
+	wedding, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return
+	}
+
+	answers, err := repository.NewRSVPQuestionRepository(database.WithContext(c.Request.Context())).FindAnswersByGuestID(wedding.ID, guest.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch rsvp answers of guest %d: %v", guest.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch rsvp answers",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"answers": toRSVPAnswerResponses(answers),
+	})
+}
+
+// SaveRSVPAnswers grava as respostas de um convidado às perguntas personalizadas
+// Resposta vazia limpa a resposta anterior; perguntas não enviadas ficam como estão
+func SaveRSVPAnswers(c *gin.Context) {
+	wedding, guest, ok := loadWeddingGuest(c)
+	if !ok {
+		return
+	}
+
+	var saveData struct {
+		Answers []struct {
+			QuestionID uint   `json:"question_id" binding:"required"`
+			Answer     string `json:"answer"`
+		} `json:"answers" binding:"required,min=1,max=30,dive"`
+	}
+
+	if err := c.ShouldBindJSON(&saveData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	repo := repository.NewRSVPQuestionRepository(database.WithContext(c.Request.Context()))
+
+	questions, err := repo.FindByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch rsvp questions of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to save rsvp answers",
+		})
+		return
+	}
+	byID := make(map[uint]*models.RSVPQuestion, len(questions))
+	for i := range questions {
+		byID[questions[i].ID] = &questions[i]
+	}
+
+	// Segurança: Apenas perguntas do próprio casamento são aceitas
+	answers := make(map[uint]string, len(saveData.Answers))
+	for _, a := range saveData.Answers {
+		question, exists := byID[a.QuestionID]
+		if !exists {
+			respondAccessError(c, authz.NotFound("rsvp question"))
+			return
+		}
+		if !question.AppliesTo(guest) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":       "question is not part of this guest's events",
+				"question_id": a.QuestionID,
+			})
+			return
+		}
+		answer, err := question.NormalizeAnswer(a.Answer)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":       err.Error(),
+				"question_id": a.QuestionID,
+			})
+			return
+		}
+		answers[a.QuestionID] = answer
+	}
+
+	if err := repo.SaveAnswers(wedding.ID, guest.ID, answers); err != nil {
+		log.Printf("[ERROR] Failed to save rsvp answers of guest %d: %v", guest.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to save rsvp answers",
+		})
+		return
+	}
+
+	saved, err := repo.FindAnswersByGuestID(wedding.ID, guest.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch rsvp answers of guest %d: %v", guest.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch rsvp answers",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "rsvp answers saved successfully",
+		"answers": toRSVPAnswerResponses(saved),
+	})
+}
+
+// GetRSVPAnswersReport agrega as respostas de todas as perguntas do casamento
+// Perguntas de escolha trazem a contagem por opção; ?status filtra pelo status do convite
+func GetRSVPAnswersReport(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	status := models.InviteStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "invalid status filter",
+		})
+		return
+	}
+
+	repo := repository.NewRSVPQuestionRepository(database.WithContext(c.Request.Context()))
+
+	questions, err := repo.FindByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch rsvp questions of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to build rsvp report",
+		})
+		return
+	}
+
+	rows, err := repo.FindAnswersReport(wedding.ID, status)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch rsvp answers of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to build rsvp report",
+		})
+		return
+	}
+
+	// Performance: Agrupamento em memória a partir de uma única query de respostas
+	report := make([]rsvpQuestionReport, len(questions))
+	index := make(map[uint]int, len(questions))
+	for i := range questions {
+		q := &questions[i]
+		report[i] = rsvpQuestionReport{
+			Question: toRSVPQuestionResponse(q),
+			Answers:  []rsvpGuestAnswer{},
+		}
+		switch q.Type {
+		case models.RSVPQuestionYesNo:
+			report[i].Counts = map[string]int{models.RSVPAnswerYes: 0, models.RSVPAnswerNo: 0}
+		case models.RSVPQuestionSingleChoice:
+			report[i].Counts = make(map[string]int, len(q.Options))
+			for _, option := range q.Options {
+				report[i].Counts[option] = 0
+			}
+		}
+		index[q.ID] = i
+	}
+
+	for _, row := range rows {
+		pos, exists := index[row.QuestionID]
+		if !exists {
+			continue
+		}
+		r := &report[pos]
+		r.TotalAnswers++
+		if r.Counts != nil {
+			r.Counts[row.Answer]++
+		}
+		r.Answers = append(r.Answers, rsvpGuestAnswer{
+			GuestID:  row.GuestID,
+			FullName: row.FullName,
+			Answer:   row.Answer,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"questions": report,
+		"status":    status,
+	})
+}
+
+// loadWeddingRSVPQuestion extrai o casamento :id e a pergunta :questionId
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func loadWeddingRSVPQuestion(c *gin.Context) (*models.Wedding, *models.RSVPQuestion, bool) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	questionID, err := parseIDParam(c, "questionId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	question, err := repository.NewRSVPQuestionRepository(database.WithContext(c.Request.Context())).FindByIDAndWeddingID(questionID, wedding.ID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("rsvp question"))
+		return nil, nil, false
+	}
+
+	return wedding, question, true
+}
+
+// toRSVPQuestionResponse converte model para response
+func toRSVPQuestionResponse(q *models.RSVPQuestion) rsvpQuestionResponse {
+	options := q.Options
+	if options == nil {
+		options = []string{}
+	}
+	return rsvpQuestionResponse{
+		ID:        q.ID,
+		WeddingID: q.WeddingID,
+		Prompt:    q.Prompt,
+		Type:      q.Type,
+		Options:   options,
+		Position:  q.Position,
+		Event:     q.Event,
+		CreatedAt: q.CreatedAt,
+		UpdatedAt: q.UpdatedAt,
+	}
+}
+
+// toRSVPAnswerResponses converte models para response
+func toRSVPAnswerResponses(answers []models.RSVPAnswer) []rsvpAnswerResponse {
+	response := make([]rsvpAnswerResponse, len(answers))
+	for i, a := range answers {
+		response[i] = rsvpAnswerResponse{
+			QuestionID: a.QuestionID,
+			Answer:     a.Answer,
+			UpdatedAt:  a.UpdatedAt,
+		}
+	}
+	return response
+}
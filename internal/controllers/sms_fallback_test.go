@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/database/dbtest"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/sms"
+)
+
+// fakeSMSSender registra as mensagens no lugar do provedor
+type fakeSMSSender struct {
+	sent []sms.Message
+}
+
+func (s *fakeSMSSender) Name() string {
+	return "fake"
+}
+
+func (s *fakeSMSSender) Send(_ context.Context, msg sms.Message) (string, error) {
+	s.sent = append(s.sent, msg)
+	return "SM123", nil
+}
+
+// withSMSFallbackTestDB liga o banco em memória (casamento 10 sem conta de WhatsApp) e o sender de SMS falso
+func withSMSFallbackTestDB(t *testing.T, fallback bool) (*dbtest.DB, *fakeSMSSender) {
+	t.Helper()
+
+	db, fake := dbtest.Open()
+	fake.Insert("weddings", dbtest.Row{"id": 10, "user_id": 1, "venue_name": "Espaço", "sms_fallback": fallback})
+	fake.Insert("guests", dbtest.Row{"id": 7, "wedding_id": 10, "full_name": "Ana", "phone_e164": "+5511987654321"})
+
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+
+	sender := &fakeSMSSender{}
+	sms.Register(sender)
+	t.Cleanup(func() { sms.Register(nil) })
+	return fake, sender
+}
+
+func TestDeliverInviteFallsBackToSMS(t *testing.T) {
+	fake, sender := withSMSFallbackTestDB(t, true)
+
+	wedding := &models.Wedding{ID: 10, UserID: 1, VenueName: "Espaço", SMSFallback: true}
+	guest := &models.Guest{ID: 7, WeddingID: 10, FullName: "Ana", PhoneE164: "+5511987654321"}
+	invite := &models.Invite{SentVia: models.InviteViaWhatsApp}
+
+	if err := deliverInvite(context.Background(), wedding, guest, invite, models.StatusActor{Channel: models.StatusChannelInvite}); err != nil {
+		t.Fatalf("deliverInvite: %v", err)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].To != guest.PhoneE164 {
+		t.Fatalf("sms sent = %+v, want one message to %s", sender.sent, guest.PhoneE164)
+	}
+	if invite.SentVia != models.InviteViaSMS {
+		t.Errorf("sent_via = %s, want sms", invite.SentVia)
+	}
+
+	// Histórico: a falha do WhatsApp e o envio por SMS, com o ID da mensagem no provedor
+	deliveries := fake.Inserted("invite_deliveries")
+	if len(deliveries) != 2 {
+		t.Fatalf("deliveries = %v, want the whatsapp failure and the sms attempt", deliveries)
+	}
+	if deliveries[0]["channel"] != models.InviteViaWhatsApp || deliveries[0]["status"] != string(models.InviteDeliveryFailed) {
+		t.Errorf("first delivery = %v, want failed whatsapp", deliveries[0])
+	}
+	if deliveries[1]["channel"] != models.InviteViaSMS || deliveries[1]["reason"] != smsFallbackReason ||
+		deliveries[1]["provider"] != "fake" || deliveries[1]["provider_message_id"] != "SM123" {
+		t.Errorf("second delivery = %v, want the sms fallback", deliveries[1])
+	}
+}
+
+func TestDeliverInviteWithoutSMSFallback(t *testing.T) {
+	_, sender := withSMSFallbackTestDB(t, false)
+
+	wedding := &models.Wedding{ID: 10, UserID: 1, VenueName: "Espaço"}
+	guest := &models.Guest{ID: 7, WeddingID: 10, FullName: "Ana", PhoneE164: "+5511987654321"}
+	invite := &models.Invite{SentVia: models.InviteViaWhatsApp}
+
+	err := deliverInvite(context.Background(), wedding, guest, invite, models.StatusActor{Channel: models.StatusChannelInvite})
+	if !errors.Is(err, errWhatsAppNotConfigured) {
+		t.Fatalf("deliverInvite = %v, want %v", err, errWhatsAppNotConfigured)
+	}
+	if len(sender.sent) != 0 {
+		t.Errorf("sms sent without fallback enabled: %+v", sender.sent)
+	}
+}
+
+// TestFallBackToSMSAfterUndelivered cobre o número sem WhatsApp informado pelo webhook da Twilio
+func TestFallBackToSMSAfterUndelivered(t *testing.T) {
+	fake, sender := withSMSFallbackTestDB(t, true)
+
+	invite := &models.Invite{ID: 3, WeddingID: 10, GuestID: 7, SentVia: models.InviteViaWhatsApp, DeliveryStatus: models.InviteDeliveryBounced}
+	fallBackToSMS(context.Background(), invite)
+
+	if len(sender.sent) != 1 {
+		t.Fatalf("sms sent = %+v, want one message", sender.sent)
+	}
+	if invite.SentVia != models.InviteViaSMS || invite.DeliveryStatus != models.InviteDeliverySent {
+		t.Errorf("invite = sent_via %s, status %s, want sms sent", invite.SentVia, invite.DeliveryStatus)
+	}
+	deliveries := fake.Inserted("invite_deliveries")
+	if len(deliveries) != 1 || deliveries[0]["channel"] != models.InviteViaSMS || deliveries[0]["status"] != string(models.InviteDeliverySent) {
+		t.Errorf("deliveries = %v, want the sms attempt", deliveries)
+	}
+}
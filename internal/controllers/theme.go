@@ -0,0 +1,366 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/storage"
+)
+
+// coverPhotoBasePath é a rota pública que serve as fotos de capa
+const coverPhotoBasePath = "/api/v1/public/covers/"
+
+// themeResponse representa a configuração de tema retornada ao casal
+type themeResponse struct {
+	WeddingID      uint                 `json:"wedding_id"`
+	Template       models.ThemeTemplate `json:"template"`
+	PrimaryColor   string               `json:"primary_color"`
+	SecondaryColor string               `json:"secondary_color"`
+	CoverPhotoURL  string               `json:"cover_photo_url"`
+	Published      bool                 `json:"published"`
+	PublishedAt    *time.Time           `json:"published_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+// publicPageResponse representa os dados estruturados consumidos pelo frontend estático
+// Segurança: Contém apenas dados que o casal aceita tornar públicos
+type publicPageResponse struct {
+	CoupleNames   []string        `json:"couple_names"`
+	VenueName     string          `json:"venue_name"`
+	VenueAddress  string          `json:"venue_address"`
+	EventDate     time.Time       `json:"event_date"`
+	EventTime     string          `json:"event_time"`
+	DaysRemaining int             `json:"days_remaining"`
+	Theme         publicPageTheme `json:"theme"`
+}
+
+type publicPageTheme struct {
+	Template       models.ThemeTemplate `json:"template"`
+	PrimaryColor   string               `json:"primary_color"`
+	SecondaryColor string               `json:"secondary_color"`
+	CoverPhotoURL  string               `json:"cover_photo_url"`
+}
+
+// GetTheme retorna a configuração de tema da página pública
+func GetTheme(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	theme, err := repository.NewThemeRepository(database.WithContext(c.Request.Context())).FindOrDefault(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch theme for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch theme",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"theme": toThemeResponse(theme),
+	})
+}
+
+// UpdateTheme atualiza a configuração de tema (rascunho, não afeta a página publicada)
+func UpdateTheme(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	repo := repository.NewThemeRepository(database.WithContext(c.Request.Context()))
+	theme, err := repo.FindOrDefault(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch theme for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch theme",
+		})
+		return
+	}
+
+	var updateData struct {
+		Template       *models.ThemeTemplate `json:"template"`
+		PrimaryColor   *string               `json:"primary_color"`
+		SecondaryColor *string               `json:"secondary_color"`
+	}
+
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	// Atualiza apenas campos fornecidos (PATCH behavior)
+	if updateData.Template != nil {
+		theme.Template = *updateData.Template
+	}
+	if updateData.PrimaryColor != nil {
+		theme.PrimaryColor = *updateData.PrimaryColor
+	}
+	if updateData.SecondaryColor != nil {
+		theme.SecondaryColor = *updateData.SecondaryColor
+	}
+
+	if err := theme.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repo.Save(theme); err != nil {
+		log.Printf("[ERROR] Failed to save theme for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to update theme",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "theme updated successfully",
+		"theme":   toThemeResponse(theme),
+	})
+}
+
+// UploadCoverPhoto recebe a foto de capa da página pública (multipart, campo "cover")
+func UploadCoverPhoto(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	fileHeader, err := c.FormFile("cover")
+	if err != nil {
+		if respondBodyTooLarge(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "cover file is required",
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "unable to read uploaded file",
+		})
+		return
+	}
+	defer file.Close()
+
+	stored, err := storage.Save(storage.KindPhoto, file)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	repo := repository.NewThemeRepository(database.WithContext(c.Request.Context()))
+	theme, err := repo.FindOrDefault(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch theme for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch theme",
+		})
+		return
+	}
+
+	// A foto anterior só é removida se não estiver no snapshot publicado
+	previous := theme.CoverPhoto
+	theme.CoverPhoto = stored.Name
+
+	if err := repo.Save(theme); err != nil {
+		log.Printf("[ERROR] Failed to save cover photo for wedding %d: %v", wedding.ID, err)
+		_ = storage.Remove(storage.KindPhoto, stored.Name)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to update cover photo",
+		})
+		return
+	}
+
+	if previous != "" && !publishedUsesCover(theme, previous) {
+		if err := storage.Remove(storage.KindPhoto, previous); err != nil {
+			log.Printf("[WARN] Failed to remove old cover photo %s: %v", previous, err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "cover photo uploaded successfully",
+		"theme":   toThemeResponse(theme),
+	})
+}
+
+// PreviewPublicPage retorna a página pública renderizada com as configurações atuais (não publicadas)
+func PreviewPublicPage(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	page, _, ok := buildPublicPage(c, wedding)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preview": page,
+	})
+}
+
+// PublishPublicPage publica um snapshot das configurações atuais
+func PublishPublicPage(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	page, theme, ok := buildPublicPage(c, wedding)
+	if !ok {
+		return
+	}
+
+	snapshot, err := json.Marshal(page)
+	if err != nil {
+		log.Printf("[ERROR] Failed to encode public page for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to publish page",
+		})
+		return
+	}
+
+	now := time.Now()
+	theme.PublishedSnapshot = string(snapshot)
+	theme.PublishedAt = &now
+
+	if err := repository.NewThemeRepository(database.WithContext(c.Request.Context())).Save(theme); err != nil {
+		log.Printf("[ERROR] Failed to publish page for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to publish page",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "page published successfully",
+		"page":    page,
+	})
+}
+
+// GetCoverPhoto serve uma foto de capa pelo nome aleatório gerado no upload
+// Segurança: Nomes aleatórios de 128 bits não são enumeráveis
+func GetCoverPhoto(c *gin.Context) {
+	file, err := storage.Open(storage.KindPhoto, c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "file not found",
+		})
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "file not found",
+		})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Header("X-Content-Type-Options", "nosniff")
+	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), file)
+}
+
+// buildPublicPage monta a estrutura da página pública a partir do casamento e do tema atual
+func buildPublicPage(c *gin.Context, wedding *models.Wedding) (*publicPageResponse, *models.WeddingTheme, bool) {
+	theme, err := repository.NewThemeRepository(database.WithContext(c.Request.Context())).FindOrDefault(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch theme for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch theme",
+		})
+		return nil, nil, false
+	}
+
+	user, err := repository.NewUserRepository(database.WithContext(c.Request.Context())).FindByID(wedding.UserID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch owner of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to build page",
+		})
+		return nil, nil, false
+	}
+
+	return &publicPageResponse{
+		CoupleNames:   []string{user.Name, user.PartnerName},
+		VenueName:     wedding.VenueName,
+		VenueAddress:  wedding.VenueAddress,
+		EventDate:     wedding.EventDate,
+		EventTime:     wedding.EventTime,
+		DaysRemaining: wedding.DaysRemaining(),
+		Theme: publicPageTheme{
+			Template:       theme.Template,
+			PrimaryColor:   theme.PrimaryColor,
+			SecondaryColor: theme.SecondaryColor,
+			CoverPhotoURL:  coverPhotoURL(theme.CoverPhoto),
+		},
+	}, theme, true
+}
+
+// publishedUsesCover verifica se o snapshot publicado ainda referencia a foto
+func publishedUsesCover(theme *models.WeddingTheme, name string) bool {
+	if !theme.IsPublished() {
+		return false
+	}
+	var page publicPageResponse
+	if err := json.Unmarshal([]byte(theme.PublishedSnapshot), &page); err != nil {
+		return true // na dúvida, mantém o arquivo
+	}
+	return page.Theme.CoverPhotoURL == coverPhotoURL(name)
+}
+
+// coverPhotoURL monta a URL pública da foto de capa
+func coverPhotoURL(name string) string {
+	if name == "" {
+		return ""
+	}
+	return coverPhotoBasePath + name
+}
+
+// toThemeResponse converte model para response
+func toThemeResponse(t *models.WeddingTheme) themeResponse {
+	return themeResponse{
+		WeddingID:      t.WeddingID,
+		Template:       t.Template,
+		PrimaryColor:   t.PrimaryColor,
+		SecondaryColor: t.SecondaryColor,
+		CoverPhotoURL:  coverPhotoURL(t.CoverPhoto),
+		Published:      t.IsPublished(),
+		PublishedAt:    t.PublishedAt,
+		UpdatedAt:      t.UpdatedAt,
+	}
+}
+
+// respondUploadError traduz erros de storage para respostas HTTP
+func respondUploadError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, storage.ErrFileTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, errorResponse{Error: err.Error()})
+	case errors.Is(err, storage.ErrContentNotAllowed):
+		c.JSON(http.StatusUnsupportedMediaType, errorResponse{Error: err.Error()})
+	case errors.Is(err, storage.ErrEmptyFile), errors.Is(err, storage.ErrInfectedFile):
+		c.JSON(http.StatusBadRequest, errorResponse{Error: err.Error()})
+	default:
+		log.Printf("[ERROR] Failed to store upload: %v", err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to store file",
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/routing"
+)
+
+// Tempo máximo da consulta à API de rotas dentro de um request
+const travelTimeTimeout = 10 * time.Second
+
+// transferResponse resume o deslocamento entre cerimônia e recepção para o painel do casal
+type transferResponse struct {
+	From          string `json:"from"`
+	To            string `json:"to"`
+	TravelMinutes *int   `json:"travel_minutes"` // nil quando a API de rotas não está disponível
+
+	// Intervalo na programação entre o último momento da cerimônia e o primeiro da recepção
+	CeremonyEndsAt    string `json:"ceremony_ends_at,omitempty"`
+	ReceptionStartsAt string `json:"reception_starts_at,omitempty"`
+	WindowMinutes     *int   `json:"window_minutes"`
+
+	// Sobra do intervalo após o tempo de carro (negativa quando o intervalo não cobre o trajeto)
+	BufferMinutes *int   `json:"buffer_minutes"`
+	Warning       string `json:"warning,omitempty"`
+}
+
+// eventTransfer calcula o deslocamento entre os locais quando a recepção é em outro endereço
+// Falhas são apenas registradas: o aviso é complementar e não deve derrubar a resposta principal
+func eventTransfer(c *gin.Context, wedding *models.Wedding, info *models.EventInfo) *transferResponse {
+	if info.ReceptionAddress == "" || wedding.VenueAddress == "" {
+		return nil
+	}
+
+	transfer := &transferResponse{From: wedding.VenueAddress, To: info.ReceptionAddress}
+	from, to, window, hasWindow := info.TransferWindow()
+	if hasWindow {
+		transfer.CeremonyEndsAt = from
+		transfer.ReceptionStartsAt = to
+		transfer.WindowMinutes = &window
+	}
+
+	route := models.TravelRouteKey(wedding.VenueAddress, info.ReceptionAddress)
+	if info.TravelRoute != route {
+		if err := refreshTravelTime(c.Request.Context(), wedding, info, route, from); err != nil {
+			if !errors.Is(err, routing.ErrNoProviderConfigured) {
+				log.Printf("[WARN] Failed to compute travel time for wedding %d: %v", wedding.ID, err)
+			}
+			return transfer
+		}
+	}
+	transfer.TravelMinutes = info.TravelMinutes
+
+	switch {
+	case info.TravelMinutes == nil:
+		transfer.Warning = "no driving route found between the ceremony and reception addresses"
+	case !hasWindow:
+		transfer.Warning = "add ceremony and reception moments to the timeline to check the transfer time"
+	default:
+		buffer := window - *info.TravelMinutes
+		transfer.BufferMinutes = &buffer
+		if buffer < models.MinTransferBufferMinutes {
+			transfer.Warning = fmt.Sprintf("timeline leaves %d minutes to travel from the ceremony to the reception, but the trip takes about %d minutes", window, *info.TravelMinutes)
+		}
+	}
+	return transfer
+}
+
+// refreshTravelTime consulta a API de rotas e guarda o resultado para o par de endereços
+// Rotas inexistentes também são guardadas (sem tempo) para não repetir a consulta a cada leitura
+func refreshTravelTime(ctx context.Context, wedding *models.Wedding, info *models.EventInfo, route, departureHHMM string) error {
+	provider, err := routing.Default()
+	if err != nil {
+		return err
+	}
+
+	apiCtx, cancel := context.WithTimeout(ctx, travelTimeTimeout)
+	defer cancel()
+
+	d, err := provider.TravelTime(apiCtx, wedding.VenueAddress, info.ReceptionAddress, transferDeparture(wedding, departureHHMM))
+	switch {
+	case err == nil:
+		minutes := int(math.Ceil(d.Minutes()))
+		info.TravelMinutes = &minutes
+	case errors.Is(err, routing.ErrRouteNotFound):
+		info.TravelMinutes = nil
+	default:
+		return err
+	}
+	info.TravelRoute = route
+
+	if info.ID == 0 {
+		return nil
+	}
+	return repository.NewEventInfoRepository(database.WithContext(ctx)).SaveTravelTime(info)
+}
+
+// transferDeparture estima o horário de saída da cerimônia para a previsão de trânsito
+// Sem horário na programação, usa o horário do casamento
+func transferDeparture(wedding *models.Wedding, hhmm string) time.Time {
+	if hhmm == "" {
+		hhmm = wedding.EventTime
+	}
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil || wedding.EventDate.IsZero() {
+		return time.Time{}
+	}
+	d := wedding.EventDate
+	return time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), 0, 0, d.Location())
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// pickupPointResponse agrupa os passageiros de um ponto de embarque do transfer
+type pickupPointResponse struct {
+	PickupPoint string                 `json:"pickup_point"` // vazio: ponto ainda não informado
+	Seats       int                    `json:"seats"`
+	Passengers  []shuttlePassengerInfo `json:"passengers"`
+}
+
+type shuttlePassengerInfo struct {
+	GuestID  uint   `json:"guest_id"`
+	FullName string `json:"full_name"`
+	Phone    string `json:"phone"`
+	Seats    int    `json:"seats"`
+}
+
+// hotelResponse resume a hospedagem por hotel
+type hotelResponse struct {
+	HotelName    string `json:"hotel_name"`    // vazio: hotel ainda não definido
+	Guests       int    `json:"guests"`        // convites
+	Seats        int    `json:"seats"`         // pessoas (max_guests)
+	NeedsBooking int    `json:"needs_booking"` // convites que pediram reserva ao casal
+}
+
+// GetTransportReport agrupa o transfer por ponto de embarque e a hospedagem por hotel
+// Por padrão considera apenas confirmados; ?status=all inclui todos
+func GetTransportReport(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	status, ok := parseReportStatus(c)
+	if !ok {
+		return
+	}
+
+	guests, err := repository.NewGuestRepository(database.WithContext(c.Request.Context())).FindWithLogistics(wedding.ID, status)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch guest logistics of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to build transport report",
+		})
+		return
+	}
+
+	points := map[string]*pickupPointResponse{}
+	hotels := map[string]*hotelResponse{}
+	outOfTown, shuttleSeats := 0, 0
+	for i := range guests {
+		g := &guests[i]
+		l := g.Logistics
+		if l.OutOfTown {
+			outOfTown++
+		}
+
+		if l.NeedsShuttle {
+			p, exists := points[l.PickupPoint]
+			if !exists {
+				p = &pickupPointResponse{PickupPoint: l.PickupPoint, Passengers: []shuttlePassengerInfo{}}
+				points[l.PickupPoint] = p
+			}
+			p.Seats += l.ShuttleSeats
+			p.Passengers = append(p.Passengers, shuttlePassengerInfo{
+				GuestID:  g.ID,
+				FullName: g.FullName,
+				Phone:    g.Phone,
+				Seats:    l.ShuttleSeats,
+			})
+			shuttleSeats += l.ShuttleSeats
+		}
+
+		if l.NeedsHotel || l.HotelName != "" {
+			h, exists := hotels[l.HotelName]
+			if !exists {
+				h = &hotelResponse{HotelName: l.HotelName}
+				hotels[l.HotelName] = h
+			}
+			h.Guests++
+			h.Seats += g.MaxGuests
+			if l.NeedsHotel {
+				h.NeedsBooking++
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":        reportStatusLabel(status),
+		"out_of_town":   outOfTown,
+		"shuttle_seats": shuttleSeats,
+		"pickup_points": sortedByName(points, func(p *pickupPointResponse) string { return p.PickupPoint }),
+		"hotels":        sortedByName(hotels, func(h *hotelResponse) string { return h.HotelName }),
+	})
+}
+
+// sortedByName ordena os grupos pelo nome, com o grupo sem nome (não informado) por último
+func sortedByName[T any](groups map[string]*T, name func(*T) string) []*T {
+	list := make([]*T, 0, len(groups))
+	for _, g := range groups {
+		list = append(list, g)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		a, b := name(list[i]), name(list[j])
+		if a == "" || b == "" {
+			return b == "" && a != ""
+		}
+		return a < b
+	})
+	return list
+}
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/matheushermes/wedding_planner_service/internal/auth"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
 	"github.com/matheushermes/wedding_planner_service/internal/database"
 	"github.com/matheushermes/wedding_planner_service/internal/models"
 	"github.com/matheushermes/wedding_planner_service/internal/repository"
@@ -18,23 +20,29 @@ import (
 
 // Constantes de configuração
 const (
-	maxRequestBodySize = 1 << 20 // 1MB
-	timingAttackDelay  = 100 * time.Millisecond
+	timingAttackDelay = 100 * time.Millisecond
 )
 
 // Response structs padronizadas para consistência da API
 type userResponse struct {
-	ID          uint      `json:"id"`
-	Name        string    `json:"name"`
-	Email       string    `json:"email"`
-	PartnerName string    `json:"partner_name"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID                 uint       `json:"id"`
+	Name               string     `json:"name"`
+	Email              string     `json:"email"`
+	PartnerName        string     `json:"partner_name"`
+	DefaultWeddingID   *uint      `json:"default_wedding_id"`
+	BlockDateConflicts bool       `json:"block_date_conflicts"`
+	LifecycleEmails    bool       `json:"lifecycle_emails"`
+	ProUntil           *time.Time `json:"pro_until"`
+	CreatedAt          time.Time  `json:"created_at"`
 }
 
 type loginResponse struct {
-	Token     string       `json:"token"`
-	ExpiresIn int64        `json:"expires_in"` // em segundos
-	User      userResponse `json:"user"`
+	Token            string        `json:"token"`
+	ExpiresIn        int64         `json:"expires_in"` // em segundos
+	Client           auth.Audience `json:"client"`
+	RefreshToken     string        `json:"refresh_token,omitempty"`      // apenas clientes com refresh (mobile)
+	RefreshExpiresIn int64         `json:"refresh_expires_in,omitempty"` // em segundos
+	User             userResponse  `json:"user"`
 }
 
 type errorResponse struct {
@@ -45,10 +53,20 @@ type errorResponse struct {
 func RegisterUser(c *gin.Context) {
 	var user models.User
 
-	// Proteção contra DoS (limita tamanho do body)
-	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxRequestBodySize)
-
-	if err := c.ShouldBindJSON(&user); err != nil {
+	// ShouldBindBodyWith: o mesmo body também traz o código de indicação opcional
+	var referralData struct {
+		ReferralCode string `json:"referral_code"`
+	}
+	if err := c.ShouldBindBodyWith(&user, binding.JSON); err != nil {
+		if respondBodyTooLarge(c, err) {
+			return
+		}
+		c.JSON(http.StatusUnprocessableEntity, errorResponse{
+			Error: "invalid request data",
+		})
+		return
+	}
+	if err := c.ShouldBindBodyWith(&referralData, binding.JSON); err != nil {
 		c.JSON(http.StatusUnprocessableEntity, errorResponse{
 			Error: "invalid request data",
 		})
@@ -63,8 +81,34 @@ func RegisterUser(c *gin.Context) {
 		return
 	}
 
-	repo := repository.NewUserRepository(database.DB)
-	if err := repo.Create(&user); err != nil {
+	db := database.WithContext(c.Request.Context())
+
+	var referrer *models.User
+	if code := models.NormalizeReferralCode(referralData.ReferralCode); code != "" {
+		var err error
+		referrer, err = repository.NewReferralRepository(db).FindReferrerByCode(code)
+		if err != nil {
+			if errors.Is(err, repository.ErrInvalidReferralCode) {
+				c.JSON(http.StatusBadRequest, errorResponse{
+					Error: err.Error(),
+				})
+				return
+			}
+			log.Printf("[ERROR] Failed to look up referral code: %v", err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to register user at this time",
+			})
+			return
+		}
+	}
+
+	var err error
+	if referrer != nil {
+		err = repository.NewReferralRepository(db).CreateReferredUser(&user, referrer.ID)
+	} else {
+		err = repository.NewUserRepository(db).Create(&user)
+	}
+	if err != nil {
 		log.Printf("[ERROR] Failed to create user: %v", err)
 
 		// Tratamento de erro de duplicação (race condition entre check e insert)
@@ -81,15 +125,13 @@ func RegisterUser(c *gin.Context) {
 		return
 	}
 
+	if referrer != nil {
+		log.Printf("[INFO] User %d signed up with referral from user %d", user.ID, referrer.ID)
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "user registered successfully",
-		"user": userResponse{
-			ID:          user.ID,
-			Name:        user.Name,
-			Email:       user.Email,
-			PartnerName: user.PartnerName,
-			CreatedAt:   user.CreatedAt,
-		},
+		"user":    toUserResponse(&user),
 	})
 }
 
@@ -97,18 +139,26 @@ func RegisterUser(c *gin.Context) {
 func Login(c *gin.Context) {
 	var loginReq models.LoginRequest
 
-	// Proteção contra DoS
-	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxRequestBodySize)
-
 	if err := c.ShouldBindJSON(&loginReq); err != nil {
+		if respondBodyTooLarge(c, err) {
+			return
+		}
 		c.JSON(http.StatusUnprocessableEntity, errorResponse{
 			Error: "invalid request data",
 		})
 		return
 	}
 
+	audience, err := auth.ParseAudience(loginReq.Client)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
 	// Busca usuário no banco
-	repo := repository.NewUserRepository(database.DB)
+	repo := repository.NewUserRepository(database.WithContext(c.Request.Context()))
 	user, err := repo.FindByEmail(loginReq.Email)
 	if err != nil {
 		// Delay constante para prevenir timing attacks (impede enumeração de usuários)
@@ -135,7 +185,7 @@ func Login(c *gin.Context) {
 	}
 
 	// Gera token JWT
-	token, err := auth.CreateToken(user.ID, user.Email)
+	response, err := issueTokens(user, audience)
 	if err != nil {
 		log.Printf("[ERROR] Failed to create token for user %d: %v", user.ID, err)
 		c.JSON(http.StatusInternalServerError, errorResponse{
@@ -145,20 +195,83 @@ func Login(c *gin.Context) {
 	}
 
 	// Log de login bem-sucedido (auditoria)
-	log.Printf("[INFO] Successful login for user %d (%s) from IP: %s", user.ID, user.Email, c.ClientIP())
+	log.Printf("[INFO] Successful login for user %d (%s, %s client) from IP: %s", user.ID, user.Email, audience, c.ClientIP())
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RefreshToken troca um refresh token válido por um novo token de acesso e um novo refresh token
+// Segurança: o usuário é relido do banco (conta removida não renova) e o refresh token nunca autentica rotas
+func RefreshToken(c *gin.Context) {
+	var body struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	claims, err := auth.ParseRefreshToken(body.RefreshToken)
+	if err != nil {
+		log.Printf("[SECURITY] Rejected refresh token from IP: %s: %v", c.ClientIP(), err)
+		c.JSON(http.StatusUnauthorized, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	user, err := repository.NewUserRepository(database.WithContext(c.Request.Context())).FindByID(claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusUnauthorized, errorResponse{
+				Error: auth.ErrTokenInvalid.Error(),
+			})
+			return
+		}
+		log.Printf("[ERROR] Failed to fetch user %d for token refresh: %v", claims.UserID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to refresh token",
+		})
+		return
+	}
 
-	// Resposta estruturada
-	c.JSON(http.StatusOK, loginResponse{
+	response, err := issueTokens(user, claims.Audience())
+	if err != nil {
+		log.Printf("[ERROR] Failed to refresh token for user %d: %v", user.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to refresh token",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// issueTokens emite o token de acesso e, para clientes com refresh, o refresh token
+func issueTokens(user *models.User, audience auth.Audience) (loginResponse, error) {
+	policy, _ := auth.PolicyFor(audience)
+
+	token, err := auth.CreateToken(user.ID, user.Email, audience)
+	if err != nil {
+		return loginResponse{}, err
+	}
+	response := loginResponse{
 		Token:     token,
-		ExpiresIn: int64(auth.TokenExpirationTime.Seconds()),
-		User: userResponse{
-			ID:          user.ID,
-			Name:        user.Name,
-			Email:       user.Email,
-			PartnerName: user.PartnerName,
-			CreatedAt:   user.CreatedAt,
-		},
-	})
+		ExpiresIn: int64(policy.Lifetime.Seconds()),
+		Client:    audience,
+		User:      toUserResponse(user),
+	}
+
+	if policy.CanRefresh() {
+		refresh, err := auth.CreateRefreshToken(user.ID, user.Email, audience)
+		if err != nil {
+			return loginResponse{}, err
+		}
+		response.RefreshToken = refresh
+		response.RefreshExpiresIn = int64(policy.RefreshLifetime.Seconds())
+	}
+	return response, nil
 }
 
 // GetProfile retorna o perfil do usuário autenticado
@@ -172,7 +285,7 @@ func GetProfile(c *gin.Context) {
 		return
 	}
 
-	repo := repository.NewUserRepository(database.DB)
+	repo := repository.NewUserRepository(database.WithContext(c.Request.Context()))
 	user, err := repo.FindByID(userID.(uint))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -189,13 +302,7 @@ func GetProfile(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, userResponse{
-		ID:          user.ID,
-		Name:        user.Name,
-		Email:       user.Email,
-		PartnerName: user.PartnerName,
-		CreatedAt:   user.CreatedAt,
-	})
+	c.JSON(http.StatusOK, toUserResponse(user))
 }
 
 // UpdateProfile atualiza o perfil do usuário autenticado
@@ -211,18 +318,17 @@ func UpdateProfile(c *gin.Context) {
 	var updateData struct {
 		Name        string `json:"name" binding:"omitempty,min=2,max=100"`
 		PartnerName string `json:"partner_name" binding:"omitempty,max=100"`
-	}
 
-	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, 1<<20)
+		// Recusa (true) ou apenas avisa (false) sobre dois casamentos da conta na mesma data
+		BlockDateConflicts *bool `json:"block_date_conflicts"`
+	}
 
 	if err := c.ShouldBindJSON(&updateData); err != nil {
-		c.JSON(http.StatusBadRequest, errorResponse{
-			Error: "invalid request data",
-		})
+		respondBindError(c, err)
 		return
 	}
 
-	repo := repository.NewUserRepository(database.DB)
+	repo := repository.NewUserRepository(database.WithContext(c.Request.Context()))
 	user, err := repo.FindByID(userID.(uint))
 	if err != nil {
 		c.JSON(http.StatusNotFound, errorResponse{
@@ -238,6 +344,9 @@ func UpdateProfile(c *gin.Context) {
 	if updateData.PartnerName != "" {
 		user.PartnerName = strings.TrimSpace(updateData.PartnerName)
 	}
+	if updateData.BlockDateConflicts != nil {
+		user.BlockDateConflicts = *updateData.BlockDateConflicts
+	}
 
 	if err := repo.Update(user); err != nil {
 		log.Printf("[ERROR] Failed to update user %d: %v", userID, err)
@@ -249,13 +358,7 @@ func UpdateProfile(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "profile updated successfully",
-		"user": userResponse{
-			ID:          user.ID,
-			Name:        user.Name,
-			Email:       user.Email,
-			PartnerName: user.PartnerName,
-			CreatedAt:   user.CreatedAt,
-		},
+		"user":    toUserResponse(user),
 	})
 }
 
@@ -269,7 +372,7 @@ func DeleteUser(c *gin.Context) {
 		return
 	}
 
-	repo := repository.NewUserRepository(database.DB)
+	repo := repository.NewUserRepository(database.WithContext(c.Request.Context()))
 
 	// Busca o usuário para log de auditoria
 	user, err := repo.FindByID(userID.(uint))
@@ -296,3 +399,60 @@ func DeleteUser(c *gin.Context) {
 		"message": "user account permanently deleted",
 	})
 }
+
+// SetDefaultWedding define o casamento padrão ("atual") do usuário autenticado
+// wedding_id null remove o padrão e volta para a escolha automática
+func SetDefaultWedding(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, errorResponse{
+			Error: "authentication required",
+		})
+		return
+	}
+
+	var requestData struct {
+		WeddingID *uint `json:"wedding_id"`
+	}
+
+	if err := c.ShouldBindJSON(&requestData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	// Segurança: Só permite definir como padrão um casamento do próprio usuário
+	if requestData.WeddingID != nil {
+		if _, err := authz.LoadWedding(database.WithContext(c.Request.Context()), authz.Subject{UserID: userID.(uint)}, *requestData.WeddingID, authz.ActionRead); err != nil {
+			respondAccessError(c, err)
+			return
+		}
+	}
+
+	if err := repository.NewUserRepository(database.WithContext(c.Request.Context())).SetDefaultWedding(userID.(uint), requestData.WeddingID); err != nil {
+		log.Printf("[ERROR] Failed to set default wedding for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to set default wedding",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":            "default wedding updated successfully",
+		"default_wedding_id": requestData.WeddingID,
+	})
+}
+
+// toUserResponse converte model para response
+func toUserResponse(u *models.User) userResponse {
+	return userResponse{
+		ID:                 u.ID,
+		Name:               u.Name,
+		Email:              u.Email,
+		PartnerName:        u.PartnerName,
+		DefaultWeddingID:   u.DefaultWeddingID,
+		BlockDateConflicts: u.BlockDateConflicts,
+		LifecycleEmails:    u.LifecycleOptOutAt == nil,
+		ProUntil:           u.ProUntil,
+		CreatedAt:          u.CreatedAt,
+	}
+}
@@ -0,0 +1,463 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/money"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// vendorResponse representa a resposta padronizada de fornecedor
+type vendorResponse struct {
+	ID          uint                   `json:"id"`
+	Name        string                 `json:"name"`
+	Category    models.ExpenseCategory `json:"category"`
+	ContactName string                 `json:"contact_name"`
+	Email       string                 `json:"email"`
+	Phone       string                 `json:"phone"`
+	Website     string                 `json:"website"`
+	Notes       string                 `json:"notes"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+// weddingVendorResponse representa um fornecedor associado a um casamento
+type weddingVendorResponse struct {
+	Vendor       vendorResponse `json:"vendor"`
+	Price        float64        `json:"price"`
+	Currency     string         `json:"currency"`
+	PriceDisplay string         `json:"price_display"`
+	Notes        string         `json:"notes"`
+	DueDate      *time.Time     `json:"due_date"`
+	AttachedAt   time.Time      `json:"attached_at"`
+
+	NeedsDateConfirmation bool `json:"needs_date_confirmation"`
+}
+
+// CreateVendor cadastra um fornecedor no catálogo da conta
+func CreateVendor(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, errorResponse{
+			Error: "authentication required",
+		})
+		return
+	}
+
+	var vendor models.Vendor
+	if err := c.ShouldBindJSON(&vendor); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	// Segurança: Impede que usuário crie fornecedor para outro user_id
+	vendor.ID = 0
+	vendor.UserID = userID.(uint)
+
+	if err := vendor.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repository.NewVendorRepository(database.WithContext(c.Request.Context())).Create(&vendor); err != nil {
+		log.Printf("[ERROR] Failed to create vendor for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to create vendor",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "vendor created successfully",
+		"vendor":  toVendorResponse(&vendor),
+	})
+}
+
+// GetVendors lista o catálogo de fornecedores do usuário autenticado
+func GetVendors(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, errorResponse{
+			Error: "authentication required",
+		})
+		return
+	}
+
+	vendors, err := repository.NewVendorRepository(database.WithContext(c.Request.Context())).FindByUserID(userID.(uint))
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch vendors for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch vendors",
+		})
+		return
+	}
+
+	response := make([]vendorResponse, len(vendors))
+	for i := range vendors {
+		response[i] = toVendorResponse(&vendors[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"vendors": response,
+		"count":   len(response),
+	})
+}
+
+// GetVendor retorna um fornecedor do catálogo
+func GetVendor(c *gin.Context) {
+	vendor, ok := loadOwnedVendor(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"vendor": toVendorResponse(vendor),
+	})
+}
+
+// UpdateVendor atualiza o contato compartilhado de um fornecedor
+func UpdateVendor(c *gin.Context) {
+	vendor, ok := loadOwnedVendor(c)
+	if !ok {
+		return
+	}
+
+	var updateData struct {
+		Name        *string                 `json:"name"`
+		Category    *models.ExpenseCategory `json:"category"`
+		ContactName *string                 `json:"contact_name"`
+		Email       *string                 `json:"email"`
+		Phone       *string                 `json:"phone"`
+		Website     *string                 `json:"website"`
+		Notes       *string                 `json:"notes"`
+	}
+
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	// Atualiza apenas campos fornecidos (PATCH behavior)
+	if updateData.Name != nil {
+		vendor.Name = *updateData.Name
+	}
+	if updateData.Category != nil {
+		vendor.Category = *updateData.Category
+	}
+	if updateData.ContactName != nil {
+		vendor.ContactName = *updateData.ContactName
+	}
+	if updateData.Email != nil {
+		vendor.Email = *updateData.Email
+	}
+	if updateData.Phone != nil {
+		vendor.Phone = *updateData.Phone
+	}
+	if updateData.Website != nil {
+		vendor.Website = *updateData.Website
+	}
+	if updateData.Notes != nil {
+		vendor.Notes = *updateData.Notes
+	}
+
+	if err := vendor.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repository.NewVendorRepository(database.WithContext(c.Request.Context())).Update(vendor); err != nil {
+		log.Printf("[ERROR] Failed to update vendor %d: %v", vendor.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to update vendor",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "vendor updated successfully",
+		"vendor":  toVendorResponse(vendor),
+	})
+}
+
+// DeleteVendor remove um fornecedor do catálogo (e de todos os casamentos)
+func DeleteVendor(c *gin.Context) {
+	vendor, ok := loadOwnedVendor(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.NewVendorRepository(database.WithContext(c.Request.Context())).Delete(vendor.ID); err != nil {
+		log.Printf("[ERROR] Failed to delete vendor %d: %v", vendor.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to delete vendor",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "vendor deleted successfully",
+	})
+}
+
+// AttachVendor associa um fornecedor do catálogo a um casamento
+func AttachVendor(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var attachData struct {
+		VendorID uint       `json:"vendor_id" binding:"required"`
+		Price    float64    `json:"price"`
+		Notes    string     `json:"notes"`
+		DueDate  *time.Time `json:"due_date"`
+	}
+
+	if err := c.ShouldBindJSON(&attachData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	repo := repository.NewVendorRepository(database.WithContext(c.Request.Context()))
+
+	// Segurança: Fornecedor precisa ser acessível pelo usuário autenticado
+	vendor, err := authz.LoadVendor(database.WithContext(c.Request.Context()), currentSubject(c), attachData.VendorID, authz.ActionRead)
+	if err != nil {
+		respondAccessError(c, err)
+		return
+	}
+
+	weddingVendor := models.WeddingVendor{
+		WeddingID: wedding.ID,
+		VendorID:  vendor.ID,
+		Vendor:    *vendor,
+		Price:     attachData.Price,
+		Notes:     attachData.Notes,
+		DueDate:   attachData.DueDate,
+	}
+
+	if err := weddingVendor.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repo.Attach(&weddingVendor); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			c.JSON(http.StatusConflict, errorResponse{
+				Error: "vendor is already attached to this wedding",
+			})
+			return
+		}
+
+		log.Printf("[ERROR] Failed to attach vendor %d to wedding %d: %v", vendor.ID, wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to attach vendor",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "vendor attached successfully",
+		"vendor":  toWeddingVendorResponse(&weddingVendor, wedding.Currency),
+	})
+}
+
+// GetWeddingVendors lista os fornecedores de um casamento com preços específicos
+func GetWeddingVendors(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	weddingVendors, err := repository.NewVendorRepository(database.WithContext(c.Request.Context())).FindByWeddingID(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch vendors for wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch vendors",
+		})
+		return
+	}
+
+	response := make([]weddingVendorResponse, len(weddingVendors))
+	for i := range weddingVendors {
+		response[i] = toWeddingVendorResponse(&weddingVendors[i], wedding.Currency)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"vendors": response,
+		"count":   len(response),
+	})
+}
+
+// UpdateWeddingVendor atualiza preço e observações de um fornecedor no casamento
+func UpdateWeddingVendor(c *gin.Context) {
+	wedding, weddingVendor, ok := loadWeddingVendor(c)
+	if !ok {
+		return
+	}
+
+	var updateData struct {
+		Price   *float64   `json:"price"`
+		Notes   *string    `json:"notes"`
+		DueDate *time.Time `json:"due_date"`
+
+		// Fornecedor confirmou a nova data depois da remarcação
+		DateConfirmed *bool `json:"date_confirmed"`
+	}
+
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if updateData.Price != nil {
+		weddingVendor.Price = *updateData.Price
+	}
+	if updateData.Notes != nil {
+		weddingVendor.Notes = *updateData.Notes
+	}
+	if updateData.DueDate != nil {
+		weddingVendor.DueDate = updateData.DueDate
+	}
+	if updateData.DateConfirmed != nil {
+		weddingVendor.NeedsDateConfirmation = !*updateData.DateConfirmed
+	}
+
+	if err := weddingVendor.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repository.NewVendorRepository(database.WithContext(c.Request.Context())).UpdateAttachment(weddingVendor); err != nil {
+		log.Printf("[ERROR] Failed to update vendor %d on wedding %d: %v", weddingVendor.VendorID, weddingVendor.WeddingID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to update vendor",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "vendor updated successfully",
+		"vendor":  toWeddingVendorResponse(weddingVendor, wedding.Currency),
+	})
+}
+
+// DetachVendor remove a associação de um fornecedor com o casamento
+// O contato continua disponível no catálogo da conta
+func DetachVendor(c *gin.Context) {
+	_, weddingVendor, ok := loadWeddingVendor(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.NewVendorRepository(database.WithContext(c.Request.Context())).Detach(weddingVendor.WeddingID, weddingVendor.VendorID); err != nil {
+		log.Printf("[ERROR] Failed to detach vendor %d from wedding %d: %v", weddingVendor.VendorID, weddingVendor.WeddingID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to detach vendor",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "vendor detached successfully",
+	})
+}
+
+// loadOwnedVendor extrai o fornecedor do parâmetro :vendorId do catálogo do usuário autenticado
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func loadOwnedVendor(c *gin.Context) (*models.Vendor, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, errorResponse{
+			Error: "authentication required",
+		})
+		return nil, false
+	}
+
+	vendorID, err := parseIDParam(c, "vendorId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return nil, false
+	}
+
+	vendor, err := authz.LoadVendor(database.WithContext(c.Request.Context()), authz.Subject{UserID: userID.(uint)}, vendorID, authz.ActionForMethod(c.Request.Method))
+	if err != nil {
+		respondAccessError(c, err)
+		return nil, false
+	}
+
+	return vendor, true
+}
+
+// loadWeddingVendor extrai o casamento :id e sua associação com o fornecedor :vendorId
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func loadWeddingVendor(c *gin.Context) (*models.Wedding, *models.WeddingVendor, bool) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	vendorID, err := parseIDParam(c, "vendorId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	weddingVendor, err := repository.NewVendorRepository(database.WithContext(c.Request.Context())).FindAttachment(wedding.ID, vendorID)
+	if err != nil {
+		respondAccessError(c, authz.NotFound("vendor"))
+		return nil, nil, false
+	}
+
+	return wedding, weddingVendor, true
+}
+
+// toVendorResponse converte model para response
+func toVendorResponse(v *models.Vendor) vendorResponse {
+	return vendorResponse{
+		ID:          v.ID,
+		Name:        v.Name,
+		Category:    v.Category,
+		ContactName: v.ContactName,
+		Email:       v.Email,
+		Phone:       v.Phone,
+		Website:     v.Website,
+		Notes:       v.Notes,
+		CreatedAt:   v.CreatedAt,
+		UpdatedAt:   v.UpdatedAt,
+	}
+}
+
+// toWeddingVendorResponse converte model para response
+func toWeddingVendorResponse(wv *models.WeddingVendor, currency string) weddingVendorResponse {
+	return weddingVendorResponse{
+		Vendor:       toVendorResponse(&wv.Vendor),
+		Price:        wv.Price,
+		Currency:     currency,
+		PriceDisplay: money.Format(wv.Price, currency),
+		Notes:        wv.Notes,
+		DueDate:      wv.DueDate,
+		AttachedAt:   wv.CreatedAt,
+
+		NeedsDateConfirmation: wv.NeedsDateConfirmation,
+	}
+}
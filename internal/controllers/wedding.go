@@ -3,29 +3,44 @@ package controllers
 import (
 	"errors"
 	"log"
+	"math"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
 	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/metrics"
 	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/payments"
 	"github.com/matheushermes/wedding_planner_service/internal/repository"
 )
 
 // weddingResponse representa a resposta padronizada de wedding
 type weddingResponse struct {
-	ID                uint      `json:"id"`
-	UserID            uint      `json:"user_id"`
-	VenueName         string    `json:"venue_name"`
-	VenueAddress      string    `json:"venue_address"`
-	EventDate         time.Time `json:"event_date"`
-	EventTime         string    `json:"event_time"`
-	MaxGuests         int       `json:"max_guests"`
-	CurrentGuestCount int       `json:"current_guest_count"`
-	DaysRemaining     int       `json:"days_remaining"`
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
+	ID                      uint       `json:"id"`
+	UserID                  uint       `json:"user_id"`
+	VenueName               string     `json:"venue_name"`
+	VenueAddress            string     `json:"venue_address"`
+	EventDate               time.Time  `json:"event_date"`
+	EventTime               string     `json:"event_time"`
+	MaxGuests               int        `json:"max_guests"`
+	CurrentGuestCount       int        `json:"current_guest_count"`
+	ConfirmedCompanionCount int        `json:"confirmed_companion_count"`
+	DaysRemaining           int        `json:"days_remaining"`
+	Currency                string     `json:"currency"`
+	PaymentProvider         string     `json:"payment_provider"`
+	Slug                    *string    `json:"slug"`
+	CustomDomain            *string    `json:"custom_domain"`
+	AnniversaryReminders    bool       `json:"anniversary_reminders"`
+	AutoPromoteGuests       bool       `json:"auto_promote_guests"`
+	AutoInvitePromoted      bool       `json:"auto_invite_promoted"`
+	SMSFallback             bool       `json:"sms_fallback"`
+	EnforceCapacity         bool       `json:"enforce_capacity"`
+	RSVPDeadline            *time.Time `json:"rsvp_deadline"`
+	CreatedAt               time.Time  `json:"created_at"`
+	UpdatedAt               time.Time  `json:"updated_at"`
 }
 
 // weddingListResponse retorna dados resumidos para listagem
@@ -45,6 +60,13 @@ type countdownResponse struct {
 	EventDate     time.Time `json:"event_date"`
 	DaysRemaining int       `json:"days_remaining"`
 	Status        string    `json:"status"` // upcoming, today, past
+	Mode          string    `json:"mode"`   // countdown, anniversary
+
+	// Modo aniversário (após o evento)
+	DaysSince            *int       `json:"days_since,omitempty"`
+	YearsMarried         *int       `json:"years_married,omitempty"`
+	NextAnniversary      *time.Time `json:"next_anniversary,omitempty"`
+	DaysUntilAnniversary *int       `json:"days_until_anniversary,omitempty"`
 }
 
 // CreateWedding cria um novo casamento para o usuário autenticado
@@ -59,18 +81,16 @@ func CreateWedding(c *gin.Context) {
 	}
 
 	var wedding models.Wedding
-	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxRequestBodySize)
-
 	if err := c.ShouldBindJSON(&wedding); err != nil {
-		c.JSON(http.StatusBadRequest, errorResponse{
-			Error: "invalid request data",
-		})
+		respondBindError(c, err)
 		return
 	}
 
 	// Associa o casamento ao usuário autenticado
 	// Segurança: Impede que usuário crie casamento para outro user_id
 	wedding.UserID = userID.(uint)
+	// Segurança: Domínio customizado só é ativado pela verificação DNS (PUT /public-address + /verify)
+	wedding.CustomDomain = nil
 
 	// Validações de negócio no model
 	if err := wedding.IsValid(); err != nil {
@@ -80,7 +100,13 @@ func CreateWedding(c *gin.Context) {
 		return
 	}
 
-	repo := repository.NewWeddingRepository(database.DB)
+	// Cerimonialistas: outro casamento da conta na mesma data
+	warnings, ok := checkDateConflicts(c, wedding.UserID, &wedding)
+	if !ok {
+		return
+	}
+
+	repo := repository.NewWeddingRepository(database.WithContext(c.Request.Context()))
 
 	// Performance: Uma única operação de INSERT no banco
 	if err := repo.Create(&wedding); err != nil {
@@ -91,9 +117,15 @@ func CreateWedding(c *gin.Context) {
 		return
 	}
 
+	metrics.RecordFunnelStep(metrics.FunnelWeddingCreated, wedding.CreatedAt, 1)
+
+	// Indicação: o primeiro casamento do indicado converte a indicação e recompensa quem indicou
+	convertReferral(c, userID.(uint))
+
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "wedding created successfully",
-		"wedding": toWeddingResponse(&wedding),
+		"message":  "wedding created successfully",
+		"wedding":  toWeddingResponse(&wedding),
+		"warnings": warnings,
 	})
 }
 
@@ -108,7 +140,7 @@ func GetWeddings(c *gin.Context) {
 		return
 	}
 
-	repo := repository.NewWeddingRepository(database.DB)
+	repo := repository.NewWeddingRepository(database.WithContext(c.Request.Context()))
 
 	// Performance: Query otimizada com índice em user_id + ordenação
 	weddings, err := repo.FindByUserID(userID.(uint))
@@ -170,20 +202,60 @@ func GetWedding(c *gin.Context) {
 		return
 	}
 
-	repo := repository.NewWeddingRepository(database.DB)
+	// Segurança: Verifica acesso no ponto central de autorização
+	wedding, err := authz.LoadWedding(database.WithContext(c.Request.Context()), authz.Subject{UserID: userID.(uint)}, weddingID, authz.ActionRead)
+	if err != nil {
+		respondAccessError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"wedding":  toWeddingResponse(wedding),
+		"capacity": weddingCapacity(c, wedding),
+	})
+}
 
-	// Performance: Query com índice composto (id + user_id)
-	// Segurança: Verifica ownership em uma única query
-	wedding, err := repo.FindByIDAndUserID(weddingID, userID.(uint))
+// GetCurrentWedding retorna o casamento "atual" do usuário (usado por deep links do app)
+// Usa o casamento padrão do usuário; sem padrão, escolhe o próximo evento
+func GetCurrentWedding(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, errorResponse{
+			Error: "authentication required",
+		})
+		return
+	}
+
+	user, err := repository.NewUserRepository(database.WithContext(c.Request.Context())).FindByID(userID.(uint))
 	if err != nil {
 		c.JSON(http.StatusNotFound, errorResponse{
-			Error: err.Error(),
+			Error: "user not found",
+		})
+		return
+	}
+
+	repo := repository.NewWeddingRepository(database.WithContext(c.Request.Context()))
+
+	source := "default"
+	var wedding *models.Wedding
+	if user.DefaultWeddingID != nil {
+		wedding, err = authz.LoadWedding(database.WithContext(c.Request.Context()), currentSubject(c), *user.DefaultWeddingID, authz.ActionRead)
+	}
+	if wedding == nil {
+		source = "automatic"
+		wedding, err = repo.FindCurrentByUserID(user.ID)
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: "no wedding found",
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"wedding": toWeddingResponse(wedding),
+		"wedding":   toWeddingResponse(wedding),
+		"capacity":  weddingCapacity(c, wedding),
+		"selection": source, // default (escolhido pelo usuário) ou automatic
 	})
 }
 
@@ -206,32 +278,37 @@ func UpdateWedding(c *gin.Context) {
 		return
 	}
 
-	repo := repository.NewWeddingRepository(database.DB)
+	repo := repository.NewWeddingRepository(database.WithContext(c.Request.Context()))
 
 	// Busca e valida ownership
-	wedding, err := repo.FindByIDAndUserID(weddingID, userID.(uint))
+	wedding, err := authz.LoadWedding(database.WithContext(c.Request.Context()), authz.Subject{UserID: userID.(uint)}, weddingID, authz.ActionWrite)
 	if err != nil {
-		c.JSON(http.StatusNotFound, errorResponse{
-			Error: err.Error(),
-		})
+		respondAccessError(c, err)
 		return
 	}
 
 	// Estrutura para atualização parcial
 	var updateData struct {
-		VenueName    *string    `json:"venue_name"`
-		VenueAddress *string    `json:"venue_address"`
-		EventDate    *time.Time `json:"event_date"`
-		EventTime    *string    `json:"event_time"`
-		MaxGuests    *int       `json:"max_guests"`
-	}
+		VenueName       *string    `json:"venue_name"`
+		VenueAddress    *string    `json:"venue_address"`
+		EventDate       *time.Time `json:"event_date"`
+		EventTime       *string    `json:"event_time"`
+		MaxGuests       *int       `json:"max_guests"`
+		PaymentProvider *string    `json:"payment_provider"`
+		Currency        *string    `json:"currency"`
+
+		AnniversaryReminders *bool `json:"anniversary_reminders"`
+		AutoPromoteGuests    *bool `json:"auto_promote_guests"`
+		AutoInvitePromoted   *bool `json:"auto_invite_promoted"`
+		SMSFallback          *bool `json:"sms_fallback"`
+		EnforceCapacity      *bool `json:"enforce_capacity"`
 
-	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxRequestBodySize)
+		// RFC 3339; vazio remove o prazo
+		RSVPDeadline *string `json:"rsvp_deadline"`
+	}
 
 	if err := c.ShouldBindJSON(&updateData); err != nil {
-		c.JSON(http.StatusBadRequest, errorResponse{
-			Error: "invalid request data",
-		})
+		respondBindError(c, err)
 		return
 	}
 
@@ -243,7 +320,9 @@ func UpdateWedding(c *gin.Context) {
 	if updateData.VenueAddress != nil {
 		wedding.VenueAddress = *updateData.VenueAddress
 	}
+	rescheduled := false
 	if updateData.EventDate != nil {
+		rescheduled = !updateData.EventDate.Equal(wedding.EventDate)
 		wedding.EventDate = *updateData.EventDate
 	}
 	if updateData.EventTime != nil {
@@ -252,6 +331,50 @@ func UpdateWedding(c *gin.Context) {
 	if updateData.MaxGuests != nil {
 		wedding.MaxGuests = *updateData.MaxGuests
 	}
+	if updateData.PaymentProvider != nil {
+		// Provedor precisa estar registrado (vazio volta para o padrão do serviço)
+		if *updateData.PaymentProvider != "" {
+			if _, err := payments.Get(*updateData.PaymentProvider); err != nil {
+				c.JSON(http.StatusBadRequest, errorResponse{
+					Error: err.Error(),
+				})
+				return
+			}
+		}
+		wedding.PaymentProvider = *updateData.PaymentProvider
+	}
+
+	if updateData.Currency != nil {
+		wedding.Currency = *updateData.Currency
+	}
+	if updateData.AnniversaryReminders != nil {
+		wedding.AnniversaryReminders = *updateData.AnniversaryReminders
+	}
+	if updateData.AutoPromoteGuests != nil {
+		wedding.AutoPromoteGuests = *updateData.AutoPromoteGuests
+	}
+	if updateData.AutoInvitePromoted != nil {
+		wedding.AutoInvitePromoted = *updateData.AutoInvitePromoted
+	}
+	if updateData.SMSFallback != nil {
+		wedding.SMSFallback = *updateData.SMSFallback
+	}
+	if updateData.EnforceCapacity != nil {
+		wedding.EnforceCapacity = *updateData.EnforceCapacity
+	}
+	if updateData.RSVPDeadline != nil {
+		wedding.RSVPDeadline = nil
+		if *updateData.RSVPDeadline != "" {
+			deadline, err := time.Parse(time.RFC3339, *updateData.RSVPDeadline)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, errorResponse{
+					Error: "rsvp deadline must be an RFC 3339 date-time",
+				})
+				return
+			}
+			wedding.RSVPDeadline = &deadline
+		}
+	}
 
 	// Validações após atualização (normalize é chamado dentro do IsValid)
 	if err := wedding.IsValid(); err != nil {
@@ -261,6 +384,16 @@ func UpdateWedding(c *gin.Context) {
 		return
 	}
 
+	// Remarcação: outro casamento da conta na nova data
+	warnings := []weddingWarning{}
+	if rescheduled {
+		conflictWarnings, ok := checkDateConflicts(c, wedding.UserID, wedding)
+		if !ok {
+			return
+		}
+		warnings = conflictWarnings
+	}
+
 	// Performance: GORM otimiza UPDATE apenas dos campos alterados
 	if err := repo.Update(wedding); err != nil {
 		log.Printf("[ERROR] Failed to update wedding %d: %v", weddingID, err)
@@ -271,8 +404,9 @@ func UpdateWedding(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "wedding updated successfully",
-		"wedding": toWeddingResponse(wedding),
+		"message":  "wedding updated successfully",
+		"wedding":  toWeddingResponse(wedding),
+		"warnings": warnings,
 	})
 }
 
@@ -295,14 +429,12 @@ func DeleteWedding(c *gin.Context) {
 		return
 	}
 
-	repo := repository.NewWeddingRepository(database.DB)
+	repo := repository.NewWeddingRepository(database.WithContext(c.Request.Context()))
 
 	// Verifica ownership antes de deletar
-	wedding, err := repo.FindByIDAndUserID(weddingID, userID.(uint))
+	wedding, err := authz.LoadWedding(database.WithContext(c.Request.Context()), authz.Subject{UserID: userID.(uint)}, weddingID, authz.ActionDelete)
 	if err != nil {
-		c.JSON(http.StatusNotFound, errorResponse{
-			Error: err.Error(),
-		})
+		respondAccessError(c, err)
 		return
 	}
 
@@ -316,6 +448,11 @@ func DeleteWedding(c *gin.Context) {
 		return
 	}
 
+	// Casamento removido deixa de ser o padrão do usuário
+	if err := repository.NewUserRepository(database.WithContext(c.Request.Context())).ClearDefaultWeddingIf(userID.(uint), weddingID); err != nil {
+		log.Printf("[WARN] Failed to clear default wedding %d for user %d: %v", weddingID, userID, err)
+	}
+
 	log.Printf("[INFO] User %d deleted wedding %d (%s)", userID, weddingID, wedding.VenueName)
 
 	c.JSON(http.StatusOK, gin.H{
@@ -325,63 +462,98 @@ func DeleteWedding(c *gin.Context) {
 
 // GetCountdown retorna contagem regressiva até o casamento
 func GetCountdown(c *gin.Context) {
-	// Pega userID do contexto (colocado pelo AuthMiddleware)
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, errorResponse{
-			Error: "authentication required",
-		})
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
 		return
 	}
 
-	weddingID, err := parseIDParam(c, "id")
-	if err != nil {
+	daysRemaining := wedding.DaysRemaining()
+	response := countdownResponse{
+		EventDate:     wedding.EventDate,
+		DaysRemaining: daysRemaining,
+		Status:        countdownStatus(daysRemaining),
+		Mode:          "countdown",
+	}
+
+	// Após o evento o padrão passa a ser o modo aniversário (?mode=countdown mantém o formato antigo)
+	mode := c.Query("mode")
+	switch {
+	case mode == "" && response.Status == "past", mode == "anniversary":
+		if response.Status != "past" {
+			c.JSON(http.StatusBadRequest, errorResponse{
+				Error: "anniversary mode is available after the event",
+			})
+			return
+		}
+		applyAnniversaryMode(&response, wedding, time.Now())
+	case mode != "" && mode != "countdown":
 		c.JSON(http.StatusBadRequest, errorResponse{
-			Error: err.Error(),
+			Error: "mode must be countdown or anniversary",
 		})
 		return
 	}
 
-	repo := repository.NewWeddingRepository(database.DB)
-	wedding, err := repo.FindByIDAndUserID(weddingID, userID.(uint))
-	if err != nil {
-		c.JSON(http.StatusNotFound, errorResponse{
-			Error: err.Error(),
-		})
-		return
-	}
+	c.JSON(http.StatusOK, response)
+}
 
-	// Calcula status baseado na data
-	daysRemaining := wedding.DaysRemaining()
-	status := "upcoming"
+// applyAnniversaryMode preenche os dias desde o casamento e o próximo aniversário
+func applyAnniversaryMode(response *countdownResponse, wedding *models.Wedding, now time.Time) {
+	next, years := wedding.NextAnniversary(now)
+	local := now.In(wedding.EventDate.Location())
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	eventDay := time.Date(wedding.EventDate.Year(), wedding.EventDate.Month(), wedding.EventDate.Day(), 0, 0, 0, 0, local.Location())
+
+	// Arredondamento absorve dias de 23h/25h em mudanças de horário de verão
+	daysSince := int(math.Round(today.Sub(eventDay).Hours() / 24))
+	daysUntil := int(math.Round(next.Sub(today).Hours() / 24))
+	yearsMarried := years - 1
+	if daysUntil == 0 {
+		yearsMarried = years
+	}
+
+	response.Mode = "anniversary"
+	response.DaysSince = &daysSince
+	response.YearsMarried = &yearsMarried
+	response.NextAnniversary = &next
+	response.DaysUntilAnniversary = &daysUntil
+}
+
+// countdownStatus calcula o status baseado nos dias restantes
+func countdownStatus(daysRemaining int) string {
 	if daysRemaining < 0 {
-		status = "past"
+		return "past"
 	} else if daysRemaining == 0 {
-		status = "today"
+		return "today"
 	}
-
-	c.JSON(http.StatusOK, countdownResponse{
-		EventDate:     wedding.EventDate,
-		DaysRemaining: daysRemaining,
-		Status:        status,
-	})
+	return "upcoming"
 }
 
 // toWeddingResponse converte model para response
 // Performance: Centraliza lógica de conversão evitando duplicação
 func toWeddingResponse(w *models.Wedding) weddingResponse {
 	return weddingResponse{
-		ID:                w.ID,
-		UserID:            w.UserID,
-		VenueName:         w.VenueName,
-		VenueAddress:      w.VenueAddress,
-		EventDate:         w.EventDate,
-		EventTime:         w.EventTime,
-		MaxGuests:         w.MaxGuests,
-		CurrentGuestCount: w.CurrentGuestCount,
-		DaysRemaining:     w.DaysRemaining(),
-		CreatedAt:         w.CreatedAt,
-		UpdatedAt:         w.UpdatedAt,
+		ID:                      w.ID,
+		UserID:                  w.UserID,
+		VenueName:               w.VenueName,
+		VenueAddress:            w.VenueAddress,
+		EventDate:               w.EventDate,
+		EventTime:               w.EventTime,
+		MaxGuests:               w.MaxGuests,
+		CurrentGuestCount:       w.CurrentGuestCount,
+		ConfirmedCompanionCount: w.ConfirmedCompanionCount,
+		DaysRemaining:           w.DaysRemaining(),
+		Currency:                w.Currency,
+		PaymentProvider:         w.PaymentProvider,
+		Slug:                    w.Slug,
+		CustomDomain:            w.CustomDomain,
+		AnniversaryReminders:    w.AnniversaryReminders,
+		AutoPromoteGuests:       w.AutoPromoteGuests,
+		AutoInvitePromoted:      w.AutoInvitePromoted,
+		SMSFallback:             w.SMSFallback,
+		EnforceCapacity:         w.EnforceCapacity,
+		RSVPDeadline:            w.RSVPDeadline,
+		CreatedAt:               w.CreatedAt,
+		UpdatedAt:               w.UpdatedAt,
 	}
 }
 
@@ -395,3 +567,82 @@ func parseIDParam(c *gin.Context, paramName string) (uint, error) {
 	}
 	return uint(id), nil
 }
+
+// loadOwnedWedding extrai o usuário autenticado e o casamento do parâmetro :id
+// Segurança: A ação (leitura, escrita ou remoção) é derivada do método HTTP e checada em authz.Can
+// Em caso de erro, a resposta já foi escrita e ok retorna false
+func loadOwnedWedding(c *gin.Context) (*models.Wedding, bool) {
+	// Performance: Rotas aninhadas já recebem o casamento do WeddingScopeMiddleware
+	if wedding, exists := c.Get("wedding"); exists {
+		return wedding.(*models.Wedding), true
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, errorResponse{
+			Error: "authentication required",
+		})
+		return nil, false
+	}
+
+	weddingID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return nil, false
+	}
+
+	wedding, err := authz.LoadWedding(database.WithContext(c.Request.Context()), authz.Subject{UserID: userID.(uint)}, weddingID, authz.ActionForMethod(c.Request.Method))
+	if err != nil {
+		respondAccessError(c, err)
+		return nil, false
+	}
+
+	return wedding, true
+}
+
+// currentSubject monta o sujeito das checagens de autorização a partir do usuário autenticado
+func currentSubject(c *gin.Context) authz.Subject {
+	return authz.Subject{UserID: c.GetUint("user_id")}
+}
+
+// respondAccessError responde falhas de autorização de forma uniforme
+// Segurança: Recurso inexistente e acesso negado retornam o mesmo 404
+func respondAccessError(c *gin.Context, err error) {
+	if authz.IsNotFound(err) {
+		c.JSON(http.StatusNotFound, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	log.Printf("[ERROR] Failed to authorize %s %s: %v", c.Request.Method, c.FullPath(), err)
+	c.JSON(http.StatusInternalServerError, errorResponse{
+		Error: "internal server error",
+	})
+}
+
+// respondBindError responde 413 para bodies acima do limite da rota e 400 para os demais erros de leitura
+func respondBindError(c *gin.Context, err error) {
+	if respondBodyTooLarge(c, err) {
+		return
+	}
+	c.JSON(http.StatusBadRequest, errorResponse{
+		Error: "invalid request data",
+	})
+}
+
+// respondBodyTooLarge responde 413 se a leitura parou no limite do BodyLimitMiddleware
+// Retorna false (sem responder) para os demais erros
+func respondBodyTooLarge(c *gin.Context, err error) bool {
+	var tooLarge *http.MaxBytesError
+	if !errors.As(err, &tooLarge) {
+		return false
+	}
+	c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+		"error":       "request body too large",
+		"limit_bytes": tooLarge.Limit,
+	})
+	return true
+}
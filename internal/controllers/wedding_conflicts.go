@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// weddingWarning representa um aviso que não impede a operação
+type weddingWarning struct {
+	Code      string         `json:"code"`
+	Message   string         `json:"message"`
+	Conflicts []dateConflict `json:"conflicts,omitempty"`
+}
+
+// dateConflict identifica outro casamento da conta na mesma data
+type dateConflict struct {
+	WeddingID uint      `json:"wedding_id"`
+	VenueName string    `json:"venue_name"`
+	EventDate time.Time `json:"event_date"`
+	EventTime string    `json:"event_time"`
+}
+
+// checkDateConflicts procura outros casamentos do usuário na data do casamento
+// Com BlockDateConflicts a operação é recusada (409); sem, o conflito volta como aviso na resposta
+// Retorna false quando a resposta de erro já foi enviada
+func checkDateConflicts(c *gin.Context, userID uint, wedding *models.Wedding) ([]weddingWarning, bool) {
+	db := database.WithContext(c.Request.Context())
+
+	others, err := repository.NewWeddingRepository(db).FindOnDateByUserID(userID, wedding.EventDate, wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to check date conflicts for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to check wedding date conflicts",
+		})
+		return nil, false
+	}
+	if len(others) == 0 {
+		return []weddingWarning{}, true
+	}
+
+	conflicts := make([]dateConflict, len(others))
+	for i, w := range others {
+		conflicts[i] = dateConflict{WeddingID: w.ID, VenueName: w.VenueName, EventDate: w.EventDate, EventTime: w.EventTime}
+	}
+
+	user, err := repository.NewUserRepository(db).FindByID(userID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to fetch user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to check wedding date conflicts",
+		})
+		return nil, false
+	}
+	if user.BlockDateConflicts {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":     "you already have another wedding on this date",
+			"conflicts": conflicts,
+		})
+		return nil, false
+	}
+
+	return []weddingWarning{{
+		Code:      "date_conflict",
+		Message:   "you already have another wedding on this date",
+		Conflicts: conflicts,
+	}}, true
+}
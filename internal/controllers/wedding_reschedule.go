@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/asyncjobs"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// Limite da mensagem opcional do casal no aviso de nova data
+const maxAnnouncementMessage = 1000
+
+// rescheduleAnnouncement oferece o aviso da nova data aos convidados confirmados
+type rescheduleAnnouncement struct {
+	Recipients int    `json:"recipients"` // confirmados com email que aceitam mensagens
+	Endpoint   string `json:"endpoint"`
+}
+
+// RescheduleWedding muda a data do casamento e ajusta o que depende dela
+// Vencimentos das despesas planejadas são recalculados, fornecedores ficam marcados para confirmar
+// a nova data e a resposta indica quantos convites já saíram com a data antiga
+func RescheduleWedding(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		EventDate *time.Time `json:"event_date" binding:"required"`
+		EventTime *string    `json:"event_time"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	now := time.Now()
+	if body.EventDate.Equal(wedding.EventDate) {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "new event date must differ from the current one",
+		})
+		return
+	}
+	if body.EventDate.Before(now) {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "new event date must be in the future",
+		})
+		return
+	}
+
+	previousDate, previousTime := wedding.EventDate, wedding.EventTime
+	wedding.EventDate = *body.EventDate
+	if body.EventTime != nil {
+		wedding.EventTime = *body.EventTime
+	}
+
+	// Validações após atualização (normalize é chamado dentro do IsValid)
+	if err := wedding.IsValid(); err != nil {
+		wedding.EventDate, wedding.EventTime = previousDate, previousTime
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	warnings, ok := checkDateConflicts(c, wedding.UserID, wedding)
+	if !ok {
+		return
+	}
+
+	db := database.WithContext(c.Request.Context())
+	result, err := repository.NewWeddingRepository(db).Reschedule(wedding, previousDate, now)
+	if err != nil {
+		log.Printf("[ERROR] Failed to reschedule wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to reschedule wedding",
+		})
+		return
+	}
+
+	// O aviso é complementar: sem a contagem a remarcação continua válida
+	var announcement *rescheduleAnnouncement
+	recipients, err := repository.NewGuestRepository(db).FindConfirmedReachable(wedding.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to count announcement recipients of wedding %d: %v", wedding.ID, err)
+	} else {
+		announcement = &rescheduleAnnouncement{
+			Recipients: len(recipients),
+			Endpoint:   fmt.Sprintf("/api/v1/weddings/%d/reschedule/announce", wedding.ID),
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":             "wedding rescheduled successfully",
+		"wedding":             toWeddingResponse(wedding),
+		"previous_event_date": previousDate,
+		"changes":             result,
+		"announcement":        announcement,
+		"warnings":            warnings,
+	})
+}
+
+// rescheduleAnnouncementParams é a mensagem do casal gravada no job de aviso
+type rescheduleAnnouncementParams struct {
+	Message string `json:"message"`
+}
+
+// AnnounceReschedule envia a nova data por email aos convidados confirmados
+// O envio roda em segundo plano: responde 202 e o total enviado fica no job (GET /jobs/:id)
+func AnnounceReschedule(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Message string `json:"message"`
+	}
+
+	// Corpo opcional: sem mensagem o aviso vai só com a nova data
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+	body.Message = strings.TrimSpace(body.Message)
+	if len(body.Message) > maxAnnouncementMessage {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: fmt.Sprintf("message must not exceed %d characters", maxAnnouncementMessage),
+		})
+		return
+	}
+
+	enqueueJob(c, wedding, models.AsyncJobRescheduleAnnouncement, rescheduleAnnouncementParams{
+		Message: body.Message,
+	})
+}
+
+// runRescheduleAnnouncement envia o aviso da nova data de um job
+// Falhas de envio são contadas e registradas sem interromper os demais convidados
+func runRescheduleAnnouncement(ctx context.Context, job *models.AsyncJob, progress func(int)) (*asyncjobs.Output, error) {
+	var params rescheduleAnnouncementParams
+	if err := asyncjobs.DecodeParams(job, &params); err != nil {
+		return nil, err
+	}
+	wedding, err := loadJobWedding(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	guests, err := repository.NewGuestRepository(database.WithContext(ctx)).FindConfirmedReachable(wedding.ID)
+	if err != nil {
+		return nil, asyncjobs.Fail("unable to send announcement", err)
+	}
+
+	sent, failed := 0, 0
+	for i := range guests {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		_, err := sendGuestEmail(ctx, &guests[i], "Nova data do nosso casamento", rescheduleEmailText(wedding, &guests[i], params.Message), nil)
+		switch {
+		case err == nil:
+			sent++
+		case errors.Is(err, errGuestUnreachable):
+		default:
+			failed++
+			log.Printf("[WARN] Failed to send reschedule announcement to guest %d of wedding %d: %v", guests[i].ID, wedding.ID, err)
+		}
+		progress((i + 1) * 100 / len(guests))
+	}
+
+	return &asyncjobs.Output{
+		Result: gin.H{
+			"sent":   sent,
+			"failed": failed,
+		},
+	}, nil
+}
+
+// rescheduleEmailText monta o aviso da nova data com o link de RSVP para o convidado rever a presença
+func rescheduleEmailText(wedding *models.Wedding, guest *models.Guest, message string) string {
+	when := wedding.EventDate.Format("02/01/2006")
+	if wedding.EventTime != "" {
+		when += " às " + wedding.EventTime
+	}
+
+	text := fmt.Sprintf("Olá, %s!\n\nNosso casamento mudou de data: agora será em %s, no %s.\n\n", guest.FullName, when, wedding.VenueName)
+	if message != "" {
+		text += message + "\n\n"
+	}
+	return text + "Se não puder comparecer na nova data, atualize sua resposta pelo link: " +
+		configs.PUBLIC_BASE_URL + RSVPPath(guest.ID) + "\n"
+}
@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// whatsAppAccountResponse representa a conta de WhatsApp do casal
+// Segurança: o token nunca é devolvido, apenas se está cadastrado
+type whatsAppAccountResponse struct {
+	Provider         models.WhatsAppProvider `json:"provider"`
+	AccountID        string                  `json:"account_id"`
+	FromNumber       string                  `json:"from_number,omitempty"`
+	HasToken         bool                    `json:"has_token"`
+	TemplateName     string                  `json:"template_name"`
+	TemplateLanguage string                  `json:"template_language"`
+	TemplateParams   []string                `json:"template_params"`
+	UpdatedAt        time.Time               `json:"updated_at"`
+}
+
+// GetWhatsAppAccount retorna a conta de WhatsApp usada no envio dos convites
+func GetWhatsAppAccount(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	account, err := repository.NewWhatsAppRepository(database.WithContext(c.Request.Context())).FindByWeddingID(wedding.ID)
+	if err != nil {
+		if err.Error() == "whatsapp account not found" {
+			respondAccessError(c, authz.NotFound("whatsapp account"))
+			return
+		}
+		log.Printf("[ERROR] Failed to fetch whatsapp account of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to fetch whatsapp account",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"whatsapp": toWhatsAppAccountResponse(account),
+	})
+}
+
+// UpdateWhatsAppAccount cadastra ou atualiza a conta de WhatsApp do casamento
+// O token é apenas de escrita: omitido, o token atual é mantido
+func UpdateWhatsAppAccount(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	repo := repository.NewWhatsAppRepository(database.WithContext(c.Request.Context()))
+	account, err := repo.FindByWeddingID(wedding.ID)
+	if err != nil {
+		if err.Error() != "whatsapp account not found" {
+			log.Printf("[ERROR] Failed to fetch whatsapp account of wedding %d: %v", wedding.ID, err)
+			c.JSON(http.StatusInternalServerError, errorResponse{
+				Error: "unable to update whatsapp account",
+			})
+			return
+		}
+		account = &models.WhatsAppAccount{WeddingID: wedding.ID}
+	}
+
+	var updateData struct {
+		Provider         *models.WhatsAppProvider `json:"provider"`
+		AccountID        *string                  `json:"account_id"`
+		FromNumber       *string                  `json:"from_number"`
+		Token            *string                  `json:"token"`
+		TemplateName     *string                  `json:"template_name"`
+		TemplateLanguage *string                  `json:"template_language"`
+		TemplateParams   *[]string                `json:"template_params"`
+	}
+
+	if err := c.ShouldBindJSON(&updateData); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	// Segurança: o token pertence à conta do provedor, trocar de conta sem novo token reaproveitaria o antigo
+	changesAccount := (updateData.Provider != nil && *updateData.Provider != account.Provider) ||
+		(updateData.AccountID != nil && *updateData.AccountID != account.AccountID)
+	if account.ID != 0 && changesAccount && updateData.Token == nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: "token is required when changing provider or account id",
+		})
+		return
+	}
+
+	// Atualiza apenas campos fornecidos (PATCH behavior)
+	if updateData.Provider != nil {
+		account.Provider = *updateData.Provider
+	}
+	if updateData.AccountID != nil {
+		account.AccountID = *updateData.AccountID
+	}
+	if updateData.FromNumber != nil {
+		account.FromNumber = *updateData.FromNumber
+	}
+	if updateData.Token != nil {
+		account.Token = *updateData.Token
+	}
+	if updateData.TemplateName != nil {
+		account.TemplateName = *updateData.TemplateName
+	}
+	if updateData.TemplateLanguage != nil {
+		account.TemplateLanguage = *updateData.TemplateLanguage
+	}
+	if updateData.TemplateParams != nil {
+		account.TemplateParams = *updateData.TemplateParams
+	}
+
+	// Validações após atualização (normalize é chamado dentro do IsValid)
+	if err := account.IsValid(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := repo.Save(account); err != nil {
+		log.Printf("[ERROR] Failed to save whatsapp account of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to update whatsapp account",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "whatsapp account updated successfully",
+		"whatsapp": toWhatsAppAccountResponse(account),
+	})
+}
+
+// DeleteWhatsAppAccount desconecta a conta de WhatsApp; novos convites só podem sair por email
+func DeleteWhatsAppAccount(c *gin.Context) {
+	wedding, ok := loadOwnedWedding(c)
+	if !ok {
+		return
+	}
+
+	err := repository.NewWhatsAppRepository(database.WithContext(c.Request.Context())).Delete(wedding.ID)
+	if err != nil {
+		if err.Error() == "whatsapp account not found" {
+			respondAccessError(c, authz.NotFound("whatsapp account"))
+			return
+		}
+		log.Printf("[ERROR] Failed to delete whatsapp account of wedding %d: %v", wedding.ID, err)
+		c.JSON(http.StatusInternalServerError, errorResponse{
+			Error: "unable to delete whatsapp account",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "whatsapp account deleted successfully",
+	})
+}
+
+// toWhatsAppAccountResponse converte model para response
+func toWhatsAppAccountResponse(a *models.WhatsAppAccount) whatsAppAccountResponse {
+	params := a.TemplateParams
+	if params == nil {
+		params = []string{}
+	}
+	return whatsAppAccountResponse{
+		Provider:         a.Provider,
+		AccountID:        a.AccountID,
+		FromNumber:       a.FromNumber,
+		HasToken:         a.Token != "",
+		TemplateName:     a.TemplateName,
+		TemplateLanguage: a.TemplateLanguage,
+		TemplateParams:   params,
+		UpdatedAt:        a.UpdatedAt,
+	}
+}
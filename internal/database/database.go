@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/matheushermes/wedding_planner_service/configs"
-	"github.com/matheushermes/wedding_planner_service/internal/models"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -34,7 +33,7 @@ func ConnectDB() error {
 	customLogger := logger.New(
 		log.New(os.Stdout, "\r\n", log.LstdFlags),
 		logger.Config{
-			SlowThreshold:             200 * time.Millisecond, // Log queries lentas
+			SlowThreshold:             time.Duration(configs.Runtime().DBSlowQueryMS) * time.Millisecond, // Log queries lentas
 			LogLevel:                  logLevel,
 			IgnoreRecordNotFoundError: true,
 			Colorful:                  configs.ENV != "production",
@@ -65,6 +64,11 @@ func ConnectDB() error {
 		return fmt.Errorf("falha ao conectar após %d tentativas: %w", maxRetries, err)
 	}
 
+	// Deadline por query e métricas de queries lentas
+	if err := registerQueryCallbacks(DB); err != nil {
+		return fmt.Errorf("erro ao registrar callbacks de query: %w", err)
+	}
+
 	// Configurações de pool otimizadas para produção
 	sqlDB, err := DB.DB()
 	if err != nil {
@@ -85,6 +89,12 @@ func ConnectDB() error {
 		return fmt.Errorf("falha no ping do banco: %w", err)
 	}
 
+	// Valida o tamanho do pool contra o limite do servidor e inicia o monitor
+	if err := validatePoolSize(); err != nil {
+		return err
+	}
+	startPoolMonitor(sqlDB)
+
 	log.Println("✅ Conexão com banco de dados estabelecida com sucesso!")
 	return nil
 }
@@ -98,13 +108,13 @@ func InitializeDatabase() {
 	// Executa migrações em desenvolvimento e staging
 	if configs.ENV != "production" {
 		log.Println("🔄 Executando migrações automáticas...")
-		if err := MigrateDB(
-			&models.User{},
-			&models.Wedding{},
-			&models.Fundraising{},
-			&models.Guest{},
-			&models.Invite{},
-		); err != nil {
+		var err error
+		if configs.MIGRATION_MODE == "safe" {
+			_, err = MigrateSafe(SafeMigrateOptions{}, Models()...)
+		} else {
+			err = MigrateDB(Models()...)
+		}
+		if err != nil {
 			log.Fatalf("❌ Erro ao executar migrações: %v", err)
 		}
 		log.Println("✅ Migrações concluídas!")
@@ -120,6 +130,7 @@ func CloseDatabase() error {
 		if err != nil {
 			return err
 		}
+		stopPoolMonitoring()
 		log.Println("🔒 Fechando conexões com o banco...")
 		return sqlDB.Close()
 	}
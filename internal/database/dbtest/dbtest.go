@@ -0,0 +1,456 @@
+// Package dbtest fornece um banco em memória para testes que não têm MySQL disponível
+//
+// O driver responde às queries geradas pelo GORM a partir de linhas cadastradas por tabela:
+//   - SELECT filtra as linhas pelas condições de igualdade (coluna = ?) da cláusula WHERE
+//   - Condições que o driver não interpreta (IN, >, IS NULL, JOIN) não filtram
+//   - INSERT, UPDATE e DELETE são aceitos sem efeito (UPDATE informa uma linha afetada)
+//   - As linhas dos INSERT ficam disponíveis em Inserted, sem aparecer nos SELECT, e recebem IDs sequenciais
+//   - Toda query executada fica registrada em Statements, para o teste conferir escritas e filtros
+//
+// Em modo estrito (Strict) o SELECT só aceita WHERE com igualdades "coluna = ?" e "coluna IS NULL"
+// ligadas por AND, da própria tabela; qualquer outra condição (OR, IN, >, JOIN) falha a query em vez
+// de ser ignorada. Testes de isolamento entre contas usam o modo estrito para que um filtro de dono
+// que o driver não entende não passe por filtrado
+//
+// Suficiente para exercitar autorização e handlers que leem registros pela chave; consultas
+// agregadas e a semântica real do banco ficam para os testes com MySQL (TEST_DATABASE_URL)
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Row é uma linha de uma tabela, indexada pelo nome da coluna
+type Row map[string]any
+
+// DB guarda as linhas cadastradas pelo teste
+type DB struct {
+	mu         sync.Mutex
+	tables     map[string][]Row
+	inserted   map[string][]Row
+	statements []Statement
+	lastID     int64
+	strict     bool
+}
+
+// Statement é uma query recebida pelo driver, com os argumentos na ordem dos placeholders
+type Statement struct {
+	SQL  string
+	Args []any
+}
+
+// IsWrite indica se a query altera dados (INSERT, UPDATE ou DELETE)
+func (s Statement) IsWrite() bool {
+	return writeRegex.MatchString(s.SQL)
+}
+
+var (
+	registerOnce sync.Once
+	instances    sync.Map // nome da conexão -> *DB
+	nextID       atomic.Int64
+
+	fromRegex   = regexp.MustCompile("(?i)\\bFROM\\s+`?(\\w+)`?")
+	whereRegex  = regexp.MustCompile("(?is)\\bWHERE\\b(.*?)(?:\\bORDER BY\\b|\\bGROUP BY\\b|\\bLIMIT\\b|$)")
+	updateRegex = regexp.MustCompile("(?i)^\\s*UPDATE\\b")
+	countRegex  = regexp.MustCompile("(?i)^\\s*SELECT\\s+count\\([^)]*\\)\\s+FROM\\b")
+	equalRegex  = regexp.MustCompile("(?:`?(\\w+)`?\\.)?`?(\\w+)`?\\s*=\\s*\\?")
+	writeRegex  = regexp.MustCompile("(?i)^\\s*(INSERT|UPDATE|DELETE)\\b")
+	insertRegex = regexp.MustCompile("(?is)^\\s*INSERT\\s+INTO\\s+`?(\\w+)`?\\s*\\(([^)]*)\\)")
+	joinRegex   = regexp.MustCompile("(?i)\\bJOIN\\b")
+	andRegex    = regexp.MustCompile("(?i)\\s+AND\\s+")
+	strictRegex = regexp.MustCompile("(?i)^(?:`?(\\w+)`?\\.)?`?(\\w+)`?\\s*(=\\s*\\?|IS\\s+NULL)$")
+)
+
+// condition é uma condição do WHERE entendida pelo modo estrito
+type condition struct {
+	column   string
+	isNull   bool
+	position int // índice do argumento do placeholder (apenas igualdades)
+}
+
+// Open cria um *gorm.DB isolado, ligado a um banco em memória vazio
+func Open() (*gorm.DB, *DB) {
+	registerOnce.Do(func() {
+		sql.Register("dbtest", fakeDriver{})
+	})
+
+	name := fmt.Sprintf("dbtest-%d", nextID.Add(1))
+	fake := &DB{tables: map[string][]Row{}, inserted: map[string][]Row{}}
+	instances.Store(name, fake)
+
+	conn, err := sql.Open("dbtest", name)
+	if err != nil {
+		panic(err)
+	}
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger:                 logger.Discard,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return db, fake
+}
+
+// Insert cadastra linhas na tabela
+func (f *DB) Insert(table string, rows ...Row) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, row := range rows {
+		normalized := make(Row, len(row))
+		for column, value := range row {
+			normalized[column] = driverValue(value)
+		}
+		f.tables[table] = append(f.tables[table], normalized)
+	}
+}
+
+// Strict passa a falhar os SELECT cujo WHERE tenha condições que o driver não avalia
+func (f *DB) Strict() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.strict = true
+}
+
+// Inserted retorna as linhas gravadas por INSERT na tabela, em ordem
+func (f *DB) Inserted(table string) []Row {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Row(nil), f.inserted[table]...)
+}
+
+// Statements retorna as queries executadas, em ordem
+func (f *DB) Statements() []Statement {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Statement(nil), f.statements...)
+}
+
+// record guarda a query executada (com o lock do chamador)
+func (f *DB) record(query string, args []driver.NamedValue) {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	f.statements = append(f.statements, Statement{SQL: query, Args: values})
+}
+
+// exec registra as linhas dos INSERT (um ou vários VALUES) pelas colunas da query
+func (f *DB) exec(query string, args []driver.NamedValue) driver.Result {
+	f.mu.Lock()
+	f.record(query, args)
+	f.mu.Unlock()
+
+	match := insertRegex.FindStringSubmatch(query)
+	if match == nil {
+		return execResult(query)
+	}
+
+	columns := strings.Split(match[2], ",")
+	for i := range columns {
+		columns[i] = strings.Trim(strings.TrimSpace(columns[i]), "`")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var count int64
+	for start := 0; start+len(columns) <= len(args); start += len(columns) {
+		row := make(Row, len(columns))
+		for i, column := range columns {
+			row[column] = args[start+i].Value
+		}
+		f.inserted[match[1]] = append(f.inserted[match[1]], row)
+		count++
+	}
+	// MySQL informa o ID da primeira linha do lote
+	result := insertResult{firstID: f.lastID + 1, rows: count}
+	f.lastID += count
+	return result
+}
+
+// insertResult informa os IDs gerados para as linhas do INSERT
+type insertResult struct {
+	firstID int64
+	rows    int64
+}
+
+func (r insertResult) LastInsertId() (int64, error) { return r.firstID, nil }
+func (r insertResult) RowsAffected() (int64, error) { return r.rows, nil }
+
+// query seleciona as linhas da tabela da query que atendem às igualdades do WHERE
+func (f *DB) query(query string, args []driver.NamedValue) (*rows, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record(query, args)
+
+	match := fromRegex.FindStringSubmatch(query)
+	if match == nil {
+		return &rows{}, nil
+	}
+	table := match[1]
+
+	var selected []Row
+	if f.strict {
+		conditions, err := strictConditions(query, table, args)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range f.tables[table] {
+			if matchesAll(conditions, row, args) {
+				selected = append(selected, row)
+			}
+		}
+	} else {
+		for _, row := range f.tables[table] {
+			if matches(query, table, row, args) {
+				selected = append(selected, row)
+			}
+		}
+	}
+
+	if countRegex.MatchString(query) {
+		return &rows{columns: []string{"count"}, values: [][]driver.Value{{int64(len(selected))}}}, nil
+	}
+
+	columns := map[string]bool{}
+	for _, row := range f.tables[table] {
+		for column := range row {
+			columns[column] = true
+		}
+	}
+	result := &rows{}
+	for column := range columns {
+		result.columns = append(result.columns, column)
+	}
+	sort.Strings(result.columns)
+	for _, row := range selected {
+		values := make([]driver.Value, len(result.columns))
+		for i, column := range result.columns {
+			values[i] = row[column]
+		}
+		result.values = append(result.values, values)
+	}
+	return result, nil
+}
+
+// matches avalia as condições coluna = ? do WHERE contra a linha
+// Placeholders são associados aos argumentos pela posição na query
+func matches(query, table string, row Row, args []driver.NamedValue) bool {
+	where := whereRegex.FindStringSubmatchIndex(query)
+	if where == nil {
+		return true
+	}
+	start := where[2]
+	clause := query[start:where[3]]
+	before := strings.Count(query[:start], "?")
+
+	for _, cond := range equalRegex.FindAllStringSubmatchIndex(clause, -1) {
+		qualifier, column := "", clause[cond[4]:cond[5]]
+		if cond[2] >= 0 {
+			qualifier = clause[cond[2]:cond[3]]
+		}
+		if qualifier != "" && qualifier != table {
+			continue
+		}
+		position := before + strings.Count(clause[:cond[1]], "?") - 1
+		if position >= len(args) {
+			continue
+		}
+		if fmt.Sprint(row[column]) != fmt.Sprint(driverValue(args[position].Value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// strictConditions interpreta o WHERE inteiro e falha na primeira condição não suportada
+func strictConditions(query, table string, args []driver.NamedValue) ([]condition, error) {
+	if joinRegex.MatchString(query) {
+		return nil, fmt.Errorf("dbtest: strict mode does not evaluate JOIN: %s", query)
+	}
+	where := whereRegex.FindStringSubmatchIndex(query)
+	if where == nil {
+		return nil, nil
+	}
+	start := where[2]
+	clause := query[start:where[3]]
+	position := strings.Count(query[:start], "?")
+
+	var conditions []condition
+	for _, part := range andRegex.Split(strings.Trim(strings.TrimSpace(clause), "()"), -1) {
+		part = strings.TrimSpace(strings.Trim(strings.TrimSpace(part), "()"))
+		match := strictRegex.FindStringSubmatch(part)
+		if match == nil {
+			return nil, fmt.Errorf("dbtest: strict mode cannot evaluate condition %q: %s", part, query)
+		}
+		if match[1] != "" && match[1] != table {
+			return nil, fmt.Errorf("dbtest: strict mode cannot evaluate condition on table %s: %s", match[1], query)
+		}
+		cond := condition{column: match[2], isNull: !strings.HasPrefix(match[3], "=")}
+		if !cond.isNull {
+			cond.position = position
+			position++
+			if cond.position >= len(args) {
+				return nil, fmt.Errorf("dbtest: missing argument for %q: %s", part, query)
+			}
+		}
+		conditions = append(conditions, cond)
+	}
+	return conditions, nil
+}
+
+// matchesAll avalia todas as condições do modo estrito contra a linha
+func matchesAll(conditions []condition, row Row, args []driver.NamedValue) bool {
+	for _, cond := range conditions {
+		if cond.isNull {
+			if row[cond.column] != nil {
+				return false
+			}
+			continue
+		}
+		if fmt.Sprint(row[cond.column]) != fmt.Sprint(driverValue(args[cond.position].Value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// driverValue converte o valor para um dos tipos aceitos por database/sql/driver
+// Tipos nomeados (ex: models.InviteStatus) são reduzidos ao tipo básico
+func driverValue(value any) driver.Value {
+	if value == nil {
+		return nil
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		if v, err := valuer.Value(); err == nil {
+			return v
+		}
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return driverValue(v.Elem().Interface())
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	if t, ok := value.(time.Time); ok {
+		return t
+	}
+	return fmt.Sprint(value)
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fake, ok := instances.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("dbtest: unknown database %s", name)
+	}
+	return &conn{db: fake.(*DB)}, nil
+}
+
+type conn struct {
+	db *DB
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+func (c *conn) Close() error { return nil }
+
+func (c *conn) Begin() (driver.Tx, error) { return tx{}, nil }
+
+func (c *conn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) { return tx{}, nil }
+
+func (c *conn) Ping(context.Context) error { return nil }
+
+func (c *conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.db.query(query, args)
+}
+
+func (c *conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.db.exec(query, args), nil
+}
+
+// execResult simula o resultado da escrita: UPDATE condicional "encontra" a linha
+func execResult(query string) driver.Result {
+	if updateRegex.MatchString(query) {
+		return driver.RowsAffected(1)
+	}
+	return driver.RowsAffected(0)
+}
+
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+func (s *stmt) Close() error  { return nil }
+func (s *stmt) NumInput() int { return -1 }
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return s.conn.db.exec(s.query, named), nil
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return s.conn.db.query(s.query, named)
+}
+
+type tx struct{}
+
+func (tx) Commit() error   { return nil }
+func (tx) Rollback() error { return nil }
+
+type rows struct {
+	columns []string
+	values  [][]driver.Value
+	next    int
+}
+
+func (r *rows) Columns() []string { return r.columns }
+func (r *rows) Close() error      { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,55 @@
+package dbtest
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type wedding struct {
+	ID     uint
+	UserID uint
+}
+
+// TestStrictRejectsUnsupportedConditions garante que o modo estrito não ignora filtros que não avalia
+func TestStrictRejectsUnsupportedConditions(t *testing.T) {
+	db, fake := Open()
+	fake.Strict()
+	fake.Insert("weddings", Row{"id": 10, "user_id": 1})
+
+	queries := map[string]func() error{
+		"or":   func() error { return db.Where("id = ? OR user_id = ?", 10, 2).Find(&[]wedding{}).Error },
+		"in":   func() error { return db.Where("user_id IN ?", []uint{1, 2}).Find(&[]wedding{}).Error },
+		"gt":   func() error { return db.Where("id > ?", 1).Find(&[]wedding{}).Error },
+		"join": func() error { return db.Joins("JOIN users ON users.id = weddings.user_id").Find(&[]wedding{}).Error },
+		"literal": func() error {
+			return db.Where("user_id = 1").Find(&[]wedding{}).Error
+		},
+	}
+	for name, run := range queries {
+		t.Run(name, func(t *testing.T) {
+			if err := run(); err == nil || !strings.Contains(err.Error(), "strict mode") {
+				t.Errorf("error = %v, want strict mode failure", err)
+			}
+		})
+	}
+}
+
+// TestStrictEvaluatesEqualitiesAndNull cobre as condições aceitas: igualdade e IS NULL (soft delete)
+func TestStrictEvaluatesEqualitiesAndNull(t *testing.T) {
+	db, fake := Open()
+	fake.Strict()
+	fake.Insert("weddings",
+		Row{"id": 10, "user_id": 1},
+		Row{"id": 11, "user_id": 2},
+		Row{"id": 12, "user_id": 1, "deleted_at": time.Now()},
+	)
+
+	var found []wedding
+	if err := db.Where("user_id = ? AND (deleted_at IS NULL)", 1).Find(&found).Error; err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if len(found) != 1 || found[0].ID != 10 {
+		t.Errorf("found = %+v, want only wedding 10", found)
+	}
+}
@@ -0,0 +1,200 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// Volume mínimo para que o otimizador prefira índices a uma varredura completa
+const (
+	indexTestWeddings          = 20
+	indexTestRowsPerWedding    = 100
+	indexTestRowsPerInsertStep = 500
+)
+
+// explainRow é a parte do EXPLAIN usada nas verificações
+type explainRow struct {
+	Table        string
+	PossibleKeys *string `gorm:"column:possible_keys"`
+	Key          *string
+	Rows         int64
+	Extra        *string
+}
+
+// hotQuery é uma consulta frequente com o índice composto que deve atendê-la
+type hotQuery struct {
+	name  string
+	index string
+	table string
+	where string // cláusulas após FROM <tabela> [IGNORE INDEX]
+}
+
+// openIndexTestDB conecta ao MySQL de testes (TEST_DATABASE_URL) ou pula o teste
+// Segurança: use um banco descartável; o schema é migrado e os dados de teste são removidos ao final
+func openIndexTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set: EXPLAIN checks need a MySQL test database")
+	}
+
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	previous := DB
+	DB = db
+	t.Cleanup(func() { DB = previous })
+
+	if err := MigrateDB(Models()...); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// seedIndexTestData cria casamentos com convidados, gastos e arrecadações suficientes para o otimizador
+func seedIndexTestData(t *testing.T, db *gorm.DB) []uint {
+	t.Helper()
+	tx := db.Session(&gorm.Session{SkipHooks: true})
+
+	user := models.User{
+		Name:         "Index Test",
+		Email:        fmt.Sprintf("index-test-%d@example.com", time.Now().UnixNano()),
+		PasswordHash: "x",
+	}
+	if err := tx.Create(&user).Error; err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM guests WHERE wedding_id IN (SELECT id FROM weddings WHERE user_id = ?)", user.ID)
+		db.Exec("DELETE FROM expenses WHERE wedding_id IN (SELECT id FROM weddings WHERE user_id = ?)", user.ID)
+		db.Exec("DELETE FROM fundraisings WHERE wedding_id IN (SELECT id FROM weddings WHERE user_id = ?)", user.ID)
+		db.Exec("DELETE FROM weddings WHERE user_id = ?", user.ID)
+		db.Exec("DELETE FROM users WHERE id = ?", user.ID)
+	})
+
+	statuses := []models.InviteStatus{models.InviteStatusPending, models.InviteStatusSent, models.InviteStatusConfirmed, models.InviteStatusDeclined}
+	categories := []models.ExpenseCategory{models.ExpenseCategoryFood, models.ExpenseCategoryDecoration}
+	day := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var weddingIDs []uint
+	for w := 0; w < indexTestWeddings; w++ {
+		wedding := models.Wedding{UserID: user.ID, VenueName: "Espaço", EventDate: day.AddDate(0, w, 0), MaxGuests: 1000}
+		if err := tx.Create(&wedding).Error; err != nil {
+			t.Fatalf("seed wedding: %v", err)
+		}
+		weddingIDs = append(weddingIDs, wedding.ID)
+
+		guests := make([]models.Guest, indexTestRowsPerWedding)
+		expenses := make([]models.Expense, indexTestRowsPerWedding)
+		fundraising := make([]models.Fundraising, indexTestRowsPerWedding)
+		for i := range guests {
+			guests[i] = models.Guest{WeddingID: wedding.ID, FullName: fmt.Sprintf("Guest %d", i), InviteStatus: statuses[i%len(statuses)]}
+			expenses[i] = models.Expense{WeddingID: wedding.ID, Category: categories[i%len(categories)], Amount: 10, Status: models.ExpenseStatusPlanned}
+			fundraising[i] = models.Fundraising{WeddingID: wedding.ID, Type: models.FundraisingTypeGift, Amount: 10, Date: day.AddDate(0, 0, i)}
+		}
+		for _, batch := range []any{&guests, &expenses, &fundraising} {
+			if err := tx.Omit(clause.Associations).CreateInBatches(batch, indexTestRowsPerInsertStep).Error; err != nil {
+				t.Fatalf("seed %T: %v", batch, err)
+			}
+		}
+	}
+
+	for _, table := range []string{"guests", "expenses", "fundraisings"} {
+		if err := db.Exec("ANALYZE TABLE " + table).Error; err != nil {
+			t.Fatalf("analyze %s: %v", table, err)
+		}
+	}
+	return weddingIDs
+}
+
+// explain executa o EXPLAIN da consulta e retorna a linha da tabela principal
+func explain(t *testing.T, db *gorm.DB, q hotQuery, ignoreIndex bool, args ...any) explainRow {
+	t.Helper()
+
+	from := "FROM " + q.table
+	if ignoreIndex {
+		from += " IGNORE INDEX (" + q.index + ")"
+	}
+
+	var rows []explainRow
+	if err := db.Raw("EXPLAIN SELECT * "+from+" "+q.where, args...).Scan(&rows).Error; err != nil {
+		t.Fatalf("explain %s: %v", q.name, err)
+	}
+	for _, row := range rows {
+		if row.Table == q.table {
+			return row
+		}
+	}
+	t.Fatalf("explain %s: no row for table %s", q.name, q.table)
+	return explainRow{}
+}
+
+// TestCompositeIndexesExplain compara o plano das consultas frequentes sem (antes) e com (depois)
+// os índices compostos: depois da migração, key não é nulo e o índice composto é um candidato
+func TestCompositeIndexesExplain(t *testing.T) {
+	db := openIndexTestDB(t)
+	weddingIDs := seedIndexTestData(t, db)
+	weddingID := weddingIDs[len(weddingIDs)/2]
+
+	queries := []struct {
+		query hotQuery
+		args  []any
+	}{
+		{hotQuery{
+			name:  "guests by wedding and invite status",
+			index: "idx_guest_wedding_status",
+			table: "guests",
+			where: "WHERE wedding_id = ? AND invite_status = ? AND deleted_at IS NULL",
+		}, []any{weddingID, models.InviteStatusConfirmed}},
+		{hotQuery{
+			name:  "expenses by wedding, category and status",
+			index: "idx_expense_wedding_category_status",
+			table: "expenses",
+			where: "WHERE wedding_id = ? AND category = ? AND status = ? AND deleted_at IS NULL",
+		}, []any{weddingID, models.ExpenseCategoryFood, models.ExpenseStatusPlanned}},
+		{hotQuery{
+			name:  "fundraising by wedding ordered by date",
+			index: "idx_fundraising_wedding_date",
+			table: "fundraisings",
+			where: "WHERE wedding_id = ? AND date >= ? AND deleted_at IS NULL ORDER BY date DESC",
+		}, []any{weddingID, time.Date(2030, 2, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	for _, tt := range queries {
+		t.Run(tt.query.name, func(t *testing.T) {
+			before := explain(t, db, tt.query, true, tt.args...)
+			after := explain(t, db, tt.query, false, tt.args...)
+			t.Logf("before: key=%s rows=%d extra=%s", deref(before.Key), before.Rows, deref(before.Extra))
+			t.Logf("after:  key=%s rows=%d extra=%s", deref(after.Key), after.Rows, deref(after.Extra))
+
+			if after.Key == nil {
+				t.Fatalf("query does not use an index (possible_keys=%s)", deref(after.PossibleKeys))
+			}
+			if !strings.Contains(deref(after.PossibleKeys), tt.query.index) {
+				t.Errorf("%s is not a candidate index (possible_keys=%s)", tt.query.index, deref(after.PossibleKeys))
+			}
+			if after.Rows > before.Rows {
+				t.Errorf("composite index plan reads more rows (%d) than the plan without it (%d)", after.Rows, before.Rows)
+			}
+		})
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return "NULL"
+	}
+	return *s
+}
@@ -0,0 +1,203 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// ErrDestructiveMigration indica mudanças que quebrariam a versão anterior durante um deploy blue/green
+var ErrDestructiveMigration = errors.New("migration contains destructive changes")
+
+// ChangeKind classifica um statement DDL gerado pelas migrações
+type ChangeKind string
+
+const (
+	// ChangeExpand é compatível com a versão anterior (nova tabela, coluna opcional, índice)
+	ChangeExpand ChangeKind = "expand"
+	// ChangeDestructive remove ou altera estrutura usada pela versão anterior
+	ChangeDestructive ChangeKind = "destructive"
+)
+
+// Change representa um statement DDL planejado
+type Change struct {
+	Kind   ChangeKind `json:"kind"`
+	SQL    string     `json:"sql"`
+	Reason string     `json:"reason,omitempty"`
+}
+
+// MigrationPlan lista as mudanças que as migrações aplicariam
+type MigrationPlan struct {
+	Changes []Change `json:"changes"`
+}
+
+// Destructive retorna apenas as mudanças destrutivas do plano
+func (p *MigrationPlan) Destructive() []Change {
+	var changes []Change
+	for _, c := range p.Changes {
+		if c.Kind == ChangeDestructive {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// SafeMigrateOptions controla a execução no modo seguro
+type SafeMigrateOptions struct {
+	// AllowDestructive libera mudanças destrutivas e os passos de contract (após todas as réplicas atualizadas)
+	AllowDestructive bool
+	// DryRun apenas planeja, sem executar nada
+	DryRun bool
+}
+
+// ContractStep é a fase "contract" de uma mudança expand/contract
+// Ex: após migrar leituras para uma coluna nova, remover a antiga em um deploy posterior
+type ContractStep struct {
+	Description string
+	Run         func(m gorm.Migrator) error
+}
+
+// contractSteps lista as remoções pendentes, executadas apenas com AllowDestructive
+// Adicione o passo somente quando nenhuma versão em produção usar mais a estrutura removida
+var contractSteps = []ContractStep{}
+
+// MigrateSafe planeja as migrações e só as executa se forem compatíveis com a versão anterior
+// Usado em deploys blue/green e rolling: as duas versões convivem com o mesmo schema
+func MigrateSafe(opts SafeMigrateOptions, models ...interface{}) (*MigrationPlan, error) {
+	plan, err := planMigrations(models...)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range contractSteps {
+		plan.Changes = append(plan.Changes, Change{Kind: ChangeDestructive, SQL: step.Description, Reason: "contract step"})
+	}
+
+	if destructive := plan.Destructive(); len(destructive) > 0 && !opts.AllowDestructive {
+		for _, c := range destructive {
+			log.Printf("[WARN] Mudança destrutiva bloqueada: %s (%s)", c.SQL, c.Reason)
+		}
+		return plan, fmt.Errorf("%w: %d mudança(s); aplique em duas fases (expand/contract) ou confirme explicitamente", ErrDestructiveMigration, len(destructive))
+	}
+
+	if opts.DryRun {
+		return plan, nil
+	}
+
+	if err := MigrateDB(models...); err != nil {
+		return plan, err
+	}
+
+	if opts.AllowDestructive {
+		for _, step := range contractSteps {
+			if err := step.Run(DB.Migrator()); err != nil {
+				return plan, fmt.Errorf("erro no passo de contract %q: %w", step.Description, err)
+			}
+			log.Printf("  ✅ Contract aplicado: %s", step.Description)
+		}
+	}
+	return plan, nil
+}
+
+// planMigrations executa o AutoMigrate em uma conexão dedicada que registra os DDLs sem aplicá-los
+// Consultas de introspecção (information_schema) continuam rodando normalmente
+func planMigrations(models ...interface{}) (*MigrationPlan, error) {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle separado: o callback de captura não afeta as queries da aplicação
+	planner, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB}), &gorm.Config{Logger: DB.Logger})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao preparar planejamento de migrações: %w", err)
+	}
+
+	plan := &MigrationPlan{}
+	err = planner.Callback().Raw().Before("gorm:raw").Register("app:plan_ddl", func(db *gorm.DB) {
+		statement := strings.TrimSpace(db.Statement.SQL.String())
+		if !isDDL(statement) {
+			return
+		}
+		plan.Changes = append(plan.Changes, classifyDDL(statement))
+		db.Statement.ConnPool = skipExecPool{db.Statement.ConnPool}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, model := range models {
+		if err := planner.AutoMigrate(model); err != nil {
+			return nil, fmt.Errorf("erro ao planejar %T: %w", model, err)
+		}
+	}
+	return plan, nil
+}
+
+var (
+	ddlPrefix        = regexp.MustCompile(`(?i)^(CREATE|ALTER|DROP|RENAME|TRUNCATE)\s`)
+	alterTablePrefix = regexp.MustCompile("(?i)^ALTER TABLE\\s+`?[\\w.]+`?\\s+")
+)
+
+func isDDL(statement string) bool {
+	return ddlPrefix.MatchString(statement)
+}
+
+// classifyDDL decide se um DDL é compatível com a versão anterior da aplicação
+func classifyDDL(statement string) Change {
+	upper := strings.ToUpper(statement)
+	change := Change{Kind: ChangeExpand, SQL: statement}
+
+	switch {
+	case strings.HasPrefix(upper, "CREATE"):
+		return change
+	case strings.HasPrefix(upper, "DROP"), strings.HasPrefix(upper, "TRUNCATE"):
+		change.Kind, change.Reason = ChangeDestructive, "drops structure still used by the previous version"
+		return change
+	case strings.HasPrefix(upper, "RENAME"):
+		change.Kind, change.Reason = ChangeDestructive, "rename breaks the previous version"
+		return change
+	}
+
+	action := strings.ToUpper(alterTablePrefix.ReplaceAllString(statement, ""))
+	switch {
+	case strings.HasPrefix(action, "ADD CONSTRAINT"), strings.HasPrefix(action, "ADD INDEX"), strings.HasPrefix(action, "ADD UNIQUE"):
+		return change
+	case strings.HasPrefix(action, "ADD"):
+		// Coluna obrigatória sem default quebra os INSERTs da versão anterior
+		if strings.Contains(action, "NOT NULL") && !strings.Contains(action, "DEFAULT") && !strings.Contains(action, "AUTO_INCREMENT") {
+			change.Kind, change.Reason = ChangeDestructive, "NOT NULL column without default breaks inserts from the previous version"
+		}
+		return change
+	case strings.HasPrefix(action, "DROP"):
+		change.Kind, change.Reason = ChangeDestructive, "drops structure still used by the previous version"
+	case strings.HasPrefix(action, "RENAME"), strings.HasPrefix(action, "CHANGE"):
+		change.Kind, change.Reason = ChangeDestructive, "rename breaks the previous version"
+	case strings.HasPrefix(action, "MODIFY"), strings.HasPrefix(action, "ALTER"):
+		change.Kind, change.Reason = ChangeDestructive, "column type/nullability change may reject data from the previous version"
+	default:
+		change.Kind, change.Reason = ChangeDestructive, "unrecognized ALTER TABLE operation"
+	}
+	return change
+}
+
+// skipExecPool descarta a execução de um statement capturado pelo planejamento
+type skipExecPool struct {
+	gorm.ConnPool
+}
+
+func (skipExecPool) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return driverResult{}, nil
+}
+
+// driverResult é o resultado vazio de um statement não executado
+type driverResult struct{}
+
+func (driverResult) LastInsertId() (int64, error) { return 0, nil }
+func (driverResult) RowsAffected() (int64, error) { return 0, nil }
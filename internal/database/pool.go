@@ -0,0 +1,101 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/metrics"
+)
+
+const (
+	// Intervalo de coleta do monitor do pool
+	poolMonitorInterval = 15 * time.Second
+
+	// Utilização a partir da qual o pool é considerado saturado
+	poolSaturationRatio = 0.9
+
+	// Espera média por conexão que dispara alerta
+	poolWaitWarnThreshold = 50 * time.Millisecond
+
+	// Fração de max_connections do MySQL que o serviço pode usar sozinho
+	// Deixa folga para réplicas, migrações e ferramentas administrativas
+	poolServerShareWarn = 0.8
+)
+
+var (
+	stopPoolMonitor     = make(chan struct{})
+	stopPoolMonitorOnce sync.Once
+)
+
+// stopPoolMonitoring encerra o monitor do pool (seguro para chamadas repetidas)
+func stopPoolMonitoring() {
+	stopPoolMonitorOnce.Do(func() { close(stopPoolMonitor) })
+}
+
+// validatePoolSize garante que MAX_DB_CONNS cabe no max_connections do servidor
+// Falha se exceder o limite; apenas alerta se consumir quase todo o limite
+func validatePoolSize() error {
+	var maxConnections int
+	if err := DB.Raw("SELECT @@max_connections").Scan(&maxConnections).Error; err != nil {
+		return fmt.Errorf("erro ao consultar max_connections: %w", err)
+	}
+
+	if configs.MAX_DB_CONNS > maxConnections {
+		return fmt.Errorf("MAX_DB_CONNS (%d) excede max_connections do banco (%d)", configs.MAX_DB_CONNS, maxConnections)
+	}
+
+	if float64(configs.MAX_DB_CONNS) > float64(maxConnections)*poolServerShareWarn {
+		log.Printf("[WARN] MAX_DB_CONNS (%d) usa mais de %.0f%% do max_connections do banco (%d): múltiplas réplicas podem esgotar o limite",
+			configs.MAX_DB_CONNS, poolServerShareWarn*100, maxConnections)
+	}
+
+	return nil
+}
+
+// startPoolMonitor publica as métricas do pool e alerta quando ele satura ou a espera cresce
+// Concorrência: Uma única goroutine, encerrada por CloseDatabase
+func startPoolMonitor(sqlDB *sql.DB) {
+	metrics.RegisterDBStats(sqlDB.Stats)
+
+	go func() {
+		ticker := time.NewTicker(poolMonitorInterval)
+		defer ticker.Stop()
+
+		previous := sqlDB.Stats()
+		for {
+			select {
+			case <-stopPoolMonitor:
+				return
+			case <-ticker.C:
+				current := sqlDB.Stats()
+				checkPoolHealth(previous, current)
+				previous = current
+			}
+		}
+	}()
+}
+
+// checkPoolHealth compara duas coletas e registra saturação ou aumento de espera
+func checkPoolHealth(previous, current sql.DBStats) {
+	waits := current.WaitCount - previous.WaitCount
+	waited := current.WaitDuration - previous.WaitDuration
+
+	saturated := current.MaxOpenConnections > 0 &&
+		float64(current.InUse) >= float64(current.MaxOpenConnections)*poolSaturationRatio
+
+	if saturated {
+		metrics.RecordPoolSaturation()
+		log.Printf("[WARN] DB pool saturated: %d/%d connections in use, %d requests waited in the last %v",
+			current.InUse, current.MaxOpenConnections, waits, poolMonitorInterval)
+		return
+	}
+
+	if waits > 0 && waited/time.Duration(waits) >= poolWaitWarnThreshold {
+		log.Printf("[WARN] DB pool wait time growing: %d waits averaging %v in the last %v",
+			waits, waited/time.Duration(waits), poolMonitorInterval)
+	}
+}
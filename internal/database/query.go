@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/metrics"
+	"gorm.io/gorm"
+)
+
+const (
+	queryStartKey  = "query:start"
+	queryCancelKey = "query:cancel"
+)
+
+// WithContext retorna uma sessão do banco vinculada ao contexto da requisição
+// Queries são canceladas quando o cliente desconecta ou o deadline da request expira
+func WithContext(ctx context.Context) *gorm.DB {
+	return DB.WithContext(ctx)
+}
+
+// registerQueryCallbacks aplica um deadline a cada query e mede queries lentas
+// O deadline é o menor entre o do contexto da request e DB_QUERY_TIMEOUT_MS
+func registerQueryCallbacks(db *gorm.DB) error {
+	cb := db.Callback()
+
+	// "*" garante que o deadline envolva também a transação implícita, preloads e hooks
+	// Operações cujo resultado é consumido dentro do próprio callback recebem deadline
+	if err := cb.Create().Before("*").Register("app:before_create", beforeQuery(true)); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("*").Register("app:before_query", beforeQuery(true)); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("*").Register("app:before_update", beforeQuery(true)); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("*").Register("app:before_delete", beforeQuery(true)); err != nil {
+		return err
+	}
+	if err := cb.Raw().Before("*").Register("app:before_raw", beforeQuery(true)); err != nil {
+		return err
+	}
+	// Row/Rows: o *sql.Rows é lido depois do callback, cancelar o contexto aqui interromperia a leitura
+	if err := cb.Row().Before("*").Register("app:before_row", beforeQuery(false)); err != nil {
+		return err
+	}
+
+	if err := cb.Create().After("*").Register("app:after_create", afterQuery); err != nil {
+		return err
+	}
+	if err := cb.Query().After("*").Register("app:after_query", afterQuery); err != nil {
+		return err
+	}
+	if err := cb.Update().After("*").Register("app:after_update", afterQuery); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("*").Register("app:after_delete", afterQuery); err != nil {
+		return err
+	}
+	if err := cb.Raw().After("*").Register("app:after_raw", afterQuery); err != nil {
+		return err
+	}
+	return cb.Row().After("*").Register("app:after_row", afterQuery)
+}
+
+// beforeQuery registra o início da query e, se permitido, aplica o deadline
+func beforeQuery(withDeadline bool) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		db.InstanceSet(queryStartKey, time.Now())
+
+		if !withDeadline || db.Statement.Context == nil {
+			return
+		}
+
+		timeout := time.Duration(configs.Runtime().DBQueryTimeoutMS) * time.Millisecond
+		if timeout <= 0 {
+			return
+		}
+
+		// Contexto da request com deadline menor prevalece
+		if deadline, ok := db.Statement.Context.Deadline(); ok && time.Until(deadline) <= timeout {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(db.Statement.Context, timeout)
+		db.Statement.Context = ctx
+		db.InstanceSet(queryCancelKey, cancel)
+	}
+}
+
+// afterQuery libera o deadline e contabiliza queries lentas ou canceladas
+func afterQuery(db *gorm.DB) {
+	if cancel, ok := db.InstanceGet(queryCancelKey); ok {
+		cancel.(context.CancelFunc)()
+	}
+
+	if db.Error != nil && errors.Is(db.Error, context.DeadlineExceeded) {
+		metrics.RecordQueryTimeout()
+	}
+
+	start, ok := db.InstanceGet(queryStartKey)
+	if !ok {
+		return
+	}
+
+	elapsed := time.Since(start.(time.Time))
+	if elapsed >= time.Duration(configs.Runtime().DBSlowQueryMS)*time.Millisecond {
+		// Segurança: SQL com placeholders, sem os valores dos parâmetros
+		metrics.RecordSlowQuery(db.Statement.SQL.String(), elapsed)
+	}
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// Models lista todos os models persistidos, na ordem de migração
+func Models() []interface{} {
+	return []interface{}{
+		&models.User{},
+		&models.Wedding{},
+		&models.GuestGroup{},
+		&models.Fundraising{},
+		&models.Guest{},
+		&models.Companion{},
+		&models.GuestTag{},
+		&models.GuestTagAssignment{},
+		&models.RSVPQuestion{},
+		&models.RSVPAnswer{},
+		&models.Invite{},
+		&models.InviteDelivery{},
+		&models.Budget{},
+		&models.Expense{},
+		&models.WeddingTheme{},
+		&models.Vendor{},
+		&models.WeddingVendor{},
+		&models.LedgerEntry{},
+		&models.LifecycleEmail{},
+		&models.Referral{},
+		&models.PhotoPortal{},
+		&models.GuestPhoto{},
+		&models.EventInfo{},
+		&models.PrintOrder{},
+		&models.PrintOrderItem{},
+		&models.GuestStatusHistory{},
+		&models.JobLease{},
+		&models.MaintenanceFlag{},
+		&models.WhatsAppAccount{},
+		&models.AsyncJob{},
+		&models.InviteTemplate{},
+		&models.FunnelSnapshot{},
+		&models.Announcement{},
+		&models.Notification{},
+	}
+}
+
+// VerifySchema confere se todas as tabelas e colunas esperadas pelos models existem
+// Em produção as migrações não rodam automaticamente: detecta deploys sem migração antes do primeiro request
+func VerifySchema() error {
+	migrator := DB.Migrator()
+	var problems []error
+
+	for _, model := range Models() {
+		stmt := &gorm.Statement{DB: DB}
+		if err := stmt.Parse(model); err != nil {
+			return fmt.Errorf("erro ao analisar %T: %w", model, err)
+		}
+
+		table := stmt.Schema.Table
+		if !migrator.HasTable(model) {
+			problems = append(problems, fmt.Errorf("tabela %s ausente", table))
+			continue
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" || field.IgnoreMigration {
+				continue
+			}
+			if !migrator.HasColumn(model, field.DBName) {
+				problems = append(problems, fmt.Errorf("coluna %s.%s ausente", table, field.DBName))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("schema do banco desatualizado (execute as migrações desta versão): %w", errors.Join(problems...))
+	}
+	return nil
+}
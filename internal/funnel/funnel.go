@@ -0,0 +1,177 @@
+package funnel
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/jobs"
+	"github.com/matheushermes/wedding_planner_service/internal/metrics"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// Dias de histórico publicados nas métricas
+	publishedDays = 7
+
+	// O último funil é relido do banco no máximo nesse intervalo (réplicas que não rodaram o job também publicam)
+	cacheTTL = 10 * time.Minute
+)
+
+// Setup registra o job diário que consolida o funil (antes de jobs.Start) e publica o resultado nas métricas
+func Setup() {
+	metrics.RegisterFunnelSnapshot(published)
+
+	jobs.Register(jobs.Job{
+		Name:     "funnel_snapshot",
+		Interval: 24 * time.Hour,
+		Timeout:  10 * time.Minute,
+		Run: func(ctx context.Context) error {
+			rows, err := Run(ctx, database.DB.WithContext(ctx), time.Now())
+			if err != nil {
+				return err
+			}
+			log.Printf("[INFO] Funnel snapshot saved: %d age buckets", rows)
+			cache.invalidate()
+			return nil
+		},
+	})
+}
+
+// weddingProgress indica as etapas do funil já alcançadas por um casamento
+type weddingProgress struct {
+	CreatedAt      time.Time
+	HasGuests      bool
+	HasInvitesSent bool
+	HasRSVPs       bool `gorm:"column:has_rsvps"`
+}
+
+// Run calcula o funil de todos os casamentos e grava o dia de now (reexecuções no mesmo dia sobrescrevem)
+// Performance: uma única query com EXISTS por etapa, lida em streaming
+func Run(ctx context.Context, db *gorm.DB, now time.Time) (int, error) {
+	rows, err := db.Raw(`SELECT w.created_at,
+			EXISTS (SELECT 1 FROM guests g WHERE g.wedding_id = w.id AND g.deleted_at IS NULL) AS has_guests,
+			EXISTS (SELECT 1 FROM invites i WHERE i.wedding_id = w.id AND i.sent_at IS NOT NULL AND i.deleted_at IS NULL) AS has_invites_sent,
+			EXISTS (SELECT 1 FROM guests g WHERE g.wedding_id = w.id AND g.deleted_at IS NULL AND g.invite_status IN ?) AS has_rsvps
+		FROM weddings w
+		WHERE w.deleted_at IS NULL`,
+		[]models.InviteStatus{models.InviteStatusConfirmed, models.InviteStatusDeclined}).Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	byBucket := map[string]*models.FunnelSnapshot{}
+	for _, bucket := range metrics.WeddingAgeBuckets {
+		byBucket[bucket] = &models.FunnelSnapshot{Date: date, AgeBucket: bucket}
+	}
+
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		var p weddingProgress
+		if err := db.ScanRows(rows, &p); err != nil {
+			return 0, err
+		}
+
+		s := byBucket[metrics.WeddingAgeBucket(p.CreatedAt, now)]
+		s.Weddings++
+		if p.HasGuests {
+			s.WithGuests++
+		}
+		if p.HasInvitesSent {
+			s.WithInvitesSent++
+		}
+		if p.HasRSVPs {
+			s.WithRSVPs++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	snapshots := make([]models.FunnelSnapshot, 0, len(byBucket))
+	for _, bucket := range metrics.WeddingAgeBuckets {
+		snapshots = append(snapshots, *byBucket[bucket])
+	}
+	err = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "date"}, {Name: "age_bucket"}},
+		DoUpdates: clause.AssignmentColumns([]string{"weddings", "with_guests", "with_invites_sent", "with_rsvps", "updated_at"}),
+	}).Create(&snapshots).Error
+	if err != nil {
+		return 0, err
+	}
+	return len(snapshots), nil
+}
+
+// bucketCounts são as etapas de uma faixa de idade publicadas nas métricas
+type bucketCounts struct {
+	Weddings        int `json:"weddings"`
+	WithGuests      int `json:"with_guests"`
+	WithInvitesSent int `json:"with_invites_sent"`
+	WithRSVPs       int `json:"with_rsvps"`
+}
+
+// snapshotCache guarda os últimos dias consolidados publicados em /debug/vars
+type snapshotCache struct {
+	mu       sync.Mutex
+	loadedAt time.Time
+	value    interface{}
+}
+
+var cache = &snapshotCache{}
+
+func (c *snapshotCache) invalidate() {
+	c.mu.Lock()
+	c.loadedAt = time.Time{}
+	c.mu.Unlock()
+}
+
+// published retorna o funil dos últimos dias por data e faixa de idade
+// Falhas de leitura mantêm o valor anterior: a coleta de métricas não deve falhar por causa do banco
+func published() interface{} {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if database.DB == nil || time.Since(cache.loadedAt) < cacheTTL {
+		return cache.value
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var snapshots []models.FunnelSnapshot
+	err := database.DB.WithContext(ctx).
+		Where("date >= ?", time.Now().UTC().AddDate(0, 0, -publishedDays)).
+		Order("date DESC").
+		Find(&snapshots).Error
+	if err != nil {
+		log.Printf("[WARN] Failed to load funnel snapshots for metrics: %v", err)
+		return cache.value
+	}
+
+	days := map[string]map[string]bucketCounts{}
+	for _, s := range snapshots {
+		day := s.Date.Format("2006-01-02")
+		if days[day] == nil {
+			days[day] = map[string]bucketCounts{}
+		}
+		days[day][s.AgeBucket] = bucketCounts{
+			Weddings:        s.Weddings,
+			WithGuests:      s.WithGuests,
+			WithInvitesSent: s.WithInvitesSent,
+			WithRSVPs:       s.WithRSVPs,
+		}
+	}
+
+	cache.value = days
+	cache.loadedAt = time.Now()
+	return cache.value
+}
@@ -0,0 +1,131 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// State representa a situação de uma integração opcional
+type State string
+
+const (
+	StateOK          State = "ok"
+	StateDegraded    State = "degraded"    // última chamada falhou, ainda abaixo do limite de falhas seguidas
+	StateUnavailable State = "unavailable" // falhas seguidas acima do limite
+	StateUnknown     State = "unknown"     // nenhuma chamada desde o boot
+	StateDisabled    State = "disabled"    // integração não configurada
+)
+
+// Integrações opcionais acompanhadas: falhas degradam o serviço sem derrubar a readiness
+const (
+	Mailer   = "mailer"
+	WhatsApp = "whatsapp"
+	SMS      = "sms"
+	Payments = "payments"
+	Storage  = "storage"
+)
+
+// Falhas seguidas a partir das quais a integração é considerada indisponível
+const unavailableAfter = 3
+
+// Dependency é a situação de uma integração exposta no /health/status
+// Segurança: a mensagem de erro não é exposta (endpoint público e erros de provedor citam destinatários)
+type Dependency struct {
+	State               State      `json:"state"`
+	LastSuccessAt       *time.Time `json:"last_success_at"`
+	LastFailureAt       *time.Time `json:"last_failure_at"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+}
+
+// dependency acumula os resultados das chamadas a uma integração
+type dependency struct {
+	configured  func() bool
+	lastSuccess time.Time
+	lastFailure time.Time
+	failures    int
+}
+
+var (
+	mu           sync.Mutex
+	dependencies = map[string]*dependency{}
+)
+
+// Register passa a acompanhar a integração; configured (opcional) indica se ela está habilitada
+func Register(name string, configured func() bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	dependencies[name] = &dependency{configured: configured}
+}
+
+// Record registra o resultado de uma chamada à integração (err nil = sucesso)
+// Cancelamentos do chamador não contam como falha da integração
+func Record(name string, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	d, ok := dependencies[name]
+	if !ok {
+		return
+	}
+	now := time.Now()
+	if err != nil {
+		d.lastFailure = now
+		d.failures++
+		return
+	}
+	d.lastSuccess = now
+	d.failures = 0
+}
+
+// RecordHTTP registra o resultado de uma chamada HTTP ao provedor
+// Erros de rede e respostas 5xx são falhas; 4xx recusam a requisição, mas o provedor respondeu
+func RecordHTTP(name string, status int, err error) {
+	if err == nil && status >= 500 {
+		err = errors.New("provider returned server error")
+	}
+	Record(name, err)
+}
+
+// Report retorna a situação de cada integração acompanhada
+func Report() map[string]Dependency {
+	mu.Lock()
+	defer mu.Unlock()
+
+	report := make(map[string]Dependency, len(dependencies))
+	for name, d := range dependencies {
+		report[name] = Dependency{
+			State:               d.state(),
+			LastSuccessAt:       timePtr(d.lastSuccess),
+			LastFailureAt:       timePtr(d.lastFailure),
+			ConsecutiveFailures: d.failures,
+		}
+	}
+	return report
+}
+
+// state calcula a situação a partir das falhas seguidas
+func (d *dependency) state() State {
+	switch {
+	case d.configured != nil && !d.configured():
+		return StateDisabled
+	case d.failures >= unavailableAfter:
+		return StateUnavailable
+	case d.failures > 0:
+		return StateDegraded
+	case d.lastSuccess.IsZero():
+		return StateUnknown
+	}
+	return StateOK
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,271 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/metrics"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Erros customizados para melhor tratamento
+var (
+	ErrAlreadyStarted = errors.New("scheduler already started")
+	errLockNotHeld    = errors.New("job lock held by another replica")
+)
+
+// Job representa uma tarefa periódica
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Timeout  time.Duration // 0 usa o próprio Interval
+	Run      func(ctx context.Context) error
+}
+
+// Frequência com que cada job verifica se já é hora de executar
+const tickInterval = time.Minute
+
+var (
+	mu         sync.Mutex
+	registered []Job
+	started    bool
+	stopping   chan struct{}      // fechado no shutdown: nenhuma execução nova começa
+	cancelRuns context.CancelFunc // aborta execuções em andamento quando o prazo de shutdown expira
+	wake       = map[string]chan struct{}{}
+	wg         sync.WaitGroup
+	owner      = replicaID()
+)
+
+// Register adiciona um job ao agendador (antes de Start)
+func Register(job Job) {
+	mu.Lock()
+	defer mu.Unlock()
+	registered = append(registered, job)
+}
+
+// Start inicia uma goroutine por job
+// Concorrência: Cada execução é protegida por um advisory lock do MySQL (GET_LOCK)
+// e pela tabela job_leases, então várias réplicas nunca executam o mesmo job em dobro
+func Start(db *gorm.DB) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if started {
+		return ErrAlreadyStarted
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("erro ao obter *sql.DB: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelRuns = cancel
+	stopping = make(chan struct{})
+	started = true
+
+	for _, job := range registered {
+		wake[job.Name] = make(chan struct{}, 1)
+		wg.Add(1)
+		go loop(ctx, stopping, wake[job.Name], db, sqlDB, job)
+	}
+
+	log.Printf("[INFO] Scheduler started with %d job(s) on replica %s", len(registered), owner)
+	return nil
+}
+
+// Trigger antecipa a próxima verificação do job nesta réplica (ex: tarefa recém-enfileirada)
+// O lease continua valendo: o job só executa se o intervalo desde a última execução passou
+func Trigger(name string) {
+	mu.Lock()
+	ch := wake[name]
+	mu.Unlock()
+
+	select {
+	case ch <- struct{}{}:
+	default:
+		// Já há um aviso pendente (ou o job não está registrado)
+	}
+}
+
+// Shutdown para de agendar execuções e aguarda as que estão em andamento
+// Se ctx expirar antes, as execuções são canceladas: como o lease só é renovado
+// em caso de sucesso, o job interrompido volta para a fila e roda no próximo tick
+func Shutdown(ctx context.Context) error {
+	mu.Lock()
+	if !started {
+		mu.Unlock()
+		return nil
+	}
+	close(stopping)
+	started = false
+	cancel := cancelRuns
+	mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		cancel()
+		log.Println("[INFO] Scheduler drained: all in-flight jobs finished")
+		return nil
+	case <-ctx.Done():
+		cancel()
+		<-done
+		log.Println("[WARN] Scheduler shutdown deadline reached: in-flight jobs were cancelled and will run again on the next tick")
+		return ctx.Err()
+	}
+}
+
+// loop verifica periodicamente (ou quando acionado por Trigger) se o job deve rodar até o início do shutdown
+func loop(ctx context.Context, stop <-chan struct{}, wakeUp <-chan struct{}, db *gorm.DB, sqlDB *sql.DB, job Job) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		runOnce(ctx, db, sqlDB, job)
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		case <-wakeUp:
+		}
+	}
+}
+
+// runOnce tenta adquirir o lock do job e executá-lo se o intervalo desde a última execução passou
+func runOnce(ctx context.Context, db *gorm.DB, sqlDB *sql.DB, job Job) {
+	// GET_LOCK pertence à conexão: usa uma conexão dedicada durante toda a execução
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("[ERROR] Job %s: failed to get connection: %v", job.Name, err)
+		}
+		return
+	}
+	defer conn.Close()
+
+	lockName := "wedding_planner:job:" + job.Name
+	if err := acquireLock(ctx, conn, lockName); err != nil {
+		if errors.Is(err, errLockNotHeld) {
+			metrics.RecordJob(job.Name, metrics.JobSkippedLocked, 0)
+		} else if ctx.Err() == nil {
+			log.Printf("[ERROR] Job %s: failed to acquire lock: %v", job.Name, err)
+		}
+		return
+	}
+	defer releaseLock(conn, lockName)
+
+	// Lease: com o lock em mãos, confere se outra réplica já executou neste intervalo
+	due, err := isDue(ctx, db, job)
+	if err != nil {
+		log.Printf("[ERROR] Job %s: failed to read lease: %v", job.Name, err)
+		return
+	}
+	if !due {
+		metrics.RecordJob(job.Name, metrics.JobSkippedRecent, 0)
+		return
+	}
+
+	timeout := job.Timeout
+	if timeout <= 0 {
+		timeout = job.Interval
+	}
+	runCtx, cancelRun := context.WithTimeout(ctx, timeout)
+	defer cancelRun()
+
+	start := time.Now()
+	runErr := job.Run(runCtx)
+	elapsed := time.Since(start)
+
+	if runErr != nil {
+		metrics.RecordJob(job.Name, metrics.JobFailed, elapsed)
+		if ctx.Err() != nil {
+			log.Printf("[WARN] Job %s interrupted by shutdown after %v, it will be retried", job.Name, elapsed)
+		} else {
+			log.Printf("[ERROR] Job %s failed after %v: %v", job.Name, elapsed, runErr)
+		}
+		return
+	}
+
+	// Só renova o lease em caso de sucesso: falhas são reexecutadas no próximo tick
+	if err := renewLease(db, job, start); err != nil {
+		log.Printf("[ERROR] Job %s: failed to renew lease: %v", job.Name, err)
+	}
+
+	metrics.RecordJob(job.Name, metrics.JobSucceeded, elapsed)
+	log.Printf("[INFO] Job %s finished in %v", job.Name, elapsed)
+}
+
+// acquireLock tenta o advisory lock sem esperar (timeout 0)
+func acquireLock(ctx context.Context, conn *sql.Conn, name string) error {
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", name).Scan(&acquired); err != nil {
+		return err
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return errLockNotHeld
+	}
+	return nil
+}
+
+// releaseLock libera o advisory lock (também liberado automaticamente se a conexão cair)
+func releaseLock(conn *sql.Conn, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", name); err != nil {
+		log.Printf("[WARN] Failed to release job lock %s: %v", name, err)
+	}
+}
+
+// isDue verifica se o intervalo desde a última execução bem-sucedida já passou
+func isDue(ctx context.Context, db *gorm.DB, job Job) (bool, error) {
+	var lease models.JobLease
+	err := db.WithContext(ctx).Where("name = ?", job.Name).First(&lease).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return time.Since(lease.LastRunAt) >= job.Interval, nil
+}
+
+// renewLease grava o início da execução como última execução do job
+func renewLease(db *gorm.DB, job Job, ranAt time.Time) error {
+	lease := models.JobLease{Name: job.Name, Owner: owner, LastRunAt: ranAt}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"owner", "last_run_at", "updated_at"}),
+	}).Create(&lease).Error
+}
+
+// replicaID identifica a réplica nos leases e logs
+func replicaID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
@@ -0,0 +1,156 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/jobs"
+	"github.com/matheushermes/wedding_planner_service/internal/mailer"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// Lembretes atrasados (job parado, deploy) ainda são enviados até 7 dias após a data
+	anniversaryGracePeriod = 7 * 24 * time.Hour
+
+	// Máximo de casamentos avaliados por execução (o restante fica para a próxima)
+	maxAnniversaryCandidates = 2000
+)
+
+// anniversaryCandidate é um casamento com lembrete de bodas ativo
+type anniversaryCandidate struct {
+	WeddingID               uint
+	EventDate               time.Time
+	LastAnniversaryReminder int
+	UserID                  uint
+	Email                   string
+	Name                    string
+}
+
+// SetupAnniversaryReminders registra o job diário de lembretes de bodas (antes de jobs.Start)
+// Independe de LIFECYCLE_EMAILS_ENABLED: o lembrete é pedido pelo próprio casal (opt-in por casamento)
+func SetupAnniversaryReminders() {
+	jobs.Register(jobs.Job{
+		Name:     "anniversary_reminders",
+		Interval: 24 * time.Hour,
+		Timeout:  10 * time.Minute,
+		Run: func(ctx context.Context) error {
+			sent, err := RunAnniversaryReminders(ctx, database.DB.WithContext(ctx), mailer.Default(), time.Now())
+			log.Printf("[INFO] Anniversary reminders sent: %d", sent)
+			return err
+		},
+	})
+}
+
+// RunAnniversaryReminders envia o lembrete dos casamentos que completaram mais um ano
+// O ano é registrado antes do envio (UPDATE condicional): réplicas e reexecuções nunca enviam em dobro
+func RunAnniversaryReminders(ctx context.Context, db *gorm.DB, m mailer.Mailer, now time.Time) (int, error) {
+	var candidates []anniversaryCandidate
+	err := db.Table("weddings").
+		Select(`weddings.id AS wedding_id, weddings.event_date, weddings.last_anniversary_reminder,
+			users.id AS user_id, users.email, users.name`).
+		Joins("JOIN users ON users.id = weddings.user_id AND users.deleted_at IS NULL").
+		Where("weddings.deleted_at IS NULL AND weddings.anniversary_reminders = ?", true).
+		Where("weddings.event_date <= ?", now.AddDate(-1, 0, 0).Add(anniversaryGracePeriod)).
+		Where("weddings.last_anniversary_reminder < ? - YEAR(weddings.event_date)", now.Year()).
+		Order("weddings.id ASC").
+		Limit(maxAnniversaryCandidates).
+		Scan(&candidates).Error
+	if err != nil {
+		return 0, fmt.Errorf("erro ao buscar casamentos com lembrete de bodas: %w", err)
+	}
+
+	var (
+		sent     int
+		problems []error
+	)
+	for _, candidate := range candidates {
+		if ctx.Err() != nil {
+			return sent, errors.Join(append(problems, ctx.Err())...)
+		}
+
+		years, due := dueAnniversary(candidate, now)
+		if !due {
+			continue
+		}
+
+		delivered, err := deliverAnniversary(ctx, db, m, candidate, years)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("casamento %d: %w", candidate.WeddingID, err))
+		}
+		if delivered {
+			sent++
+		}
+	}
+	return sent, errors.Join(problems...)
+}
+
+// dueAnniversary retorna o último aniversário completado e se o lembrete dele ainda é devido
+func dueAnniversary(c anniversaryCandidate, now time.Time) (int, bool) {
+	wedding := models.Wedding{EventDate: c.EventDate}
+	next, years := wedding.NextAnniversary(now)
+	date := next
+	if next.After(now) {
+		years--
+		date = wedding.AnniversaryOf(years)
+	}
+	if years < 1 || years <= c.LastAnniversaryReminder {
+		return years, false
+	}
+	return years, now.Sub(date) < anniversaryGracePeriod
+}
+
+// deliverAnniversary registra e envia o lembrete; em falha no envio o registro é revertido
+func deliverAnniversary(ctx context.Context, db *gorm.DB, m mailer.Mailer, c anniversaryCandidate, years int) (bool, error) {
+	result := db.Model(&models.Wedding{}).
+		Where("id = ? AND last_anniversary_reminder = ?", c.WeddingID, c.LastAnniversaryReminder).
+		UpdateColumn("last_anniversary_reminder", years)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil // já enviado por outra execução
+	}
+
+	_, err := m.Send(ctx, mailer.Message{
+		To:      c.Email,
+		Subject: anniversarySubject(years),
+		Text: fmt.Sprintf("Olá, %s!\n\n"+
+			"Vocês estão completando %s de casados (o grande dia foi em %s). Parabéns pelas bodas!\n\n"+
+			"Você recebe este lembrete porque ativou os lembretes de aniversário do casamento.\n"+
+			"Para desativar, altere a opção anniversary_reminders nas configurações do casamento.\n",
+			c.Name, yearsLabel(years), c.EventDate.Format("02/01/2006")),
+	})
+	if err != nil {
+		revert := db.Model(&models.Wedding{}).
+			Where("id = ? AND last_anniversary_reminder = ?", c.WeddingID, years).
+			UpdateColumn("last_anniversary_reminder", c.LastAnniversaryReminder)
+		if revert.Error != nil {
+			log.Printf("[ERROR] Failed to release anniversary reminder of wedding %d after send failure: %v", c.WeddingID, revert.Error)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// anniversarySubject monta o assunto com o nome tradicional das bodas mais conhecidas
+func anniversarySubject(years int) string {
+	names := map[int]string{1: "papel", 5: "madeira", 10: "estanho", 15: "cristal", 25: "prata", 50: "ouro"}
+	if name, ok := names[years]; ok {
+		return fmt.Sprintf("Feliz aniversário de casamento: bodas de %s!", name)
+	}
+	return fmt.Sprintf("Feliz aniversário de casamento: %s juntos!", yearsLabel(years))
+}
+
+// yearsLabel formata "1 ano" / "N anos"
+func yearsLabel(years int) string {
+	if years == 1 {
+		return "1 ano"
+	}
+	return fmt.Sprintf("%d anos", years)
+}
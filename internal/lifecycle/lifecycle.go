@@ -0,0 +1,167 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/jobs"
+	"github.com/matheushermes/wedding_planner_service/internal/mailer"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/security"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UnsubscribeTokenPurpose separa os tokens de descadastro de outros tokens assinados
+const UnsubscribeTokenPurpose = "user-lifecycle-opt-out"
+
+// Máximo de destinatários por regra em cada execução (o restante fica para a próxima)
+const maxCandidatesPerRule = 500
+
+// Candidate é um casamento elegível a uma regra, com os dados do dono da conta
+type Candidate struct {
+	UserID    uint
+	Email     string
+	Name      string
+	WeddingID uint
+	EventDate time.Time
+}
+
+// DaysUntilEvent retorna os dias restantes até o casamento
+func (c Candidate) DaysUntilEvent(now time.Time) int {
+	return int(c.EventDate.Sub(now).Hours() / 24)
+}
+
+// UnsubscribePath retorna o link de descadastro que acompanha todo email de ciclo de vida
+func UnsubscribePath(userID uint) string {
+	return "/api/v1/public/unsubscribe/" + security.SignID(UnsubscribeTokenPurpose, userID)
+}
+
+// Setup registra o job de emails de ciclo de vida (antes de jobs.Start)
+// Com LIFECYCLE_EMAILS_ENABLED diferente de "true" o job não é registrado
+func Setup() {
+	if !configs.LIFECYCLE_EMAILS_ENABLED {
+		return
+	}
+
+	jobs.Register(jobs.Job{
+		Name:     "lifecycle_emails",
+		Interval: time.Hour,
+		Timeout:  10 * time.Minute,
+		Run: func(ctx context.Context) error {
+			sent, err := Run(ctx, database.DB.WithContext(ctx), mailer.Default(), time.Now())
+			log.Printf("[INFO] Lifecycle emails sent: %d", sent)
+			return err
+		},
+	})
+}
+
+// Run avalia as regras ativas e envia os emails devidos, respeitando opt-out e o limite semanal por usuário
+// O envio é registrado antes de disparar: o índice único garante um único email por regra e casamento
+func Run(ctx context.Context, db *gorm.DB, m mailer.Mailer, now time.Time) (int, error) {
+	var (
+		sent     int
+		problems []error
+		weekly   = map[uint]int64{}
+	)
+
+	for _, rule := range Rules {
+		if slices.Contains(configs.LIFECYCLE_DISABLED_RULES, rule.Name) {
+			continue
+		}
+
+		candidates, err := findCandidates(db, rule, now)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("regra %s: %w", rule.Name, err))
+			continue
+		}
+
+		for _, candidate := range candidates {
+			if ctx.Err() != nil {
+				return sent, errors.Join(append(problems, ctx.Err())...)
+			}
+
+			count, ok := weekly[candidate.UserID]
+			if !ok {
+				err := db.Model(&models.LifecycleEmail{}).
+					Where("user_id = ? AND created_at >= ?", candidate.UserID, now.AddDate(0, 0, -7)).
+					Count(&count).Error
+				if err != nil {
+					problems = append(problems, err)
+					continue
+				}
+			}
+			if count >= int64(configs.LIFECYCLE_MAX_EMAILS_PER_WEEK) {
+				weekly[candidate.UserID] = count
+				continue
+			}
+
+			delivered, err := deliver(ctx, db, m, rule, candidate, now)
+			if err != nil {
+				problems = append(problems, fmt.Errorf("regra %s, usuário %d: %w", rule.Name, candidate.UserID, err))
+			}
+			if delivered {
+				count++
+				sent++
+			}
+			weekly[candidate.UserID] = count
+		}
+	}
+
+	return sent, errors.Join(problems...)
+}
+
+// findCandidates lista os casamentos elegíveis à regra que ainda não receberam o email
+// Segurança: Contas removidas e usuários com opt-out nunca entram na lista
+func findCandidates(db *gorm.DB, rule Rule, now time.Time) ([]Candidate, error) {
+	query := db.Table("weddings").
+		Select("users.id AS user_id, users.email, users.name, weddings.id AS wedding_id, weddings.event_date").
+		Joins("JOIN users ON users.id = weddings.user_id AND users.deleted_at IS NULL").
+		Where("weddings.deleted_at IS NULL AND users.lifecycle_opt_out_at IS NULL").
+		Where("NOT EXISTS (?)", db.Model(&models.LifecycleEmail{}).
+			Select("1").
+			Where("lifecycle_emails.user_id = users.id AND lifecycle_emails.wedding_id = weddings.id AND lifecycle_emails.rule = ?", rule.Name))
+
+	var candidates []Candidate
+	err := rule.Scope(query, now).
+		Order("weddings.id ASC").
+		Limit(maxCandidatesPerRule).
+		Scan(&candidates).Error
+	return candidates, err
+}
+
+// deliver registra e envia um email; em falha no envio o registro é removido para nova tentativa
+func deliver(ctx context.Context, db *gorm.DB, m mailer.Mailer, rule Rule, c Candidate, now time.Time) (bool, error) {
+	record := models.LifecycleEmail{UserID: c.UserID, Rule: rule.Name, WeddingID: c.WeddingID}
+	result := db.Omit("User").Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil // já enviado
+	}
+
+	unsubscribeURL := configs.PUBLIC_BASE_URL + UnsubscribePath(c.UserID)
+	_, err := m.Send(ctx, mailer.Message{
+		To:      c.Email,
+		Subject: rule.Subject,
+		Text:    rule.Body(c, now) + "\nNão quer mais receber estas dicas? " + unsubscribeURL + "\n",
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	})
+	if err != nil {
+		if delErr := db.Delete(&models.LifecycleEmail{}, record.ID).Error; delErr != nil {
+			log.Printf("[ERROR] Failed to release lifecycle email %d after send failure: %v", record.ID, delErr)
+		}
+		return false, err
+	}
+	return true, nil
+}
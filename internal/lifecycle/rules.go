@@ -0,0 +1,66 @@
+package lifecycle
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Rule define uma campanha de ciclo de vida: quem recebe (Scope) e o que recebe (Subject/Body)
+// Cada regra dispara no máximo uma vez por casamento
+type Rule struct {
+	Name    string
+	Subject string
+
+	// Scope restringe a query base de casamentos elegíveis (tabelas weddings e users)
+	Scope func(q *gorm.DB, now time.Time) *gorm.DB
+
+	Body func(c Candidate, now time.Time) string
+}
+
+// Rules lista as campanhas disponíveis (desativáveis via LIFECYCLE_DISABLED_RULES)
+var Rules = []Rule{
+	{
+		Name:    "no_guests_first_week",
+		Subject: "Comece sua lista de convidados",
+		Scope: func(q *gorm.DB, now time.Time) *gorm.DB {
+			return q.Where("weddings.created_at BETWEEN ? AND ?", now.AddDate(0, 0, -30), now.AddDate(0, 0, -7)).
+				Where("weddings.current_guest_count = 0 AND weddings.event_date > ?", now)
+		},
+		Body: func(c Candidate, now time.Time) string {
+			return fmt.Sprintf("Olá, %s!\n\n"+
+				"Faz uma semana que você criou seu casamento e a lista de convidados ainda está vazia.\n"+
+				"Dica: comece pela família próxima e importe o restante por planilha (CSV).\n"+
+				"Com a lista pronta você já pode organizar grupos, mesas e enviar os convites.\n", c.Name)
+		},
+	},
+	{
+		Name:    "event_in_120_days_no_guests",
+		Subject: "Faltam poucos meses para o grande dia",
+		Scope: func(q *gorm.DB, now time.Time) *gorm.DB {
+			return q.Where("weddings.event_date BETWEEN ? AND ?", now, now.AddDate(0, 0, 120)).
+				Where("weddings.current_guest_count = 0")
+		},
+		Body: func(c Candidate, now time.Time) string {
+			return fmt.Sprintf("Olá, %s!\n\n"+
+				"Seu casamento é daqui a %d dias e você ainda não adicionou convidados.\n"+
+				"Convites costumam ser enviados de 3 a 4 meses antes: é uma boa hora para montar a lista.\n",
+				c.Name, c.DaysUntilEvent(now))
+		},
+	},
+	{
+		Name:    "inactive_30_days",
+		Subject: "Sentimos sua falta",
+		Scope: func(q *gorm.DB, now time.Time) *gorm.DB {
+			return q.Where("users.last_seen_at < ?", now.AddDate(0, 0, -30)).
+				Where("weddings.event_date > ?", now.AddDate(0, 0, 14))
+		},
+		Body: func(c Candidate, now time.Time) string {
+			return fmt.Sprintf("Olá, %s!\n\n"+
+				"Faz um tempo que você não acessa o planejamento do seu casamento (faltam %d dias).\n"+
+				"Confira as confirmações de presença, o orçamento e os pagamentos pendentes.\n",
+				c.Name, c.DaysUntilEvent(now))
+		},
+	},
+}
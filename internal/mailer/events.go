@@ -0,0 +1,142 @@
+package mailer
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+)
+
+// ErrInvalidWebhook indica evento de entrega com assinatura ausente ou inválida
+var ErrInvalidWebhook = errors.New("invalid email event webhook")
+
+// Limite do lote de eventos recebido da SendGrid (proteção contra DoS)
+const maxEventsBodySize = 1 << 20 // 1MB
+
+// DeliveryEvent representa a entrega de um email informada pelo provedor
+type DeliveryEvent struct {
+	Metadata  map[string]string // valores de Message.Metadata enviados com o email
+	MessageID string            // sg_message_id: o retorno de Send seguido de um sufixo do lote
+	Status    models.InviteDeliveryStatus
+	Reason    string
+	At        time.Time
+}
+
+// ParseSendGridEvents valida a assinatura do Event Webhook da SendGrid e normaliza o lote
+// Só delivered, bounce e dropped são retornados; processed, deferred, open, click etc. são ignorados
+// Segurança: assinatura ECDSA de timestamp+payload com a chave pública da conta (Signed Event Webhook)
+func ParseSendGridEvents(r *http.Request, publicKey string, metadataKeys ...string) ([]DeliveryEvent, error) {
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxEventsBodySize))
+	if err != nil {
+		return nil, ErrInvalidWebhook
+	}
+
+	timestamp := r.Header.Get("X-Twilio-Email-Event-Webhook-Timestamp")
+	signature := r.Header.Get("X-Twilio-Email-Event-Webhook-Signature")
+	if err := verifySendGridSignature(publicKey, timestamp, signature, payload); err != nil {
+		return nil, err
+	}
+
+	var raw []map[string]any
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, ErrInvalidWebhook
+	}
+
+	events := make([]DeliveryEvent, 0, len(raw))
+	for _, item := range raw {
+		kind, _ := item["event"].(string)
+		var status models.InviteDeliveryStatus
+		switch kind {
+		case "delivered":
+			status = models.InviteDeliveryDelivered
+		case "bounce":
+			status = models.InviteDeliveryBounced
+		case "dropped":
+			status = models.InviteDeliveryFailed
+		default:
+			continue
+		}
+
+		// custom_args chegam como campos do próprio evento
+		metadata := make(map[string]string, len(metadataKeys))
+		for _, key := range metadataKeys {
+			if value, ok := item[key].(string); ok {
+				metadata[key] = value
+			}
+		}
+		reason, _ := item["reason"].(string)
+		messageID, _ := item["sg_message_id"].(string)
+		at := time.Now()
+		if ts, ok := item["timestamp"].(float64); ok && ts > 0 {
+			at = time.Unix(int64(ts), 0)
+		}
+
+		events = append(events, DeliveryEvent{
+			Metadata:  metadata,
+			MessageID: messageID,
+			Status:    status,
+			Reason:    strings.TrimSpace(reason),
+			At:        at,
+		})
+	}
+	return events, nil
+}
+
+// verifySendGridSignature confere a assinatura ECDSA (base64, ASN.1) de timestamp+payload
+// Sem tolerância de timestamp: a SendGrid reenvia lotes por até 72h e reaplicar um evento não altera
+// a entrega (os status só avançam)
+func verifySendGridSignature(publicKey, timestamp, signature string, payload []byte) error {
+	if publicKey == "" || timestamp == "" || signature == "" {
+		return ErrInvalidWebhook
+	}
+
+	key, err := parseECDSAPublicKey(publicKey)
+	if err != nil {
+		return ErrInvalidWebhook
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidWebhook
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(timestamp))
+	hash.Write(payload)
+	if !ecdsa.VerifyASN1(key, hash.Sum(nil), sig) {
+		return ErrInvalidWebhook
+	}
+	return nil
+}
+
+// parseECDSAPublicKey aceita a chave em PEM ou em base64 (formato exibido no painel da SendGrid)
+func parseECDSAPublicKey(value string) (*ecdsa.PublicKey, error) {
+	var der []byte
+	if block, _ := pem.Decode([]byte(value)); block != nil {
+		der = block.Bytes
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+		der = decoded
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("sendgrid webhook key is not an ECDSA public key")
+	}
+	return key, nil
+}
@@ -0,0 +1,154 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/health"
+)
+
+// ErrInvalidMessage indica destinatário ou headers que permitiriam injeção de headers (CR/LF)
+var ErrInvalidMessage = errors.New("invalid email message")
+
+// Message representa um email em texto puro, com versão HTML opcional (multipart/alternative)
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	Headers map[string]string // ex: List-Unsubscribe
+	// Metadata volta nos eventos de entrega do provedor (SendGrid custom_args); SMTP e SES ignoram
+	Metadata map[string]string
+}
+
+// Mailer abstrai o envio de emails
+// Quem envia depende apenas desta interface: um novo provedor exige só uma nova implementação
+// Send retorna o ID da mensagem no provedor (vazio quando o provedor não informa), usado na auditoria dos envios
+type Mailer interface {
+	Name() string
+	Send(ctx context.Context, msg Message) (string, error)
+}
+
+var (
+	mu      sync.RWMutex
+	current Mailer
+)
+
+func init() {
+	// Mailer de log: emails só registrados, nada a acompanhar
+	health.Register(health.Mailer, func() bool { return Default().Name() != "log" })
+}
+
+// Setup registra o provedor de MAIL_PROVIDER (re-registrado a cada rotação de credenciais)
+// Deve ser chamado após configs.LoadEnv
+func Setup() {
+	registerConfigured()
+	configs.OnSecretsRotated(registerConfigured)
+}
+
+// registerConfigured registra o provedor com as credenciais atuais
+// Provedor sem credenciais cai no mailer de log com aviso: o serviço sobe e os envios ficam visíveis no log
+func registerConfigured() {
+	secrets := configs.CurrentSecrets()
+
+	var m Mailer
+	switch configs.MAIL_PROVIDER {
+	case "smtp":
+		if configs.SMTP_HOST == "" {
+			log.Println("[WARN] MAIL_PROVIDER=smtp sem SMTP_HOST, emails serão apenas registrados no log")
+			break
+		}
+		m = newSMTPMailer(configs.SMTP_HOST, configs.SMTP_PORT, configs.SMTP_USERNAME, secrets.SMTPPassword, configs.MAIL_FROM)
+	case "sendgrid":
+		if secrets.SendGridAPIKey == "" {
+			log.Println("[WARN] MAIL_PROVIDER=sendgrid sem SENDGRID_API_KEY, emails serão apenas registrados no log")
+			break
+		}
+		m = newSendGridMailer(secrets.SendGridAPIKey, configs.MAIL_FROM)
+	case "ses":
+		accessKey, secretKey := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+		if accessKey == "" || secretKey == "" {
+			log.Println("[WARN] MAIL_PROVIDER=ses sem AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY, emails serão apenas registrados no log")
+			break
+		}
+		m = newSESMailer(configs.SES_REGION, accessKey, secretKey, configs.MAIL_FROM)
+	}
+	Register(m)
+}
+
+// SelfCheck confere o remetente e se o provedor de MAIL_PROVIDER foi registrado com credenciais
+// Deve ser chamado após Setup
+func SelfCheck(context.Context) error {
+	if configs.MAIL_PROVIDER == "log" {
+		return nil
+	}
+	if _, err := mail.ParseAddress(configs.MAIL_FROM); err != nil {
+		return fmt.Errorf("MAIL_FROM inválido: %w", err)
+	}
+	if Default().Name() != configs.MAIL_PROVIDER {
+		return fmt.Errorf("MAIL_PROVIDER=%s não está configurado (verifique as credenciais do provedor)", configs.MAIL_PROVIDER)
+	}
+	return nil
+}
+
+// Register define o mailer usado nos envios (nil volta para o mailer de log)
+func Register(m Mailer) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = m
+}
+
+// Default retorna o mailer configurado
+// Sem provedor configurado, as mensagens são apenas registradas no log
+func Default() Mailer {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		return logMailer{}
+	}
+	return current
+}
+
+// logMailer registra as mensagens no log sem enviá-las (desenvolvimento)
+type logMailer struct{}
+
+func (logMailer) Name() string {
+	return "log"
+}
+
+// Send registra destinatário e assunto
+// LGPD: o endereço é mascarado e o corpo não é registrado
+func (logMailer) Send(_ context.Context, msg Message) (string, error) {
+	log.Printf("[INFO] Email not sent (log mailer) to %s: %s", maskEmail(msg.To), msg.Subject)
+	return "", nil
+}
+
+// validate recusa quebras de linha no destinatário, no assunto e nos headers
+// Segurança: impede que dados do convidado injetem headers (ex: Bcc) na mensagem
+func validate(msg Message) error {
+	if msg.To == "" || strings.ContainsAny(msg.To+msg.Subject, "\r\n") {
+		return ErrInvalidMessage
+	}
+	for name, value := range msg.Headers {
+		if name == "" || strings.ContainsAny(name, "\r\n: ") || strings.ContainsAny(value, "\r\n") {
+			return ErrInvalidMessage
+		}
+	}
+	return nil
+}
+
+// maskEmail mantém apenas a primeira letra e o domínio (j***@example.com)
+func maskEmail(email string) string {
+	local, domain, found := strings.Cut(email, "@")
+	if !found || local == "" {
+		return "***"
+	}
+	return local[:1] + "***@" + domain
+}
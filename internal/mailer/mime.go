@@ -0,0 +1,105 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+)
+
+// buildMIME monta a mensagem completa (headers + corpo quoted-printable em UTF-8)
+// Com HTML, o corpo vira multipart/alternative com o texto puro primeiro
+// Usada pelo SMTP e pelo SES (envio raw), que recebem a mensagem pronta; retorna também o Message-ID
+func buildMIME(from *mail.Address, msg Message, now time.Time) ([]byte, string, error) {
+	if err := validate(msg); err != nil {
+		return nil, "", err
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, "", ErrInvalidMessage
+	}
+	id := messageID(from.Address)
+
+	var buf bytes.Buffer
+	writeHeader := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+	writeHeader("From", from.String())
+	writeHeader("To", to.String())
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	writeHeader("Date", now.Format(time.RFC1123Z))
+	writeHeader("Message-ID", id)
+	writeHeader("MIME-Version", "1.0")
+
+	var body bytes.Buffer
+	if msg.HTML == "" {
+		writeHeader("Content-Type", "text/plain; charset=utf-8")
+		writeHeader("Content-Transfer-Encoding", "quoted-printable")
+		if err := writeQuotedPrintable(&body, msg.Text); err != nil {
+			return nil, "", err
+		}
+	} else {
+		parts := multipart.NewWriter(&body)
+		writeHeader("Content-Type", `multipart/alternative; boundary="`+parts.Boundary()+`"`)
+		for _, part := range []struct{ contentType, content string }{
+			{"text/plain; charset=utf-8", msg.Text},
+			{"text/html; charset=utf-8", msg.HTML},
+		} {
+			w, err := parts.CreatePart(textproto.MIMEHeader{
+				"Content-Type":              {part.contentType},
+				"Content-Transfer-Encoding": {"quoted-printable"},
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			if err := writeQuotedPrintable(w, part.content); err != nil {
+				return nil, "", err
+			}
+		}
+		if err := parts.Close(); err != nil {
+			return nil, "", err
+		}
+	}
+
+	// Ordem fixa dos headers extras: mensagens idênticas geram o mesmo conteúdo
+	names := make([]string, 0, len(msg.Headers))
+	for name := range msg.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeHeader(name, msg.Headers[name])
+	}
+	buf.WriteString("\r\n")
+	buf.Write(body.Bytes())
+	return buf.Bytes(), id, nil
+}
+
+// writeQuotedPrintable codifica o conteúdo com quebras de linha CRLF
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(content, "\n", "\r\n"))); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// messageID gera um Message-ID único no domínio do remetente
+func messageID(from string) string {
+	domain := "localhost"
+	if _, d, ok := strings.Cut(from, "@"); ok && d != "" {
+		domain = d
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain)
+}
@@ -0,0 +1,100 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/health"
+)
+
+const (
+	sendGridURL = "https://api.sendgrid.com/v3/mail/send"
+
+	// Limite das respostas de erro lidas da API
+	maxSendGridResponseSize = 64 << 10 // 64KB
+)
+
+// sendGridMailer envia pela API v3 da SendGrid (API REST, sem SDK)
+type sendGridMailer struct {
+	apiKey string
+	from   string
+	client *http.Client
+}
+
+func newSendGridMailer(apiKey, from string) *sendGridMailer {
+	return &sendGridMailer{
+		apiKey: apiKey,
+		from:   from,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (s *sendGridMailer) Name() string {
+	return "sendgrid"
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+// Send retorna o X-Message-Id da SendGrid (o sg_message_id dos eventos começa por ele)
+func (s *sendGridMailer) Send(ctx context.Context, msg Message) (string, error) {
+	if err := validate(msg); err != nil {
+		return "", err
+	}
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return "", fmt.Errorf("MAIL_FROM inválido: %w", err)
+	}
+
+	// A SendGrid exige text/plain antes de text/html
+	content := []map[string]string{{"type": "text/plain", "value": msg.Text}}
+	if msg.HTML != "" {
+		content = append(content, map[string]string{"type": "text/html", "value": msg.HTML})
+	}
+	personalization := map[string]any{"to": []sendGridAddress{{Email: msg.To}}}
+	if len(msg.Metadata) > 0 {
+		personalization["custom_args"] = msg.Metadata
+	}
+	payload := map[string]any{
+		"personalizations": []map[string]any{personalization},
+		"from":             sendGridAddress{Email: from.Address, Name: from.Name},
+		"subject":          msg.Subject,
+		"content":          content,
+	}
+	if len(msg.Headers) > 0 {
+		payload["headers"] = msg.Headers
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sendGridURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		health.Record(health.Mailer, err)
+		return "", fmt.Errorf("erro ao chamar sendgrid: %w", err)
+	}
+	defer resp.Body.Close()
+	health.RecordHTTP(health.Mailer, resp.StatusCode, nil)
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxSendGridResponseSize))
+		return "", fmt.Errorf("sendgrid retornou %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	return resp.Header.Get("X-Message-Id"), nil
+}
@@ -0,0 +1,143 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/health"
+)
+
+// Limite das respostas de erro lidas da API
+const maxSESResponseSize = 64 << 10 // 64KB
+
+// sesMailer envia pela API v2 do Amazon SES (mensagem raw, assinatura SigV4, sem SDK)
+// O envio raw preserva os headers extras (List-Unsubscribe), que a API simples não aceita
+type sesMailer struct {
+	endpoint  string // ex: https://email.us-east-1.amazonaws.com
+	region    string
+	accessKey string
+	secretKey string
+	from      string
+	client    *http.Client
+}
+
+func newSESMailer(region, accessKey, secretKey, from string) *sesMailer {
+	return &sesMailer{
+		endpoint:  "https://email." + region + ".amazonaws.com",
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		from:      from,
+		client:    &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (s *sesMailer) Name() string {
+	return "ses"
+}
+
+// Send retorna o MessageId do SES
+func (s *sesMailer) Send(ctx context.Context, msg Message) (string, error) {
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return "", fmt.Errorf("MAIL_FROM inválido: %w", err)
+	}
+	raw, _, err := buildMIME(from, msg, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	// encoding/json codifica []byte em base64, formato exigido em Content.Raw.Data
+	body, err := json.Marshal(map[string]any{
+		"FromEmailAddress": from.String(),
+		"Destination":      map[string][]string{"ToAddresses": {msg.To}},
+		"Content":          map[string]any{"Raw": map[string][]byte{"Data": raw}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/v2/email/outbound-emails", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.sign(req, body, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		health.Record(health.Mailer, err)
+		return "", fmt.Errorf("erro ao chamar ses: %w", err)
+	}
+	defer resp.Body.Close()
+	health.RecordHTTP(health.Mailer, resp.StatusCode, nil)
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxSESResponseSize))
+		return "", fmt.Errorf("ses retornou %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+
+	var result struct {
+		MessageId string
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, maxSESResponseSize)).Decode(&result)
+	return result.MessageId, nil
+}
+
+// sign aplica a assinatura AWS Signature Version 4 (header Authorization)
+func (s *sesMailer) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	signedHeaders := "content-type;host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "content-type:" + req.Header.Get("Content-Type") + "\n" +
+		"host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/ses/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "ses")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
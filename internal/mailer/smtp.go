@@ -0,0 +1,116 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/health"
+)
+
+// Tempo máximo de uma entrega SMTP (conexão, TLS, autenticação e envio)
+const smtpTimeout = 30 * time.Second
+
+// smtpMailer envia pelo servidor SMTP configurado (porta 465 com TLS implícito, demais com STARTTLS)
+type smtpMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+func newSMTPMailer(host string, port int, username, password, from string) *smtpMailer {
+	return &smtpMailer{host: host, port: port, username: username, password: password, from: from}
+}
+
+func (s *smtpMailer) Name() string {
+	return "smtp"
+}
+
+// Send entrega a mensagem em uma conexão própria e retorna o Message-ID gerado
+// Concorrência: sem pool compartilhado, envios simultâneos não disputam a mesma sessão SMTP
+func (s *smtpMailer) Send(ctx context.Context, msg Message) (string, error) {
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return "", fmt.Errorf("MAIL_FROM inválido: %w", err)
+	}
+	data, id, err := buildMIME(from, msg, time.Now())
+	if err != nil {
+		return "", err
+	}
+	to, _ := mail.ParseAddress(msg.To) // já validado em buildMIME
+
+	deadline := time.Now().Add(smtpTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	dialer := net.Dialer{Deadline: deadline}
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		health.Record(health.Mailer, err)
+		return "", fmt.Errorf("erro ao conectar no servidor smtp: %w", err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return "", err
+	}
+
+	tlsConfig := &tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}
+	if s.port == 465 {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		health.Record(health.Mailer, err)
+		return "", fmt.Errorf("erro ao iniciar sessão smtp: %w", err)
+	}
+	defer client.Close()
+
+	if s.port != 465 {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				health.Record(health.Mailer, err)
+				return "", fmt.Errorf("erro no starttls: %w", err)
+			}
+		}
+	}
+
+	// Segurança: PlainAuth só envia a senha em conexão TLS (ou localhost)
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			health.Record(health.Mailer, err)
+			return "", fmt.Errorf("erro na autenticação smtp: %w", err)
+		}
+	}
+
+	// Sessão autenticada: recusas a partir daqui são da mensagem, não do servidor
+	health.Record(health.Mailer, nil)
+
+	if err := client.Mail(from.Address); err != nil {
+		return "", fmt.Errorf("remetente recusado pelo servidor smtp: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return "", fmt.Errorf("destinatário recusado pelo servidor smtp: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(data); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("mensagem recusada pelo servidor smtp: %w", err)
+	}
+	return id, client.Quit()
+}
@@ -0,0 +1,77 @@
+package maintenance
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// Intervalo entre leituras da tabela: mudanças feitas em outra réplica valem em até cacheTTL
+const cacheTTL = 5 * time.Second
+
+// snapshot guarda as áreas em manutenção lidas do banco
+type snapshot struct {
+	flags    map[models.MaintenanceFeature]models.MaintenanceFlag
+	loadedAt time.Time
+}
+
+var (
+	// Concorrência: snapshot imutável trocado atomicamente, leituras nos requests não precisam de lock
+	current atomic.Pointer[snapshot]
+	// Apenas um request por vez recarrega; os demais seguem com o snapshot anterior
+	refreshMu sync.Mutex
+)
+
+// Check indica se a área está em manutenção
+// Falhas ao ler o banco mantêm o último estado conhecido (a manutenção não derruba a API por si só)
+func Check(ctx context.Context, feature models.MaintenanceFeature) (*models.MaintenanceFlag, bool) {
+	s := current.Load()
+	if s == nil || time.Since(s.loadedAt) >= cacheTTL {
+		s = refresh(ctx, s)
+	}
+	if s == nil {
+		return nil, false
+	}
+	flag, ok := s.flags[feature]
+	if !ok {
+		return nil, false
+	}
+	return &flag, true
+}
+
+// Invalidate força a releitura no próximo request (usado após alterações pelo /admin)
+func Invalidate() {
+	current.Store(nil)
+}
+
+// refresh relê as áreas em manutenção do banco
+func refresh(ctx context.Context, stale *snapshot) *snapshot {
+	if !refreshMu.TryLock() {
+		return stale
+	}
+	defer refreshMu.Unlock()
+
+	flags, err := repository.NewMaintenanceRepository(database.WithContext(ctx)).FindAll()
+	if err != nil {
+		log.Printf("[WARN] Failed to load maintenance flags: %v", err)
+		if stale != nil {
+			// Evita consultar o banco a cada request enquanto ele estiver indisponível
+			stale = &snapshot{flags: stale.flags, loadedAt: time.Now()}
+			current.Store(stale)
+		}
+		return stale
+	}
+
+	next := &snapshot{flags: make(map[models.MaintenanceFeature]models.MaintenanceFlag, len(flags)), loadedAt: time.Now()}
+	for _, f := range flags {
+		next.flags[f.Feature] = f
+	}
+	current.Store(next)
+	return next
+}
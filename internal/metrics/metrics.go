@@ -0,0 +1,263 @@
+package metrics
+
+import (
+	"database/sql"
+	"expvar"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Limite de statements distintos acompanhados (protege memória contra SQL dinâmico)
+const maxTrackedStatements = 100
+
+// Quantidade de statements exibidos no ranking de lentidão
+const topStatements = 10
+
+var (
+	slowQueries    = expvar.NewInt("db_slow_queries_total")
+	queryTimeouts  = expvar.NewInt("db_query_timeouts_total")
+	poolSaturation = expvar.NewInt("db_pool_saturation_events_total")
+	routeTimeouts  = expvar.NewMap("http_route_timeouts")
+	slowTable      = &statementTable{stats: map[string]*statementStat{}}
+	jobStats       = expvar.NewMap("jobs")
+	jobStatsMu     sync.Mutex
+	backupStats    = expvar.NewMap("backups")
+	funnelStats    = expvar.NewMap("funnel_events")
+	funnelStatsMu  sync.Mutex
+)
+
+func init() {
+	expvar.Publish("db_slow_statements", expvar.Func(func() interface{} {
+		return slowTable.top(topStatements)
+	}))
+}
+
+// Handler expõe as métricas no formato JSON do expvar
+func Handler() http.Handler {
+	return expvar.Handler()
+}
+
+// RecordSlowQuery contabiliza uma query acima do limite de lentidão
+// O SQL deve conter placeholders (?) e não os valores, para não expor dados pessoais
+func RecordSlowQuery(statement string, elapsed time.Duration) {
+	slowQueries.Add(1)
+	slowTable.record(statement, elapsed)
+}
+
+// RecordQueryTimeout contabiliza uma query cancelada pelo deadline
+func RecordQueryTimeout() {
+	queryTimeouts.Add(1)
+}
+
+// RecordRouteTimeout contabiliza uma requisição encerrada com 504 pelo timeout da rota
+func RecordRouteTimeout(route string) {
+	routeTimeouts.Add(route, 1)
+}
+
+// RegisterDBStats publica as estatísticas do pool de conexões (lidas a cada coleta)
+func RegisterDBStats(stats func() sql.DBStats) {
+	expvar.Publish("db_pool", expvar.Func(func() interface{} {
+		s := stats()
+		utilization := 0.0
+		if s.MaxOpenConnections > 0 {
+			utilization = float64(s.InUse) / float64(s.MaxOpenConnections)
+		}
+		return map[string]interface{}{
+			"max_open":            s.MaxOpenConnections,
+			"open":                s.OpenConnections,
+			"in_use":              s.InUse,
+			"idle":                s.Idle,
+			"utilization":         utilization,
+			"wait_count":          s.WaitCount,
+			"wait_duration_ms":    s.WaitDuration.Milliseconds(),
+			"max_idle_closed":     s.MaxIdleClosed,
+			"max_lifetime_closed": s.MaxLifetimeClosed,
+		}
+	}))
+}
+
+// RecordPoolSaturation contabiliza um intervalo em que o pool ficou saturado
+func RecordPoolSaturation() {
+	poolSaturation.Add(1)
+}
+
+// Resultados possíveis de uma tentativa de execução de job
+const (
+	JobSucceeded     = "succeeded"
+	JobFailed        = "failed"
+	JobSkippedLocked = "skipped_locked" // outra réplica está executando
+	JobSkippedRecent = "skipped_recent" // já executado neste intervalo
+)
+
+// RecordJob contabiliza uma tentativa de execução de job agendado
+func RecordJob(name, outcome string, elapsed time.Duration) {
+	// Concorrência: Mutex evita que duas goroutines criem o map do mesmo job
+	jobStatsMu.Lock()
+	stats, ok := jobStats.Get(name).(*expvar.Map)
+	if !ok {
+		stats = new(expvar.Map).Init()
+		jobStats.Set(name, stats)
+	}
+	jobStatsMu.Unlock()
+
+	stats.Add(outcome, 1)
+	if outcome == JobSucceeded || outcome == JobFailed {
+		durationMs := new(expvar.Int)
+		durationMs.Set(elapsed.Milliseconds())
+		stats.Set("last_duration_ms", durationMs)
+
+		lastRun := new(expvar.String)
+		lastRun.Set(time.Now().UTC().Format(time.RFC3339))
+		stats.Set("last_run_at", lastRun)
+	}
+}
+
+// RecordBackupExport contabiliza uma exportação de backup por tenant
+func RecordBackupExport(err error) {
+	if err != nil {
+		backupStats.Add("export_failures_total", 1)
+		return
+	}
+	backupStats.Add("exports_total", 1)
+}
+
+// RecordBackupVerification registra o resultado da restauração de verificação do último backup
+func RecordBackupVerification(key string, rows int, err error) {
+	if err != nil {
+		backupStats.Add("verification_failures_total", 1)
+		return
+	}
+	backupStats.Add("verifications_total", 1)
+
+	lastKey := new(expvar.String)
+	lastKey.Set(key)
+	backupStats.Set("last_verified_key", lastKey)
+
+	lastRows := new(expvar.Int)
+	lastRows.Set(int64(rows))
+	backupStats.Set("last_verified_rows", lastRows)
+
+	lastAt := new(expvar.String)
+	lastAt.Set(time.Now().UTC().Format(time.RFC3339))
+	backupStats.Set("last_verified_at", lastAt)
+}
+
+// Etapas do funil do casal, na ordem em que costumam acontecer
+const (
+	FunnelWeddingCreated = "wedding_created"
+	FunnelGuestAdded     = "guest_added"
+	FunnelInviteSent     = "invite_sent"
+	FunnelRSVPReceived   = "rsvp_received"
+)
+
+// FunnelSteps lista as etapas do funil na ordem
+var FunnelSteps = []string{FunnelWeddingCreated, FunnelGuestAdded, FunnelInviteSent, FunnelRSVPReceived}
+
+// WeddingAgeBuckets são as faixas de idade do casamento (tempo desde o cadastro) usadas no funil
+var WeddingAgeBuckets = []string{"0-7d", "8-30d", "31-90d", "91d+"}
+
+// WeddingAgeBucket classifica o casamento pela idade do cadastro
+func WeddingAgeBucket(createdAt, now time.Time) string {
+	days := int(now.Sub(createdAt).Hours() / 24)
+	switch {
+	case days <= 7:
+		return WeddingAgeBuckets[0]
+	case days <= 30:
+		return WeddingAgeBuckets[1]
+	case days <= 90:
+		return WeddingAgeBuckets[2]
+	}
+	return WeddingAgeBuckets[3]
+}
+
+// RecordFunnelStep contabiliza uma etapa do funil pela idade do casamento (count eventos, ex: 30 convidados = 30)
+// Contadores da réplica desde o boot; o total consolidado é o funil diário (RegisterFunnelSnapshot)
+func RecordFunnelStep(step string, weddingCreatedAt time.Time, count int) {
+	if count <= 0 {
+		return
+	}
+
+	// Concorrência: Mutex evita que duas goroutines criem o map da mesma etapa
+	funnelStatsMu.Lock()
+	stats, ok := funnelStats.Get(step).(*expvar.Map)
+	if !ok {
+		stats = new(expvar.Map).Init()
+		funnelStats.Set(step, stats)
+	}
+	funnelStatsMu.Unlock()
+
+	stats.Add(WeddingAgeBucket(weddingCreatedAt, time.Now()), int64(count))
+}
+
+// RegisterFunnelSnapshot publica o último funil diário consolidado (lido a cada coleta)
+func RegisterFunnelSnapshot(snapshot func() interface{}) {
+	expvar.Publish("funnel_daily", expvar.Func(snapshot))
+}
+
+// statementStat acumula as ocorrências de um statement lento
+type statementStat struct {
+	Statement string  `json:"statement"`
+	Count     int64   `json:"count"`
+	TotalMs   float64 `json:"total_ms"`
+	MaxMs     float64 `json:"max_ms"`
+}
+
+// statementTable agrega statements lentos
+// Concorrência: Protegido por mutex, chamado a partir de callbacks do GORM em várias goroutines
+type statementTable struct {
+	mu      sync.Mutex
+	stats   map[string]*statementStat
+	dropped int64
+}
+
+func (t *statementTable) record(statement string, elapsed time.Duration) {
+	ms := float64(elapsed) / float64(time.Millisecond)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stat, ok := t.stats[statement]
+	if !ok {
+		if len(t.stats) >= maxTrackedStatements {
+			t.dropped++
+			return
+		}
+		stat = &statementStat{Statement: statement}
+		t.stats[statement] = stat
+	}
+
+	stat.Count++
+	stat.TotalMs += ms
+	if ms > stat.MaxMs {
+		stat.MaxMs = ms
+	}
+}
+
+// top retorna os statements com mais ocorrências lentas
+func (t *statementTable) top(n int) map[string]interface{} {
+	t.mu.Lock()
+	list := make([]statementStat, 0, len(t.stats))
+	for _, s := range t.stats {
+		list = append(list, *s)
+	}
+	tracked, dropped := len(t.stats), t.dropped
+	t.mu.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].TotalMs > list[j].TotalMs
+	})
+	if len(list) > n {
+		list = list[:n]
+	}
+
+	return map[string]interface{}{
+		"top":     list,
+		"tracked": tracked,
+		"dropped": dropped,
+	}
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// PostalAddress representa o endereço postal de um convidado (convites impressos)
+// LGPD: logradouro, complemento e CEP criptografados em repouso; cidade/UF/país ficam em claro para relatórios
+type PostalAddress struct {
+	Line1      string `gorm:"type:varchar(1024);serializer:encrypted" json:"line1"`
+	Line2      string `gorm:"type:varchar(1024);serializer:encrypted" json:"line2"`
+	City       string `gorm:"size:100" json:"city"`
+	State      string `gorm:"size:50" json:"state"`
+	PostalCode string `gorm:"type:varchar(255);serializer:encrypted" json:"postal_code"`
+	Country    string `gorm:"size:2" json:"country"` // ISO 3166-1 alpha-2
+}
+
+// IsEmpty indica se nenhum campo do endereço foi informado
+func (a *PostalAddress) IsEmpty() bool {
+	return a.Line1 == "" && a.Line2 == "" && a.City == "" && a.State == "" && a.PostalCode == "" && a.Country == ""
+}
+
+// IsComplete indica se o endereço tem o mínimo para uma etiqueta (logradouro, cidade e CEP)
+func (a *PostalAddress) IsComplete() bool {
+	return a.Line1 != "" && a.City != "" && a.PostalCode != ""
+}
+
+// IsValid normaliza e valida o endereço (vazio é válido: endereço não informado)
+func (a *PostalAddress) IsValid() error {
+	a.normalize()
+
+	if a.IsEmpty() {
+		return nil
+	}
+	if !a.IsComplete() {
+		return errors.New("address requires line1, city and postal_code")
+	}
+	if len(a.Line1) > 200 || len(a.Line2) > 200 {
+		return errors.New("address lines must not exceed 200 characters")
+	}
+	if len(a.City) > 100 {
+		return errors.New("city must not exceed 100 characters")
+	}
+	if len(a.State) > 50 {
+		return errors.New("state must not exceed 50 characters")
+	}
+	if len(a.PostalCode) > 20 {
+		return errors.New("postal code must not exceed 20 characters")
+	}
+	if a.Country != "" && len(a.Country) != 2 {
+		return errors.New("address country must be a 2-letter ISO code")
+	}
+	return nil
+}
+
+// Lines retorna as linhas do endereço no formato de etiqueta (sem linhas vazias)
+func (a *PostalAddress) Lines() []string {
+	lines := []string{a.Line1}
+	if a.Line2 != "" {
+		lines = append(lines, a.Line2)
+	}
+
+	city := a.City
+	if a.State != "" {
+		city += " - " + a.State
+	}
+	lines = append(lines, city, a.PostalCode)
+
+	if a.Country != "" {
+		lines[len(lines)-1] += " " + a.Country
+	}
+	return lines
+}
+
+// normalize remove espaços extras e padroniza UF e país
+func (a *PostalAddress) normalize() {
+	clean := func(s string) string { return strings.Join(strings.Fields(s), " ") }
+	a.Line1 = clean(a.Line1)
+	a.Line2 = clean(a.Line2)
+	a.City = clean(a.City)
+	a.State = strings.ToUpper(clean(a.State))
+	a.PostalCode = strings.ToUpper(clean(a.PostalCode))
+	a.Country = strings.ToUpper(clean(a.Country))
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AnnouncementKind representa o tipo de um comunicado do serviço
+type AnnouncementKind string
+
+const (
+	AnnouncementMaintenance AnnouncementKind = "maintenance" // janela de manutenção programada
+	AnnouncementFeature     AnnouncementKind = "feature"     // novidade no produto
+	AnnouncementInfo        AnnouncementKind = "info"        // aviso geral
+)
+
+// AnnouncementPlan restringe o público do comunicado pelo plano da conta (vazio: todos)
+type AnnouncementPlan string
+
+const (
+	AnnouncementPlanPro  AnnouncementPlan = "pro"  // contas com ProUntil no futuro
+	AnnouncementPlanFree AnnouncementPlan = "free" // contas sem Pro ou com Pro vencido
+)
+
+const (
+	maxAnnouncementTitle = 200
+	maxAnnouncementBody  = 5000
+
+	// Atividade mais antiga aceita no filtro de público (active_within_days)
+	maxAnnouncementActiveDays = 3650
+)
+
+// Announcement é um comunicado publicado pela administração para os usuários
+// Cada destinatário recebe uma Notification na caixa de entrada; o email é opcional
+type Announcement struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Kind  AnnouncementKind `gorm:"type:varchar(20);not null" json:"kind"`
+	Title string           `gorm:"size:200;not null" json:"title"`
+	Body  string           `gorm:"type:text;not null" json:"body"`
+
+	// Público: plano e atividade recente (0: todos os usuários)
+	Plan             AnnouncementPlan `gorm:"type:varchar(20);not null;default:''" json:"plan"`
+	ActiveWithinDays int              `gorm:"not null;default:0" json:"active_within_days"`
+
+	SendEmail  bool `gorm:"not null;default:false" json:"send_email"`
+	Recipients int  `gorm:"not null;default:0" json:"recipients"` // notificações criadas na publicação
+}
+
+// IsValid normaliza e valida o comunicado
+func (a *Announcement) IsValid() error {
+	a.Kind = AnnouncementKind(strings.ToLower(strings.TrimSpace(string(a.Kind))))
+	a.Plan = AnnouncementPlan(strings.ToLower(strings.TrimSpace(string(a.Plan))))
+	a.Title = strings.Join(strings.Fields(a.Title), " ")
+	a.Body = strings.TrimSpace(a.Body)
+
+	switch a.Kind {
+	case AnnouncementMaintenance, AnnouncementFeature, AnnouncementInfo:
+	default:
+		return errors.New("invalid kind, expected maintenance, feature or info")
+	}
+	switch a.Plan {
+	case "", AnnouncementPlanPro, AnnouncementPlanFree:
+	default:
+		return errors.New("invalid plan, expected pro or free")
+	}
+	if a.Title == "" || len(a.Title) > maxAnnouncementTitle {
+		return fmt.Errorf("title must be between 1 and %d characters long", maxAnnouncementTitle)
+	}
+	if a.Body == "" || len(a.Body) > maxAnnouncementBody {
+		return fmt.Errorf("body must be between 1 and %d characters long", maxAnnouncementBody)
+	}
+	if a.ActiveWithinDays < 0 || a.ActiveWithinDays > maxAnnouncementActiveDays {
+		return fmt.Errorf("active_within_days must be between 0 and %d", maxAnnouncementActiveDays)
+	}
+	return nil
+}
+
+// NotificationKind identifica os avisos de um casamento na caixa de entrada (sem comunicado associado)
+type NotificationKind string
+
+const (
+	NotificationFundraisingRefunded NotificationKind = "fundraising_refunded" // contribuição estornada
+	NotificationFundraisingDisputed NotificationKind = "fundraising_disputed" // disputa aberta com prazo de defesa
+	NotificationGuestDeclined       NotificationKind = "guest_declined"       // recusa pelo link pessoal, com o recado do convidado
+)
+
+// Notification é a entrada de um comunicado ou de um aviso de casamento na caixa de entrada de um usuário
+// Avisos de casamento não têm comunicado: tipo, título e texto ficam na própria notificação
+type Notification struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Um comunicado entra uma única vez na caixa de cada usuário (NULL nos avisos não participa do índice único)
+	UserID         uint          `gorm:"not null;uniqueIndex:idx_notification_once,priority:1" json:"user_id"`
+	User           User          `gorm:"foreignKey:UserID" json:"-"`
+	AnnouncementID *uint         `gorm:"uniqueIndex:idx_notification_once,priority:2" json:"announcement_id"`
+	Announcement   *Announcement `gorm:"foreignKey:AnnouncementID" json:"-"`
+
+	// Aviso de casamento
+	// LGPD: o texto cita convidados e doadores; removido na anonimização
+	WeddingID *uint            `gorm:"index" json:"wedding_id"`
+	Kind      NotificationKind `gorm:"type:varchar(30);not null;default:''" json:"kind"`
+	Title     string           `gorm:"size:200;not null;default:''" json:"title"`
+	Body      string           `gorm:"type:text" json:"body"`
+
+	ReadAt *time.Time `json:"read_at"`
+
+	// Email ainda a enviar pelo job de comunicados
+	EmailPending bool       `gorm:"not null;default:false;index" json:"-"`
+	EmailedAt    *time.Time `json:"emailed_at"`
+}
+
+// NewWeddingNotification cria o aviso de um casamento para o dono da conta
+func NewWeddingNotification(userID, weddingID uint, kind NotificationKind, title, body string) *Notification {
+	return &Notification{UserID: userID, WeddingID: &weddingID, Kind: kind, Title: title, Body: body}
+}
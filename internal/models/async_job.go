@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// AsyncJobKind representa as operações longas executadas em segundo plano
+type AsyncJobKind string
+
+const (
+	AsyncJobLedgerExport           AsyncJobKind = "ledger_export"
+	AsyncJobAddressExport          AsyncJobKind = "address_export"
+	AsyncJobFullReport             AsyncJobKind = "full_report"
+	AsyncJobGuestImport            AsyncJobKind = "guest_import"
+	AsyncJobRescheduleAnnouncement AsyncJobKind = "reschedule_announcement"
+	AsyncJobInviteBulk             AsyncJobKind = "invite_bulk"
+)
+
+// AsyncJobStatus representa o estado de uma operação em segundo plano
+type AsyncJobStatus string
+
+const (
+	AsyncJobQueued    AsyncJobStatus = "queued"
+	AsyncJobRunning   AsyncJobStatus = "running"
+	AsyncJobSucceeded AsyncJobStatus = "succeeded"
+	AsyncJobFailed    AsyncJobStatus = "failed"
+)
+
+// AsyncJob representa uma operação longa (exportação, importação, envio em massa) pedida pela API
+// A requisição responde 202 com o ID e o cliente acompanha o progresso em GET /jobs/:id
+type AsyncJob struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID    uint           `gorm:"not null;index" json:"user_id"` // quem pediu: apenas ele consulta o job
+	WeddingID uint           `gorm:"not null;index" json:"wedding_id"`
+	Kind      AsyncJobKind   `gorm:"type:varchar(40);not null" json:"kind"`
+	Status    AsyncJobStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	Progress  int            `json:"progress"` // 0 a 100
+
+	// Parâmetros validados na requisição e resultado resumido (JSON)
+	Params string `gorm:"type:text" json:"-"`
+	Result string `gorm:"type:text" json:"-"`
+
+	// Arquivo gerado no storage (exportações), baixado em GET /jobs/:id/download
+	FileName     string `gorm:"size:64" json:"-"`
+	DownloadName string `gorm:"size:100" json:"download_name"`
+	ContentType  string `gorm:"size:100" json:"content_type"`
+
+	Error      string     `gorm:"size:255" json:"error"`
+	Attempts   int        `json:"attempts"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	ExpiresAt  *time.Time `gorm:"index" json:"expires_at"` // resultado e arquivo removidos após a expiração
+}
+
+// IsFinished indica se o job terminou (com sucesso ou falha)
+func (j *AsyncJob) IsFinished() bool {
+	return j.Status == AsyncJobSucceeded || j.Status == AsyncJobFailed
+}
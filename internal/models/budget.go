@@ -27,12 +27,14 @@ type Expense struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
-	WeddingID   uint            `gorm:"not null" json:"wedding_id"`
+	// Performance: Índice composto (wedding_id, category, status) cobre agregações por categoria/status
+	WeddingID   uint            `gorm:"not null;index:idx_expense_wedding_category_status,priority:1" json:"wedding_id"`
 	Wedding     Wedding         `gorm:"foreignKey:WeddingID" json:"-"`
-	Category    ExpenseCategory `gorm:"type:varchar(50);not null" json:"category"`
+	Category    ExpenseCategory `gorm:"type:varchar(50);not null;index:idx_expense_wedding_category_status,priority:2" json:"category"`
 	Description string          `gorm:"type:text" json:"description"`
 	Amount      float64         `gorm:"not null" json:"amount"`
-	Status      ExpenseStatus   `gorm:"type:varchar(20);default:'planned'" json:"status"`
+	Status      ExpenseStatus   `gorm:"type:varchar(20);default:'planned';index:idx_expense_wedding_category_status,priority:3" json:"status"`
+	DueDate     *time.Time      `json:"due_date"` // vencimento do pagamento (opcional)
 }
 
 // ExpenseCategory representa as categorias de gastos
@@ -45,9 +47,21 @@ const (
 	ExpenseCategoryPhotography ExpenseCategory = "photography"
 	ExpenseCategoryMusic       ExpenseCategory = "music"
 	ExpenseCategoryVenue       ExpenseCategory = "venue"
+	ExpenseCategoryStationery  ExpenseCategory = "stationery" // convites e papelaria
 	ExpenseCategoryOther       ExpenseCategory = "other"
 )
 
+// IsValid verifica se a categoria é uma das categorias conhecidas
+func (c ExpenseCategory) IsValid() bool {
+	switch c {
+	case ExpenseCategoryFood, ExpenseCategoryDecoration, ExpenseCategoryClothing,
+		ExpenseCategoryPhotography, ExpenseCategoryMusic, ExpenseCategoryVenue, ExpenseCategoryStationery,
+		ExpenseCategoryOther:
+		return true
+	}
+	return false
+}
+
 // ExpenseStatus representa o status do gasto
 type ExpenseStatus string
 
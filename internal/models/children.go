@@ -0,0 +1,36 @@
+package models
+
+import "errors"
+
+// ChildAgeBands conta as crianças do convite por faixa etária (locais e bufês cobram cada faixa de um jeito)
+// As crianças fazem parte de MaxGuests: o convidado principal também conta se for criança
+type ChildAgeBands struct {
+	Age0To3   int `gorm:"default:0" json:"age_0_3"`
+	Age4To10  int `gorm:"default:0" json:"age_4_10"`
+	Age11Plus int `gorm:"default:0" json:"age_11_plus"`
+}
+
+// Total retorna o número de crianças do convite
+func (b ChildAgeBands) Total() int {
+	return b.Age0To3 + b.Age4To10 + b.Age11Plus
+}
+
+// IsZero indica que nenhuma criança foi informada
+func (b ChildAgeBands) IsZero() bool {
+	return b.Total() == 0
+}
+
+// Add soma as faixas de outro convite (usado nos totais do casamento)
+func (b *ChildAgeBands) Add(other ChildAgeBands) {
+	b.Age0To3 += other.Age0To3
+	b.Age4To10 += other.Age4To10
+	b.Age11Plus += other.Age11Plus
+}
+
+// IsValid valida as contagens por faixa
+func (b ChildAgeBands) IsValid() error {
+	if b.Age0To3 < 0 || b.Age4To10 < 0 || b.Age11Plus < 0 {
+		return errors.New("children counts must not be negative")
+	}
+	return nil
+}
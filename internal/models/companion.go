@@ -0,0 +1,79 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Companion representa um acompanhante nomeado de um convidado (dentro do limite MaxGuests)
+type Companion struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	GuestID uint  `gorm:"not null;index" json:"guest_id"`
+	Guest   Guest `gorm:"foreignKey:GuestID" json:"-"`
+
+	// Segurança: wedding_id desnormalizado garante o escopo do tenant nas queries e nos totais
+	WeddingID uint    `gorm:"not null;index" json:"wedding_id"`
+	Wedding   Wedding `gorm:"foreignKey:WeddingID" json:"-"`
+
+	FullName    string     `gorm:"size:200;not null" json:"full_name"`
+	Confirmed   bool       `gorm:"default:false" json:"confirmed"`
+	ConfirmedAt *time.Time `json:"confirmed_at"`
+
+	// Buffet: prato e faixa etária informados no RSVP (vazio quando não informados)
+	MealOption MealOption        `gorm:"type:varchar(20)" json:"meal_option"`
+	AgeGroup   CompanionAgeGroup `gorm:"type:varchar(20)" json:"age_group"`
+}
+
+// CompanionAgeGroup representa a faixa etária do acompanhante, nas mesmas faixas de ChildAgeBands
+type CompanionAgeGroup string
+
+const (
+	CompanionAgeAdult  CompanionAgeGroup = "adult"
+	CompanionAge0To3   CompanionAgeGroup = "age_0_3"
+	CompanionAge4To10  CompanionAgeGroup = "age_4_10"
+	CompanionAge11Plus CompanionAgeGroup = "age_11_plus"
+)
+
+// IsValid verifica se a faixa etária é conhecida (vazio significa não informada)
+func (a CompanionAgeGroup) IsValid() bool {
+	switch a {
+	case "", CompanionAgeAdult, CompanionAge0To3, CompanionAge4To10, CompanionAge11Plus:
+		return true
+	}
+	return false
+}
+
+// IsValid normaliza e valida os campos do acompanhante
+func (c *Companion) IsValid() error {
+	c.FullName = strings.Join(strings.Fields(c.FullName), " ")
+
+	if len(c.FullName) < 2 || len(c.FullName) > 200 {
+		return errors.New("full name must be between 2 and 200 characters long")
+	}
+
+	c.MealOption = MealOption(strings.ToLower(strings.TrimSpace(string(c.MealOption))))
+	if !c.MealOption.IsValid() {
+		return errors.New("invalid meal option")
+	}
+	c.AgeGroup = CompanionAgeGroup(strings.ToLower(strings.TrimSpace(string(c.AgeGroup))))
+	if !c.AgeGroup.IsValid() {
+		return errors.New("age group must be adult, age_0_3, age_4_10 or age_11_plus")
+	}
+	return nil
+}
+
+// CompanionLimit retorna quantos acompanhantes o convidado pode registrar
+// MaxGuests inclui o próprio convidado
+func (g *Guest) CompanionLimit() int {
+	if g.MaxGuests <= 1 {
+		return 0
+	}
+	return g.MaxGuests - 1
+}
@@ -0,0 +1,199 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Limites das informações do evento exibidas ao convidado
+const (
+	MaxTimelineHighlights = 20
+	MaxDressCodeLength    = 500
+
+	// Folga mínima na programação, além do tempo de carro, para a saída da cerimônia e a chegada na recepção
+	MinTransferBufferMinutes = 15
+)
+
+var highlightTimeRegex = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// EventInfo guarda as informações práticas do evento que o casal escolhe compartilhar
+// com os convidados pelo link pessoal (local, traje, programação e mesas)
+type EventInfo struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	WeddingID  uint                `gorm:"not null;uniqueIndex" json:"wedding_id"`
+	Wedding    Wedding             `gorm:"foreignKey:WeddingID" json:"-"`
+	DressCode  string              `gorm:"type:text" json:"dress_code"`
+	Highlights []TimelineHighlight `gorm:"type:text;serializer:json" json:"highlights"`
+
+	// Segurança: nada é exibido ao convidado sem opt-in explícito do casal
+	ShareVenue     bool `gorm:"default:false" json:"share_venue"`
+	ShareDressCode bool `gorm:"default:false" json:"share_dress_code"`
+	ShareTimeline  bool `gorm:"default:false" json:"share_timeline"`
+
+	// Mapa de mesas publicado: cada convidado passa a ver a própria mesa
+	TablesPublishedAt *time.Time `json:"tables_published_at"`
+
+	// Local da recepção quando diferente do local da cerimônia (Wedding.VenueAddress)
+	ReceptionVenueName string `gorm:"size:200" json:"reception_venue_name"`
+	ReceptionAddress   string `gorm:"type:text" json:"reception_address"`
+
+	// Tempo de carro entre cerimônia e recepção, calculado uma vez por par de endereços
+	// Performance: evita chamar a API de rotas a cada leitura da programação
+	TravelMinutes *int   `json:"-"`
+	TravelRoute   string `gorm:"size:64" json:"-"` // TravelRouteKey dos endereços usados no cálculo
+}
+
+// TimelineHighlight representa um momento da programação (ex: 19:30 Cerimônia)
+type TimelineHighlight struct {
+	Time  string       `json:"time"` // HH:MM
+	Title string       `json:"title"`
+	Event WeddingEvent `json:"event,omitempty"` // parte do casamento; vazio vale para todos os convidados
+}
+
+// IsValid normaliza e valida as informações do evento
+func (e *EventInfo) IsValid() error {
+	e.DressCode = strings.TrimSpace(e.DressCode)
+	if len(e.DressCode) > MaxDressCodeLength {
+		return errors.New("dress code must not exceed 500 characters")
+	}
+
+	e.ReceptionVenueName = strings.TrimSpace(e.ReceptionVenueName)
+	e.ReceptionAddress = strings.TrimSpace(e.ReceptionAddress)
+	if len(e.ReceptionVenueName) > 200 {
+		return errors.New("reception venue name must not exceed 200 characters")
+	}
+	if e.ReceptionAddress != "" && (len(e.ReceptionAddress) < 10 || len(e.ReceptionAddress) > 1000) {
+		return errors.New("reception address must be between 10 and 1000 characters long")
+	}
+
+	if len(e.Highlights) > MaxTimelineHighlights {
+		return errors.New("timeline must have at most 20 highlights")
+	}
+	highlights := make([]TimelineHighlight, 0, len(e.Highlights))
+	for _, h := range e.Highlights {
+		h.Time = strings.TrimSpace(h.Time)
+		h.Title = strings.Join(strings.Fields(h.Title), " ")
+		if !highlightTimeRegex.MatchString(h.Time) {
+			return errors.New("highlight time must be in HH:MM format")
+		}
+		if len(h.Title) < 2 || len(h.Title) > 100 {
+			return errors.New("highlight title must be between 2 and 100 characters long")
+		}
+		if h.Event == WeddingEventBoth {
+			h.Event = ""
+		}
+		if h.Event != "" && !h.Event.IsValid() {
+			return errors.New("highlight event must be ceremony, reception or both")
+		}
+		highlights = append(highlights, h)
+	}
+	e.Highlights = highlights
+
+	return nil
+}
+
+// TablesPublished indica se o casal já publicou o mapa de mesas
+func (e *EventInfo) TablesPublished() bool {
+	return e.TablesPublishedAt != nil
+}
+
+// TravelRouteKey identifica o par de endereços do cálculo de deslocamento
+// Mudar o endereço da cerimônia ou da recepção invalida o tempo guardado
+func TravelRouteKey(origin, destination string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(origin) + "\n" + strings.ToLower(destination)))
+	return hex.EncodeToString(sum[:])
+}
+
+// TransferWindow retorna os horários (HH:MM) do último momento da cerimônia e do primeiro da recepção
+// e os minutos entre eles; ok é falso quando a programação não tem momentos das duas partes
+// Momentos sem parte definida valem para todos e não delimitam o deslocamento
+func (e *EventInfo) TransferWindow() (from, to string, minutes int, ok bool) {
+	lastCeremony, firstReception := -1, -1
+	for _, h := range e.Highlights {
+		m, valid := highlightMinutes(h.Time)
+		if !valid {
+			continue
+		}
+		switch h.Event {
+		case WeddingEventCeremony:
+			if m > lastCeremony {
+				lastCeremony, from = m, h.Time
+			}
+		case WeddingEventReception:
+			if firstReception < 0 || m < firstReception {
+				firstReception, to = m, h.Time
+			}
+		}
+	}
+	if lastCeremony < 0 || firstReception < 0 {
+		return "", "", 0, false
+	}
+	return from, to, firstReception - lastCeremony, true
+}
+
+// TimelineStatus é a marcação de um momento da programação no painel do dia do evento
+type TimelineStatus string
+
+const (
+	TimelineDone     TimelineStatus = "done"     // já passou
+	TimelineNow      TimelineStatus = "now"      // em andamento: o último momento já iniciado
+	TimelineNext     TimelineStatus = "next"     // o primeiro momento ainda não iniciado
+	TimelineUpcoming TimelineStatus = "upcoming" // depois do próximo
+)
+
+// MarkedHighlight é um momento da programação com a marcação do painel do dia do evento
+type MarkedHighlight struct {
+	TimelineHighlight
+	Status TimelineStatus `json:"status"`
+}
+
+// TimelineAt marca a programação, em ordem de horário, para o horário informado em minutos desde a meia-noite
+// Antes do dia do evento use -1 (tudo por vir); depois do dia, 24*60 (tudo já passou)
+func (e *EventInfo) TimelineAt(minute int) []MarkedHighlight {
+	marked := make([]MarkedHighlight, 0, len(e.Highlights))
+	for _, h := range e.Highlights {
+		marked = append(marked, MarkedHighlight{TimelineHighlight: h, Status: TimelineUpcoming})
+	}
+	sort.SliceStable(marked, func(i, j int) bool {
+		return marked[i].Time < marked[j].Time // HH:MM ordena como texto
+	})
+
+	current := -1
+	for i := range marked {
+		m, _ := highlightMinutes(marked[i].Time)
+		if m > minute {
+			marked[i].Status = TimelineNext
+			break
+		}
+		if current >= 0 {
+			marked[current].Status = TimelineDone
+		}
+		current = i
+		marked[i].Status = TimelineNow
+	}
+	// Depois do dia do evento nenhum momento está em andamento
+	if current >= 0 && minute >= 24*60 {
+		marked[current].Status = TimelineDone
+	}
+	return marked
+}
+
+// highlightMinutes converte um horário HH:MM em minutos desde a meia-noite
+func highlightMinutes(hhmm string) (int, bool) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
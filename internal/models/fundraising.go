@@ -13,20 +13,85 @@ type Fundraising struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
-	WeddingID       uint              `gorm:"not null" json:"wedding_id"`
-	Wedding         Wedding           `gorm:"foreignKey:WeddingID" json:"-"`
-	Type            FundraisingType   `gorm:"type:varchar(20);not null" json:"type"`
-	Amount          float64           `gorm:"not null" json:"amount"`
-	Date            time.Time         `json:"date"`
-	Observation     string            `gorm:"type:text" json:"observation"`
-	DonorName       string            `json:"donor_name"` // nome de quem doou
+	// Performance: Índice composto (wedding_id, date) para listagens ordenadas por data
+	WeddingID   uint            `gorm:"not null;index:idx_fundraising_wedding_date,priority:1" json:"wedding_id"`
+	Wedding     Wedding         `gorm:"foreignKey:WeddingID" json:"-"`
+	Type        FundraisingType `gorm:"type:varchar(20);not null" json:"type"`
+	Amount      float64         `gorm:"not null" json:"amount"`
+	Date        time.Time       `gorm:"index:idx_fundraising_wedding_date,priority:2" json:"date"`
+	Observation string          `gorm:"type:text" json:"observation"`
+	DonorName   string          `json:"donor_name"` // nome de quem doou
+
+	// Status da contribuição: estornos mantêm o registro original para auditoria
+	Status       FundraisingStatus `gorm:"type:varchar(20);default:'received'" json:"status"`
+	RefundedAt   *time.Time        `json:"refunded_at"`
+	RefundReason string            `gorm:"type:text" json:"refund_reason"`
+
+	// Disputa (chargeback): a contribuição fica congelada fora dos totais até a resolução
+	DisputeDeadline   *time.Time     `json:"dispute_deadline"`
+	DisputeOutcome    DisputeOutcome `gorm:"type:varchar(20)" json:"dispute_outcome"`
+	DisputeResolvedAt *time.Time     `json:"dispute_resolved_at"`
+
+	// Referência da cobrança no PSP (vazio para contribuições registradas manualmente)
+	ProviderName     string `gorm:"size:30" json:"provider_name"`
+	ProviderChargeID string `gorm:"size:100;index" json:"provider_charge_id"`
 }
 
 // FundraisingType representa os tipos de arrecadação
 type FundraisingType string
 
 const (
-	FundraisingTypeGift   FundraisingType = "gift"
-	FundraisingTypeTie    FundraisingType = "tie"       // Gravata
-	FundraisingTypeShoe   FundraisingType = "shoe"      // Sapatinho
+	FundraisingTypeGift FundraisingType = "gift"
+	FundraisingTypeTie  FundraisingType = "tie"  // Gravata
+	FundraisingTypeShoe FundraisingType = "shoe" // Sapatinho
+)
+
+// FundraisingStatus representa o status de uma contribuição
+type FundraisingStatus string
+
+const (
+	FundraisingStatusReceived FundraisingStatus = "received"
+	FundraisingStatusRefunded FundraisingStatus = "refunded"
+	FundraisingStatusDisputed FundraisingStatus = "disputed"
+	// Disputa perdida: o valor foi devolvido ao pagador pelo emissor do cartão
+	FundraisingStatusChargedBack FundraisingStatus = "charged_back"
 )
+
+// DisputeOutcome representa o resultado de uma disputa
+type DisputeOutcome string
+
+const (
+	DisputeOutcomeWon  DisputeOutcome = "won"
+	DisputeOutcomeLost DisputeOutcome = "lost"
+)
+
+// IsValid valida o resultado de uma disputa
+func (o DisputeOutcome) IsValid() bool {
+	return o == DisputeOutcomeWon || o == DisputeOutcomeLost
+}
+
+// OpenDispute congela a contribuição fora dos totais até a resolução
+func (f *Fundraising) OpenDispute(deadline *time.Time) {
+	f.Status = FundraisingStatusDisputed
+	f.DisputeDeadline = deadline
+	f.DisputeOutcome = ""
+	f.DisputeResolvedAt = nil
+}
+
+// ResolveDispute registra o resultado da disputa
+// Disputa ganha devolve a contribuição aos totais; perdida a marca como chargeback
+func (f *Fundraising) ResolveDispute(outcome DisputeOutcome) {
+	now := time.Now()
+	f.DisputeOutcome = outcome
+	f.DisputeResolvedAt = &now
+	if outcome == DisputeOutcomeWon {
+		f.Status = FundraisingStatusReceived
+	} else {
+		f.Status = FundraisingStatusChargedBack
+	}
+}
+
+// CountsTowardTotals indica se a contribuição entra nos totais arrecadados
+func (f *Fundraising) CountsTowardTotals() bool {
+	return f.Status == "" || f.Status == FundraisingStatusReceived
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// FunnelSnapshot consolida o funil dos casais em um dia, por faixa de idade do casamento
+// Cada etapa conta casamentos que já passaram por ela (cadastro → convidados → convites → RSVPs)
+type FunnelSnapshot struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Date      time.Time `gorm:"type:date;not null;uniqueIndex:idx_funnel_date_bucket,priority:1" json:"date"`
+	AgeBucket string    `gorm:"size:10;not null;uniqueIndex:idx_funnel_date_bucket,priority:2" json:"age_bucket"` // ver metrics.WeddingAgeBuckets
+
+	Weddings        int `json:"weddings"`
+	WithGuests      int `json:"with_guests"`
+	WithInvitesSent int `json:"with_invites_sent"`
+	WithRSVPs       int `gorm:"column:with_rsvps" json:"with_rsvps"` // algum convidado confirmou ou recusou
+}
@@ -1,8 +1,12 @@
 package models
 
 import (
+	"errors"
+	"net/mail"
+	"strings"
 	"time"
 
+	"github.com/matheushermes/wedding_planner_service/internal/security"
 	"gorm.io/gorm"
 )
 
@@ -13,13 +17,93 @@ type Guest struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
-	FullName     string       `gorm:"not null" json:"full_name"`
-	Phone        string       `json:"phone"`
-	Email        string       `json:"email"`
-	InviteStatus InviteStatus `gorm:"type:varchar(20);default:'pending'" json:"invite_status"`
-	MaxGuests    int          `gorm:"default:1" json:"max_guests"` // número máximo de convidados que essa pessoa pode trazer
-	WeddingID    uint         `gorm:"not null" json:"wedding_id"`
-	Wedding      Wedding      `gorm:"foreignKey:WeddingID" json:"-"`
+	FullName string `gorm:"not null" json:"full_name"`
+
+	// LGPD: telefone e email criptografados em repouso (AES-GCM); buscas por igualdade usam os hashes
+	Phone     string `gorm:"type:varchar(512);serializer:encrypted" json:"phone"`
+	Email     string `gorm:"type:varchar(512);serializer:encrypted" json:"email"`
+	PhoneHash string `gorm:"size:64;index" json:"-"`
+	EmailHash string `gorm:"size:64;index" json:"-"`
+
+	// Telefone em E.164 (+5511987654321) derivado de Phone, usado no envio por WhatsApp/SMS
+	// Phone guarda o valor como foi digitado para exibição ao casal
+	PhoneE164 string `gorm:"type:varchar(512);serializer:encrypted" json:"phone_e164"`
+
+	InviteStatus InviteStatus `gorm:"type:varchar(20);default:'pending';index:idx_guest_wedding_status,priority:2" json:"invite_status"`
+	MaxGuests    int          `gorm:"default:1" json:"max_guests"` // número máximo de pessoas do convite, incluindo o próprio convidado
+
+	// Prioridade do convite: a lista B só é chamada quando sobra vaga na lista A
+	Priority GuestPriority `gorm:"type:varchar(1);default:'a'" json:"priority"`
+
+	// Partes do casamento para as quais o convidado é convidado (cerimônia, recepção ou ambas)
+	Events WeddingEvent `gorm:"type:varchar(20);default:'both'" json:"events"`
+
+	// Performance: Índice composto (wedding_id, invite_status) para listagens filtradas por status
+	WeddingID uint    `gorm:"not null;index:idx_guest_wedding_status,priority:1" json:"wedding_id"`
+	Wedding   Wedding `gorm:"foreignKey:WeddingID" json:"-"`
+
+	// Grupo (família) do convidado, opcional
+	GroupID *uint       `gorm:"index" json:"group_id"`
+	Group   *GuestGroup `gorm:"foreignKey:GroupID" json:"-"`
+
+	// Buffet: opção de prato e restrições alimentares informadas pelo convidado
+	MealOption          MealOption `gorm:"type:varchar(20)" json:"meal_option"`
+	DietaryRestrictions string     `gorm:"type:text" json:"dietary_restrictions"`
+	IsChild             bool       `gorm:"default:false" json:"is_child"`
+
+	// Crianças do convite por faixa etária (incluídas em MaxGuests)
+	Children ChildAgeBands `gorm:"embedded;embeddedPrefix:children_" json:"children"`
+
+	// Convidados de fora da cidade: hotel e transfer
+	Logistics GuestLogistics `gorm:"embedded;embeddedPrefix:logistics_" json:"logistics"`
+
+	// Idioma e país do convite/página de RSVP (inferidos pelo DDI, sobrescrevíveis)
+	Locale      string `gorm:"size:10" json:"locale"`
+	CountryCode string `gorm:"size:2" json:"country_code"`
+
+	// LGPD: convidado que optou por não receber mensagens automáticas
+	OptedOutAt *time.Time `json:"opted_out_at"`
+
+	// Recado do convidado ao recusar pelo link pessoal; limpo quando ele confirma presença
+	DeclineMessage string `gorm:"type:text" json:"decline_message"`
+
+	// Check-in na portaria do evento (QR Code ou busca manual)
+	CheckedInAt *time.Time `json:"checked_in_at"`
+
+	// Mesa no salão; o convidado só a vê depois que o casal publica o mapa de mesas
+	TableName string `gorm:"size:50" json:"table_name"`
+
+	// Contexto do casal sobre o convidado; nunca exibidos ao próprio convidado
+	Relationship string `gorm:"size:50" json:"relationship"` // ex: amigo, primo, colega de trabalho
+	Notes        string `gorm:"type:text" json:"notes"`
+
+	// Endereço postal para convites impressos (informado pelo casal ou pelo próprio convidado)
+	Address          PostalAddress `gorm:"embedded;embeddedPrefix:address_" json:"address"`
+	AddressUpdatedAt *time.Time    `json:"address_updated_at"`
+}
+
+// BeforeSave mantém os hashes pesquisáveis sincronizados com telefone e email
+func (g *Guest) BeforeSave(tx *gorm.DB) error {
+	g.RefreshPIIHashes()
+	return nil
+}
+
+// RefreshPIIHashes recalcula os hashes de busca (blind index) de telefone e email
+func (g *Guest) RefreshPIIHashes() {
+	email, phone, _ := g.DedupKeys()
+	g.EmailHash = security.BlindIndex(email)
+	g.PhoneHash = security.BlindIndex(phone)
+}
+
+// CountsTowardCapacity indica se o convidado ocupa uma vaga em Wedding.CurrentGuestCount
+func (g *Guest) CountsTowardCapacity() bool {
+	return g.InviteStatus.HoldsSeat()
+}
+
+// CanReceiveMessages indica se o convidado aceita mensagens automáticas (email/WhatsApp)
+// Todo envio automatizado deve checar esta regra antes de disparar
+func (g *Guest) CanReceiveMessages() bool {
+	return g.OptedOutAt == nil
 }
 
 // InviteStatus representa os possíveis status de convite
@@ -30,4 +114,374 @@ const (
 	InviteStatusSent      InviteStatus = "sent"
 	InviteStatusConfirmed InviteStatus = "confirmed"
 	InviteStatusDeclined  InviteStatus = "declined"
+
+	// Lista de espera: criado com o casamento lotado, não ocupa vaga até ser promovido
+	InviteStatusWaitlisted InviteStatus = "waitlisted"
 )
+
+// SeatlessStatuses lista os status que não ocupam vaga no casamento
+// Quem recusa libera a vaga para a lista de espera
+var SeatlessStatuses = []InviteStatus{InviteStatusWaitlisted, InviteStatusDeclined}
+
+// GuestPriority representa a prioridade do convidado (lista A ou lista B)
+type GuestPriority string
+
+const (
+	GuestPriorityA GuestPriority = "a"
+	GuestPriorityB GuestPriority = "b"
+)
+
+// IsValid verifica se a prioridade é conhecida
+func (p GuestPriority) IsValid() bool {
+	return p == GuestPriorityA || p == GuestPriorityB
+}
+
+// WeddingEvent representa uma parte do casamento (lista de convidados da cerimônia ou da recepção)
+type WeddingEvent string
+
+const (
+	WeddingEventBoth      WeddingEvent = "both"
+	WeddingEventCeremony  WeddingEvent = "ceremony"
+	WeddingEventReception WeddingEvent = "reception"
+)
+
+// IsValid verifica se o evento é conhecido
+func (e WeddingEvent) IsValid() bool {
+	return e == WeddingEventBoth || e == WeddingEventCeremony || e == WeddingEventReception
+}
+
+// Includes indica se o convite para e abrange o evento (both abrange todos)
+// Com event = both, basta o convite abranger alguma parte do casamento
+func (e WeddingEvent) Includes(event WeddingEvent) bool {
+	return e == WeddingEventBoth || event == WeddingEventBoth || e == event
+}
+
+// Events lista os valores de convite que abrangem o evento (para filtros no banco)
+func (e WeddingEvent) Events() []WeddingEvent {
+	if e == WeddingEventBoth {
+		return []WeddingEvent{WeddingEventBoth, WeddingEventCeremony, WeddingEventReception}
+	}
+	return []WeddingEvent{WeddingEventBoth, e}
+}
+
+// MealOption representa as opções de prato oferecidas pelo buffet
+type MealOption string
+
+const (
+	MealOptionMeat       MealOption = "meat"
+	MealOptionFish       MealOption = "fish"
+	MealOptionVegetarian MealOption = "vegetarian"
+	MealOptionVegan      MealOption = "vegan"
+	MealOptionKids       MealOption = "kids"
+)
+
+// MealOptions lista as opções de prato na ordem exibida para o buffet
+var MealOptions = []MealOption{MealOptionMeat, MealOptionFish, MealOptionVegetarian, MealOptionVegan, MealOptionKids}
+
+// IsValid verifica se a opção de prato é conhecida (vazio significa não escolhida)
+func (m MealOption) IsValid() bool {
+	if m == "" {
+		return true
+	}
+	for _, option := range MealOptions {
+		if m == option {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid normaliza e valida os campos do convidado
+func (g *Guest) IsValid() error {
+	g.normalize()
+
+	if len(g.FullName) < 2 || len(g.FullName) > 200 {
+		return errors.New("full name must be between 2 and 200 characters long")
+	}
+
+	if g.Email != "" {
+		if _, err := mail.ParseAddress(g.Email); err != nil {
+			return errors.New("invalid email format")
+		}
+	}
+
+	if len(g.Phone) > 30 {
+		return errors.New("phone must not exceed 30 characters")
+	}
+	e164, err := NormalizePhone(g.Phone, g.phoneCountry())
+	if err != nil {
+		return err
+	}
+	g.PhoneE164 = e164
+
+	if g.MaxGuests < 1 || g.MaxGuests > 20 {
+		return errors.New("max guests must be between 1 and 20")
+	}
+
+	if !g.InviteStatus.IsValid() {
+		return errors.New("invalid invite status")
+	}
+
+	if err := g.Children.IsValid(); err != nil {
+		return err
+	}
+	if g.Children.Total() > g.MaxGuests {
+		return errors.New("children must not exceed max guests")
+	}
+
+	if err := g.Logistics.IsValid(g.MaxGuests); err != nil {
+		return err
+	}
+
+	if !g.Priority.IsValid() {
+		return errors.New("priority must be a or b")
+	}
+
+	if !g.Events.IsValid() {
+		return errors.New("events must be ceremony, reception or both")
+	}
+
+	if !g.MealOption.IsValid() {
+		return errors.New("invalid meal option")
+	}
+
+	if len(g.DietaryRestrictions) > 500 {
+		return errors.New("dietary restrictions must not exceed 500 characters")
+	}
+
+	if g.Locale != "" && !IsValidLocale(g.Locale) {
+		return errors.New("invalid locale, expected format like pt-BR")
+	}
+
+	if g.CountryCode != "" && len(g.CountryCode) != 2 {
+		return errors.New("country code must be a 2-letter ISO code")
+	}
+
+	if len(g.TableName) > 50 {
+		return errors.New("table name must not exceed 50 characters")
+	}
+	// Mesas são da recepção: quem vai só à cerimônia não tem lugar no salão
+	if g.TableName != "" && !g.Events.Includes(WeddingEventReception) {
+		return errors.New("only reception guests can be assigned a table")
+	}
+
+	if len(g.Relationship) > 50 {
+		return errors.New("relationship must not exceed 50 characters")
+	}
+
+	if len(g.Notes) > 2000 {
+		return errors.New("notes must not exceed 2000 characters")
+	}
+
+	if err := g.Address.IsValid(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// normalize remove espaços extras e padroniza os campos do convidado
+func (g *Guest) normalize() {
+	g.FullName = strings.Join(strings.Fields(g.FullName), " ")
+	g.Email = strings.ToLower(strings.TrimSpace(g.Email))
+	g.Phone = strings.TrimSpace(g.Phone)
+	g.CountryCode = strings.ToUpper(strings.TrimSpace(g.CountryCode))
+	g.Locale = strings.TrimSpace(g.Locale)
+	g.MealOption = MealOption(strings.ToLower(strings.TrimSpace(string(g.MealOption))))
+	g.DietaryRestrictions = strings.TrimSpace(g.DietaryRestrictions)
+	g.TableName = strings.Join(strings.Fields(g.TableName), " ")
+	g.Relationship = strings.ToLower(strings.Join(strings.Fields(g.Relationship), " "))
+	g.Notes = strings.TrimSpace(g.Notes)
+	if g.InviteStatus == "" {
+		g.InviteStatus = InviteStatusPending
+	}
+	if g.MaxGuests == 0 {
+		g.MaxGuests = 1
+	}
+	g.Priority = GuestPriority(strings.ToLower(strings.TrimSpace(string(g.Priority))))
+	if g.Priority == "" {
+		g.Priority = GuestPriorityA
+	}
+	g.Events = WeddingEvent(strings.ToLower(strings.TrimSpace(string(g.Events))))
+	if g.Events == "" {
+		g.Events = WeddingEventBoth
+	}
+	g.Logistics.normalize(g.MaxGuests)
+}
+
+// IsValid verifica se o status é um dos status conhecidos
+func (s InviteStatus) IsValid() bool {
+	switch s {
+	case InviteStatusPending, InviteStatusSent, InviteStatusConfirmed, InviteStatusDeclined, InviteStatusWaitlisted:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo indica se o casal pode mudar o status diretamente para to
+// A lista de espera altera as vagas do casamento: entrada só na criação (ou pelo RSVP) e saída pelo promote
+func (s InviteStatus) CanTransitionTo(to InviteStatus) bool {
+	return s == to || (s != InviteStatusWaitlisted && to != InviteStatusWaitlisted)
+}
+
+// HoldsSeat indica se convidados com este status ocupam uma vaga do casamento
+func (s InviteStatus) HoldsSeat() bool {
+	for _, seatless := range SeatlessStatuses {
+		if s == seatless {
+			return false
+		}
+	}
+	return true
+}
+
+// phoneCountry retorna o país usado para telefones sem DDI: o do convidado, se conhecido, ou o padrão
+func (g *Guest) phoneCountry() string {
+	if phoneLocaleByCountry(g.CountryCode) != nil {
+		return g.CountryCode
+	}
+	return DefaultPhoneCountry()
+}
+
+// ApplyLocaleDefaults preenche idioma e país a partir do telefone quando não informados
+// Valores definidos explicitamente para o convidado nunca são sobrescritos
+func (g *Guest) ApplyLocaleDefaults() {
+	phone := g.Phone
+	if g.PhoneE164 != "" {
+		phone = g.PhoneE164
+	}
+	country, locale := InferLocaleFromPhone(phone)
+
+	if g.CountryCode == "" {
+		g.CountryCode = country
+	}
+	if g.Locale == "" {
+		g.Locale = locale
+	}
+	if g.Locale == "" {
+		g.Locale = DefaultLocale
+	}
+}
+
+// DedupKeys retorna as chaves normalizadas (email, telefone, nome) usadas na detecção de duplicados
+// Chaves vazias indicam que o campo não foi informado
+func (g *Guest) DedupKeys() (email, phone, name string) {
+	email = strings.ToLower(strings.TrimSpace(g.Email))
+
+	phone = strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, g.Phone)
+
+	name = strings.ToLower(strings.Join(strings.Fields(g.FullName), " "))
+
+	return email, phone, name
+}
+
+// HasValidEmail indica se o email informado é um endereço simples válido
+// Registros antigos ou importados antes da validação podem ter valores como "joao@" ou "Nome <email>"
+func (g *Guest) HasValidEmail() bool {
+	email := strings.TrimSpace(g.Email)
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
+// HasValidPhone indica se o telefone informado pode ser convertido para E.164
+func (g *Guest) HasValidPhone() bool {
+	if strings.TrimSpace(g.Phone) == "" {
+		return false
+	}
+	_, err := NormalizePhone(g.Phone, g.phoneCountry())
+	return err == nil
+}
+
+// statusRank ordena os status pelo avanço no RSVP (resposta do convidado vale mais que envio)
+var statusRank = map[InviteStatus]int{
+	InviteStatusWaitlisted: -1,
+	InviteStatusPending:    0,
+	InviteStatusSent:       1,
+	InviteStatusDeclined:   2,
+	InviteStatusConfirmed:  3,
+}
+
+// MergeFrom incorpora os dados de um registro duplicado do mesmo convidado
+// O status mais avançado de RSVP vence (com a opção de prato de quem respondeu);
+// campos vazios são preenchidos e o opt-out de qualquer um dos registros é mantido (LGPD)
+func (g *Guest) MergeFrom(other *Guest) {
+	if statusRank[other.InviteStatus] > statusRank[g.InviteStatus] {
+		g.InviteStatus = other.InviteStatus
+		if other.MealOption != "" {
+			g.MealOption = other.MealOption
+		}
+	}
+	if other.MaxGuests > g.MaxGuests {
+		g.MaxGuests = other.MaxGuests
+	}
+	if other.Priority == GuestPriorityA {
+		g.Priority = GuestPriorityA
+	}
+	// O convite mesclado abrange as partes do casamento de ambos os registros
+	if other.Events != g.Events && other.Events != "" {
+		g.Events = WeddingEventBoth
+	}
+
+	if g.Email == "" {
+		g.Email = other.Email
+	}
+	if g.Phone == "" {
+		g.Phone = other.Phone
+		g.PhoneE164 = other.PhoneE164
+	}
+	if g.GroupID == nil {
+		g.GroupID = other.GroupID
+	}
+	if g.MealOption == "" {
+		g.MealOption = other.MealOption
+	}
+	if g.DietaryRestrictions == "" {
+		g.DietaryRestrictions = other.DietaryRestrictions
+	}
+	if g.Locale == "" {
+		g.Locale = other.Locale
+	}
+	if g.CountryCode == "" {
+		g.CountryCode = other.CountryCode
+	}
+	if g.TableName == "" {
+		g.TableName = other.TableName
+	}
+	if g.Relationship == "" {
+		g.Relationship = other.Relationship
+	}
+	// Anotações dos dois registros são mantidas
+	switch {
+	case g.Notes == "":
+		g.Notes = other.Notes
+	case other.Notes != "" && other.Notes != g.Notes:
+		g.Notes += "\n\n" + other.Notes
+	}
+	if g.Address.IsEmpty() {
+		g.Address = other.Address
+		g.AddressUpdatedAt = other.AddressUpdatedAt
+	}
+	g.IsChild = g.IsChild || other.IsChild
+	if g.Children.IsZero() && other.Children.Total() <= g.MaxGuests {
+		g.Children = other.Children
+	}
+	if !g.Logistics.HasNeeds() {
+		g.Logistics = other.Logistics
+		g.Logistics.ShuttleSeats = min(g.Logistics.ShuttleSeats, g.MaxGuests)
+	}
+
+	g.OptedOutAt = earliest(g.OptedOutAt, other.OptedOutAt)
+	g.CheckedInAt = earliest(g.CheckedInAt, other.CheckedInAt)
+}
+
+// earliest retorna a data mais antiga entre duas datas opcionais
+func earliest(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.Before(*a)) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GuestGroup representa um grupo de convidados (família, casal, "Família Silva")
+// RSVP e mesas costumam ser organizados por grupo, não por pessoa
+type GuestGroup struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	WeddingID uint    `gorm:"not null;index" json:"wedding_id"`
+	Wedding   Wedding `gorm:"foreignKey:WeddingID" json:"-"`
+	Name      string  `gorm:"size:100;not null" json:"name"`
+}
+
+// IsValid normaliza e valida os campos do grupo
+func (g *GuestGroup) IsValid() error {
+	g.Name = strings.Join(strings.Fields(g.Name), " ")
+
+	if len(g.Name) < 2 || len(g.Name) > 100 {
+		return errors.New("group name must be between 2 and 100 characters long")
+	}
+	return nil
+}
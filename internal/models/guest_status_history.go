@@ -0,0 +1,72 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrStatusHistoryImmutable é retornado ao tentar alterar ou remover um registro do histórico
+var ErrStatusHistoryImmutable = errors.New("guest status history is immutable")
+
+// StatusChannel representa por onde a mudança de status do convite foi feita
+type StatusChannel string
+
+const (
+	StatusChannelDashboard     StatusChannel = "dashboard"      // casal ou colaborador pela API autenticada
+	StatusChannelRSVP          StatusChannel = "rsvp"           // o próprio convidado pelo link pessoal
+	StatusChannelInvite        StatusChannel = "invite"         // envio do convite
+	StatusChannelImport        StatusChannel = "import"         // importação de outro casamento ou da agenda
+	StatusChannelMerge         StatusChannel = "merge"          // mesclagem de duplicados
+	StatusChannelWaitlist      StatusChannel = "waitlist"       // promoção da lista de espera pelo casal
+	StatusChannelAutoPromotion StatusChannel = "auto_promotion" // vaga liberada por uma recusa
+)
+
+// StatusActor identifica quem fez a mudança de status e por qual canal
+// UserID é nulo em mudanças automáticas do sistema
+type StatusActor struct {
+	Channel StatusChannel
+	UserID  *uint
+	Note    string
+}
+
+// GuestStatusHistory registra cada transição de InviteStatus de um convidado
+// Trilha de auditoria: registros são imutáveis e sobrevivem à remoção do convidado
+type GuestStatusHistory struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"index:idx_guest_status_history,priority:2" json:"created_at"`
+
+	// Performance: Índice composto (guest_id, created_at) para a linha do tempo do convidado
+	GuestID   uint `gorm:"not null;index:idx_guest_status_history,priority:1" json:"guest_id"`
+	WeddingID uint `gorm:"not null;index" json:"wedding_id"`
+
+	FromStatus      InviteStatus  `gorm:"type:varchar(20)" json:"from_status"` // vazio na criação do convidado
+	ToStatus        InviteStatus  `gorm:"type:varchar(20);not null" json:"to_status"`
+	Channel         StatusChannel `gorm:"type:varchar(20);not null" json:"channel"`
+	ChangedByUserID *uint         `gorm:"index" json:"changed_by_user_id"` // sem FK: o histórico sobrevive à conta
+	Note            string        `gorm:"size:255" json:"note"`
+}
+
+// BeforeUpdate bloqueia alterações no histórico
+func (h *GuestStatusHistory) BeforeUpdate(tx *gorm.DB) error {
+	return ErrStatusHistoryImmutable
+}
+
+// BeforeDelete bloqueia remoções do histórico
+func (h *GuestStatusHistory) BeforeDelete(tx *gorm.DB) error {
+	return ErrStatusHistoryImmutable
+}
+
+// NewGuestStatusHistory cria o registro de uma transição de status do convidado
+func NewGuestStatusHistory(g *Guest, from InviteStatus, actor StatusActor) GuestStatusHistory {
+	return GuestStatusHistory{
+		GuestID:         g.ID,
+		WeddingID:       g.WeddingID,
+		FromStatus:      from,
+		ToStatus:        g.InviteStatus,
+		Channel:         actor.Channel,
+		ChangedByUserID: actor.UserID,
+		Note:            actor.Note,
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GuestTag representa uma etiqueta livre de convidados ("lado da noiva", "lado do noivo", "trabalho")
+// Um convidado pode ter várias etiquetas, diferente do grupo (família) que é único
+type GuestTag struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	WeddingID uint    `gorm:"not null;index" json:"wedding_id"`
+	Wedding   Wedding `gorm:"foreignKey:WeddingID" json:"-"`
+	Name      string  `gorm:"size:50;not null" json:"name"`
+	Color     string  `gorm:"size:7" json:"color"` // opcional, #rrggbb
+}
+
+// GuestTagAssignment vincula um convidado a uma etiqueta (relação muitos-para-muitos)
+// Sem soft delete: o vínculo é removido de fato, o índice único impede duplicatas
+type GuestTagAssignment struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// wedding_id redundante: backup por tenant e isolamento entre casamentos nas queries
+	WeddingID uint     `gorm:"not null;index" json:"wedding_id"`
+	GuestID   uint     `gorm:"not null;uniqueIndex:idx_guest_tag,priority:1" json:"guest_id"`
+	Guest     Guest    `gorm:"foreignKey:GuestID" json:"-"`
+	TagID     uint     `gorm:"not null;uniqueIndex:idx_guest_tag,priority:2;index" json:"tag_id"`
+	Tag       GuestTag `gorm:"foreignKey:TagID" json:"-"`
+}
+
+// IsValid normaliza e valida os campos da etiqueta
+func (t *GuestTag) IsValid() error {
+	t.Name = strings.Join(strings.Fields(t.Name), " ")
+	t.Color = strings.ToLower(strings.TrimSpace(t.Color))
+
+	if len(t.Name) < 2 || len(t.Name) > 50 {
+		return errors.New("tag name must be between 2 and 50 characters long")
+	}
+	if t.Color != "" && !hexColorRegex.MatchString(t.Color) {
+		return errors.New("invalid color, expected #rrggbb")
+	}
+	return nil
+}
@@ -16,8 +16,63 @@ type Invite struct {
 	GuestID   uint       `gorm:"not null" json:"guest_id"`
 	Guest     Guest      `gorm:"foreignKey:GuestID" json:"guest,omitempty"`
 	SentAt    *time.Time `json:"sent_at"`
-	SentVia   string     `gorm:"type:varchar(20)" json:"sent_via"` // email, whatsapp
-	Template  string     `gorm:"type:text" json:"template"`
+	SentVia   string     `gorm:"type:varchar(20)" json:"sent_via"` // email, whatsapp, sms (fallback)
+	Subject   string     `gorm:"size:200" json:"subject"`          // assunto do email, aceita as mesmas variáveis
+	Template  string     `gorm:"type:text" json:"template"`        // texto com variáveis {{guest_name}}, {{rsvp_link}}...
 	WeddingID uint       `gorm:"not null" json:"wedding_id"`
 	Wedding   Wedding    `gorm:"foreignKey:WeddingID" json:"-"`
+
+	// Entrega informada pelo provedor (webhooks da SendGrid e da Twilio); SMTP, SES e Meta param em sent
+	DeliveryStatus    InviteDeliveryStatus `gorm:"type:varchar(20);index" json:"delivery_status"`
+	DeliveryError     string               `gorm:"size:255" json:"delivery_error"`
+	DeliveryUpdatedAt *time.Time           `json:"delivery_updated_at"`
+
+	// Engajamento dos convites por email: primeira abertura (pixel) e primeiro clique no link de RSVP
+	OpenedAt  *time.Time `json:"opened_at"`
+	ClickedAt *time.Time `json:"clicked_at"`
+}
+
+// InviteDeliveryStatus representa a entrega do convite ao convidado
+type InviteDeliveryStatus string
+
+const (
+	InviteDeliveryQueued    InviteDeliveryStatus = "queued" // registrado, aguardando o provedor aceitar
+	InviteDeliverySent      InviteDeliveryStatus = "sent"   // aceito pelo provedor
+	InviteDeliveryDelivered InviteDeliveryStatus = "delivered"
+	InviteDeliveryBounced   InviteDeliveryStatus = "bounced" // recusado pelo servidor ou operadora do convidado
+	InviteDeliveryFailed    InviteDeliveryStatus = "failed"  // recusado pelo provedor ou erro no envio
+)
+
+// IsValid indica se o status de entrega é suportado
+func (s InviteDeliveryStatus) IsValid() bool {
+	switch s {
+	case InviteDeliveryQueued, InviteDeliverySent, InviteDeliveryDelivered, InviteDeliveryBounced, InviteDeliveryFailed:
+		return true
+	}
+	return false
+}
+
+// PreviousDeliveryStatuses lista de quais status a entrega pode avançar para s
+// Webhooks chegam fora de ordem e repetidos: um "sent" atrasado não desfaz um "delivered"
+func (s InviteDeliveryStatus) PreviousDeliveryStatuses() []InviteDeliveryStatus {
+	switch s {
+	case InviteDeliverySent:
+		return []InviteDeliveryStatus{InviteDeliveryQueued}
+	case InviteDeliveryDelivered, InviteDeliveryBounced, InviteDeliveryFailed:
+		return []InviteDeliveryStatus{InviteDeliveryQueued, InviteDeliverySent}
+	}
+	return nil
+}
+
+// Canais de envio do convite (SentVia)
+const (
+	InviteViaEmail    = "email"
+	InviteViaWhatsApp = "whatsapp"
+	// SMS não é escolhido pelo casal: só registra o fallback dos convites por WhatsApp (Wedding.SMSFallback)
+	InviteViaSMS = "sms"
+)
+
+// IsValidInviteVia indica se o canal de envio pode ser escolhido pelo casal
+func IsValidInviteVia(via string) bool {
+	return via == InviteViaEmail || via == InviteViaWhatsApp
 }
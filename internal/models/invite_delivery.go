@@ -0,0 +1,45 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInviteDeliveryImmutable é retornado ao tentar alterar ou remover um registro da auditoria de envios
+var ErrInviteDeliveryImmutable = errors.New("invite delivery log is immutable")
+
+// InviteDelivery registra cada passo da entrega de um convite: a tentativa de envio (com o conteúdo enviado)
+// e cada mudança de status informada pelo provedor
+// Trilha de auditoria para o suporte ("minha tia não recebeu o convite"): registros são imutáveis
+type InviteDelivery struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"index:idx_invite_delivery,priority:2" json:"created_at"`
+
+	// Performance: Índice composto (invite_id, created_at) para a linha do tempo do convite
+	InviteID  uint `gorm:"not null;index:idx_invite_delivery,priority:1" json:"invite_id"`
+	WeddingID uint `gorm:"not null;index" json:"wedding_id"`
+
+	Channel           string               `gorm:"type:varchar(20);not null" json:"channel"` // email, whatsapp, sms
+	Provider          string               `gorm:"size:30" json:"provider"`                  // sendgrid, ses, twilio, meta...
+	ProviderMessageID string               `gorm:"size:150;index" json:"provider_message_id"`
+	Status            InviteDeliveryStatus `gorm:"type:varchar(20);not null" json:"status"`
+	Reason            string               `gorm:"size:255" json:"reason"`
+	// Momento informado pelo provedor (pode ser anterior ao registro: webhooks chegam atrasados)
+	OccurredAt time.Time `json:"occurred_at"`
+
+	// Conteúdo enviado (JSON com destinatário, assunto e texto), apenas no registro da tentativa
+	// LGPD: contém dados do convidado; removido na anonimização
+	Payload string `gorm:"type:text" json:"payload,omitempty"`
+}
+
+// BeforeUpdate bloqueia alterações na auditoria
+func (d *InviteDelivery) BeforeUpdate(tx *gorm.DB) error {
+	return ErrInviteDeliveryImmutable
+}
+
+// BeforeDelete bloqueia remoções da auditoria
+func (d *InviteDelivery) BeforeDelete(tx *gorm.DB) error {
+	return ErrInviteDeliveryImmutable
+}
@@ -0,0 +1,316 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidInviteTemplate indica template de convite com sintaxe ou variável inválida
+var ErrInvalidInviteTemplate = errors.New("invalid invite template")
+
+const (
+	// Limites do template e do texto gerado (protege contra templates que multiplicam o conteúdo)
+	MaxInviteTemplateLength = 5000
+	maxRenderedInviteLength = 20000
+)
+
+// InviteTemplateData são os valores das variáveis de um convite, calculados por convidado no envio
+type InviteTemplateData struct {
+	GuestName  string
+	VenueName  string
+	EventDate  string // 02/01/2006
+	EventTime  string
+	RSVPLink   string
+	OptOutLink string
+}
+
+// InviteTemplateVariables são as variáveis aceitas nos templates, usadas como {{guest_name}}
+var InviteTemplateVariables = []string{
+	"guest_name", "venue_name", "event_date", "event_time", "rsvp_link", "opt_out_link",
+}
+
+// Segurança: funções nativas que formatam ou repetem conteúdo ficam indisponíveis no template do casal
+// (ex: {{printf "%0999999999d" 0}} alocaria memória antes do limite do texto gerado)
+var disabledTemplateFuncs = []string{"call", "html", "js", "print", "printf", "println", "urlquery"}
+
+// values retorna o valor de cada variável do template
+func (d InviteTemplateData) values() map[string]string {
+	return map[string]string{
+		"guest_name":   d.GuestName,
+		"venue_name":   d.VenueName,
+		"event_date":   d.EventDate,
+		"event_time":   d.EventTime,
+		"rsvp_link":    d.RSVPLink,
+		"opt_out_link": d.OptOutLink,
+	}
+}
+
+// templateFuncs expõe cada variável como função, permitindo {{guest_name}} sem o ponto do text/template
+// Condicionais como {{if event_time}} às {{event_time}}{{end}} continuam disponíveis
+func (d InviteTemplateData) templateFuncs() template.FuncMap {
+	values := d.values()
+
+	funcs := template.FuncMap{}
+	for _, name := range InviteTemplateVariables {
+		value := values[name]
+		funcs[name] = func() string { return value }
+	}
+	for _, name := range disabledTemplateFuncs {
+		funcs[name] = func(...any) (string, error) {
+			return "", fmt.Errorf("%w: function %s is not allowed", ErrInvalidInviteTemplate, name)
+		}
+	}
+	return funcs
+}
+
+// parseInviteTemplate interpreta o template com as funções das variáveis
+// Variáveis desconhecidas falham aqui, antes de qualquer envio
+func parseInviteTemplate(text string, data InviteTemplateData) (*template.Template, error) {
+	if len(text) > MaxInviteTemplateLength {
+		return nil, fmt.Errorf("%w: template must not exceed %d characters", ErrInvalidInviteTemplate, MaxInviteTemplateLength)
+	}
+
+	tmpl, err := template.New("invite").Funcs(data.templateFuncs()).Parse(text)
+	if err != nil {
+		// Mensagem do text/template sem o nome interno do template
+		msg := strings.TrimPrefix(err.Error(), "template: ")
+		if strings.Contains(msg, "function") && strings.Contains(msg, "not defined") {
+			msg += ", use one of: " + strings.Join(InviteTemplateVariables, ", ")
+		}
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInviteTemplate, msg)
+	}
+	return tmpl, nil
+}
+
+// ValidateInviteTemplate confere a sintaxe e as variáveis do template
+func ValidateInviteTemplate(text string) error {
+	_, err := RenderInviteTemplate(text, InviteTemplateData{})
+	return err
+}
+
+// RenderInviteTemplate gera o texto do convite com os valores do convidado (text/template)
+func RenderInviteTemplate(text string, data InviteTemplateData) (string, error) {
+	tmpl, err := parseInviteTemplate(text, data)
+	if err != nil {
+		return "", err
+	}
+	return executeInviteTemplate(tmpl)
+}
+
+// RenderInviteTemplateMissing gera o texto do convite e lista as variáveis impressas que ficaram vazias
+// para o convidado (ex: guest_name sem nome), na ordem de InviteTemplateVariables
+// Variáveis impressas dentro de {{if variavel}} com a mesma variável não contam: o template já trata a ausência
+func RenderInviteTemplateMissing(text string, data InviteTemplateData) (string, []string, error) {
+	tmpl, err := parseInviteTemplate(text, data)
+	if err != nil {
+		return "", nil, err
+	}
+	rendered, err := executeInviteTemplate(tmpl)
+	if err != nil {
+		return "", nil, err
+	}
+
+	printed := map[string]bool{}
+	collectPrintedVariables(tmpl.Tree.Root, map[string]bool{}, printed)
+
+	values := data.values()
+	missing := []string{}
+	for _, name := range InviteTemplateVariables {
+		if printed[name] && strings.TrimSpace(values[name]) == "" {
+			missing = append(missing, name)
+		}
+	}
+	return rendered, missing, nil
+}
+
+// collectPrintedVariables percorre o template e registra as variáveis impressas sem condicional sobre elas
+func collectPrintedVariables(node parse.Node, guarded, printed map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectPrintedVariables(child, guarded, printed)
+		}
+	case *parse.ActionNode:
+		for _, name := range pipeVariables(n.Pipe) {
+			if !guarded[name] {
+				printed[name] = true
+			}
+		}
+	case *parse.IfNode:
+		collectBranchVariables(&n.BranchNode, guarded, printed)
+	case *parse.WithNode:
+		collectBranchVariables(&n.BranchNode, guarded, printed)
+	case *parse.RangeNode:
+		collectBranchVariables(&n.BranchNode, guarded, printed)
+	}
+}
+
+// collectBranchVariables trata as variáveis da condição como garantidas no bloco principal
+func collectBranchVariables(n *parse.BranchNode, guarded, printed map[string]bool) {
+	inner := maps.Clone(guarded)
+	for _, name := range pipeVariables(n.Pipe) {
+		inner[name] = true
+	}
+	collectPrintedVariables(n.List, inner, printed)
+	collectPrintedVariables(n.ElseList, guarded, printed)
+}
+
+// pipeVariables lista as variáveis chamadas em um pipeline, incluindo sub-pipelines entre parênteses
+func pipeVariables(pipe *parse.PipeNode) []string {
+	if pipe == nil {
+		return nil
+	}
+	var names []string
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			switch a := arg.(type) {
+			case *parse.IdentifierNode:
+				names = append(names, a.Ident)
+			case *parse.PipeNode:
+				names = append(names, pipeVariables(a)...)
+			}
+		}
+	}
+	return names
+}
+
+// executeInviteTemplate gera o texto limitado a maxRenderedInviteLength
+func executeInviteTemplate(tmpl *template.Template) (string, error) {
+	out := &limitedBuilder{limit: maxRenderedInviteLength}
+	if err := tmpl.Execute(out, nil); err != nil {
+		if errors.Is(err, ErrInvalidInviteTemplate) {
+			return "", err
+		}
+		return "", fmt.Errorf("%w: %s", ErrInvalidInviteTemplate, strings.TrimPrefix(err.Error(), "template: "))
+	}
+	return out.String(), nil
+}
+
+// limitedBuilder acumula o texto gerado até o limite
+type limitedBuilder struct {
+	strings.Builder
+	limit int
+}
+
+func (b *limitedBuilder) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, fmt.Errorf("%w: rendered invite must not exceed %d characters", ErrInvalidInviteTemplate, b.limit)
+	}
+	return b.Builder.Write(p)
+}
+
+// InviteTemplate representa um modelo de mensagem salvo pelo casal (save-the-date, convite, lembrete)
+// O assunto e o corpo aceitam as mesmas variáveis dos convites ({{guest_name}}, {{rsvp_link}}...)
+type InviteTemplate struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	WeddingID uint               `gorm:"not null;index" json:"wedding_id"`
+	Wedding   Wedding            `gorm:"foreignKey:WeddingID" json:"-"`
+	Name      string             `gorm:"size:100;not null" json:"name"`
+	Kind      InviteTemplateKind `gorm:"type:varchar(20);default:'custom'" json:"kind"`
+	Subject   string             `gorm:"size:200" json:"subject"` // assunto do email (vazio: assunto padrão)
+	Body      string             `gorm:"type:text;not null" json:"body"`
+}
+
+// InviteTemplateKind classifica o momento da mensagem na jornada do convidado
+type InviteTemplateKind string
+
+const (
+	InviteTemplateSaveTheDate InviteTemplateKind = "save_the_date"
+	InviteTemplateInvite      InviteTemplateKind = "invite"
+	InviteTemplateReminder    InviteTemplateKind = "reminder"
+	InviteTemplateCustom      InviteTemplateKind = "custom"
+)
+
+// Limite do assunto do email
+const maxInviteSubjectLength = 200
+
+// IsValid indica se o tipo de template é suportado
+func (k InviteTemplateKind) IsValid() bool {
+	switch k {
+	case InviteTemplateSaveTheDate, InviteTemplateInvite, InviteTemplateReminder, InviteTemplateCustom:
+		return true
+	}
+	return false
+}
+
+// IsValid normaliza e valida os campos do template, incluindo a sintaxe e as variáveis
+func (t *InviteTemplate) IsValid() error {
+	t.Name = strings.Join(strings.Fields(t.Name), " ")
+	t.Subject = strings.Join(strings.Fields(t.Subject), " ")
+	t.Body = strings.TrimSpace(t.Body)
+	if t.Kind == "" {
+		t.Kind = InviteTemplateCustom
+	}
+
+	if len(t.Name) < 2 || len(t.Name) > 100 {
+		return errors.New("template name must be between 2 and 100 characters long")
+	}
+	if !t.Kind.IsValid() {
+		return errors.New("kind must be one of: save_the_date, invite, reminder, custom")
+	}
+	if len(t.Subject) > maxInviteSubjectLength {
+		return fmt.Errorf("subject must not exceed %d characters", maxInviteSubjectLength)
+	}
+	if t.Body == "" {
+		return errors.New("template body is required")
+	}
+	if err := ValidateInviteTemplate(t.Subject); err != nil {
+		return err
+	}
+	return ValidateInviteTemplate(t.Body)
+}
+
+// BuiltinInviteTemplates são os templates padrão oferecidos a todos os casais, identificados pelo tipo
+// Não ficam no banco: o casal usa direto ou copia para um template próprio e edita
+var BuiltinInviteTemplates = []InviteTemplate{
+	{
+		Name:    "Save the date",
+		Kind:    InviteTemplateSaveTheDate,
+		Subject: "Reserve a data: {{event_date}}",
+		Body: "Olá, {{guest_name}}!\n\n" +
+			"Vamos nos casar e queremos muito você com a gente. Reserve a data: {{event_date}}, no {{venue_name}}.\n\n" +
+			"O convite oficial chega em breve.\n",
+	},
+	{
+		Name:    "Convite formal",
+		Kind:    InviteTemplateInvite,
+		Subject: "Você está convidado(a) para o nosso casamento",
+		Body: "Olá, {{guest_name}}!\n\n" +
+			"É com muita alegria que convidamos você para o nosso casamento, em {{event_date}}" +
+			"{{if event_time}} às {{event_time}}{{end}}, no {{venue_name}}.\n\n" +
+			"Confirme sua presença pelo link: {{rsvp_link}}\n",
+	},
+	{
+		Name:    "Lembrete de confirmação",
+		Kind:    InviteTemplateReminder,
+		Subject: "Falta pouco: confirme sua presença",
+		Body: "Olá, {{guest_name}}!\n\n" +
+			"Nosso casamento está chegando ({{event_date}}, no {{venue_name}}) e ainda não recebemos sua resposta.\n\n" +
+			"Confirme sua presença pelo link: {{rsvp_link}}\n",
+	},
+}
+
+// BuiltinInviteTemplate busca um template padrão pelo tipo
+func BuiltinInviteTemplate(kind InviteTemplateKind) (*InviteTemplate, bool) {
+	for i := range BuiltinInviteTemplates {
+		if BuiltinInviteTemplates[i].Kind == kind {
+			t := BuiltinInviteTemplates[i]
+			return &t, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// JobLease registra a última execução de cada job agendado
+// Garante uma execução por intervalo mesmo com várias réplicas do serviço
+type JobLease struct {
+	Name      string    `gorm:"primarykey;size:100" json:"name"`
+	Owner     string    `gorm:"size:255" json:"owner"` // réplica (host:pid) que executou por último
+	LastRunAt time.Time `json:"last_run_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
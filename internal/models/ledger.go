@@ -0,0 +1,96 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrLedgerImmutable é retornado ao tentar alterar ou remover um lançamento
+var ErrLedgerImmutable = errors.New("ledger entries are immutable")
+
+// LedgerEntry representa um lançamento financeiro imutável
+// Totais de orçamento e arrecadação podem ser reproduzidos somando os lançamentos
+type LedgerEntry struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Performance: Índice composto (wedding_id, occurred_at) para extratos por período
+	WeddingID  uint            `gorm:"not null;index:idx_ledger_wedding_occurred,priority:1" json:"wedding_id"`
+	Wedding    Wedding         `gorm:"foreignKey:WeddingID" json:"-"`
+	Kind       LedgerEntryKind `gorm:"type:varchar(30);not null" json:"kind"`
+	Account    LedgerAccount   `gorm:"type:varchar(20);not null" json:"account"`
+	Amount     float64         `gorm:"not null" json:"amount"` // com sinal: negativo reverte um lançamento anterior
+	SourceType string          `gorm:"size:30;not null;index:idx_ledger_source,priority:1" json:"source_type"`
+	SourceID   uint            `gorm:"not null;index:idx_ledger_source,priority:2" json:"source_id"`
+	Memo       string          `gorm:"size:255" json:"memo"`
+	OccurredAt time.Time       `gorm:"not null;index:idx_ledger_wedding_occurred,priority:2" json:"occurred_at"`
+}
+
+// LedgerAccount separa os lançamentos de gastos e de arrecadações
+type LedgerAccount string
+
+const (
+	LedgerAccountExpenses    LedgerAccount = "expenses"
+	LedgerAccountFundraising LedgerAccount = "fundraising"
+)
+
+// LedgerEntryKind representa o tipo de mutação financeira
+type LedgerEntryKind string
+
+const (
+	LedgerExpensePaid          LedgerEntryKind = "expense_paid"
+	LedgerExpenseReopened      LedgerEntryKind = "expense_reopened"
+	LedgerContributionReceived LedgerEntryKind = "contribution_received"
+	LedgerContributionRefunded LedgerEntryKind = "contribution_refunded"
+	LedgerContributionDisputed LedgerEntryKind = "contribution_disputed"
+	LedgerDisputeWon           LedgerEntryKind = "dispute_won"
+)
+
+// BeforeUpdate bloqueia alterações: correções são feitas com um novo lançamento de estorno
+func (e *LedgerEntry) BeforeUpdate(tx *gorm.DB) error {
+	return ErrLedgerImmutable
+}
+
+// BeforeDelete bloqueia remoções de lançamentos
+func (e *LedgerEntry) BeforeDelete(tx *gorm.DB) error {
+	return ErrLedgerImmutable
+}
+
+// NewFundraisingLedgerEntry cria o lançamento de uma mutação em uma contribuição
+// Recebimentos e disputas ganhas somam; estornos e disputas abertas subtraem
+func NewFundraisingLedgerEntry(f *Fundraising, kind LedgerEntryKind, memo string) *LedgerEntry {
+	amount := f.Amount
+	if kind == LedgerContributionRefunded || kind == LedgerContributionDisputed {
+		amount = -amount
+	}
+	return &LedgerEntry{
+		WeddingID:  f.WeddingID,
+		Kind:       kind,
+		Account:    LedgerAccountFundraising,
+		Amount:     amount,
+		SourceType: "fundraising",
+		SourceID:   f.ID,
+		Memo:       memo,
+		OccurredAt: time.Now(),
+	}
+}
+
+// NewExpenseLedgerEntry cria o lançamento de pagamento (ou reabertura) de um gasto
+func NewExpenseLedgerEntry(e *Expense, kind LedgerEntryKind, memo string) *LedgerEntry {
+	amount := e.Amount
+	if kind == LedgerExpenseReopened {
+		amount = -amount
+	}
+	return &LedgerEntry{
+		WeddingID:  e.WeddingID,
+		Kind:       kind,
+		Account:    LedgerAccountExpenses,
+		Amount:     amount,
+		SourceType: "expense",
+		SourceID:   e.ID,
+		Memo:       memo,
+		OccurredAt: time.Now(),
+	}
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// LifecycleEmail registra um email de ciclo de vida enviado a um usuário
+// Garante que cada regra dispare uma única vez por casamento e serve de base para o limite de envios
+type LifecycleEmail struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	UserID uint   `gorm:"not null;uniqueIndex:idx_lifecycle_once,priority:1" json:"user_id"`
+	User   User   `gorm:"foreignKey:UserID" json:"-"`
+	Rule   string `gorm:"size:50;not null;uniqueIndex:idx_lifecycle_once,priority:2" json:"rule"`
+
+	// 0 para regras da conta (não ligadas a um casamento); NULL não participaria do índice único
+	WeddingID uint `gorm:"not null;default:0;uniqueIndex:idx_lifecycle_once,priority:3" json:"wedding_id"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// phoneLocale associa um código de país (DDI) ao país, idioma padrão e tamanho do número nacional
+type phoneLocale struct {
+	prefix  string
+	country string
+	locale  string
+
+	// Quantidade de dígitos do número nacional (sem o DDI e sem o prefixo de tronco)
+	minDigits int
+	maxDigits int
+
+	// Prefixo de tronco das ligações nacionais (ex: "0" em 011..., vazio quando o país não usa)
+	trunk string
+}
+
+// phoneLocales é ordenado do prefixo mais longo para o mais curto,
+// para que "+351" (Portugal) seja avaliado antes de "+35"/"+3"
+var phoneLocales = []phoneLocale{
+	{"+351", "PT", "pt-PT", 9, 9, ""},
+	{"+353", "IE", "en-IE", 7, 9, "0"},
+	{"+595", "PY", "es-PY", 7, 9, "0"},
+	{"+598", "UY", "es-UY", 8, 8, "0"},
+	{"+244", "AO", "pt-AO", 9, 9, ""},
+	{"+258", "MZ", "pt-MZ", 8, 9, ""},
+	{"+55", "BR", "pt-BR", 10, 11, "0"},
+	{"+54", "AR", "es-AR", 10, 11, "0"},
+	{"+56", "CL", "es-CL", 9, 9, ""},
+	{"+57", "CO", "es-CO", 8, 10, ""},
+	{"+51", "PE", "es-PE", 8, 9, "0"},
+	{"+52", "MX", "es-MX", 10, 10, ""},
+	{"+34", "ES", "es-ES", 9, 9, ""},
+	{"+39", "IT", "it-IT", 6, 11, ""},
+	{"+33", "FR", "fr-FR", 9, 9, "0"},
+	{"+49", "DE", "de-DE", 6, 13, "0"},
+	{"+44", "GB", "en-GB", 9, 10, "0"},
+	{"+61", "AU", "en-AU", 9, 9, "0"},
+	{"+81", "JP", "ja-JP", 9, 10, "0"},
+	{"+1", "US", "en-US", 10, 10, "1"},
+}
+
+// DefaultLocale é usado quando não é possível inferir pelo telefone
+const DefaultLocale = "pt-BR"
+
+var localeRegex = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+
+// InferLocaleFromPhone infere país e idioma a partir do DDI do telefone
+// Telefones sem "+" são considerados nacionais (DEFAULT_PHONE_COUNTRY)
+func InferLocaleFromPhone(phone string) (country, locale string) {
+	phone = strings.TrimSpace(phone)
+	if phone == "" {
+		return "", ""
+	}
+
+	if strings.HasPrefix(phone, "00") {
+		phone = "+" + phone[2:]
+	}
+
+	pl := phoneLocaleByCountry(DefaultPhoneCountry())
+	if strings.HasPrefix(phone, "+") {
+		pl = phoneLocaleByPrefix("+" + strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, phone[1:]))
+	}
+	if pl == nil {
+		return "", ""
+	}
+	return pl.country, pl.locale
+}
+
+// IsValidLocale verifica o formato do idioma (ex: pt, pt-BR)
+func IsValidLocale(locale string) bool {
+	return localeRegex.MatchString(locale)
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// GuestLogistics guarda as necessidades de deslocamento e hospedagem do convite (casamentos em outra cidade)
+type GuestLogistics struct {
+	OutOfTown bool `gorm:"default:false" json:"out_of_town"`
+
+	// Hospedagem: necessidade de reserva e hotel escolhido (pode ser informado mesmo sem reserva pelo casal)
+	NeedsHotel bool   `gorm:"default:false" json:"needs_hotel"`
+	HotelName  string `gorm:"size:200" json:"hotel_name"`
+
+	// Transfer: ponto de embarque e lugares no ônibus/van (padrão: o convite inteiro)
+	NeedsShuttle bool   `gorm:"default:false" json:"needs_shuttle"`
+	PickupPoint  string `gorm:"size:100" json:"pickup_point"`
+	ShuttleSeats int    `gorm:"default:0" json:"shuttle_seats"`
+}
+
+// HasNeeds indica se o convite tem alguma informação de logística
+func (l GuestLogistics) HasNeeds() bool {
+	return l.OutOfTown || l.NeedsHotel || l.NeedsShuttle || l.HotelName != ""
+}
+
+// normalize remove espaços extras e completa os lugares do transfer
+func (l *GuestLogistics) normalize(maxGuests int) {
+	l.HotelName = strings.Join(strings.Fields(l.HotelName), " ")
+	l.PickupPoint = strings.Join(strings.Fields(l.PickupPoint), " ")
+	if !l.NeedsShuttle {
+		l.PickupPoint = ""
+		l.ShuttleSeats = 0
+		return
+	}
+	if l.ShuttleSeats == 0 {
+		l.ShuttleSeats = maxGuests
+	}
+}
+
+// IsValid valida a logística do convite
+func (l GuestLogistics) IsValid(maxGuests int) error {
+	if len(l.HotelName) > 200 {
+		return errors.New("hotel name must not exceed 200 characters")
+	}
+	if len(l.PickupPoint) > 100 {
+		return errors.New("pickup point must not exceed 100 characters")
+	}
+	if l.ShuttleSeats < 0 || l.ShuttleSeats > maxGuests {
+		return errors.New("shuttle seats must be between 1 and max guests")
+	}
+	return nil
+}
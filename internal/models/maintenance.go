@@ -0,0 +1,59 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// MaintenanceFeature identifica uma área da API que pode ser pausada para manutenção
+type MaintenanceFeature string
+
+const (
+	MaintenanceAll            MaintenanceFeature = "all"             // toda a API (exceto health e /admin)
+	MaintenanceInvites        MaintenanceFeature = "invites"         // envio de convites e avisos por email
+	MaintenancePayments       MaintenanceFeature = "payments"        // vaquinhas e webhooks de pagamento
+	MaintenancePrinting       MaintenanceFeature = "printing"        // pedidos de convites impressos
+	MaintenanceContactsImport MaintenanceFeature = "contacts_import" // importação da agenda (vCard e Google)
+	MaintenancePhotos         MaintenanceFeature = "photos"          // portal de fotos dos convidados
+)
+
+var validMaintenanceFeatures = map[MaintenanceFeature]bool{
+	MaintenanceAll: true, MaintenanceInvites: true, MaintenancePayments: true,
+	MaintenancePrinting: true, MaintenanceContactsImport: true, MaintenancePhotos: true,
+}
+
+// IsValid verifica se a área é uma das conhecidas
+func (f MaintenanceFeature) IsValid() bool {
+	return validMaintenanceFeatures[f]
+}
+
+// Limite do Retry-After informado aos clientes (um dia)
+const maxMaintenanceRetryAfter = 24 * 60 * 60
+
+// MaintenanceFlag pausa uma área da API com uma mensagem amigável (503 + Retry-After)
+// Gravada no banco para valer em todas as réplicas, ex: migração de provedor de email
+type MaintenanceFlag struct {
+	Feature           MaintenanceFeature `gorm:"primarykey;type:varchar(50)" json:"feature"`
+	Message           string             `gorm:"size:500" json:"message"`
+	RetryAfterSeconds int                `gorm:"default:0" json:"retry_after_seconds"` // 0 omite o Retry-After
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+}
+
+// IsValid valida a área, a mensagem e o Retry-After
+func (m *MaintenanceFlag) IsValid() error {
+	m.Feature = MaintenanceFeature(strings.ToLower(strings.TrimSpace(string(m.Feature))))
+	m.Message = strings.TrimSpace(m.Message)
+
+	if !m.Feature.IsValid() {
+		return errors.New("invalid maintenance feature")
+	}
+	if len(m.Message) > 500 {
+		return errors.New("maintenance message must not exceed 500 characters")
+	}
+	if m.RetryAfterSeconds < 0 || m.RetryAfterSeconds > maxMaintenanceRetryAfter {
+		return errors.New("retry_after_seconds must be between 0 and 86400")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+)
+
+// ErrInvalidPhone indica um telefone que não pode ser convertido para E.164
+var ErrInvalidPhone = errors.New("invalid phone number")
+
+// Limites do E.164: DDI + número nacional com no máximo 15 dígitos
+const (
+	minE164Digits = 8
+	maxE164Digits = 15
+)
+
+// NormalizePhone converte o telefone digitado para o formato E.164 (+5511987654321)
+// Números sem DDI ("+" ou "00") são interpretados como nacionais de defaultCountry (ISO 3166-1 alfa-2);
+// o prefixo de tronco (ex: 0 em "011 ...") é removido e o tamanho é conferido nos países conhecidos
+// Telefone vazio retorna vazio sem erro
+func NormalizePhone(raw, defaultCountry string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+
+	international := false
+	switch {
+	case strings.HasPrefix(raw, "+"):
+		international = true
+		raw = raw[1:]
+	case strings.HasPrefix(raw, "00"):
+		international = true
+		raw = raw[2:]
+	}
+
+	// Apenas dígitos e separadores comuns: letras indicam texto digitado no campo errado
+	var digits strings.Builder
+	for _, r := range raw {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case strings.ContainsRune(" -().", r):
+		default:
+			return "", ErrInvalidPhone
+		}
+	}
+	number := digits.String()
+
+	var pl *phoneLocale
+	if international {
+		pl = phoneLocaleByPrefix("+" + number)
+		if pl == nil {
+			// DDI fora da tabela: apenas os limites do E.164
+			if len(number) < minE164Digits || len(number) > maxE164Digits {
+				return "", ErrInvalidPhone
+			}
+			return "+" + number, nil
+		}
+		number = number[len(pl.prefix)-1:]
+	} else {
+		pl = phoneLocaleByCountry(defaultCountry)
+		if pl == nil {
+			return "", ErrInvalidPhone
+		}
+	}
+
+	// Formato "+44 (0)20 ..." ou número nacional discado com o prefixo de tronco
+	if pl.trunk != "" && len(number) > pl.minDigits && strings.HasPrefix(number, pl.trunk) {
+		if trimmed := number[len(pl.trunk):]; len(trimmed) >= pl.minDigits {
+			number = trimmed
+		}
+	}
+	if len(number) < pl.minDigits || len(number) > pl.maxDigits {
+		return "", ErrInvalidPhone
+	}
+	return pl.prefix + number, nil
+}
+
+// DefaultPhoneCountry retorna o país dos telefones sem DDI (BR quando não configurado)
+func DefaultPhoneCountry() string {
+	if configs.DEFAULT_PHONE_COUNTRY == "" {
+		return "BR"
+	}
+	return configs.DEFAULT_PHONE_COUNTRY
+}
+
+// phoneLocaleByPrefix busca o país pelo DDI (prefixos mais longos primeiro)
+func phoneLocaleByPrefix(e164 string) *phoneLocale {
+	for i := range phoneLocales {
+		if strings.HasPrefix(e164, phoneLocales[i].prefix) {
+			return &phoneLocales[i]
+		}
+	}
+	return nil
+}
+
+// phoneLocaleByCountry busca o DDI pelo código do país
+func phoneLocaleByCountry(country string) *phoneLocale {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	for i := range phoneLocales {
+		if phoneLocales[i].country == country {
+			return &phoneLocales[i]
+		}
+	}
+	return nil
+}
+
+// PhoneSelfCheck confere se o país padrão tem DDI conhecido
+// Sem isso, todo telefone digitado sem DDI seria recusado
+func PhoneSelfCheck() error {
+	if phoneLocaleByCountry(DefaultPhoneCountry()) == nil {
+		return fmt.Errorf("DEFAULT_PHONE_COUNTRY %s não tem DDI conhecido", DefaultPhoneCountry())
+	}
+	return nil
+}
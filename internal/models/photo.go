@@ -0,0 +1,89 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// PhotoPortal representa o link de envio/visualização de fotos dos convidados após o evento
+// Um portal por casamento: regenerar o link invalida o anterior
+type PhotoPortal struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	WeddingID uint    `gorm:"not null;uniqueIndex" json:"wedding_id"`
+	Wedding   Wedding `gorm:"foreignKey:WeddingID" json:"-"`
+
+	// Segurança: Token aleatório (não derivado do ID) e com validade, nulo quando o portal é fechado
+	Token     *string   `gorm:"size:64;uniqueIndex" json:"-"`
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Com moderação, fotos enviadas só aparecem para os convidados após aprovação do casal
+	RequireApproval bool `gorm:"default:true" json:"require_approval"`
+
+	// ZIP das fotos aprovadas, gerado em segundo plano pelo job photo_archives
+	ArchiveStatus      ArchiveStatus `gorm:"type:varchar(20);index" json:"archive_status"`
+	ArchiveFile        string        `gorm:"size:64" json:"-"`
+	ArchiveRequestedAt *time.Time    `json:"archive_requested_at"`
+	ArchiveReadyAt     *time.Time    `json:"archive_ready_at"`
+	ArchivePhotos      int           `json:"archive_photos"`
+	ArchiveError       string        `gorm:"size:255" json:"archive_error"`
+}
+
+// ArchiveStatus representa o estado do ZIP de fotos
+type ArchiveStatus string
+
+const (
+	ArchiveStatusPending ArchiveStatus = "pending"
+	ArchiveStatusReady   ArchiveStatus = "ready"
+	ArchiveStatusFailed  ArchiveStatus = "failed"
+)
+
+// IsOpen indica se o portal aceita envios e visualização
+func (p *PhotoPortal) IsOpen(now time.Time) bool {
+	return p.Token != nil && now.Before(p.ExpiresAt)
+}
+
+// GuestPhoto representa uma foto enviada por um convidado pelo portal
+// Sem soft delete: remover a foto apaga também o arquivo (LGPD)
+type GuestPhoto struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Performance: Índice composto (wedding_id, status) para moderação e galeria pública
+	WeddingID uint    `gorm:"not null;index:idx_guest_photo_wedding_status,priority:1" json:"wedding_id"`
+	Wedding   Wedding `gorm:"foreignKey:WeddingID" json:"-"`
+
+	FileName     string      `gorm:"size:64;not null;uniqueIndex" json:"file_name"` // nome aleatório gerado pelo storage
+	ContentType  string      `gorm:"size:50" json:"content_type"`
+	Size         int64       `json:"size"`
+	UploaderName string      `gorm:"size:100" json:"uploader_name"` // informado livremente pelo convidado
+	Status       PhotoStatus `gorm:"type:varchar(20);not null;index:idx_guest_photo_wedding_status,priority:2" json:"status"`
+	ModeratedAt  *time.Time  `json:"moderated_at"`
+}
+
+// PhotoStatus representa a moderação de uma foto
+type PhotoStatus string
+
+const (
+	PhotoStatusPending  PhotoStatus = "pending"
+	PhotoStatusApproved PhotoStatus = "approved"
+	PhotoStatusRejected PhotoStatus = "rejected"
+)
+
+// IsValid valida o status de moderação
+func (s PhotoStatus) IsValid() bool {
+	return s == PhotoStatusPending || s == PhotoStatusApproved || s == PhotoStatusRejected
+}
+
+// NormalizeUploader normaliza e valida o nome informado pelo convidado
+func (p *GuestPhoto) NormalizeUploader() error {
+	p.UploaderName = strings.Join(strings.Fields(p.UploaderName), " ")
+	if len(p.UploaderName) > 100 {
+		return errors.New("name must be at most 100 characters long")
+	}
+	return nil
+}
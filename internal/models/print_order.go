@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PrintOrder representa um lote de convites impressos enviado a um provedor de impressão e postagem
+type PrintOrder struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	WeddingID uint    `gorm:"not null;index" json:"wedding_id"`
+	Wedding   Wedding `gorm:"foreignKey:WeddingID" json:"-"`
+
+	// Provedor e templates (frente e verso) escolhidos pelo casal no catálogo do provedor
+	Provider        string           `gorm:"size:30;not null" json:"provider"`
+	FrontTemplateID string           `gorm:"size:100;not null" json:"front_template_id"`
+	BackTemplateID  string           `gorm:"size:100;not null" json:"back_template_id"`
+	Status          PrintOrderStatus `gorm:"type:varchar(20);default:'pending';index" json:"status"`
+
+	// Totais do lote, recalculados a partir das peças a cada atualização
+	Pieces          int `gorm:"default:0" json:"pieces"`
+	PiecesSubmitted int `gorm:"default:0" json:"pieces_submitted"`
+	PiecesDelivered int `gorm:"default:0" json:"pieces_delivered"`
+	PiecesFailed    int `gorm:"default:0" json:"pieces_failed"`
+
+	// Gasto registrado automaticamente quando todas as peças foram enviadas ao provedor
+	ExpenseID   *uint      `json:"expense_id"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// PrintOrderStatus representa o andamento de um lote de impressão
+type PrintOrderStatus string
+
+const (
+	PrintOrderPending    PrintOrderStatus = "pending"    // peças aguardando envio ao provedor
+	PrintOrderProcessing PrintOrderStatus = "processing" // todas enviadas, em produção ou a caminho
+	PrintOrderCompleted  PrintOrderStatus = "completed"  // todas as peças com status final
+	PrintOrderFailed     PrintOrderStatus = "failed"     // nenhuma peça aceita pelo provedor
+)
+
+// PrintOrderItem representa uma peça (um convite) do lote, por convidado ou família
+// Destinatário e endereço são copiados no pedido: a peça registra o que foi de fato impresso
+type PrintOrderItem struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Performance: Índice composto (print_order_id, status) para os totais do lote
+	PrintOrderID uint       `gorm:"not null;index:idx_print_item_order_status,priority:1" json:"print_order_id"`
+	PrintOrder   PrintOrder `gorm:"foreignKey:PrintOrderID" json:"-"`
+	WeddingID    uint       `gorm:"not null;index" json:"wedding_id"`
+	GuestID      uint       `gorm:"not null;index" json:"guest_id"`
+
+	RecipientName string        `gorm:"size:200;not null" json:"recipient_name"`
+	Guests        int           `gorm:"default:1" json:"guests"` // convidados cobertos pela peça
+	Address       PostalAddress `gorm:"embedded;embeddedPrefix:address_" json:"address"`
+
+	Status          PrintItemStatus `gorm:"type:varchar(20);default:'pending';index:idx_print_item_order_status,priority:2;index" json:"status"`
+	ProviderPieceID string          `gorm:"size:100" json:"provider_piece_id"`
+	Error           string          `gorm:"size:255" json:"error"`
+	CheckedAt       *time.Time      `json:"checked_at"` // última consulta de rastreio no provedor
+}
+
+// PrintItemStatus representa o status de uma peça no provedor
+type PrintItemStatus string
+
+const (
+	PrintItemPending   PrintItemStatus = "pending"    // ainda não enviada ao provedor
+	PrintItemSubmitted PrintItemStatus = "submitted"  // aceita pelo provedor, em produção
+	PrintItemMailed    PrintItemStatus = "mailed"     // postada
+	PrintItemInTransit PrintItemStatus = "in_transit" // em trânsito
+	PrintItemDelivered PrintItemStatus = "delivered"
+	PrintItemReturned  PrintItemStatus = "returned" // devolvida ao remetente
+	PrintItemFailed    PrintItemStatus = "failed"   // recusada ou cancelada no provedor
+)
+
+// IsFinal indica se a peça não muda mais de status
+func (s PrintItemStatus) IsFinal() bool {
+	return s == PrintItemDelivered || s == PrintItemReturned || s == PrintItemFailed
+}
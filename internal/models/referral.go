@@ -0,0 +1,58 @@
+package models
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+)
+
+// Alfabeto dos códigos de indicação (sem 0/O e 1/I, fáceis de ditar)
+const referralCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// Tamanho do código de indicação
+const ReferralCodeLength = 8
+
+// ReferralStatus representa as etapas de uma indicação
+type ReferralStatus string
+
+const (
+	ReferralStatusSignedUp  ReferralStatus = "signed_up" // indicado criou a conta
+	ReferralStatusConverted ReferralStatus = "converted" // indicado criou o primeiro casamento (limite de recompensas atingido)
+	ReferralStatusRewarded  ReferralStatus = "rewarded"  // convertida e recompensa aplicada a quem indicou
+)
+
+// Referral registra que um usuário se cadastrou pelo código de outro
+type Referral struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Quem indicou (dono do código)
+	UserID uint `gorm:"not null;index" json:"user_id"`
+	User   User `gorm:"foreignKey:UserID" json:"-"`
+
+	// Cada conta pode ter sido indicada uma única vez
+	ReferredUserID uint `gorm:"not null;uniqueIndex" json:"referred_user_id"`
+	ReferredUser   User `gorm:"foreignKey:ReferredUserID" json:"-"`
+
+	Status      ReferralStatus `gorm:"type:varchar(20);not null;default:'signed_up';index" json:"status"`
+	ConvertedAt *time.Time     `json:"converted_at"`
+	RewardedAt  *time.Time     `json:"rewarded_at"`
+}
+
+// NewReferralCode gera um código de indicação aleatório
+func NewReferralCode() (string, error) {
+	b := make([]byte, ReferralCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = referralCodeAlphabet[int(b[i])%len(referralCodeAlphabet)]
+	}
+	return string(b), nil
+}
+
+// NormalizeReferralCode padroniza o código digitado pelo usuário
+func NormalizeReferralCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
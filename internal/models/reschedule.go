@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// ShiftDueDate recalcula um prazo quando o casamento muda de data
+// Prazos entre agora e a data antiga mantêm a mesma proporção do tempo restante até o evento
+// (um pagamento na metade do caminho continua na metade do caminho); prazos posteriores ao evento
+// andam junto com ele e prazos já vencidos ficam como estão
+func ShiftDueDate(due, now, oldDate, newDate time.Time) time.Time {
+	if due.Before(now) {
+		return due
+	}
+
+	oldSpan := oldDate.Sub(now)
+	if due.After(oldDate) || oldSpan <= 0 {
+		return due.Add(newDate.Sub(oldDate))
+	}
+
+	newSpan := newDate.Sub(now)
+	if newSpan <= 0 {
+		return now
+	}
+	elapsed := float64(due.Sub(now)) * float64(newSpan) / float64(oldSpan)
+	return now.Add(time.Duration(elapsed))
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Limites das perguntas personalizadas de RSVP
+const (
+	MaxRSVPOptions      = 20
+	MaxRSVPAnswerLength = 1000
+)
+
+// RSVPQuestionType representa os tipos de pergunta personalizada
+type RSVPQuestionType string
+
+const (
+	RSVPQuestionText         RSVPQuestionType = "text"          // resposta livre (ex: pedido de música)
+	RSVPQuestionSingleChoice RSVPQuestionType = "single_choice" // uma das opções definidas pelo casal
+	RSVPQuestionYesNo        RSVPQuestionType = "yes_no"        // "yes" ou "no" (ex: precisa de transporte?)
+)
+
+// Respostas aceitas nas perguntas yes_no
+const (
+	RSVPAnswerYes = "yes"
+	RSVPAnswerNo  = "no"
+)
+
+// IsValid verifica se o tipo de pergunta é conhecido
+func (t RSVPQuestionType) IsValid() bool {
+	return t == RSVPQuestionText || t == RSVPQuestionSingleChoice || t == RSVPQuestionYesNo
+}
+
+// RSVPQuestion representa uma pergunta personalizada do casal no fluxo de RSVP
+type RSVPQuestion struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	WeddingID uint             `gorm:"not null;index" json:"wedding_id"`
+	Wedding   Wedding          `gorm:"foreignKey:WeddingID" json:"-"`
+	Prompt    string           `gorm:"size:255;not null" json:"prompt"`
+	Type      RSVPQuestionType `gorm:"type:varchar(20);not null" json:"type"`
+	Options   []string         `gorm:"type:text;serializer:json" json:"options"` // apenas single_choice
+	Position  int              `gorm:"default:0" json:"position"`                // ordem de exibição
+
+	// Parte do casamento da pergunta: só aparece para quem é convidado a ela (both = todos)
+	Event WeddingEvent `gorm:"type:varchar(20);default:'both'" json:"event"`
+}
+
+// RSVPAnswer guarda a resposta de um convidado a uma pergunta
+// Sem soft delete: responder de novo substitui a resposta anterior
+type RSVPAnswer struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	WeddingID  uint         `gorm:"not null;index" json:"wedding_id"`
+	GuestID    uint         `gorm:"not null;uniqueIndex:idx_rsvp_answer,priority:1" json:"guest_id"`
+	Guest      Guest        `gorm:"foreignKey:GuestID" json:"-"`
+	QuestionID uint         `gorm:"not null;uniqueIndex:idx_rsvp_answer,priority:2;index" json:"question_id"`
+	Question   RSVPQuestion `gorm:"foreignKey:QuestionID" json:"-"`
+	Answer     string       `gorm:"type:text" json:"answer"`
+}
+
+// IsValid normaliza e valida os campos da pergunta
+func (q *RSVPQuestion) IsValid() error {
+	q.Prompt = strings.Join(strings.Fields(q.Prompt), " ")
+
+	if len(q.Prompt) < 3 || len(q.Prompt) > 255 {
+		return errors.New("prompt must be between 3 and 255 characters long")
+	}
+	if !q.Type.IsValid() {
+		return errors.New("invalid question type")
+	}
+	if q.Position < 0 {
+		return errors.New("position must be zero or positive")
+	}
+	if q.Event == "" {
+		q.Event = WeddingEventBoth
+	}
+	if !q.Event.IsValid() {
+		return errors.New("event must be ceremony, reception or both")
+	}
+
+	if q.Type != RSVPQuestionSingleChoice {
+		q.Options = []string{}
+		return nil
+	}
+
+	options := make([]string, 0, len(q.Options))
+	for _, option := range q.Options {
+		option = strings.Join(strings.Fields(option), " ")
+		if option == "" || slices.Contains(options, option) {
+			continue
+		}
+		if len(option) > 100 {
+			return errors.New("options must have at most 100 characters")
+		}
+		options = append(options, option)
+	}
+	if len(options) < 2 || len(options) > MaxRSVPOptions {
+		return errors.New("single choice questions need between 2 and 20 options")
+	}
+	q.Options = options
+	return nil
+}
+
+// AppliesTo indica se a pergunta faz parte do RSVP do convidado
+func (q *RSVPQuestion) AppliesTo(g *Guest) bool {
+	return g.Events.Includes(q.Event)
+}
+
+// NormalizeAnswer valida uma resposta contra o tipo da pergunta e retorna o valor a gravar
+// Resposta vazia significa "sem resposta"
+func (q *RSVPQuestion) NormalizeAnswer(answer string) (string, error) {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return "", nil
+	}
+
+	switch q.Type {
+	case RSVPQuestionYesNo:
+		answer = strings.ToLower(answer)
+		if answer != RSVPAnswerYes && answer != RSVPAnswerNo {
+			return "", errors.New("answer must be yes or no")
+		}
+	case RSVPQuestionSingleChoice:
+		if !slices.Contains(q.Options, answer) {
+			return "", errors.New("answer must be one of the question options")
+		}
+	default:
+		if len(answer) > MaxRSVPAnswerLength {
+			return "", errors.New("answer must have at most 1000 characters")
+		}
+	}
+	return answer, nil
+}
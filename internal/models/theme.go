@@ -0,0 +1,68 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WeddingTheme representa a configuração visual da página pública do casamento
+type WeddingTheme struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	WeddingID      uint          `gorm:"not null;uniqueIndex" json:"wedding_id"`
+	Wedding        Wedding       `gorm:"foreignKey:WeddingID" json:"-"`
+	Template       ThemeTemplate `gorm:"type:varchar(20);default:'classic'" json:"template"`
+	PrimaryColor   string        `gorm:"size:7;default:'#ffffff'" json:"primary_color"`
+	SecondaryColor string        `gorm:"size:7;default:'#000000'" json:"secondary_color"`
+	CoverPhoto     string        `gorm:"size:100" json:"cover_photo"` // nome do arquivo no storage
+
+	// Snapshot publicado: alterações nas configurações só ficam visíveis após publicar
+	PublishedSnapshot string     `gorm:"type:text" json:"-"`
+	PublishedAt       *time.Time `json:"published_at"`
+}
+
+// ThemeTemplate representa os templates disponíveis para a página pública
+type ThemeTemplate string
+
+const (
+	ThemeTemplateClassic ThemeTemplate = "classic"
+	ThemeTemplateRustic  ThemeTemplate = "rustic"
+	ThemeTemplateModern  ThemeTemplate = "modern"
+	ThemeTemplateBeach   ThemeTemplate = "beach"
+)
+
+var hexColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// IsValid valida os campos do tema
+func (t *WeddingTheme) IsValid() error {
+	t.PrimaryColor = strings.ToLower(strings.TrimSpace(t.PrimaryColor))
+	t.SecondaryColor = strings.ToLower(strings.TrimSpace(t.SecondaryColor))
+
+	switch t.Template {
+	case ThemeTemplateClassic, ThemeTemplateRustic, ThemeTemplateModern, ThemeTemplateBeach:
+	default:
+		return errors.New("template must be one of: classic, rustic, modern, beach")
+	}
+
+	if !hexColorRegex.MatchString(t.PrimaryColor) {
+		return errors.New("primary color must be a hex color like #aabbcc")
+	}
+
+	if !hexColorRegex.MatchString(t.SecondaryColor) {
+		return errors.New("secondary color must be a hex color like #aabbcc")
+	}
+
+	return nil
+}
+
+// IsPublished indica se a página pública já foi publicada
+func (t *WeddingTheme) IsPublished() bool {
+	return t.PublishedAt != nil && t.PublishedSnapshot != ""
+}
@@ -22,12 +22,38 @@ type User struct {
 	Email        string `gorm:"uniqueIndex;not null" json:"email,omitempty"`
 	PasswordHash string `gorm:"not null" json:"password,omitempty"`
 	PartnerName  string `json:"partner_name"`
+
+	// Casamento padrão ("atual") para contas com mais de um casamento
+	DefaultWeddingID *uint `json:"default_wedding_id"`
+
+	// Contas com vários casamentos (cerimonialistas): recusa dois casamentos na mesma data em vez de só avisar
+	BlockDateConflicts bool `gorm:"default:false" json:"block_date_conflicts"`
+
+	// Token do feed iCal de pagamentos (calendários não enviam header Authorization)
+	CalendarToken *string `gorm:"size:64;uniqueIndex" json:"-"`
+
+	// Última atividade autenticada (atualizada com throttle pelo ActivityMiddleware)
+	LastSeenAt *time.Time `gorm:"index" json:"last_seen_at"`
+
+	// Opt-out dos emails de ciclo de vida (dicas e lembretes); emails transacionais continuam
+	LifecycleOptOutAt *time.Time `json:"lifecycle_opt_out_at"`
+
+	// Programa de indicação: código próprio (gerado sob demanda) e benefício Pro concedido
+	// Segurança: json:"-" impede definir os campos pelo body do cadastro
+	ReferralCode *string    `gorm:"size:16;uniqueIndex" json:"-"`
+	ProUntil     *time.Time `json:"-"`
+}
+
+// IsPro indica se a conta tem o plano Pro ativo no momento
+func (u *User) IsPro(now time.Time) bool {
+	return u.ProUntil != nil && u.ProUntil.After(now)
 }
 
 // LoginRequest representa os dados de login
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
+	Client   string `json:"client"` // web (padrão), mobile ou admin: define validade e refresh do token
 }
 
 // IsValid valida os dados do usuário
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Vendor representa um fornecedor do catálogo da conta (compartilhado entre casamentos)
+type Vendor struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Performance: Índice em user_id para listar o catálogo da conta
+	UserID uint `gorm:"not null;index:idx_user_vendors" json:"user_id"`
+
+	Name        string          `gorm:"size:200;not null" json:"name"`
+	Category    ExpenseCategory `gorm:"type:varchar(50);not null" json:"category"`
+	ContactName string          `gorm:"size:200" json:"contact_name"`
+	Email       string          `gorm:"size:254" json:"email"`
+	Phone       string          `gorm:"size:30" json:"phone"`
+	Website     string          `gorm:"size:500" json:"website"`
+	Notes       string          `gorm:"type:text" json:"notes"`
+}
+
+// WeddingVendor associa um fornecedor do catálogo a um casamento
+// Mantém preço e observações específicos do casamento separados do contato compartilhado
+type WeddingVendor struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	WeddingID uint    `gorm:"not null;uniqueIndex:idx_wedding_vendor,priority:1" json:"wedding_id"`
+	Wedding   Wedding `gorm:"foreignKey:WeddingID" json:"-"`
+	VendorID  uint    `gorm:"not null;uniqueIndex:idx_wedding_vendor,priority:2" json:"vendor_id"`
+	Vendor    Vendor  `gorm:"foreignKey:VendorID" json:"vendor"`
+	Price     float64 `gorm:"default:0" json:"price"`
+	Notes     string  `gorm:"type:text" json:"notes"`
+
+	DueDate *time.Time `json:"due_date"` // vencimento do pagamento ao fornecedor (opcional)
+
+	// Casamento remarcado: o casal precisa confirmar a nova data com o fornecedor
+	NeedsDateConfirmation bool `gorm:"default:false" json:"needs_date_confirmation"`
+}
+
+// IsValid valida todos os campos do fornecedor
+func (v *Vendor) IsValid() error {
+	v.normalize()
+
+	if len(v.Name) < 2 || len(v.Name) > 200 {
+		return errors.New("vendor name must be between 2 and 200 characters long")
+	}
+
+	if !v.Category.IsValid() {
+		return errors.New("invalid vendor category")
+	}
+
+	if v.Email != "" {
+		if _, err := mail.ParseAddress(v.Email); err != nil {
+			return errors.New("invalid email format")
+		}
+	}
+
+	if len(v.Phone) > 30 {
+		return errors.New("phone must not exceed 30 characters")
+	}
+
+	if len(v.Website) > 500 {
+		return errors.New("website must not exceed 500 characters")
+	}
+
+	return nil
+}
+
+// normalize remove espaços extras dos campos de texto
+func (v *Vendor) normalize() {
+	v.Name = strings.TrimSpace(v.Name)
+	v.ContactName = strings.TrimSpace(v.ContactName)
+	v.Email = strings.ToLower(strings.TrimSpace(v.Email))
+	v.Phone = strings.TrimSpace(v.Phone)
+	v.Website = strings.TrimSpace(v.Website)
+	v.Notes = strings.TrimSpace(v.Notes)
+}
+
+// IsValid valida os dados da associação
+func (wv *WeddingVendor) IsValid() error {
+	wv.Notes = strings.TrimSpace(wv.Notes)
+
+	if wv.Price < 0 {
+		return errors.New("price cannot be negative")
+	}
+
+	return nil
+}
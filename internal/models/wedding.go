@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/matheushermes/wedding_planner_service/internal/money"
 	"gorm.io/gorm"
 )
 
@@ -25,8 +26,63 @@ type Wedding struct {
 	EventTime         string    `gorm:"size:10" json:"event_time"`
 	MaxGuests         int       `gorm:"default:0" json:"max_guests"`
 	CurrentGuestCount int       `gorm:"default:0" json:"current_guest_count"`
+
+	// Acompanhantes confirmados (somados aos convidados confirmados nos totais do casamento)
+	ConfirmedCompanionCount int `gorm:"default:0" json:"confirmed_companion_count"`
+
+	// Recusas passam a vaga automaticamente para a lista de espera (lista A antes da lista B)
+	AutoPromoteGuests bool `gorm:"default:false" json:"auto_promote_guests"`
+	// Quem sai da lista de espera recebe o convite por email automaticamente
+	AutoInvitePromoted bool `gorm:"default:false" json:"auto_invite_promoted"`
+	// Prazo do RSVP: até ele o convidado muda a resposta pelo link pessoal; depois, só o casal altera
+	RSVPDeadline *time.Time `json:"rsvp_deadline"`
+
+	// Convites por WhatsApp que não chegam (casamento sem conta ou número sem WhatsApp) saem por SMS
+	SMSFallback bool `gorm:"default:false" json:"sms_fallback"`
+
+	// Lotação do local: confirmações além de MaxGuests (convidados + acompanhantes confirmados) são recusadas
+	// Desativado, o casal apenas recebe o aviso de lotação excedida
+	EnforceCapacity bool `gorm:"default:false" json:"enforce_capacity"`
+
+	// Endereços públicos opcionais (ponteiros para permitir múltiplos NULL no uniqueIndex)
+	Slug         *string `gorm:"size:100;uniqueIndex" json:"slug"`
+	CustomDomain *string `gorm:"size:253;uniqueIndex" json:"custom_domain"`
+
+	// Domínio customizado (contas Pro): o casal pede o domínio, publica o registro TXT com o token e
+	// só então ele passa para CustomDomain. Segurança: o pedido pendente não reserva o domínio,
+	// impedindo que alguém bloqueie ou assuma o domínio de outro casal
+	PendingCustomDomain    *string    `gorm:"size:253;index" json:"-"`
+	CustomDomainToken      *string    `gorm:"size:64" json:"-"`
+	CustomDomainVerifiedAt *time.Time `json:"-"`
+
+	// Moeda (ISO 4217) usada nos valores financeiros do casamento
+	Currency string `gorm:"size:3;default:'BRL'" json:"currency"`
+
+	// Provedor de pagamentos do casamento (vazio usa o padrão do serviço)
+	PaymentProvider string `gorm:"size:30" json:"payment_provider"`
+
+	// Token do widget de contagem regressiva embutível em sites externos
+	EmbedToken *string `gorm:"size:64;uniqueIndex" json:"-"`
+
+	// Lembrete anual de bodas por email (opt-in do casal)
+	AnniversaryReminders bool `gorm:"default:false;index" json:"anniversary_reminders"`
+	// Último aniversário lembrado (1 = bodas de papel); evita reenvio no mesmo ano
+	LastAnniversaryReminder int `gorm:"default:0" json:"-"`
+}
+
+// Palavras reservadas que não podem ser usadas como slug (conflitam com rotas ou enganam usuários)
+var reservedSlugs = map[string]bool{
+	"admin": true, "api": true, "app": true, "auth": true, "health": true,
+	"login": true, "logout": true, "public": true, "register": true, "settings": true,
+	"static": true, "support": true, "user": true, "users": true, "w": true,
+	"wedding": true, "weddings": true, "www": true,
 }
 
+var (
+	slugRegex   = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	domainRegex = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+)
+
 // DaysRemaining calcula os dias restantes até o casamento
 // Performance: Cálculo em memória, não em query SQL
 func (w *Wedding) DaysRemaining() int {
@@ -37,6 +93,40 @@ func (w *Wedding) DaysRemaining() int {
 	return int(duration.Hours() / 24)
 }
 
+// NextAnniversary retorna a data do próximo aniversário de casamento (hoje conta como próximo)
+// e quantos anos de casados serão completados nela
+// Casamentos em 29/02 comemoram em 28/02 nos anos não bissextos
+func (w *Wedding) NextAnniversary(now time.Time) (time.Time, int) {
+	now = now.In(w.EventDate.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, w.EventDate.Location())
+	years := now.Year() - w.EventDate.Year()
+	next := w.AnniversaryOf(years)
+	if next.Before(today) {
+		years++
+		next = w.AnniversaryOf(years)
+	}
+	if years < 1 {
+		years = 1
+		next = w.AnniversaryOf(years)
+	}
+	return next, years
+}
+
+// AnniversaryOf retorna a data (meia-noite) do aniversário de n anos de casamento
+func (w *Wedding) AnniversaryOf(years int) time.Time {
+	year := w.EventDate.Year() + years
+	month, day := w.EventDate.Month(), w.EventDate.Day()
+	if month == time.February && day == 29 && !isLeapYear(year) {
+		day = 28
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, w.EventDate.Location())
+}
+
+// isLeapYear indica se o ano é bissexto
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
 // Validate valida todos os campos do wedding
 func (w *Wedding) IsValid() error {
 	w.normalize()
@@ -61,14 +151,39 @@ func (w *Wedding) IsValid() error {
 		return err
 	}
 
+	if err := w.validateCurrency(); err != nil {
+		return err
+	}
+
+	if err := w.validateRSVPDeadline(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// RSVPClosed indica se o prazo do RSVP já passou (sem prazo, o RSVP fica aberto)
+func (w *Wedding) RSVPClosed(now time.Time) bool {
+	return w.RSVPDeadline != nil && now.After(*w.RSVPDeadline)
+}
+
 // normalize remove espaços extras dos campos de texto
 func (w *Wedding) normalize() {
 	w.VenueName = strings.TrimSpace(w.VenueName)
 	w.VenueAddress = strings.TrimSpace(w.VenueAddress)
 	w.EventTime = strings.TrimSpace(w.EventTime)
+	w.Currency = strings.ToUpper(strings.TrimSpace(w.Currency))
+	if w.Currency == "" {
+		w.Currency = money.DefaultCurrency
+	}
+}
+
+// validateCurrency valida se a moeda é suportada
+func (w *Wedding) validateCurrency() error {
+	if !money.IsSupported(w.Currency) {
+		return errors.New("currency is not supported")
+	}
+	return nil
 }
 
 // validateEventDate valida a data do evento
@@ -92,6 +207,14 @@ func (w *Wedding) validateEventDate() error {
 	return nil
 }
 
+// validateRSVPDeadline valida o prazo do RSVP: respostas depois do casamento não fazem sentido
+func (w *Wedding) validateRSVPDeadline() error {
+	if w.RSVPDeadline != nil && w.RSVPDeadline.After(w.EventDate) {
+		return errors.New("rsvp deadline cannot be after the event date")
+	}
+	return nil
+}
+
 // validateVenueName valida o nome do local
 func (w *Wedding) validateVenueName() error {
 	if w.VenueName == "" {
@@ -160,3 +283,57 @@ func (w *Wedding) validateMaxGuests() error {
 
 	return nil
 }
+
+// ValidateSlug normaliza e valida o slug da página pública
+func (w *Wedding) ValidateSlug() error {
+	if w.Slug == nil {
+		return nil
+	}
+
+	slug := strings.ToLower(strings.TrimSpace(*w.Slug))
+	w.Slug = &slug
+
+	if len(slug) < 3 || len(slug) > 100 {
+		return errors.New("slug must be between 3 and 100 characters long")
+	}
+
+	if !slugRegex.MatchString(slug) {
+		return errors.New("slug may only contain lowercase letters, numbers and single hyphens")
+	}
+
+	if reservedSlugs[slug] {
+		return errors.New("slug is reserved, please choose another one")
+	}
+
+	return nil
+}
+
+// ValidateCustomDomain normaliza e valida o domínio customizado pedido (ainda não verificado)
+func (w *Wedding) ValidateCustomDomain() error {
+	if w.PendingCustomDomain == nil {
+		return nil
+	}
+
+	domain := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(*w.PendingCustomDomain)), ".")
+	w.PendingCustomDomain = &domain
+
+	if len(domain) > 253 || !domainRegex.MatchString(domain) {
+		return errors.New("custom domain must be a valid hostname like www.example.com")
+	}
+
+	return nil
+}
+
+// Registro TXT de verificação do domínio customizado: _wedding-verify.<domínio> = wedding-verify=<token>
+const (
+	CustomDomainVerificationPrefix      = "_wedding-verify."
+	CustomDomainVerificationValuePrefix = "wedding-verify="
+)
+
+// CustomDomainVerificationRecord retorna o nome e o valor do registro TXT que comprova a posse do domínio pendente
+func (w *Wedding) CustomDomainVerificationRecord() (name, value string, ok bool) {
+	if w.PendingCustomDomain == nil || w.CustomDomainToken == nil {
+		return "", "", false
+	}
+	return CustomDomainVerificationPrefix + *w.PendingCustomDomain, CustomDomainVerificationValuePrefix + *w.CustomDomainToken, true
+}
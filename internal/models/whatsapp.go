@@ -0,0 +1,119 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WhatsAppProvider representa os provedores de envio por WhatsApp suportados
+type WhatsAppProvider string
+
+const (
+	WhatsAppProviderTwilio WhatsAppProvider = "twilio"
+	WhatsAppProviderMeta   WhatsAppProvider = "meta" // WhatsApp Cloud API
+)
+
+// Limite de parâmetros do template aprovado (corpo da mensagem)
+const maxWhatsAppTemplateParams = 10
+
+// WhatsAppAccount guarda a conta de WhatsApp Business do casal usada no envio dos convites
+// Cada casamento usa as próprias credenciais: a mensagem sai do número do casal e não do serviço
+type WhatsAppAccount struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	WeddingID uint             `gorm:"not null;uniqueIndex" json:"wedding_id"`
+	Wedding   Wedding          `gorm:"foreignKey:WeddingID" json:"-"`
+	Provider  WhatsAppProvider `gorm:"type:varchar(20);not null" json:"provider"`
+
+	// Twilio: Account SID e número remetente (E.164); Meta: Phone Number ID, sem remetente
+	AccountID  string `gorm:"size:64;not null" json:"account_id"`
+	FromNumber string `gorm:"size:20" json:"from_number"`
+
+	// Segurança: Auth Token (Twilio) ou Access Token (Meta) criptografado e nunca devolvido pela API
+	Token string `gorm:"type:varchar(1024);serializer:encrypted" json:"-"`
+
+	// Template aprovado: Content SID (Twilio, HX...) ou nome do template (Meta)
+	// Sem template a mensagem vai como texto livre, aceito apenas dentro da janela de 24h da conversa
+	TemplateName     string   `gorm:"size:100" json:"template_name"`
+	TemplateLanguage string   `gorm:"size:10;default:'pt_BR'" json:"template_language"`
+	TemplateParams   []string `gorm:"type:text;serializer:json" json:"template_params"` // templates de convite, ex: ["{{guest_name}}", "{{rsvp_link}}"]
+}
+
+var (
+	twilioAccountRegex = regexp.MustCompile(`^AC[0-9a-fA-F]{32}$`)
+	metaPhoneIDRegex   = regexp.MustCompile(`^[0-9]{5,32}$`)
+)
+
+// IsValid valida a conta de WhatsApp (normalize é chamado antes da validação)
+func (a *WhatsAppAccount) IsValid() error {
+	a.normalize()
+
+	switch a.Provider {
+	case WhatsAppProviderTwilio:
+		if !twilioAccountRegex.MatchString(a.AccountID) {
+			return errors.New("account id must be a twilio account sid (AC...)")
+		}
+		if a.FromNumber == "" {
+			return errors.New("from number is required for twilio")
+		}
+		from, err := NormalizePhone(a.FromNumber, DefaultPhoneCountry())
+		if err != nil {
+			return errors.New("from number must be a valid phone number")
+		}
+		a.FromNumber = from
+	case WhatsAppProviderMeta:
+		if !metaPhoneIDRegex.MatchString(a.AccountID) {
+			return errors.New("account id must be the numeric meta phone number id")
+		}
+		a.FromNumber = ""
+	default:
+		return errors.New("provider must be one of: twilio, meta")
+	}
+
+	if a.Token == "" {
+		return errors.New("token is required")
+	}
+	if len(a.TemplateName) > 100 {
+		return errors.New("template name must not exceed 100 characters")
+	}
+	if len(a.TemplateParams) > maxWhatsAppTemplateParams {
+		return fmt.Errorf("template must not have more than %d params", maxWhatsAppTemplateParams)
+	}
+	if len(a.TemplateParams) > 0 && a.TemplateName == "" {
+		return errors.New("template params require a template name")
+	}
+	for _, param := range a.TemplateParams {
+		if param == "" {
+			return errors.New("template params must not be empty")
+		}
+		if err := ValidateInviteTemplate(param); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// normalize padroniza os campos antes da validação
+func (a *WhatsAppAccount) normalize() {
+	a.Provider = WhatsAppProvider(strings.ToLower(strings.TrimSpace(string(a.Provider))))
+	a.AccountID = strings.TrimSpace(a.AccountID)
+	a.FromNumber = strings.TrimSpace(a.FromNumber)
+	a.Token = strings.TrimSpace(a.Token)
+	a.TemplateName = strings.TrimSpace(a.TemplateName)
+	a.TemplateLanguage = strings.TrimSpace(a.TemplateLanguage)
+	if a.TemplateLanguage == "" {
+		a.TemplateLanguage = "pt_BR"
+	}
+	for i := range a.TemplateParams {
+		a.TemplateParams[i] = strings.TrimSpace(a.TemplateParams[i])
+	}
+}
@@ -0,0 +1,74 @@
+package money
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency é a moeda usada quando o casamento não define uma
+const DefaultCurrency = "BRL"
+
+// format descreve como exibir valores de uma moeda
+type format struct {
+	symbol      string
+	decimal     string
+	thousands   string
+	symbolAfter bool // ex: "1.234,56 €"
+	space       bool // espaço entre símbolo e valor
+}
+
+// Moedas suportadas (ISO 4217) e seus formatos de exibição mais comuns
+var formats = map[string]format{
+	"BRL": {symbol: "R$", decimal: ",", thousands: ".", space: true},
+	"USD": {symbol: "$", decimal: ".", thousands: ","},
+	"EUR": {symbol: "€", decimal: ",", thousands: ".", symbolAfter: true, space: true},
+	"GBP": {symbol: "£", decimal: ".", thousands: ","},
+	"ARS": {symbol: "$", decimal: ",", thousands: ".", space: true},
+}
+
+// IsSupported verifica se a moeda possui formato de exibição
+func IsSupported(currency string) bool {
+	_, ok := formats[currency]
+	return ok
+}
+
+// Format formata um valor na moeda informada (ex: 1234.5 BRL -> "R$ 1.234,50")
+// Moedas desconhecidas usam o formato de DefaultCurrency
+func Format(value float64, currency string) string {
+	f, ok := formats[currency]
+	if !ok {
+		f = formats[DefaultCurrency]
+	}
+
+	// Arredonda para centavos antes de separar as partes (evita 0.1+0.2 = 0.30000000000000004)
+	cents := int64(math.Round(math.Abs(value) * 100))
+	integer := strconv.FormatInt(cents/100, 10)
+	fraction := strconv.FormatInt(cents%100+100, 10)[1:]
+
+	var b strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(f.thousands)
+		}
+		b.WriteRune(digit)
+	}
+	number := b.String() + f.decimal + fraction
+
+	sep := ""
+	if f.space {
+		sep = " "
+	}
+
+	var formatted string
+	if f.symbolAfter {
+		formatted = number + sep + f.symbol
+	} else {
+		formatted = f.symbol + sep + number
+	}
+
+	if value < 0 && cents > 0 {
+		return "-" + formatted
+	}
+	return formatted
+}
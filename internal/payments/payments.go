@@ -0,0 +1,170 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/health"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+)
+
+// Erros customizados para melhor tratamento
+var (
+	ErrProviderNotFound     = errors.New("payment provider not found")
+	ErrNoProviderConfigured = errors.New("no payment provider configured")
+	ErrInvalidWebhook       = errors.New("invalid webhook payload or signature")
+	ErrIgnoredEvent         = errors.New("webhook event type is not handled")
+)
+
+// EventType representa os eventos de webhook normalizados entre provedores
+type EventType string
+
+const (
+	EventChargeSucceeded EventType = "charge.succeeded"
+	EventChargeRefunded  EventType = "charge.refunded"
+	EventDisputeOpened   EventType = "dispute.opened"
+	EventDisputeClosed   EventType = "dispute.closed"
+)
+
+// ChargeRequest representa uma cobrança a ser criada no provedor
+type ChargeRequest struct {
+	Amount      int64  // em centavos
+	Currency    string // ISO 4217, ex: BRL
+	Description string
+	Metadata    map[string]string
+}
+
+// Charge representa uma cobrança criada no provedor
+type Charge struct {
+	ID          string
+	Status      string
+	Amount      int64
+	Currency    string
+	CheckoutURL string // link de pagamento (cartão) ou vazio
+	PixCode     string // copia-e-cola Pix, quando suportado
+}
+
+// WebhookEvent representa um webhook já validado e normalizado
+type WebhookEvent struct {
+	ID              string
+	Type            EventType
+	ChargeID        string
+	Amount          int64
+	DisputeWon      bool       // apenas para EventDisputeClosed
+	DisputeDeadline *time.Time // apenas para EventDisputeOpened
+}
+
+// Provider abstrai um provedor de pagamentos (PSP)
+// Controllers dependem apenas desta interface: um novo PSP exige só uma nova implementação
+type Provider interface {
+	Name() string
+	CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error)
+	ParseWebhook(r *http.Request) (*WebhookEvent, error)
+	Refund(ctx context.Context, chargeID string, amount int64) error
+}
+
+// Checker é implementado por provedores capazes de validar credenciais sem efeitos colaterais
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+var (
+	mu        sync.RWMutex
+	providers = map[string]Provider{}
+)
+
+func init() {
+	health.Register(health.Payments, func() bool { return len(Names()) > 0 })
+}
+
+// Setup registra os provedores configurados e os re-registra a cada rotação de credenciais
+// Deve ser chamado após configs.LoadEnv
+func Setup() {
+	registerConfigured()
+	configs.OnSecretsRotated(registerConfigured)
+}
+
+// registerConfigured registra os provedores com as credenciais atuais
+func registerConfigured() {
+	secrets := configs.CurrentSecrets()
+	if secrets.StripeSecretKey != "" {
+		Register(newStripeProvider(secrets.StripeSecretKey, secrets.StripeWebhookSecret))
+	}
+}
+
+// SelfCheck valida no boot a configuração dos provedores de pagamento
+// Chamadas de verificação na API do PSP só rodam fora de produção
+func SelfCheck(ctx context.Context) error {
+	var problems []error
+
+	if configs.PAYMENT_PROVIDER != "" {
+		if _, err := Get(configs.PAYMENT_PROVIDER); err != nil {
+			problems = append(problems, fmt.Errorf("PAYMENT_PROVIDER=%s não está configurado (verifique as credenciais do provedor)", configs.PAYMENT_PROVIDER))
+		}
+	}
+	secrets := configs.CurrentSecrets()
+	if secrets.StripeSecretKey != "" && secrets.StripeWebhookSecret == "" {
+		problems = append(problems, errors.New("STRIPE_WEBHOOK_SECRET é obrigatório quando STRIPE_SECRET_KEY está definido (webhooks seriam rejeitados)"))
+	}
+
+	if configs.ENV != "production" {
+		for _, name := range Names() {
+			p, _ := Get(name)
+			checker, ok := p.(Checker)
+			if !ok {
+				continue
+			}
+			if err := checker.Check(ctx); err != nil {
+				problems = append(problems, fmt.Errorf("credenciais do provedor %s inválidas: %w", name, err))
+			}
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// Register adiciona (ou substitui) um provedor no registro
+func Register(p Provider) {
+	mu.Lock()
+	defer mu.Unlock()
+	providers[p.Name()] = p
+}
+
+// Get retorna um provedor registrado pelo nome
+func Get(name string) (Provider, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if p, ok := providers[name]; ok {
+		return p, nil
+	}
+	return nil, ErrProviderNotFound
+}
+
+// Names lista os provedores registrados (ordenados)
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForWedding seleciona o provedor do casamento, com fallback para o padrão da conta/serviço
+func ForWedding(w *models.Wedding) (Provider, error) {
+	if w.PaymentProvider != "" {
+		return Get(w.PaymentProvider)
+	}
+	if configs.PAYMENT_PROVIDER != "" {
+		return Get(configs.PAYMENT_PROVIDER)
+	}
+	return nil, ErrNoProviderConfigured
+}
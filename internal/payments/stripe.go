@@ -0,0 +1,243 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/health"
+)
+
+const (
+	stripeAPIBase = "https://api.stripe.com/v1"
+
+	// Tolerância para o timestamp da assinatura (proteção contra replay)
+	stripeSignatureTolerance = 5 * time.Minute
+
+	// Limite do payload do webhook (proteção contra DoS)
+	maxWebhookBodySize = 1 << 20 // 1MB
+)
+
+// stripeProvider implementa Provider usando a API REST da Stripe (sem SDK)
+type stripeProvider struct {
+	secretKey     string
+	webhookSecret string
+	client        *http.Client
+}
+
+func newStripeProvider(secretKey, webhookSecret string) *stripeProvider {
+	return &stripeProvider{
+		secretKey:     secretKey,
+		webhookSecret: webhookSecret,
+		client:        &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (s *stripeProvider) Name() string {
+	return "stripe"
+}
+
+// CreateCharge cria um PaymentIntent (cartão e Pix quando habilitado na conta)
+func (s *stripeProvider) CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(req.Amount, 10))
+	form.Set("currency", strings.ToLower(req.Currency))
+	form.Set("description", req.Description)
+	form.Set("automatic_payment_methods[enabled]", "true")
+	for k, v := range req.Metadata {
+		form.Set("metadata["+k+"]", v)
+	}
+
+	var intent struct {
+		ID       string `json:"id"`
+		Status   string `json:"status"`
+		Amount   int64  `json:"amount"`
+		Currency string `json:"currency"`
+	}
+	if err := s.post(ctx, "/payment_intents", form, &intent); err != nil {
+		return nil, err
+	}
+
+	return &Charge{
+		ID:       intent.ID,
+		Status:   intent.Status,
+		Amount:   intent.Amount,
+		Currency: strings.ToUpper(intent.Currency),
+	}, nil
+}
+
+// Refund estorna total (amount = 0) ou parcialmente um PaymentIntent
+func (s *stripeProvider) Refund(ctx context.Context, chargeID string, amount int64) error {
+	form := url.Values{}
+	form.Set("payment_intent", chargeID)
+	if amount > 0 {
+		form.Set("amount", strconv.FormatInt(amount, 10))
+	}
+	return s.post(ctx, "/refunds", form, nil)
+}
+
+// Check valida a chave secreta consultando o saldo da conta (chamada sem efeitos colaterais)
+func (s *stripeProvider) Check(ctx context.Context) error {
+	return s.do(ctx, http.MethodGet, "/balance", nil, nil)
+}
+
+// ParseWebhook valida a assinatura Stripe-Signature e normaliza o evento
+func (s *stripeProvider) ParseWebhook(r *http.Request) (*WebhookEvent, error) {
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+	if err != nil {
+		return nil, ErrInvalidWebhook
+	}
+
+	if err := s.verifySignature(payload, r.Header.Get("Stripe-Signature")); err != nil {
+		return nil, err
+	}
+
+	var event struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+		Data struct {
+			Object struct {
+				ID             string `json:"id"`
+				PaymentIntent  string `json:"payment_intent"`
+				Amount         int64  `json:"amount"`
+				AmountRefunded int64  `json:"amount_refunded"`
+				Status         string `json:"status"`
+				EvidenceDetail struct {
+					DueBy int64 `json:"due_by"`
+				} `json:"evidence_details"`
+			} `json:"object"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, ErrInvalidWebhook
+	}
+
+	obj := event.Data.Object
+	normalized := &WebhookEvent{ID: event.ID}
+
+	switch event.Type {
+	case "payment_intent.succeeded":
+		normalized.Type = EventChargeSucceeded
+		normalized.ChargeID = obj.ID
+		normalized.Amount = obj.Amount
+	case "charge.refunded":
+		normalized.Type = EventChargeRefunded
+		normalized.ChargeID = obj.PaymentIntent
+		normalized.Amount = obj.AmountRefunded
+	case "charge.dispute.created":
+		normalized.Type = EventDisputeOpened
+		normalized.ChargeID = obj.PaymentIntent
+		normalized.Amount = obj.Amount
+		if obj.EvidenceDetail.DueBy > 0 {
+			deadline := time.Unix(obj.EvidenceDetail.DueBy, 0)
+			normalized.DisputeDeadline = &deadline
+		}
+	case "charge.dispute.closed":
+		normalized.Type = EventDisputeClosed
+		normalized.ChargeID = obj.PaymentIntent
+		normalized.Amount = obj.Amount
+		normalized.DisputeWon = obj.Status == "won"
+	default:
+		return nil, ErrIgnoredEvent
+	}
+
+	return normalized, nil
+}
+
+// verifySignature valida o header "t=<timestamp>,v1=<hmac>" conforme documentação da Stripe
+func (s *stripeProvider) verifySignature(payload []byte, header string) error {
+	if s.webhookSecret == "" || header == "" {
+		return ErrInvalidWebhook
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || time.Since(time.Unix(ts, 0)) > stripeSignatureTolerance {
+		return ErrInvalidWebhook
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.webhookSecret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	for _, sig := range signatures {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrInvalidWebhook
+}
+
+// post executa uma chamada form-encoded autenticada na API da Stripe
+func (s *stripeProvider) post(ctx context.Context, path string, form url.Values, out interface{}) error {
+	return s.do(ctx, http.MethodPost, path, form, out)
+}
+
+// do executa uma chamada autenticada na API da Stripe
+func (s *stripeProvider) do(ctx context.Context, method, path string, form url.Values, out interface{}) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, stripeAPIBase+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.secretKey)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		health.Record(health.Payments, err)
+		return fmt.Errorf("erro ao chamar stripe: %w", err)
+	}
+	defer resp.Body.Close()
+	health.RecordHTTP(health.Payments, resp.StatusCode, nil)
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxWebhookBodySize))
+	if err != nil {
+		return fmt.Errorf("erro ao ler resposta da stripe: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(respBody, &apiErr)
+		return fmt.Errorf("stripe retornou %d: %s", resp.StatusCode, apiErr.Error.Message)
+	}
+
+	if out != nil {
+		return json.Unmarshal(respBody, out)
+	}
+	return nil
+}
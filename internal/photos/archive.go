@@ -0,0 +1,173 @@
+package photos
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/jobs"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"github.com/matheushermes/wedding_planner_service/internal/storage"
+	"gorm.io/gorm"
+)
+
+const (
+	// Máximo de ZIPs gerados por execução (o restante fica para o próximo tick)
+	maxArchivesPerRun = 5
+
+	// Fotos carregadas do banco por lote ao montar o ZIP
+	archiveBatchSize = 200
+)
+
+// Setup registra o job que gera os ZIPs de fotos solicitados pelos casais (antes de jobs.Start)
+func Setup() {
+	jobs.Register(jobs.Job{
+		Name:     "photo_archives",
+		Interval: time.Minute,
+		Timeout:  15 * time.Minute,
+		Run: func(ctx context.Context) error {
+			built, err := Run(ctx, database.DB.WithContext(ctx), time.Now())
+			if built > 0 {
+				log.Printf("[INFO] Photo archives built: %d", built)
+			}
+			return err
+		},
+	})
+}
+
+// Run gera os ZIPs pendentes e retorna quantos ficaram prontos
+// Falhas de um portal são registradas nele (archive_status=failed) sem interromper os demais
+func Run(ctx context.Context, db *gorm.DB, now time.Time) (int, error) {
+	repo := repository.NewPhotoRepository(db)
+
+	portals, err := repo.FindPendingArchives(maxArchivesPerRun)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao buscar ZIPs pendentes: %w", err)
+	}
+
+	built := 0
+	for i := range portals {
+		if err := ctx.Err(); err != nil {
+			return built, err
+		}
+
+		portal := &portals[i]
+		previous := portal.ArchiveFile
+
+		name, count, err := buildArchive(ctx, repo, portal.WeddingID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to build photo archive for wedding %d: %v", portal.WeddingID, err)
+			portal.ArchiveStatus = models.ArchiveStatusFailed
+			portal.ArchiveError = "unable to build archive"
+		} else {
+			readyAt := now
+			portal.ArchiveStatus = models.ArchiveStatusReady
+			portal.ArchiveFile = name
+			portal.ArchivePhotos = count
+			portal.ArchiveReadyAt = &readyAt
+			portal.ArchiveError = ""
+		}
+
+		if err := repo.SaveArchiveResult(portal); err != nil {
+			if name != "" {
+				_ = storage.Remove(storage.KindArchive, name)
+			}
+			return built, fmt.Errorf("erro ao salvar ZIP do casamento %d: %w", portal.WeddingID, err)
+		}
+
+		if portal.ArchiveStatus == models.ArchiveStatusReady {
+			built++
+			if previous != "" && previous != name {
+				if err := storage.Remove(storage.KindArchive, previous); err != nil {
+					log.Printf("[WARN] Failed to remove old photo archive %s: %v", previous, err)
+				}
+			}
+		}
+	}
+	return built, nil
+}
+
+// buildArchive grava o ZIP com as fotos aprovadas e retorna o nome do arquivo e o total de fotos
+// Performance: Fotos já são comprimidas (JPEG/PNG/WebP): armazenadas sem recompressão (zip.Store)
+func buildArchive(ctx context.Context, repo *repository.PhotoRepository, weddingID uint) (string, int, error) {
+	f, name, err := storage.Create(storage.KindArchive, ".zip")
+	if err != nil {
+		return "", 0, err
+	}
+
+	count := 0
+	zw := zip.NewWriter(f)
+	err = repo.FindApprovedInBatches(weddingID, archiveBatchSize, func(batch []models.GuestPhoto) error {
+		for _, photo := range batch {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			count++
+			if err := addPhoto(zw, photo, count); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		err = zw.Close()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = storage.Remove(storage.KindArchive, name)
+		return "", 0, err
+	}
+	return name, count, nil
+}
+
+// addPhoto copia uma foto para o ZIP com um nome legível e único
+func addPhoto(zw *zip.Writer, photo models.GuestPhoto, index int) error {
+	src, err := storage.Open(storage.KindGuestPhoto, photo.FileName)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir foto %d: %w", photo.ID, err)
+	}
+	defer src.Close()
+
+	header := &zip.FileHeader{
+		Name:     entryName(photo, index),
+		Method:   zip.Store,
+		Modified: photo.CreatedAt,
+	}
+	dst, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		return fmt.Errorf("erro ao copiar foto %d: %w", photo.ID, err)
+	}
+	return nil
+}
+
+// entryName monta "0001-nome-do-convidado.jpg"
+// Segurança: O nome informado pelo convidado é reduzido a letras, dígitos e hífens (sem path traversal no ZIP)
+func entryName(photo models.GuestPhoto, index int) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(photo.UploaderName) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	uploader := strings.Trim(b.String(), "-")
+	if uploader == "" {
+		uploader = "guest"
+	}
+	return fmt.Sprintf("%04d-%s%s", index, uploader, filepath.Ext(photo.FileName))
+}
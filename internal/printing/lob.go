@@ -0,0 +1,177 @@
+package printing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+)
+
+const (
+	lobAPIBase = "https://api.lob.com/v1"
+
+	// Limite das respostas da API (proteção contra respostas inesperadamente grandes)
+	maxLobResponseSize = 1 << 20 // 1MB
+
+	// Endereços sem país são nacionais
+	defaultCountry = "BR"
+)
+
+// lobProvider implementa Provider com cartões-postais da Lob (API REST, sem SDK)
+type lobProvider struct {
+	apiKey string
+	client *http.Client
+}
+
+func newLobProvider(apiKey string) *lobProvider {
+	return &lobProvider{
+		apiKey: apiKey,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (l *lobProvider) Name() string {
+	return "lob"
+}
+
+// Templates lista os templates HTML cadastrados na conta
+func (l *lobProvider) Templates(ctx context.Context) ([]Template, error) {
+	var list struct {
+		Data []struct {
+			ID          string `json:"id"`
+			Description string `json:"description"`
+		} `json:"data"`
+	}
+	if err := l.do(ctx, http.MethodGet, "/templates?limit=100", nil, "", &list); err != nil {
+		return nil, err
+	}
+
+	templates := make([]Template, len(list.Data))
+	for i, t := range list.Data {
+		templates[i] = Template{ID: t.ID, Name: t.Description}
+	}
+	return templates, nil
+}
+
+// CreatePiece cria um cartão-postal (frente e verso a partir de templates da conta)
+func (l *lobProvider) CreatePiece(ctx context.Context, req PieceRequest) (*Piece, error) {
+	country := req.Address.Country
+	if country == "" {
+		country = defaultCountry
+	}
+
+	body := map[string]interface{}{
+		"description": "Wedding invitation",
+		"use_type":    "operational",
+		"front":       req.FrontTemplateID,
+		"back":        req.BackTemplateID,
+		"to": map[string]string{
+			"name":            req.RecipientName,
+			"address_line1":   req.Address.Line1,
+			"address_line2":   req.Address.Line2,
+			"address_city":    req.Address.City,
+			"address_state":   req.Address.State,
+			"address_zip":     req.Address.PostalCode,
+			"address_country": country,
+		},
+		"merge_variables": req.MergeVariables,
+	}
+
+	var postcard struct {
+		ID string `json:"id"`
+	}
+	if err := l.do(ctx, http.MethodPost, "/postcards", body, req.IdempotencyKey, &postcard); err != nil {
+		return nil, err
+	}
+	return &Piece{ID: postcard.ID, Status: models.PrintItemSubmitted}, nil
+}
+
+// PieceStatus consulta o rastreio do cartão-postal e normaliza o último evento
+func (l *lobProvider) PieceStatus(ctx context.Context, pieceID string) (models.PrintItemStatus, error) {
+	var postcard struct {
+		Deleted        bool `json:"deleted"`
+		TrackingEvents []struct {
+			Name string `json:"name"`
+		} `json:"tracking_events"`
+	}
+	if err := l.do(ctx, http.MethodGet, "/postcards/"+pieceID, nil, "", &postcard); err != nil {
+		return "", err
+	}
+
+	if postcard.Deleted {
+		return models.PrintItemFailed, nil
+	}
+	if len(postcard.TrackingEvents) == 0 {
+		return models.PrintItemSubmitted, nil
+	}
+
+	switch postcard.TrackingEvents[len(postcard.TrackingEvents)-1].Name {
+	case "Mailed":
+		return models.PrintItemMailed, nil
+	case "Processed for Delivery", "Delivered":
+		return models.PrintItemDelivered, nil
+	case "Returned to Sender":
+		return models.PrintItemReturned, nil
+	default:
+		// In Transit, In Local Area, Re-Routed, International Exit...
+		return models.PrintItemInTransit, nil
+	}
+}
+
+// do executa uma chamada autenticada (basic auth com a chave como usuário) na API da Lob
+func (l *lobProvider) do(ctx context.Context, method, path string, in interface{}, idempotencyKey string, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, lobAPIBase+path, body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(l.apiKey, "")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao chamar lob: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxLobResponseSize))
+	if err != nil {
+		return fmt.Errorf("erro ao ler resposta da lob: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrPieceNotFound
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(respBody, &apiErr)
+		return &ProviderError{Status: resp.StatusCode, Message: apiErr.Error.Message}
+	}
+
+	if out != nil {
+		return json.Unmarshal(respBody, out)
+	}
+	return nil
+}
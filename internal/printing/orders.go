@@ -0,0 +1,168 @@
+package printing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/jobs"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// Máximo de peças enviadas e de rastreios consultados por execução (o restante fica para o próximo tick)
+	maxSubmissionsPerRun = 200
+	maxTrackingPerRun    = 500
+
+	// Intervalo mínimo entre consultas de rastreio da mesma peça
+	trackingInterval = 6 * time.Hour
+)
+
+// registerOrdersJob registra o job que envia as peças dos lotes ao provedor e acompanha a entrega
+func registerOrdersJob() {
+	jobs.Register(jobs.Job{
+		Name:     "print_orders",
+		Interval: 5 * time.Minute,
+		Timeout:  20 * time.Minute,
+		Run: func(ctx context.Context) error {
+			submitted, tracked, err := RunOrders(ctx, database.DB.WithContext(ctx), time.Now())
+			if submitted > 0 || tracked > 0 {
+				log.Printf("[INFO] Print orders: %d pieces submitted, %d tracking updates", submitted, tracked)
+			}
+			return err
+		},
+	})
+}
+
+// RunOrders envia as peças pendentes e atualiza o rastreio das peças em andamento
+// Falhas de uma peça são registradas nela sem interromper as demais
+func RunOrders(ctx context.Context, db *gorm.DB, now time.Time) (int, int, error) {
+	repo := repository.NewPrintRepository(db)
+	touched := map[uint]bool{}
+
+	tracked := 0
+	submitted, err := submitPending(ctx, repo, touched)
+	if err == nil {
+		tracked, err = trackInProgress(ctx, repo, now, touched)
+	}
+
+	// Os totais dos lotes alterados são recalculados mesmo se a execução foi interrompida
+	for orderID := range touched {
+		if refreshErr := repo.RefreshOrder(orderID, configs.PRINT_UNIT_PRICE, now); refreshErr != nil {
+			log.Printf("[ERROR] Failed to refresh print order %d: %v", orderID, refreshErr)
+		}
+	}
+	return submitted, tracked, err
+}
+
+// submitPending envia ao provedor as peças ainda não enviadas
+func submitPending(ctx context.Context, repo *repository.PrintRepository, touched map[uint]bool) (int, error) {
+	items, err := repo.FindItemsToSubmit(maxSubmissionsPerRun)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao buscar peças pendentes: %w", err)
+	}
+
+	submitted := 0
+	for i := range items {
+		if err := ctx.Err(); err != nil {
+			return submitted, err
+		}
+
+		item := &items[i]
+		provider, err := Get(item.PrintOrder.Provider)
+		if err != nil {
+			// Provedor não configurado nesta instância: a peça espera a configuração voltar
+			continue
+		}
+
+		piece, err := provider.CreatePiece(ctx, PieceRequest{
+			FrontTemplateID: item.PrintOrder.FrontTemplateID,
+			BackTemplateID:  item.PrintOrder.BackTemplateID,
+			RecipientName:   item.RecipientName,
+			Address:         item.Address,
+			MergeVariables: map[string]string{
+				"guest_name": item.RecipientName,
+				"guests":     fmt.Sprintf("%d", item.Guests),
+			},
+			IdempotencyKey: fmt.Sprintf("print-item-%d", item.ID),
+		})
+		if err != nil {
+			var providerErr *ProviderError
+			if !errors.As(err, &providerErr) || !providerErr.Permanent() {
+				log.Printf("[WARN] Failed to submit print item %d (will retry): %v", item.ID, err)
+				continue
+			}
+			item.Status = models.PrintItemFailed
+			item.Error = truncate(providerErr.Message, 255)
+		} else {
+			item.Status = piece.Status
+			item.ProviderPieceID = piece.ID
+			item.Error = ""
+			submitted++
+		}
+
+		if err := repo.SaveItemStatus(item); err != nil {
+			log.Printf("[ERROR] Failed to save print item %d: %v", item.ID, err)
+			continue
+		}
+		touched[item.PrintOrderID] = true
+	}
+	return submitted, nil
+}
+
+// trackInProgress consulta o rastreio das peças em andamento
+func trackInProgress(ctx context.Context, repo *repository.PrintRepository, now time.Time, touched map[uint]bool) (int, error) {
+	items, err := repo.FindItemsToTrack(now.Add(-trackingInterval), maxTrackingPerRun)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao buscar peças em andamento: %w", err)
+	}
+
+	tracked := 0
+	for i := range items {
+		if err := ctx.Err(); err != nil {
+			return tracked, err
+		}
+
+		item := &items[i]
+		provider, err := Get(item.PrintOrder.Provider)
+		if err != nil {
+			continue
+		}
+
+		status, err := provider.PieceStatus(ctx, item.ProviderPieceID)
+		switch {
+		case errors.Is(err, ErrPieceNotFound):
+			status = models.PrintItemFailed
+			item.Error = "piece not found at the provider"
+		case err != nil:
+			log.Printf("[WARN] Failed to track print item %d: %v", item.ID, err)
+			continue
+		}
+
+		checkedAt := now
+		item.CheckedAt = &checkedAt
+		if status != item.Status {
+			item.Status = status
+			touched[item.PrintOrderID] = true
+			tracked++
+		}
+		if err := repo.SaveItemStatus(item); err != nil {
+			log.Printf("[ERROR] Failed to save print item %d: %v", item.ID, err)
+		}
+	}
+	return tracked, nil
+}
+
+// truncate corta a mensagem no limite da coluna
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
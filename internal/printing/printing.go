@@ -0,0 +1,129 @@
+package printing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+)
+
+// Erros customizados para melhor tratamento
+var (
+	ErrProviderNotFound     = errors.New("print provider not found")
+	ErrNoProviderConfigured = errors.New("no print provider configured")
+	ErrPieceNotFound        = errors.New("print piece not found at the provider")
+)
+
+// Template representa um design disponível no catálogo do provedor
+type Template struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PieceRequest representa um convite a ser impresso e postado
+type PieceRequest struct {
+	FrontTemplateID string
+	BackTemplateID  string
+	RecipientName   string
+	Address         models.PostalAddress
+
+	// Variáveis do template (ex: {{guest_name}})
+	MergeVariables map[string]string
+
+	// Segurança: reenvios com a mesma chave não geram uma segunda impressão (cobrança) no provedor
+	IdempotencyKey string
+}
+
+// Piece representa uma peça aceita pelo provedor
+type Piece struct {
+	ID     string
+	Status models.PrintItemStatus
+}
+
+// Provider abstrai um provedor de impressão e postagem sob demanda
+// Controllers e jobs dependem apenas desta interface: um novo provedor exige só uma nova implementação
+type Provider interface {
+	Name() string
+	Templates(ctx context.Context) ([]Template, error)
+	CreatePiece(ctx context.Context, req PieceRequest) (*Piece, error)
+	PieceStatus(ctx context.Context, pieceID string) (models.PrintItemStatus, error)
+}
+
+// ProviderError representa uma resposta de erro do provedor
+// Erros 4xx (ex: endereço recusado) são definitivos para a peça; os demais são tentados de novo
+type ProviderError struct {
+	Status  int
+	Message string
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("provedor retornou %d: %s", e.Status, e.Message)
+}
+
+// Permanent indica se repetir a chamada não mudaria o resultado
+func (e *ProviderError) Permanent() bool {
+	return e.Status >= 400 && e.Status < 500 && e.Status != http.StatusTooManyRequests
+}
+
+var (
+	mu        sync.RWMutex
+	providers = map[string]Provider{}
+)
+
+// Setup registra os provedores configurados (re-registrados a cada rotação de credenciais)
+// e o job dos lotes de impressão; deve ser chamado após configs.LoadEnv e antes de jobs.Start
+func Setup() {
+	registerConfigured()
+	configs.OnSecretsRotated(registerConfigured)
+	registerOrdersJob()
+}
+
+// registerConfigured registra os provedores com as credenciais atuais
+func registerConfigured() {
+	secrets := configs.CurrentSecrets()
+	if secrets.LobAPIKey != "" {
+		Register(newLobProvider(secrets.LobAPIKey))
+	}
+}
+
+// Register adiciona (ou substitui) um provedor no registro
+func Register(p Provider) {
+	mu.Lock()
+	defer mu.Unlock()
+	providers[p.Name()] = p
+}
+
+// Get retorna um provedor registrado pelo nome
+func Get(name string) (Provider, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if p, ok := providers[name]; ok {
+		return p, nil
+	}
+	return nil, ErrProviderNotFound
+}
+
+// Names lista os provedores registrados (ordenados)
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Default retorna o provedor configurado em PRINT_PROVIDER
+func Default() (Provider, error) {
+	if configs.PRINT_PROVIDER == "" {
+		return nil, ErrNoProviderConfigured
+	}
+	return Get(configs.PRINT_PROVIDER)
+}
@@ -0,0 +1,118 @@
+package qrcode
+
+// dataCodewords monta o fluxo de bits (modo byte) com terminador e bytes de preenchimento
+func dataCodewords(version int, payload []byte) []byte {
+	capacity := totalDataCodewords(version)
+
+	var bits bitBuffer
+	bits.append(0x4, 4) // modo byte
+	bits.append(uint32(len(payload)), countBits(version))
+	for _, b := range payload {
+		bits.append(uint32(b), 8)
+	}
+
+	// Terminador (até 4 zeros) e alinhamento ao byte
+	terminator := min(4, capacity*8-len(bits))
+	bits.append(0, terminator)
+	if rem := len(bits) % 8; rem != 0 {
+		bits.append(0, 8-rem)
+	}
+
+	data := bits.bytes()
+	for pad := byte(0xEC); len(data) < capacity; pad ^= 0xEC ^ 0x11 {
+		data = append(data, pad)
+	}
+	return data
+}
+
+// interleave divide os dados em blocos, calcula a correção de erro e intercala os codewords
+func interleave(version int, data []byte) []byte {
+	layout := layouts[version]
+	gen := rsGenerator(layout.ecPerBlock)
+
+	var dataBlocks, ecBlocks [][]byte
+	offset := 0
+	for _, n := range layout.dataBlocks {
+		block := data[offset : offset+n]
+		offset += n
+		dataBlocks = append(dataBlocks, block)
+		ecBlocks = append(ecBlocks, rsRemainder(block, gen))
+	}
+
+	var result []byte
+	longest := layout.dataBlocks[len(layout.dataBlocks)-1]
+	for i := 0; i < longest; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < layout.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// bitBuffer acumula bits (um por elemento) na ordem de escrita
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, (value>>uint(i))&1 == 1)
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	out := make([]byte, len(b)/8)
+	for i, bit := range b {
+		if bit {
+			out[i/8] |= 0x80 >> uint(i%8)
+		}
+	}
+	return out
+}
+
+// rsGenerator calcula o polinômio gerador de Reed-Solomon de grau n sobre GF(256)
+// Coeficientes do maior para o menor grau, sem o coeficiente líder (sempre 1)
+func rsGenerator(n int) []byte {
+	gen := make([]byte, n)
+	gen[n-1] = 1
+	root := byte(1)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			gen[j] = gfMul(gen[j], root)
+			if j+1 < n {
+				gen[j] ^= gen[j+1]
+			}
+		}
+		root = gfMul(root, 0x02)
+	}
+	return gen
+}
+
+// rsRemainder calcula os codewords de correção de erro de um bloco
+func rsRemainder(data, gen []byte) []byte {
+	result := make([]byte, len(gen))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, g := range gen {
+			result[i] ^= gfMul(g, factor)
+		}
+	}
+	return result
+}
+
+// gfMul multiplica em GF(256) com o polinômio primitivo 0x11D
+func gfMul(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
@@ -0,0 +1,266 @@
+package qrcode
+
+// matrix é a grade de módulos em construção; function marca os padrões fixos (não mascarados)
+type matrix struct {
+	version  int
+	size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+func newMatrix(version int) *matrix {
+	size := version*4 + 17
+	m := &matrix{version: version, size: size}
+	m.modules = make([][]bool, size)
+	m.function = make([][]bool, size)
+	for i := range m.modules {
+		m.modules[i] = make([]bool, size)
+		m.function[i] = make([]bool, size)
+	}
+	return m
+}
+
+func (m *matrix) setFunction(x, y int, dark bool) {
+	m.modules[y][x] = dark
+	m.function[y][x] = true
+}
+
+// drawFunctionPatterns desenha localizadores, temporização, alinhamento e reserva as áreas de formato/versão
+func (m *matrix) drawFunctionPatterns() {
+	for i := 0; i < m.size; i++ {
+		m.setFunction(6, i, i%2 == 0)
+		m.setFunction(i, 6, i%2 == 0)
+	}
+
+	m.drawFinder(3, 3)
+	m.drawFinder(m.size-4, 3)
+	m.drawFinder(3, m.size-4)
+
+	positions := alignmentPositions[m.version]
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// Os cantos já são ocupados pelos localizadores
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			m.drawAlignment(x, y)
+		}
+	}
+
+	m.drawFormatBits(0) // reserva; sobrescrito após a escolha da máscara
+	m.drawVersion()
+}
+
+// drawFinder desenha um padrão localizador 7x7 com a borda separadora
+func (m *matrix) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= m.size || y < 0 || y >= m.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			m.setFunction(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawAlignment desenha um padrão de alinhamento 5x5
+func (m *matrix) drawAlignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			m.setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits grava as duas cópias da informação de formato (nível M + máscara)
+func (m *matrix) drawFormatBits(mask int) {
+	data := mask // bits do nível M são 00
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	bit := func(i int) bool { return (bits>>uint(i))&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		m.setFunction(8, i, bit(i))
+	}
+	m.setFunction(8, 7, bit(6))
+	m.setFunction(8, 8, bit(7))
+	m.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		m.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		m.setFunction(m.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		m.setFunction(8, m.size-15+i, bit(i))
+	}
+	m.setFunction(8, m.size-8, true) // módulo sempre escuro
+}
+
+// drawVersion grava a informação de versão (a partir da versão 7)
+func (m *matrix) drawVersion() {
+	if m.version < 7 {
+		return
+	}
+
+	rem := m.version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := m.version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>uint(i))&1 == 1
+		a, b := m.size-11+i%3, i/3
+		m.setFunction(a, b, dark)
+		m.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords posiciona os codewords em zigue-zague, de baixo para cima, em pares de colunas
+func (m *matrix) drawCodewords(data []byte) {
+	i := 0
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // pula a coluna de temporização
+		}
+		for vert := 0; vert < m.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = m.size - 1 - vert
+				}
+				if m.function[y][x] || i >= len(data)*8 {
+					continue
+				}
+				m.modules[y][x] = (data[i>>3]>>uint(7-i&7))&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// applyMask inverte os módulos de dados segundo o padrão da máscara (aplicar duas vezes desfaz)
+func (m *matrix) applyMask(mask int) {
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if m.function[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				m.modules[y][x] = !m.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty calcula a penalidade da especificação (regras N1 a N4) para escolher a máscara
+func (m *matrix) penalty() int {
+	total := 0
+
+	// N1 e N3: sequências da mesma cor e padrões semelhantes ao localizador, em linhas e colunas
+	for y := 0; y < m.size; y++ {
+		total += m.linePenalty(func(i int) bool { return m.modules[y][i] })
+	}
+	for x := 0; x < m.size; x++ {
+		total += m.linePenalty(func(i int) bool { return m.modules[i][x] })
+	}
+
+	// N2: blocos 2x2 da mesma cor
+	for y := 0; y < m.size-1; y++ {
+		for x := 0; x < m.size-1; x++ {
+			c := m.modules[y][x]
+			if c == m.modules[y][x+1] && c == m.modules[y+1][x] && c == m.modules[y+1][x+1] {
+				total += 3
+			}
+		}
+	}
+
+	// N4: proporção de módulos escuros distante de 50%
+	dark := 0
+	for y := range m.modules {
+		for _, d := range m.modules[y] {
+			if d {
+				dark++
+			}
+		}
+	}
+	cells := m.size * m.size
+	k := (abs(dark*20-cells*10)+cells-1)/cells - 1
+	total += max(k, 0) * 10
+
+	return total
+}
+
+// Padrão 1:1:3:1:1 com 4 módulos claros de um dos lados
+var finderLike = [2][11]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+func (m *matrix) linePenalty(at func(int) bool) int {
+	total := 0
+
+	run := 1
+	for i := 1; i <= m.size; i++ {
+		if i < m.size && at(i) == at(i-1) {
+			run++
+			continue
+		}
+		if run >= 5 {
+			total += 3 + run - 5
+		}
+		run = 1
+	}
+
+	for i := 0; i+11 <= m.size; i++ {
+		for _, pattern := range finderLike {
+			match := true
+			for j, dark := range pattern {
+				if at(i+j) != dark {
+					match = false
+					break
+				}
+			}
+			if match {
+				total += 40
+			}
+		}
+	}
+	return total
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,104 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// ErrDataTooLong indica que o conteúdo não cabe na maior versão suportada
+var ErrDataTooLong = errors.New("qrcode: data too long")
+
+// Zona de silêncio exigida pela especificação (em módulos)
+const quietZone = 4
+
+// Code é um QR Code já codificado (modo byte, correção de erro nível M)
+type Code struct {
+	size    int
+	modules [][]bool // [y][x], true = escuro
+}
+
+// Encode gera o QR Code de menor versão (1 a 10) que comporta os dados
+// Implementação própria (ISO/IEC 18004) para não depender de bibliotecas externas
+func Encode(data string) (*Code, error) {
+	payload := []byte(data)
+
+	version := 0
+	for v := 1; v <= maxVersion; v++ {
+		if len(payload) <= byteCapacity(v) {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrDataTooLong
+	}
+
+	codewords := interleave(version, dataCodewords(version, payload))
+
+	m := newMatrix(version)
+	m.drawFunctionPatterns()
+	m.drawCodewords(codewords)
+
+	// Escolhe a máscara com menor penalidade
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		m.applyMask(mask)
+		m.drawFormatBits(mask)
+		if p := m.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		m.applyMask(mask) // XOR desfaz a máscara
+	}
+	m.applyMask(best)
+	m.drawFormatBits(best)
+
+	return &Code{size: m.size, modules: m.modules}, nil
+}
+
+// Size retorna a largura do código em módulos (sem a zona de silêncio)
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Dark indica se o módulo (x, y) é escuro
+func (c *Code) Dark(x, y int) bool {
+	return c.modules[y][x]
+}
+
+// Image desenha o código com scale pixels por módulo e a zona de silêncio
+func (c *Code) Image(scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+	width := (c.size + 2*quietZone) * scale
+	img := image.NewGray(image.Rect(0, 0, width, width))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			if !c.modules[y][x] {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetGray((x+quietZone)*scale+dx, (y+quietZone)*scale+dy, color.Gray{Y: 0})
+				}
+			}
+		}
+	}
+	return img
+}
+
+// PNG codifica o código como imagem PNG
+func (c *Code) PNG(scale int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, c.Image(scale)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
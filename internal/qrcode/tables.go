@@ -0,0 +1,58 @@
+package qrcode
+
+// Maior versão suportada (57x57 módulos, até 213 bytes no nível M)
+const maxVersion = 10
+
+// blockLayout descreve os blocos de Reed-Solomon de uma versão no nível M
+type blockLayout struct {
+	ecPerBlock int
+	dataBlocks []int // codewords de dados de cada bloco
+}
+
+var layouts = [maxVersion + 1]blockLayout{
+	1:  {10, []int{16}},
+	2:  {16, []int{28}},
+	3:  {26, []int{44}},
+	4:  {18, []int{32, 32}},
+	5:  {24, []int{43, 43}},
+	6:  {16, []int{27, 27, 27, 27}},
+	7:  {18, []int{31, 31, 31, 31}},
+	8:  {22, []int{38, 38, 39, 39}},
+	9:  {22, []int{36, 36, 36, 37, 37}},
+	10: {26, []int{43, 43, 43, 43, 44}},
+}
+
+// Centros dos padrões de alinhamento por versão
+var alignmentPositions = [maxVersion + 1][]int{
+	2:  {6, 18},
+	3:  {6, 22},
+	4:  {6, 26},
+	5:  {6, 30},
+	6:  {6, 34},
+	7:  {6, 22, 38},
+	8:  {6, 24, 42},
+	9:  {6, 26, 46},
+	10: {6, 28, 50},
+}
+
+// totalDataCodewords soma os codewords de dados de todos os blocos
+func totalDataCodewords(version int) int {
+	total := 0
+	for _, n := range layouts[version].dataBlocks {
+		total += n
+	}
+	return total
+}
+
+// countBits retorna o tamanho do campo de contagem no modo byte
+func countBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+// byteCapacity retorna quantos bytes cabem na versão (modo byte, nível M)
+func byteCapacity(version int) int {
+	return (totalDataCodewords(version)*8 - 4 - countBits(version)) / 8
+}
@@ -0,0 +1,282 @@
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+)
+
+// ExpenseLine representa a soma de gastos de uma categoria/status
+type ExpenseLine struct {
+	Category models.ExpenseCategory
+	Status   models.ExpenseStatus
+	Total    float64
+}
+
+// FullReportData reúne tudo que compõe o relatório completo do casamento
+type FullReportData struct {
+	CoupleNames     []string
+	Wedding         *models.Wedding
+	CountdownStatus string
+	Guests          []models.Guest
+	Timeline        []models.TimelineHighlight // programação do dia (EventInfo.Highlights)
+	Budget          *models.Budget             // nil quando o orçamento ainda não foi definido
+	Expenses        []ExpenseLine
+	GeneratedAt     time.Time
+}
+
+// BuildFullReport gera o PDF consolidado entregue ao cerimonialista no dia do evento
+func BuildFullReport(data FullReportData) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Wedding report", true)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AliasNbPages("")
+
+	// Fontes padrão do PDF usam cp1252: traduz UTF-8 para suportar acentuação
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 8, fmt.Sprintf("Generated at %s - page %d/{nb}", data.GeneratedAt.Format("02/01/2006 15:04"), pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	pdf.AddPage()
+
+	writeHeader(pdf, tr, data)
+	writeCountdown(pdf, data)
+	writeTimeline(pdf, tr, data.Timeline)
+	writeGuests(pdf, tr, data.Guests)
+	writeSeating(pdf, tr, data.Guests)
+	writeBudget(pdf, tr, data.Budget, data.Expenses)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("erro ao gerar PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeHeader(pdf *fpdf.Fpdf, tr func(string) string, data FullReportData) {
+	w := data.Wedding
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, tr(strings.Join(data.CoupleNames, " & ")), "", 1, "C", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.CellFormat(0, 6, tr(w.VenueName), "", 1, "C", false, 0, "")
+	pdf.MultiCell(0, 5, tr(w.VenueAddress), "", "C", false)
+	pdf.CellFormat(0, 6, fmt.Sprintf("%s - %s", w.EventDate.Format("02/01/2006"), w.EventTime), "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+}
+
+func writeCountdown(pdf *fpdf.Fpdf, data FullReportData) {
+	writeSection(pdf, "Countdown")
+
+	days := data.Wedding.DaysRemaining()
+	var text string
+	switch data.CountdownStatus {
+	case "today":
+		text = "The wedding is today!"
+	case "past":
+		text = fmt.Sprintf("The wedding took place %d day(s) ago", -days)
+	default:
+		text = fmt.Sprintf("%d day(s) remaining", days)
+	}
+
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.CellFormat(0, 6, text, "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+}
+
+func writeGuests(pdf *fpdf.Fpdf, tr func(string) string, guests []models.Guest) {
+	writeSection(pdf, fmt.Sprintf("Guest list (%d)", len(guests)))
+
+	// Resumo por status
+	counts := map[models.InviteStatus]int{}
+	for _, g := range guests {
+		counts[g.InviteStatus]++
+	}
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Confirmed: %d   Pending: %d   Sent: %d   Declined: %d",
+		counts[models.InviteStatusConfirmed], counts[models.InviteStatusPending],
+		counts[models.InviteStatusSent], counts[models.InviteStatusDeclined]), "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	if len(guests) == 0 {
+		pdf.CellFormat(0, 6, "No guests registered.", "", 1, "L", false, 0, "")
+		pdf.Ln(4)
+		return
+	}
+
+	widths := []float64{75, 45, 35, 25}
+	headers := []string{"Name", "Phone", "Status", "Max guests"}
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(230, 230, 230)
+	for i, h := range headers {
+		pdf.CellFormat(widths[i], 7, h, "1", 0, "L", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, g := range guests {
+		pdf.CellFormat(widths[0], 6, tr(truncate(g.FullName, 45)), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], 6, tr(g.Phone), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], 6, string(g.InviteStatus), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[3], 6, fmt.Sprintf("%d", g.MaxGuests), "1", 0, "R", false, 0, "")
+		pdf.Ln(-1)
+	}
+	pdf.Ln(4)
+}
+
+func writeTimeline(pdf *fpdf.Fpdf, tr func(string) string, timeline []models.TimelineHighlight) {
+	writeSection(pdf, "Timeline")
+
+	pdf.SetFont("Helvetica", "", 10)
+	if len(timeline) == 0 {
+		pdf.CellFormat(0, 6, "Timeline not defined.", "", 1, "L", false, 0, "")
+		pdf.Ln(4)
+		return
+	}
+
+	sorted := slices.Clone(timeline)
+	slices.SortStableFunc(sorted, func(a, b models.TimelineHighlight) int {
+		return strings.Compare(a.Time, b.Time) // HH:MM ordena como texto
+	})
+
+	widths := []float64{20, 35, 125}
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(230, 230, 230)
+	for i, h := range []string{"Time", "Part", "Moment"} {
+		pdf.CellFormat(widths[i], 7, h, "1", 0, "L", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, h := range sorted {
+		part := string(h.Event)
+		if part == "" {
+			part = "all"
+		}
+		pdf.CellFormat(widths[0], 6, h.Time, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], 6, part, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], 6, tr(truncate(h.Title, 75)), "1", 0, "L", false, 0, "")
+		pdf.Ln(-1)
+	}
+	pdf.Ln(4)
+}
+
+// writeSeating lista os convidados com vaga na recepção agrupados por mesa (Guest.TableName)
+// Quem ainda não tem mesa aparece no fim, para o cerimonialista acomodar no dia
+func writeSeating(pdf *fpdf.Fpdf, tr func(string) string, guests []models.Guest) {
+	writeSection(pdf, "Seating")
+
+	tables := map[string][]models.Guest{}
+	for _, g := range guests {
+		if g.InviteStatus.HoldsSeat() && g.Events.Includes(models.WeddingEventReception) {
+			tables[g.TableName] = append(tables[g.TableName], g)
+		}
+	}
+
+	pdf.SetFont("Helvetica", "", 10)
+	if len(tables) == 0 {
+		pdf.CellFormat(0, 6, "No guests with a seat at the reception.", "", 1, "L", false, 0, "")
+		pdf.Ln(4)
+		return
+	}
+
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	if _, ok := tables[""]; ok {
+		names = append(names, "")
+	}
+
+	widths := []float64{110, 45, 25}
+	for _, name := range names {
+		seated := tables[name]
+		people := 0
+		for _, g := range seated {
+			people += max(g.MaxGuests, 1)
+		}
+
+		title := name
+		if title == "" {
+			title = "No table assigned"
+		}
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.SetFillColor(230, 230, 230)
+		pdf.CellFormat(widths[0]+widths[1]+widths[2], 7, tr(fmt.Sprintf("%s - %d guest(s), up to %d people", title, len(seated), people)), "1", 1, "L", true, 0, "")
+
+		pdf.SetFont("Helvetica", "", 9)
+		for _, g := range seated {
+			pdf.CellFormat(widths[0], 6, tr(truncate(g.FullName, 65)), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(widths[1], 6, string(g.InviteStatus), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(widths[2], 6, fmt.Sprintf("%d", g.MaxGuests), "1", 0, "R", false, 0, "")
+			pdf.Ln(-1)
+		}
+		pdf.Ln(2)
+	}
+	pdf.Ln(2)
+}
+
+func writeBudget(pdf *fpdf.Fpdf, tr func(string) string, budget *models.Budget, expenses []ExpenseLine) {
+	writeSection(pdf, "Budget summary")
+
+	pdf.SetFont("Helvetica", "", 10)
+	if budget == nil {
+		pdf.CellFormat(0, 6, "Budget not defined.", "", 1, "L", false, 0, "")
+	} else {
+		pdf.CellFormat(0, 6, fmt.Sprintf("Total budget: %.2f", budget.TotalBudget), "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 6, fmt.Sprintf("Spent: %.2f   Planned: %.2f   Remaining: %.2f",
+			budget.TotalSpent, budget.TotalPlanned, budget.TotalBudget-budget.TotalSpent-budget.TotalPlanned), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(2)
+
+	if len(expenses) == 0 {
+		return
+	}
+
+	widths := []float64{70, 50, 60}
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(230, 230, 230)
+	for i, h := range []string{"Category", "Status", "Total"} {
+		pdf.CellFormat(widths[i], 7, h, "1", 0, "L", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, e := range expenses {
+		pdf.CellFormat(widths[0], 6, tr(string(e.Category)), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], 6, string(e.Status), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], 6, fmt.Sprintf("%.2f", e.Total), "1", 0, "R", false, 0, "")
+		pdf.Ln(-1)
+	}
+}
+
+func writeSection(pdf *fpdf.Fpdf, title string) {
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.CellFormat(0, 8, title, "B", 1, "L", false, 0, "")
+	pdf.Ln(2)
+}
+
+// truncate corta textos longos para caberem nas colunas da tabela
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-3]) + "..."
+}
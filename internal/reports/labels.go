@@ -0,0 +1,108 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+)
+
+// AddressLabel representa um destinatário de convite impresso (um convidado ou uma família)
+type AddressLabel struct {
+	GuestID uint // primeiro convidado coberto pela etiqueta
+	Name    string
+	Address models.PostalAddress
+	Guests  int // convidados cobertos pela etiqueta
+}
+
+// Layout das folhas de etiquetas A4 (3 colunas x 8 linhas, 70 x 37 mm, sem margens)
+const (
+	labelColumns = 3
+	labelRows    = 8
+	labelWidth   = 70.0
+	labelHeight  = 37.0
+	labelPadding = 5.0
+)
+
+// WriteAddressCSV escreve a lista de endereços em CSV (uma linha por etiqueta)
+func WriteAddressCSV(w io.Writer, labels []AddressLabel) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"name", "line1", "line2", "city", "state", "postal_code", "country", "guests"}); err != nil {
+		return err
+	}
+
+	for _, l := range labels {
+		record := []string{
+			csvSafe(l.Name),
+			csvSafe(l.Address.Line1),
+			csvSafe(l.Address.Line2),
+			csvSafe(l.Address.City),
+			csvSafe(l.Address.State),
+			csvSafe(l.Address.PostalCode),
+			l.Address.Country,
+			fmt.Sprint(l.Guests),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// BuildAddressLabels gera o PDF de etiquetas pronto para impressão em folhas A4 3x8
+func BuildAddressLabels(labels []AddressLabel) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Address labels", true)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetMargins(0, 0, 0)
+
+	// Fontes padrão do PDF usam cp1252: traduz UTF-8 para suportar acentuação
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pageWidth, pageHeight := pdf.GetPageSize()
+	offsetX := (pageWidth - labelColumns*labelWidth) / 2
+	offsetY := (pageHeight - labelRows*labelHeight) / 2
+
+	perPage := labelColumns * labelRows
+	if len(labels) == 0 {
+		pdf.AddPage()
+	}
+	for i, label := range labels {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+		slot := i % perPage
+		x := offsetX + float64(slot%labelColumns)*labelWidth + labelPadding
+		y := offsetY + float64(slot/labelColumns)*labelHeight + labelPadding
+		writeLabel(pdf, tr, label, x, y)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("erro ao gerar PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeLabel escreve nome e endereço dentro da área útil de uma etiqueta
+func writeLabel(pdf *fpdf.Fpdf, tr func(string) string, label AddressLabel, x, y float64) {
+	width := labelWidth - 2*labelPadding
+	maxChars := 38
+
+	pdf.SetXY(x, y)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(width, 5, tr(truncate(label.Name, maxChars)), "", 2, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, line := range label.Address.Lines() {
+		pdf.SetX(x)
+		pdf.CellFormat(width, 4.5, tr(truncate(strings.TrimSpace(line), maxChars+4)), "", 2, "L", false, 0, "")
+	}
+}
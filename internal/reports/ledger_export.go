@@ -0,0 +1,116 @@
+package reports
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LedgerExportRow representa um lançamento já mapeado para exportação contábil
+// Amount segue a ótica do caixa: entradas positivas, saídas negativas
+type LedgerExportRow struct {
+	EntryID    uint
+	Date       time.Time
+	Kind       string
+	Category   string
+	Memo       string
+	Amount     float64
+	SourceType string
+	SourceID   uint
+}
+
+// WriteLedgerCSV escreve os lançamentos em CSV (separador vírgula, valores com ponto decimal)
+func WriteLedgerCSV(w io.Writer, currency string, rows []LedgerExportRow) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"date", "kind", "category", "memo", "amount", "currency", "source_type", "source_id", "entry_id"}); err != nil {
+		return err
+	}
+
+	for _, r := range rows {
+		record := []string{
+			r.Date.Format("2006-01-02"),
+			r.Kind,
+			csvSafe(r.Category),
+			csvSafe(r.Memo),
+			strconv.FormatFloat(r.Amount, 'f', 2, 64),
+			currency,
+			r.SourceType,
+			strconv.FormatUint(uint64(r.SourceID), 10),
+			strconv.FormatUint(uint64(r.EntryID), 10),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvSafe neutraliza fórmulas em campos livres
+// Segurança: Evita CSV injection ao abrir o arquivo em planilhas (=, +, -, @)
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsAny(value[:1], "=+-@\t\r") {
+		return "'" + value
+	}
+	return value
+}
+
+// WriteLedgerOFX escreve os lançamentos em OFX 1.0.2 (SGML), aceito pela maioria dos softwares contábeis
+func WriteLedgerOFX(w io.Writer, accountID, currency string, from, to time.Time, rows []LedgerExportRow) error {
+	var b strings.Builder
+
+	b.WriteString("OFXHEADER:100\r\nDATA:OFXSGML\r\nVERSION:102\r\nSECURITY:NONE\r\n")
+	b.WriteString("ENCODING:UTF-8\r\nCHARSET:NONE\r\nCOMPRESSION:NONE\r\nOLDFILEUID:NONE\r\nNEWFILEUID:NONE\r\n\r\n")
+
+	b.WriteString("<OFX>\n<SIGNONMSGSRSV1>\n<SONRS>\n<STATUS>\n<CODE>0\n<SEVERITY>INFO\n</STATUS>\n")
+	fmt.Fprintf(&b, "<DTSERVER>%s\n<LANGUAGE>POR\n</SONRS>\n</SIGNONMSGSRSV1>\n", ofxDate(time.Now()))
+
+	b.WriteString("<BANKMSGSRSV1>\n<STMTTRNRS>\n<TRNUID>1\n<STATUS>\n<CODE>0\n<SEVERITY>INFO\n</STATUS>\n<STMTRS>\n")
+	fmt.Fprintf(&b, "<CURDEF>%s\n", currency)
+	fmt.Fprintf(&b, "<BANKACCTFROM>\n<BANKID>0000\n<ACCTID>%s\n<ACCTTYPE>CHECKING\n</BANKACCTFROM>\n", ofxText(accountID, 22))
+	fmt.Fprintf(&b, "<BANKTRANLIST>\n<DTSTART>%s\n<DTEND>%s\n", ofxDate(from), ofxDate(to))
+
+	var balance float64
+	for _, r := range rows {
+		trnType := "CREDIT"
+		if r.Amount < 0 {
+			trnType = "DEBIT"
+		}
+		balance += r.Amount
+
+		b.WriteString("<STMTTRN>\n")
+		fmt.Fprintf(&b, "<TRNTYPE>%s\n", trnType)
+		fmt.Fprintf(&b, "<DTPOSTED>%s\n", ofxDate(r.Date))
+		fmt.Fprintf(&b, "<TRNAMT>%s\n", strconv.FormatFloat(r.Amount, 'f', 2, 64))
+		fmt.Fprintf(&b, "<FITID>%d\n", r.EntryID)
+		fmt.Fprintf(&b, "<NAME>%s\n", ofxText(r.Category, 32))
+		fmt.Fprintf(&b, "<MEMO>%s\n", ofxText(r.Memo, 255))
+		b.WriteString("</STMTTRN>\n")
+	}
+
+	b.WriteString("</BANKTRANLIST>\n")
+	fmt.Fprintf(&b, "<LEDGERBAL>\n<BALAMT>%s\n<DTASOF>%s\n</LEDGERBAL>\n", strconv.FormatFloat(balance, 'f', 2, 64), ofxDate(to))
+	b.WriteString("</STMTRS>\n</STMTTRNRS>\n</BANKMSGSRSV1>\n</OFX>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ofxDate formata datas no padrão OFX (YYYYMMDDHHMMSS)
+func ofxDate(t time.Time) string {
+	return t.UTC().Format("20060102150405")
+}
+
+// ofxText remove caracteres que quebram o SGML e limita ao tamanho do campo (ex: NAME aceita 32)
+func ofxText(value string, maxLen int) string {
+	value = strings.NewReplacer("<", "", ">", "", "&", "e", "\r", " ", "\n", " ").Replace(value)
+	if runes := []rune(value); len(runes) > maxLen {
+		value = string(runes[:maxLen])
+	}
+	return value
+}
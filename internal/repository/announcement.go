@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AnnouncementRepository encapsula as operações de banco de dados para os comunicados do serviço
+type AnnouncementRepository struct {
+	db *gorm.DB
+}
+
+// NewAnnouncementRepository cria uma nova instância do AnnouncementRepository
+func NewAnnouncementRepository(db *gorm.DB) *AnnouncementRepository {
+	return &AnnouncementRepository{db: db}
+}
+
+// Publish cria o comunicado e entrega uma notificação a cada usuário do público
+// Performance: as notificações são criadas com um único INSERT ... SELECT (sem carregar os usuários)
+// O email fica pendente apenas para quem não pediu opt-out dos emails do serviço
+func (r *AnnouncementRepository) Publish(announcement *models.Announcement, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(announcement).Error; err != nil {
+			return err
+		}
+
+		audience := tx.Model(&models.User{}).
+			Select("users.id, ? AS announcement_id, users.lifecycle_opt_out_at IS NULL AND ? AS email_pending, ? AS created_at, ? AS updated_at",
+				announcement.ID, announcement.SendEmail, now, now)
+		switch announcement.Plan {
+		case models.AnnouncementPlanPro:
+			audience = audience.Where("users.pro_until > ?", now)
+		case models.AnnouncementPlanFree:
+			audience = audience.Where("users.pro_until IS NULL OR users.pro_until <= ?", now)
+		}
+		if announcement.ActiveWithinDays > 0 {
+			audience = audience.Where("users.last_seen_at >= ?", now.AddDate(0, 0, -announcement.ActiveWithinDays))
+		}
+
+		result := tx.Exec("INSERT INTO notifications (user_id, announcement_id, email_pending, created_at, updated_at) ?", audience)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		announcement.Recipients = int(result.RowsAffected)
+		return tx.Model(announcement).UpdateColumn("recipients", announcement.Recipients).Error
+	})
+}
+
+// FindPage lista os comunicados publicados (mais recentes primeiro)
+func (r *AnnouncementRepository) FindPage(page, perPage int) ([]models.Announcement, int64, error) {
+	var total int64
+	if err := r.db.Model(&models.Announcement{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var announcements []models.Announcement
+	err := r.db.Order("created_at DESC, id DESC").
+		Offset((page - 1) * perPage).
+		Limit(perPage).
+		Find(&announcements).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return announcements, total, nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AsyncJobRepository encapsula as operações de banco de dados para os jobs assíncronos da API
+type AsyncJobRepository struct {
+	db *gorm.DB
+}
+
+// NewAsyncJobRepository cria uma nova instância do AsyncJobRepository
+func NewAsyncJobRepository(db *gorm.DB) *AsyncJobRepository {
+	return &AsyncJobRepository{db: db}
+}
+
+// Create enfileira um novo job
+func (r *AsyncJobRepository) Create(job *models.AsyncJob) error {
+	job.Status = models.AsyncJobQueued
+	return r.db.Create(job).Error
+}
+
+// FindQueued busca os jobs na fila, mais antigos primeiro
+// Performance: Usa o índice em status
+func (r *AsyncJobRepository) FindQueued(limit int) ([]models.AsyncJob, error) {
+	var jobs []models.AsyncJob
+	err := r.db.Where("status = ?", models.AsyncJobQueued).
+		Order("id ASC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// Claim marca o job como em execução se ele ainda estiver na fila
+// Concorrência: Update condicional, retorna false se outra execução já pegou o job
+func (r *AsyncJobRepository) Claim(job *models.AsyncJob, now time.Time) (bool, error) {
+	result := r.db.Model(&models.AsyncJob{}).
+		Where("id = ? AND status = ?", job.ID, models.AsyncJobQueued).
+		Updates(map[string]interface{}{
+			"status":     models.AsyncJobRunning,
+			"started_at": now,
+			"attempts":   gorm.Expr("attempts + 1"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	job.Status = models.AsyncJobRunning
+	job.StartedAt = &now
+	job.Attempts++
+	return true, nil
+}
+
+// UpdateProgress grava o percentual concluído de um job em execução
+func (r *AsyncJobRepository) UpdateProgress(id uint, progress int) error {
+	return r.db.Model(&models.AsyncJob{}).
+		Where("id = ? AND status = ?", id, models.AsyncJobRunning).
+		Update("progress", progress).Error
+}
+
+// Finish grava o resultado (ou a falha) de um job
+func (r *AsyncJobRepository) Finish(job *models.AsyncJob) error {
+	return r.db.Model(job).
+		Select("status", "progress", "result", "file_name", "download_name", "content_type", "error", "finished_at", "expires_at").
+		Updates(job).Error
+}
+
+// RequeueInterrupted devolve à fila os jobs que ficaram em execução (réplica reiniciada no meio do job)
+// Jobs que já atingiram maxAttempts são marcados como falha para não repetir um erro indefinidamente
+func (r *AsyncJobRepository) RequeueInterrupted(maxAttempts int, now time.Time, retention time.Duration) (int64, error) {
+	expiresAt := now.Add(retention)
+	err := r.db.Model(&models.AsyncJob{}).
+		Where("status = ? AND attempts >= ?", models.AsyncJobRunning, maxAttempts).
+		Updates(map[string]interface{}{
+			"status":      models.AsyncJobFailed,
+			"error":       "job was interrupted too many times",
+			"finished_at": now,
+			"expires_at":  expiresAt,
+		}).Error
+	if err != nil {
+		return 0, err
+	}
+
+	result := r.db.Model(&models.AsyncJob{}).
+		Where("status = ?", models.AsyncJobRunning).
+		Update("status", models.AsyncJobQueued)
+	return result.RowsAffected, result.Error
+}
+
+// FindExpired busca jobs cujo resultado já expirou
+// Performance: Usa o índice em expires_at
+func (r *AsyncJobRepository) FindExpired(now time.Time, limit int) ([]models.AsyncJob, error) {
+	var jobs []models.AsyncJob
+	err := r.db.Where("expires_at IS NOT NULL AND expires_at < ?", now).
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// Delete remove o registro de um job expirado
+func (r *AsyncJobRepository) Delete(id uint) error {
+	return r.db.Delete(&models.AsyncJob{}, id).Error
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// BudgetRepository encapsula as operações de banco de dados para orçamentos
+type BudgetRepository struct {
+	db *gorm.DB
+}
+
+// NewBudgetRepository cria uma nova instância do BudgetRepository
+func NewBudgetRepository(db *gorm.DB) *BudgetRepository {
+	return &BudgetRepository{db: db}
+}
+
+// FindByWeddingID busca o orçamento de um casamento
+// Performance: Usa o uniqueIndex em wedding_id
+func (r *BudgetRepository) FindByWeddingID(weddingID uint) (*models.Budget, error) {
+	var budget models.Budget
+	err := r.db.Where("wedding_id = ?", weddingID).First(&budget).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("budget not found")
+		}
+		return nil, err
+	}
+	return &budget, nil
+}
+
+// IncrementTotalSpent ajusta o total gasto de forma atômica
+// Concorrência: UPDATE ... SET x = x + ? evita lost updates entre requests simultâneos
+func (r *BudgetRepository) IncrementTotalSpent(weddingID uint, delta float64) error {
+	return r.db.Model(&models.Budget{}).
+		Where("wedding_id = ?", weddingID).
+		Update("total_spent", gorm.Expr("total_spent + ?", delta)).Error
+}
+
+// IncrementTotalPlanned ajusta o total previsto de forma atômica
+// Concorrência: UPDATE ... SET x = x + ? evita lost updates entre requests simultâneos
+func (r *BudgetRepository) IncrementTotalPlanned(weddingID uint, delta float64) error {
+	return r.db.Model(&models.Budget{}).
+		Where("wedding_id = ?", weddingID).
+		Update("total_planned", gorm.Expr("total_planned + ?", delta)).Error
+}
+
+// FindDueExpensesByUserID lista gastos previstos com vencimento em todos os casamentos do usuário
+// Performance: JOIN + Preload evita N+1 queries
+func (r *BudgetRepository) FindDueExpensesByUserID(userID uint) ([]models.Expense, error) {
+	var expenses []models.Expense
+	err := r.db.Preload("Wedding").
+		Joins("JOIN weddings ON weddings.id = expenses.wedding_id AND weddings.deleted_at IS NULL").
+		Where("weddings.user_id = ? AND expenses.status = ? AND expenses.due_date IS NOT NULL", userID, models.ExpenseStatusPlanned).
+		Order("expenses.due_date ASC").
+		Find(&expenses).Error
+	if err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+// ExpenseTotal representa a soma de gastos agrupada por categoria e status
+type ExpenseTotal struct {
+	Category models.ExpenseCategory `json:"category"`
+	Status   models.ExpenseStatus   `json:"status"`
+	Total    float64                `json:"total"`
+}
+
+// ExpenseTotalsByCategory soma os gastos de um casamento por categoria e status
+// Performance: Agregação no banco coberta pelo índice (wedding_id, category, status)
+func (r *BudgetRepository) ExpenseTotalsByCategory(weddingID uint) ([]ExpenseTotal, error) {
+	var totals []ExpenseTotal
+	err := r.db.Model(&models.Expense{}).
+		Select("category, status, SUM(amount) AS total").
+		Where("wedding_id = ?", weddingID).
+		Group("category, status").
+		Order("category ASC").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+)
+
+// CheckInStats resume a portaria do evento
+type CheckInStats struct {
+	ExpectedGuests     int64 // convidados confirmados
+	ArrivedGuests      int64 // confirmados que já chegaram
+	WalkIns            int64 // chegaram sem confirmação (check-in forçado)
+	ExpectedCompanions int64 // acompanhantes confirmados de convidados confirmados
+	ArrivedCompanions  int64 // acompanhantes confirmados de convidados que já chegaram
+}
+
+// MarkCheckedIn registra a chegada do convidado e indica se o check-in é novo
+// Concorrência: A condição em checked_in_at mantém o horário da primeira leitura do QR Code
+func (r *GuestRepository) MarkCheckedIn(guest *models.Guest, at time.Time) (bool, error) {
+	result := r.db.Model(&models.Guest{}).
+		Where("id = ? AND wedding_id = ? AND checked_in_at IS NULL", guest.ID, guest.WeddingID).
+		UpdateColumn("checked_in_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	guest.CheckedInAt = &at
+	return true, nil
+}
+
+// ClearCheckIn desfaz o check-in de um convidado (leitura por engano)
+func (r *GuestRepository) ClearCheckIn(guest *models.Guest) error {
+	err := r.db.Model(&models.Guest{}).
+		Where("id = ? AND wedding_id = ?", guest.ID, guest.WeddingID).
+		UpdateColumn("checked_in_at", nil).Error
+	if err != nil {
+		return err
+	}
+	guest.CheckedInAt = nil
+	return nil
+}
+
+// CheckInStatsByWeddingID agrega chegadas e esperados do casamento
+// Performance: Duas queries agregadas (convidados e acompanhantes), sem carregar registros
+func (r *GuestRepository) CheckInStatsByWeddingID(weddingID uint) (*CheckInStats, error) {
+	var stats CheckInStats
+
+	err := r.db.Model(&models.Guest{}).
+		Select(`COALESCE(SUM(invite_status = ?), 0) AS expected_guests,
+			COALESCE(SUM(invite_status = ? AND checked_in_at IS NOT NULL), 0) AS arrived_guests,
+			COALESCE(SUM(invite_status <> ? AND checked_in_at IS NOT NULL), 0) AS walk_ins`,
+			models.InviteStatusConfirmed, models.InviteStatusConfirmed, models.InviteStatusConfirmed).
+		Where("wedding_id = ?", weddingID).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var companions struct {
+		Expected int64
+		Arrived  int64
+	}
+	err = r.db.Model(&models.Companion{}).
+		Select(`COUNT(*) AS expected,
+			COALESCE(SUM(guests.checked_in_at IS NOT NULL), 0) AS arrived`).
+		Joins("JOIN guests ON guests.id = companions.guest_id AND guests.deleted_at IS NULL").
+		Where("companions.wedding_id = ? AND companions.confirmed = ? AND guests.invite_status = ?",
+			weddingID, true, models.InviteStatusConfirmed).
+		Scan(&companions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats.ExpectedCompanions = companions.Expected
+	stats.ArrivedCompanions = companions.Arrived
+	return &stats, nil
+}
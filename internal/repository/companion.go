@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrCompanionLimit indica que o convidado já registrou todos os acompanhantes permitidos
+var ErrCompanionLimit = errors.New("guest has reached the companion limit")
+
+// CompanionRepository encapsula as operações de banco de dados para acompanhantes
+type CompanionRepository struct {
+	db *gorm.DB
+}
+
+// NewCompanionRepository cria uma nova instância do CompanionRepository
+func NewCompanionRepository(db *gorm.DB) *CompanionRepository {
+	return &CompanionRepository{db: db}
+}
+
+// FindByGuestID lista os acompanhantes de um convidado por ordem de cadastro
+func (r *CompanionRepository) FindByGuestID(guestID, weddingID uint) ([]models.Companion, error) {
+	var companions []models.Companion
+	err := r.db.Where("guest_id = ? AND wedding_id = ?", guestID, weddingID).
+		Order("id ASC").
+		Find(&companions).Error
+	if err != nil {
+		return nil, err
+	}
+	return companions, nil
+}
+
+// CountByGuestID conta os acompanhantes de um convidado
+func (r *CompanionRepository) CountByGuestID(guestID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Companion{}).Where("guest_id = ?", guestID).Count(&count).Error
+	return count, err
+}
+
+// FindByIDAndGuestID busca um acompanhante de um convidado
+// Segurança: Garante que o acompanhante pertence ao convidado e ao casamento já validados
+func (r *CompanionRepository) FindByIDAndGuestID(id, guestID, weddingID uint) (*models.Companion, error) {
+	var companion models.Companion
+	err := r.db.Where("id = ? AND guest_id = ? AND wedding_id = ?", id, guestID, weddingID).First(&companion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("companion not found")
+		}
+		return nil, err
+	}
+	return &companion, nil
+}
+
+// Create insere um acompanhante respeitando o limite do convidado
+// Concorrência: SELECT ... FOR UPDATE no convidado serializa cadastros simultâneos do mesmo convite
+func (r *CompanionRepository) Create(companion *models.Companion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var guest models.Guest
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND wedding_id = ?", companion.GuestID, companion.WeddingID).
+			First(&guest).Error
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.Companion{}).Where("guest_id = ?", guest.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if int(count) >= guest.CompanionLimit() {
+			return ErrCompanionLimit
+		}
+
+		if err := tx.Omit("Guest", "Wedding").Create(companion).Error; err != nil {
+			return err
+		}
+		if companion.Confirmed {
+			return NewWeddingRepository(tx).IncrementConfirmedCompanionCount(companion.WeddingID, 1)
+		}
+		return nil
+	})
+}
+
+// Update atualiza o acompanhante e ajusta o total de confirmados do casamento
+// Concorrência: A condição em confirmed garante que confirmações simultâneas contem uma única vez
+func (r *CompanionRepository) Update(companion *models.Companion, confirmed bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"full_name":   companion.FullName,
+			"meal_option": companion.MealOption,
+			"age_group":   companion.AgeGroup,
+		}
+
+		delta := 0
+		if confirmed != companion.Confirmed {
+			updates["confirmed"] = confirmed
+			if confirmed {
+				now := time.Now()
+				updates["confirmed_at"] = &now
+				delta = 1
+			} else {
+				updates["confirmed_at"] = nil
+				delta = -1
+			}
+		}
+
+		result := tx.Model(&models.Companion{}).
+			Where("id = ? AND confirmed = ?", companion.ID, companion.Confirmed).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("companion was modified concurrently")
+		}
+
+		if err := NewWeddingRepository(tx).IncrementConfirmedCompanionCount(companion.WeddingID, delta); err != nil {
+			return err
+		}
+		return tx.First(companion, companion.ID).Error
+	})
+}
+
+// Delete remove (soft delete) um acompanhante e ajusta o total de confirmados
+func (r *CompanionRepository) Delete(companion *models.Companion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.Companion{}, companion.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("companion not found")
+		}
+		if companion.Confirmed {
+			return NewWeddingRepository(tx).IncrementConfirmedCompanionCount(companion.WeddingID, -1)
+		}
+		return nil
+	})
+}
+
+// deleteCompanionsByGuestID remove os acompanhantes de um convidado e desconta os confirmados (na transação do chamador)
+func deleteCompanionsByGuestID(tx *gorm.DB, guestID, weddingID uint) error {
+	var confirmed int64
+	err := tx.Model(&models.Companion{}).
+		Where("guest_id = ? AND confirmed = ?", guestID, true).
+		Count(&confirmed).Error
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Where("guest_id = ?", guestID).Delete(&models.Companion{}).Error; err != nil {
+		return err
+	}
+	return NewWeddingRepository(tx).IncrementConfirmedCompanionCount(weddingID, -int(confirmed))
+}
+
+// replaceCompanions troca os acompanhantes do convidado pelos informados no RSVP (na transação do chamador)
+// Os novos acompanhantes entram confirmados e o total de confirmados do casamento acompanha a troca
+func replaceCompanions(tx *gorm.DB, guest *models.Guest, companions []models.Companion) error {
+	if err := deleteCompanionsByGuestID(tx, guest.ID, guest.WeddingID); err != nil {
+		return err
+	}
+	if len(companions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for i := range companions {
+		companions[i].GuestID = guest.ID
+		companions[i].WeddingID = guest.WeddingID
+		companions[i].Confirmed = true
+		companions[i].ConfirmedAt = &now
+	}
+	if err := tx.Omit("Guest", "Wedding").Create(&companions).Error; err != nil {
+		return err
+	}
+	return NewWeddingRepository(tx).IncrementConfirmedCompanionCount(guest.WeddingID, len(companions))
+}
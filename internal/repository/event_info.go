@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// EventInfoRepository encapsula as operações de banco de dados para as informações do evento
+type EventInfoRepository struct {
+	db *gorm.DB
+}
+
+// NewEventInfoRepository cria uma nova instância do EventInfoRepository
+func NewEventInfoRepository(db *gorm.DB) *EventInfoRepository {
+	return &EventInfoRepository{db: db}
+}
+
+// FindOrDefault busca as informações do evento ou retorna uma configuração vazia (não persistida)
+// Performance: Usa o uniqueIndex em wedding_id
+func (r *EventInfoRepository) FindOrDefault(weddingID uint) (*models.EventInfo, error) {
+	var info models.EventInfo
+	err := r.db.Where("wedding_id = ?", weddingID).First(&info).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &models.EventInfo{
+				WeddingID:  weddingID,
+				Highlights: []models.TimelineHighlight{},
+			}, nil
+		}
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Save cria ou atualiza as informações do evento
+func (r *EventInfoRepository) Save(info *models.EventInfo) error {
+	return r.db.Save(info).Error
+}
+
+// SaveTravelTime grava o tempo de deslocamento calculado entre cerimônia e recepção
+// UpdateColumns: o cálculo é um cache e não altera updated_at
+func (r *EventInfoRepository) SaveTravelTime(info *models.EventInfo) error {
+	return r.db.Model(info).
+		Select("travel_minutes", "travel_route").
+		UpdateColumns(info).Error
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// FundraisingRepository encapsula as operações de banco de dados para arrecadações
+type FundraisingRepository struct {
+	db *gorm.DB
+}
+
+// NewFundraisingRepository cria uma nova instância do FundraisingRepository
+func NewFundraisingRepository(db *gorm.DB) *FundraisingRepository {
+	return &FundraisingRepository{db: db}
+}
+
+// FindByIDAndWeddingID busca uma contribuição de um casamento
+// Segurança: Garante que a contribuição pertence ao casamento já validado
+func (r *FundraisingRepository) FindByIDAndWeddingID(id, weddingID uint) (*models.Fundraising, error) {
+	var fundraising models.Fundraising
+	err := r.db.Where("id = ? AND wedding_id = ?", id, weddingID).First(&fundraising).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("fundraising not found")
+		}
+		return nil, err
+	}
+	return &fundraising, nil
+}
+
+// FindByProviderCharge busca a contribuição associada a uma cobrança do PSP
+// Performance: Usa o índice em provider_charge_id
+func (r *FundraisingRepository) FindByProviderCharge(provider, chargeID string) (*models.Fundraising, error) {
+	var fundraising models.Fundraising
+	err := r.db.Where("provider_name = ? AND provider_charge_id = ?", provider, chargeID).First(&fundraising).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("fundraising not found")
+		}
+		return nil, err
+	}
+	return &fundraising, nil
+}
+
+// Create registra uma contribuição recebida junto com seu lançamento no livro-razão
+func (r *FundraisingRepository) Create(fundraising *models.Fundraising) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(fundraising).Error; err != nil {
+			return err
+		}
+		entry := models.NewFundraisingLedgerEntry(fundraising, models.LedgerContributionReceived, fundraising.DonorName)
+		return tx.Create(entry).Error
+	})
+}
+
+// Update atualiza os dados de uma contribuição
+func (r *FundraisingRepository) Update(fundraising *models.Fundraising) error {
+	return r.db.Save(fundraising).Error
+}
+
+// UpdateWithLedger atualiza a contribuição e registra o lançamento da mutação
+// Concorrência: Ambos na mesma transação para o livro-razão nunca divergir do registro
+func (r *FundraisingRepository) UpdateWithLedger(fundraising *models.Fundraising, entry *models.LedgerEntry) error {
+	return r.UpdateWithLedgerAndNotification(fundraising, entry, nil)
+}
+
+// UpdateWithLedgerAndNotification faz o mesmo que UpdateWithLedger e grava o aviso ao casal na mesma transação
+// O aviso só existe se a mudança foi gravada (reenvio de webhook com falha não avisa duas vezes)
+func (r *FundraisingRepository) UpdateWithLedgerAndNotification(fundraising *models.Fundraising, entry *models.LedgerEntry, notification *models.Notification) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(fundraising).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+		if notification == nil {
+			return nil
+		}
+		return tx.Create(notification).Error
+	})
+}
+
+// FundraisingTotal representa a soma de contribuições agrupada por tipo e status
+type FundraisingTotal struct {
+	Type   models.FundraisingType   `json:"type"`
+	Status models.FundraisingStatus `json:"status"`
+	Total  float64                  `json:"total"`
+	Count  int64                    `json:"count"`
+}
+
+// TotalsByWeddingID soma as contribuições de um casamento por tipo e status
+// Performance: Agregação no banco ao invés de carregar todos os registros
+func (r *FundraisingRepository) TotalsByWeddingID(weddingID uint) ([]FundraisingTotal, error) {
+	var totals []FundraisingTotal
+	err := r.db.Model(&models.Fundraising{}).
+		Select("type, status, SUM(amount) AS total, COUNT(*) AS count").
+		Where("wedding_id = ?", weddingID).
+		Group("type, status").
+		Order("type ASC").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
@@ -0,0 +1,720 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GuestRepository encapsula as operações de banco de dados para convidados
+type GuestRepository struct {
+	db *gorm.DB
+}
+
+// NewGuestRepository cria uma nova instância do GuestRepository
+func NewGuestRepository(db *gorm.DB) *GuestRepository {
+	return &GuestRepository{db: db}
+}
+
+// FindByWeddingID lista todos os convidados de um casamento ordenados por nome
+// Performance: Usa o índice composto (wedding_id, invite_status)
+func (r *GuestRepository) FindByWeddingID(weddingID uint) ([]models.Guest, error) {
+	var guests []models.Guest
+	err := r.db.Where("wedding_id = ?", weddingID).
+		Order("full_name ASC").
+		Find(&guests).Error
+	if err != nil {
+		return nil, err
+	}
+	return guests, nil
+}
+
+// GuestFilter define os filtros da listagem paginada de convidados
+type GuestFilter struct {
+	Status       models.InviteStatus
+	Name         string              // busca parcial no nome
+	EmailHashes  []string            // email exato via blind index (coluna criptografada)
+	TagID        uint                // apenas convidados com a etiqueta
+	Event        models.WeddingEvent // apenas convidados da cerimônia ou da recepção
+	Relationship string              // relação exata com o casal (ex: primo)
+	Notes        string              // busca parcial nas anotações
+	OutOfTown    bool                // apenas convidados de fora da cidade
+}
+
+// FindPageByWeddingID lista uma página de convidados filtrados e o total de resultados
+// Performance: Usa o índice composto (wedding_id, invite_status) e o índice de email_hash
+func (r *GuestRepository) FindPageByWeddingID(weddingID uint, filter GuestFilter, page, perPage int) ([]models.Guest, int64, error) {
+	query := r.db.Model(&models.Guest{}).Where("wedding_id = ?", weddingID)
+
+	if filter.Status != "" {
+		query = query.Where("invite_status = ?", filter.Status)
+	}
+
+	if filter.Name != "" {
+		search := r.db.Where("full_name LIKE ?", "%"+escapeLike(filter.Name)+"%")
+		if len(filter.EmailHashes) > 0 {
+			search = search.Or("email_hash IN ?", filter.EmailHashes)
+		}
+		query = query.Where(search)
+	}
+
+	if filter.Event != "" {
+		query = query.Where("events IN ?", filter.Event.Events())
+	}
+
+	if filter.Relationship != "" {
+		query = query.Where("relationship = ?", filter.Relationship)
+	}
+
+	if filter.Notes != "" {
+		query = query.Where("notes LIKE ?", "%"+escapeLike(filter.Notes)+"%")
+	}
+
+	if filter.OutOfTown {
+		query = query.Where("logistics_out_of_town = ?", true)
+	}
+
+	if filter.TagID != 0 {
+		query = query.Where("id IN (?)", r.db.Model(&models.GuestTagAssignment{}).
+			Select("guest_id").
+			Where("tag_id = ? AND wedding_id = ?", filter.TagID, weddingID))
+	}
+
+	// Session: reaproveita os filtros na contagem e na busca sem compartilhar o statement
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var guests []models.Guest
+	err := query.Order("full_name ASC").Order("id ASC").
+		Offset((page - 1) * perPage).
+		Limit(perPage).
+		Find(&guests).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return guests, total, nil
+}
+
+// MealCount agrega convidados por opção de prato
+type MealCount struct {
+	MealOption models.MealOption `json:"meal_option"`
+	Count      int64             `json:"count"`
+	Children   int64             `json:"children"`
+}
+
+// MealCountsByWeddingID conta convidados por opção de prato, filtrando por status quando informado
+// O buffet é da recepção: convidados só da cerimônia não entram na contagem
+// Performance: Agregação no banco usando o índice (wedding_id, invite_status)
+func (r *GuestRepository) MealCountsByWeddingID(weddingID uint, status models.InviteStatus) ([]MealCount, error) {
+	query := r.db.Model(&models.Guest{}).
+		Select("meal_option, COUNT(*) AS count, SUM(CASE WHEN is_child THEN 1 ELSE 0 END) AS children").
+		Where("wedding_id = ? AND events IN ?", weddingID, models.WeddingEventReception.Events())
+	if status != "" {
+		query = query.Where("invite_status = ?", status)
+	}
+
+	var counts []MealCount
+	if err := query.Group("meal_option").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// FindWithDietaryRestrictions lista convidados da recepção com restrições alimentares informadas
+func (r *GuestRepository) FindWithDietaryRestrictions(weddingID uint, status models.InviteStatus) ([]models.Guest, error) {
+	query := r.db.Where("wedding_id = ? AND dietary_restrictions <> '' AND events IN ?", weddingID, models.WeddingEventReception.Events())
+	if status != "" {
+		query = query.Where("invite_status = ?", status)
+	}
+
+	var guests []models.Guest
+	if err := query.Order("full_name ASC").Find(&guests).Error; err != nil {
+		return nil, err
+	}
+	return guests, nil
+}
+
+// FindWithLogistics lista convidados com alguma informação de hotel, transfer ou vindos de fora
+func (r *GuestRepository) FindWithLogistics(weddingID uint, status models.InviteStatus) ([]models.Guest, error) {
+	query := r.db.Where("wedding_id = ?", weddingID).
+		Where(r.db.Where("logistics_out_of_town = ?", true).
+			Or("logistics_needs_hotel = ?", true).
+			Or("logistics_needs_shuttle = ?", true).
+			Or("logistics_hotel_name <> ''"))
+	if status != "" {
+		query = query.Where("invite_status = ?", status)
+	}
+
+	var guests []models.Guest
+	if err := query.Order("full_name ASC").Find(&guests).Error; err != nil {
+		return nil, err
+	}
+	return guests, nil
+}
+
+// FindInviteRecipients lista os convidados de um envio de convites em massa
+// Sem status, todos fora da lista de espera (que só recebem o convite depois de promovidos)
+// e quem já recusou (não recebe convites nem lembretes automáticos)
+// Performance: Usa o índice composto (wedding_id, invite_status)
+func (r *GuestRepository) FindInviteRecipients(weddingID uint, status models.InviteStatus) ([]models.Guest, error) {
+	query := r.db.Where("wedding_id = ?", weddingID)
+	if status != "" {
+		query = query.Where("invite_status = ?", status)
+	} else {
+		query = query.Where("invite_status NOT IN ?", []models.InviteStatus{models.InviteStatusWaitlisted, models.InviteStatusDeclined})
+	}
+
+	var guests []models.Guest
+	if err := query.Order("full_name ASC").Find(&guests).Error; err != nil {
+		return nil, err
+	}
+	return guests, nil
+}
+
+// escapeLike escapa os curingas do LIKE para buscar o texto literalmente
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// FindByID busca um convidado pelo ID
+func (r *GuestRepository) FindByID(id uint) (*models.Guest, error) {
+	var guest models.Guest
+	err := r.db.First(&guest, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("guest not found")
+		}
+		return nil, err
+	}
+	return &guest, nil
+}
+
+// FindByIDAndWeddingID busca um convidado de um casamento
+// Segurança: Garante que o convidado pertence ao casamento já validado
+func (r *GuestRepository) FindByIDAndWeddingID(id, weddingID uint) (*models.Guest, error) {
+	var guest models.Guest
+	err := r.db.Where("id = ? AND wedding_id = ?", id, weddingID).First(&guest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("guest not found")
+		}
+		return nil, err
+	}
+	return &guest, nil
+}
+
+// Create insere um convidado ocupando uma vaga do casamento na mesma transação
+// Com o casamento lotado retorna ErrWeddingFull, ou com waitlist cria o convidado na lista de espera
+// O status inicial fica registrado no histórico do convidado
+// Concorrência: A vaga é reservada com a linha do casamento bloqueada (ReserveGuestCapacity)
+func (r *GuestRepository) Create(guest *models.Guest, waitlist bool, actor models.StatusActor) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if guest.CountsTowardCapacity() {
+			err := NewWeddingRepository(tx).ReserveGuestCapacity(guest.WeddingID, 1)
+			if errors.Is(err, ErrWeddingFull) && waitlist {
+				guest.InviteStatus = models.InviteStatusWaitlisted
+			} else if err != nil {
+				return err
+			}
+		}
+		if err := tx.Omit("Wedding").Create(guest).Error; err != nil {
+			return err
+		}
+		return recordStatusChange(tx, guest, "", actor)
+	})
+}
+
+// ErrNotWaitlisted indica que o convidado não está (mais) na lista de espera
+var ErrNotWaitlisted = errors.New("guest is not waitlisted")
+
+// PromoteFromWaitlist move o convidado da lista de espera para pendente, ocupando uma vaga
+// Retorna ErrWeddingFull se ainda não houver vaga
+func (r *GuestRepository) PromoteFromWaitlist(guest *models.Guest, actor models.StatusActor) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Reserva antes de alterar o convidado: mesma ordem de bloqueio do Create (casamento primeiro)
+		if err := NewWeddingRepository(tx).ReserveGuestCapacity(guest.WeddingID, 1); err != nil {
+			return err
+		}
+		result := tx.Model(&models.Guest{}).
+			Where("id = ? AND invite_status = ?", guest.ID, models.InviteStatusWaitlisted).
+			Update("invite_status", models.InviteStatusPending)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotWaitlisted
+		}
+		guest.InviteStatus = models.InviteStatusPending
+		return recordStatusChange(tx, guest, models.InviteStatusWaitlisted, actor)
+	})
+}
+
+// ErrStatusChanged indica que o status do convidado foi alterado por outra requisição
+var ErrStatusChanged = errors.New("guest status was changed by another request")
+
+// UpdateWithStatus atualiza um convidado cujo status mudou, registrando a transição no histórico
+// Quem volta a ocupar vaga (ex: recusou e depois confirmou) precisa de vaga livre (ErrWeddingFull);
+// a vaga liberada por uma recusa passa ao próximo da lista de espera quando o casamento
+// tem a promoção automática ativa, e o convidado promovido é retornado
+// Com a lotação do local ativa (EnforceCapacity), confirmações além de MaxGuests retornam ErrVenueAtCapacity
+func (r *GuestRepository) UpdateWithStatus(guest *models.Guest, previous models.InviteStatus, actor models.StatusActor) (*models.Guest, error) {
+	return r.updateWithStatus(guest, previous, actor, nil)
+}
+
+// RSVPUpdate traz o que a resposta do link pessoal grava além do status
+type RSVPUpdate struct {
+	// Companions substitui os acompanhantes do convidado; nil mantém os já registrados
+	Companions []models.Companion
+	// Notification avisa o casal da resposta (ex: recusa com recado); nil não avisa
+	Notification *models.Notification
+}
+
+// UpdateRSVP grava a resposta do link pessoal junto com os acompanhantes e o aviso ao casal
+// A lista de acompanhantes substitui os já registrados, todos confirmados, e precisa caber em
+// CompanionLimit; com EnforceCapacity, cada acompanhante também ocupa um lugar na lotação do local
+// Com a mesma resposta de antes, só o recado de recusa, os acompanhantes e o aviso são gravados
+// Concorrência: a validação roda sob o mesmo bloqueio do casamento de UpdateWithStatus, com o
+// convidado bloqueado em seguida (mesma ordem de UpdateStatusMany)
+func (r *GuestRepository) UpdateRSVP(guest *models.Guest, previous models.InviteStatus, actor models.StatusActor, update RSVPUpdate) (*models.Guest, error) {
+	return r.updateWithStatus(guest, previous, actor, &update)
+}
+
+// updateWithStatus implementa UpdateWithStatus e UpdateRSVP (rsvp nil fora do link pessoal)
+func (r *GuestRepository) updateWithStatus(guest *models.Guest, previous models.InviteStatus, actor models.StatusActor, rsvp *RSVPUpdate) (*models.Guest, error) {
+	var promoted *models.Guest
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		weddings := NewWeddingRepository(tx)
+		takesSeat := !previous.HoldsSeat() && guest.CountsTowardCapacity()
+		releasesSeat := previous.HoldsSeat() && !guest.CountsTowardCapacity()
+		confirms := guest.InviteStatus == models.InviteStatusConfirmed && previous != models.InviteStatusConfirmed
+
+		var companions []models.Companion
+		if rsvp != nil {
+			companions = rsvp.Companions
+		}
+		replacesCompanions := companions != nil
+
+		// Performance: A linha do casamento só é bloqueada quando a mudança mexe em vagas ou na lotação
+		wedding := &models.Wedding{}
+		if takesSeat || releasesSeat || confirms || replacesCompanions {
+			var err error
+			wedding, err = weddings.LockGuestCapacity(guest.WeddingID)
+			if err != nil {
+				return err
+			}
+		}
+		if takesSeat && wedding.CurrentGuestCount+1 > wedding.MaxGuests {
+			return ErrWeddingFull
+		}
+
+		// Acompanhantes confirmados hoje, que deixam a lotação quando a lista é substituída
+		releasedCompanions := 0
+		if replacesCompanions {
+			var locked models.Guest
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id", "max_guests").
+				Where("id = ? AND wedding_id = ?", guest.ID, guest.WeddingID).
+				First(&locked).Error
+			if err != nil {
+				return err
+			}
+			if len(companions) > locked.CompanionLimit() {
+				return ErrCompanionLimit
+			}
+
+			var confirmed int64
+			err = tx.Model(&models.Companion{}).
+				Where("guest_id = ? AND confirmed = ?", guest.ID, true).
+				Count(&confirmed).Error
+			if err != nil {
+				return err
+			}
+			releasedCompanions = int(confirmed)
+		}
+
+		if wedding.EnforceCapacity && (confirms || replacesCompanions && guest.InviteStatus == models.InviteStatusConfirmed) {
+			// Concorrência: Confirmações simultâneas serializam no bloqueio do casamento
+			headcount, err := weddings.ConfirmedHeadcount(wedding)
+			if err != nil {
+				return err
+			}
+			seats := len(companions) - releasedCompanions
+			if confirms {
+				seats++
+			}
+			if seats > 0 && headcount+seats > wedding.MaxGuests {
+				return ErrVenueAtCapacity
+			}
+		}
+
+		// Reenvio do RSVP com a mesma resposta: só os acompanhantes mudam
+		if guest.InviteStatus != previous {
+			// Concorrência: a condição no status anterior impede que duas atualizações ajustem a mesma vaga
+			result := tx.Model(&models.Guest{}).
+				Where("id = ? AND invite_status = ?", guest.ID, previous).
+				Update("invite_status", guest.InviteStatus)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrStatusChanged
+			}
+			if err := tx.Omit("Wedding").Save(guest).Error; err != nil {
+				return err
+			}
+			if err := recordStatusChange(tx, guest, previous, actor); err != nil {
+				return err
+			}
+		} else if rsvp != nil {
+			err := tx.Model(&models.Guest{}).
+				Where("id = ? AND wedding_id = ?", guest.ID, guest.WeddingID).
+				Update("decline_message", guest.DeclineMessage).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if replacesCompanions {
+			if err := replaceCompanions(tx, guest, companions); err != nil {
+				return err
+			}
+		}
+		if rsvp != nil && rsvp.Notification != nil {
+			if err := tx.Create(rsvp.Notification).Error; err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case takesSeat:
+			return weddings.IncrementGuestCount(guest.WeddingID, 1)
+		case releasesSeat && wedding.AutoPromoteGuests:
+			next, err := promoteWaitlisted(tx, guest.WeddingID, 1)
+			if err != nil {
+				return err
+			}
+			if len(next) == 1 {
+				// A vaga muda de dono: o contador não se altera
+				promoted = &next[0]
+				return recordStatusChange(tx, promoted, models.InviteStatusWaitlisted, models.StatusActor{
+					Channel: models.StatusChannelAutoPromotion,
+					Note:    fmt.Sprintf("seat released by guest #%d", guest.ID),
+				})
+			}
+			return weddings.IncrementGuestCount(guest.WeddingID, -1)
+		case releasesSeat:
+			return weddings.IncrementGuestCount(guest.WeddingID, -1)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return promoted, nil
+}
+
+// ErrInvalidStatusTransition indica que algum convidado do lote não pode ir para o status pedido
+var ErrInvalidStatusTransition = errors.New("status transition not allowed for some guests")
+
+// BulkStatusResult resume uma mudança de status em lote
+type BulkStatusResult struct {
+	Updated  []models.Guest // convidados cujo status mudou
+	Promoted []models.Guest // promovidos da lista de espera com as vagas liberadas
+}
+
+// UpdateStatusMany muda o status de vários convidados do casamento com um único UPDATE
+// Valida todas as transições antes de alterar qualquer convidado (tudo ou nada) e ajusta
+// as vagas, a lotação do local e o histórico como em UpdateWithStatus
+// Concorrência: Casamento e convidados ficam bloqueados (FOR UPDATE), nessa ordem, até o commit
+func (r *GuestRepository) UpdateStatusMany(weddingID uint, guestIDs []uint, status models.InviteStatus, actor models.StatusActor) (*BulkStatusResult, error) {
+	result := &BulkStatusResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		weddings := NewWeddingRepository(tx)
+		wedding, err := weddings.LockGuestCapacity(weddingID)
+		if err != nil {
+			return err
+		}
+
+		var guests []models.Guest
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND wedding_id = ?", guestIDs, weddingID).
+			Order("id ASC").
+			Find(&guests).Error
+		if err != nil {
+			return err
+		}
+		if len(guests) != len(guestIDs) {
+			return errors.New("guest not found")
+		}
+
+		var ids []uint
+		var history []models.GuestStatusHistory
+		delta := 0
+		for i := range guests {
+			g := &guests[i]
+			if g.InviteStatus == status {
+				continue
+			}
+			if !g.InviteStatus.CanTransitionTo(status) {
+				return ErrInvalidStatusTransition
+			}
+			switch {
+			case !g.InviteStatus.HoldsSeat() && status.HoldsSeat():
+				delta++
+			case g.InviteStatus.HoldsSeat() && !status.HoldsSeat():
+				delta--
+			}
+			previous := g.InviteStatus
+			g.InviteStatus = status
+			ids = append(ids, g.ID)
+			history = append(history, models.NewGuestStatusHistory(g, previous, actor))
+			result.Updated = append(result.Updated, *g)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if delta > 0 && wedding.CurrentGuestCount+delta > wedding.MaxGuests {
+			return ErrWeddingFull
+		}
+		if status == models.InviteStatusConfirmed && wedding.EnforceCapacity {
+			headcount, err := weddings.ConfirmedHeadcount(wedding)
+			if err != nil {
+				return err
+			}
+			if headcount+len(ids) > wedding.MaxGuests {
+				return ErrVenueAtCapacity
+			}
+		}
+
+		err = tx.Model(&models.Guest{}).
+			Where("id IN ?", ids).
+			Update("invite_status", status).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.CreateInBatches(&history, 100).Error; err != nil {
+			return err
+		}
+
+		// As vagas liberadas pelas recusas passam para a lista de espera (mesma regra do UpdateWithStatus)
+		if delta < 0 && wedding.AutoPromoteGuests {
+			result.Promoted, err = promoteWaitlisted(tx, weddingID, -delta)
+			if err != nil {
+				return err
+			}
+			promotedHistory := make([]models.GuestStatusHistory, len(result.Promoted))
+			for i := range result.Promoted {
+				promotedHistory[i] = models.NewGuestStatusHistory(&result.Promoted[i], models.InviteStatusWaitlisted, models.StatusActor{
+					Channel: models.StatusChannelAutoPromotion,
+					Note:    "seat released by a bulk status update",
+				})
+			}
+			if len(promotedHistory) > 0 {
+				if err := tx.Create(&promotedHistory).Error; err != nil {
+					return err
+				}
+			}
+			delta += len(result.Promoted)
+		}
+		if delta == 0 {
+			return nil
+		}
+		return weddings.IncrementGuestCount(weddingID, delta)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PromoteWaitlist preenche as vagas livres com a lista de espera (lista A antes da lista B,
+// por ordem de cadastro) e retorna os convidados promovidos
+func (r *GuestRepository) PromoteWaitlist(weddingID uint, actor models.StatusActor) ([]models.Guest, error) {
+	var promoted []models.Guest
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		weddings := NewWeddingRepository(tx)
+		wedding, err := weddings.LockGuestCapacity(weddingID)
+		if err != nil {
+			return err
+		}
+		free := wedding.MaxGuests - wedding.CurrentGuestCount
+		if free <= 0 {
+			return nil
+		}
+
+		promoted, err = promoteWaitlisted(tx, weddingID, free)
+		if err != nil || len(promoted) == 0 {
+			return err
+		}
+		history := make([]models.GuestStatusHistory, len(promoted))
+		for i := range promoted {
+			history[i] = models.NewGuestStatusHistory(&promoted[i], models.InviteStatusWaitlisted, actor)
+		}
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+		return weddings.IncrementGuestCount(weddingID, len(promoted))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return promoted, nil
+}
+
+// FindWaitlistQueue lista os próximos convidados da lista de espera, na ordem de promoção
+// (lista A antes da lista B, por ordem de cadastro)
+func (r *GuestRepository) FindWaitlistQueue(weddingID uint, limit int) ([]models.Guest, error) {
+	var guests []models.Guest
+	err := r.db.Where("wedding_id = ? AND invite_status = ?", weddingID, models.InviteStatusWaitlisted).
+		Order("priority ASC, created_at ASC, id ASC").
+		Limit(limit).
+		Find(&guests).Error
+	if err != nil {
+		return nil, err
+	}
+	return guests, nil
+}
+
+// FindPendingByPriority lista os convidados da lista (A ou B) que ainda não receberam o convite
+func (r *GuestRepository) FindPendingByPriority(weddingID uint, priority models.GuestPriority) ([]models.Guest, error) {
+	var guests []models.Guest
+	err := r.db.Where("wedding_id = ? AND invite_status = ? AND priority = ?", weddingID, models.InviteStatusPending, priority).
+		Order("created_at ASC, id ASC").
+		Find(&guests).Error
+	if err != nil {
+		return nil, err
+	}
+	return guests, nil
+}
+
+// promoteWaitlisted move até limit convidados da lista de espera para pendente, sem mexer no contador
+// Deve rodar dentro de uma transação que já bloqueou a linha do casamento
+func promoteWaitlisted(tx *gorm.DB, weddingID uint, limit int) ([]models.Guest, error) {
+	var guests []models.Guest
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("wedding_id = ? AND invite_status = ?", weddingID, models.InviteStatusWaitlisted).
+		Order("priority ASC, created_at ASC, id ASC").
+		Limit(limit).
+		Find(&guests).Error
+	if err != nil || len(guests) == 0 {
+		return nil, err
+	}
+
+	ids := make([]uint, len(guests))
+	for i := range guests {
+		ids[i] = guests[i].ID
+		guests[i].InviteStatus = models.InviteStatusPending
+	}
+	err = tx.Model(&models.Guest{}).
+		Where("id IN ?", ids).
+		Update("invite_status", models.InviteStatusPending).Error
+	if err != nil {
+		return nil, err
+	}
+	return guests, nil
+}
+
+// guestManagedColumns são as colunas do convidado gravadas apenas por operações próprias
+// (mudança de status, grupo, descadastro, RSVP e check-in) e nunca pela edição do cadastro
+var guestManagedColumns = []string{
+	"Wedding", "Group", "wedding_id", "created_at",
+	"invite_status", "group_id", "opted_out_at", "decline_message", "checked_in_at",
+}
+
+// Update atualiza os dados de um convidado
+// Concorrência: As colunas de guestManagedColumns ficam fora do Save: o valor lido no início do request
+// sobrescreveria um check-in, descadastro ou RSVP gravado no meio tempo
+func (r *GuestRepository) Update(guest *models.Guest) error {
+	return r.db.Omit(guestManagedColumns...).Save(guest).Error
+}
+
+// Delete remove (soft delete) um convidado com seus acompanhantes e decrementa os contadores do casamento
+// Concorrência: A condição no DELETE garante que remoções simultâneas decrementem uma única vez;
+// a condição no status garante que convidados sem vaga (lista de espera, recusados) não decrementem
+func (r *GuestRepository) Delete(guest *models.Guest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		counted := tx.Where("invite_status NOT IN ?", models.SeatlessStatuses).Delete(&models.Guest{}, guest.ID)
+		if counted.Error != nil {
+			return counted.Error
+		}
+		if counted.RowsAffected == 0 {
+			waitlisted := tx.Delete(&models.Guest{}, guest.ID)
+			if waitlisted.Error != nil {
+				return waitlisted.Error
+			}
+			if waitlisted.RowsAffected == 0 {
+				return errors.New("guest not found")
+			}
+		}
+		if err := deleteCompanionsByGuestID(tx, guest.ID, guest.WeddingID); err != nil {
+			return err
+		}
+		if counted.RowsAffected == 0 {
+			return nil
+		}
+		return NewWeddingRepository(tx).IncrementGuestCount(guest.WeddingID, -1)
+	})
+}
+
+// MarkOptedOut registra o opt-out do convidado (idempotente, mantém a data original)
+func (r *GuestRepository) MarkOptedOut(guestID uint, at time.Time) error {
+	return r.db.Model(&models.Guest{}).
+		Where("id = ? AND opted_out_at IS NULL", guestID).
+		Update("opted_out_at", at).Error
+}
+
+// CreateMany insere vários convidados ocupando as vagas do casamento na mesma transação
+// Retorna ErrWeddingFull se o lote não couber inteiro (nenhum convidado é criado)
+// Concorrência: A reserva bloqueia a linha do casamento, evitando ultrapassar MaxGuests
+func (r *GuestRepository) CreateMany(weddingID uint, guests []models.Guest, actor models.StatusActor) error {
+	if len(guests) == 0 {
+		return nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := NewWeddingRepository(tx).ReserveGuestCapacity(weddingID, len(guests)); err != nil {
+			return err
+		}
+		if err := tx.Omit("Wedding").CreateInBatches(&guests, 100).Error; err != nil {
+			return err
+		}
+		history := make([]models.GuestStatusHistory, len(guests))
+		for i := range guests {
+			history[i] = models.NewGuestStatusHistory(&guests[i], "", actor)
+		}
+		return tx.CreateInBatches(&history, 100).Error
+	})
+}
+
+// ImportMany cria os convidados importados e as etiquetas de origem na mesma transação
+// sourceTags[i] são as etiquetas de guests[i] no casamento de origem; retorna quantas etiquetas foram criadas
+func (r *GuestRepository) ImportMany(weddingID uint, guests []models.Guest, sourceTags [][]models.GuestTag, actor models.StatusActor) (int, error) {
+	tagsCreated := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := NewGuestRepository(tx).CreateMany(weddingID, guests, actor); err != nil {
+			return err
+		}
+		created, err := NewGuestTagRepository(tx).CopyAssignments(weddingID, guests, sourceTags)
+		tagsCreated = created
+		return err
+	})
+	return tagsCreated, err
+}
+
+// UpdateAddress grava apenas o endereço postal do convidado (formulário público do convite)
+// Concorrência: Não sobrescreve alterações do casal em outros campos feitas em paralelo
+func (r *GuestRepository) UpdateAddress(guest *models.Guest) error {
+	return r.db.Model(guest).
+		Select("address_line1", "address_line2", "address_city", "address_state", "address_postal_code", "address_country", "address_updated_at").
+		Updates(guest).Error
+}
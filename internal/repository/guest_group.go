@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// GuestGroupRepository encapsula as operações de banco de dados para grupos de convidados
+type GuestGroupRepository struct {
+	db *gorm.DB
+}
+
+// NewGuestGroupRepository cria uma nova instância do GuestGroupRepository
+func NewGuestGroupRepository(db *gorm.DB) *GuestGroupRepository {
+	return &GuestGroupRepository{db: db}
+}
+
+// FindByWeddingID lista os grupos de um casamento ordenados por nome
+func (r *GuestGroupRepository) FindByWeddingID(weddingID uint) ([]models.GuestGroup, error) {
+	var groups []models.GuestGroup
+	err := r.db.Where("wedding_id = ?", weddingID).
+		Order("name ASC").
+		Find(&groups).Error
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// FindByIDAndWeddingID busca um grupo de um casamento
+// Segurança: Garante que o grupo pertence ao casamento já validado
+func (r *GuestGroupRepository) FindByIDAndWeddingID(id, weddingID uint) (*models.GuestGroup, error) {
+	var group models.GuestGroup
+	err := r.db.Where("id = ? AND wedding_id = ?", id, weddingID).First(&group).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("guest group not found")
+		}
+		return nil, err
+	}
+	return &group, nil
+}
+
+// Create insere um grupo
+func (r *GuestGroupRepository) Create(group *models.GuestGroup) error {
+	return r.db.Omit("Wedding").Create(group).Error
+}
+
+// Update atualiza os dados de um grupo
+func (r *GuestGroupRepository) Update(group *models.GuestGroup) error {
+	return r.db.Omit("Wedding").Save(group).Error
+}
+
+// Delete remove (soft delete) um grupo e desvincula seus convidados na mesma transação
+// Os convidados continuam cadastrados, apenas sem grupo
+func (r *GuestGroupRepository) Delete(group *models.GuestGroup) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Guest{}).
+			Where("group_id = ? AND wedding_id = ?", group.ID, group.WeddingID).
+			Update("group_id", nil).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&models.GuestGroup{}, group.ID).Error
+	})
+}
+
+// AssignGuests vincula convidados ao grupo e retorna quantos foram atualizados
+// Segurança: A condição em wedding_id impede mover convidados de outro casamento
+func (r *GuestGroupRepository) AssignGuests(group *models.GuestGroup, guestIDs []uint) (int64, error) {
+	result := r.db.Model(&models.Guest{}).
+		Where("id IN ? AND wedding_id = ?", guestIDs, group.WeddingID).
+		Update("group_id", group.ID)
+	return result.RowsAffected, result.Error
+}
+
+// UnassignGuest remove o convidado do grupo
+func (r *GuestGroupRepository) UnassignGuest(group *models.GuestGroup, guestID uint) error {
+	result := r.db.Model(&models.Guest{}).
+		Where("id = ? AND group_id = ? AND wedding_id = ?", guestID, group.ID, group.WeddingID).
+		Update("group_id", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("guest not found in group")
+	}
+	return nil
+}
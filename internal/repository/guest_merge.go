@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrMergeCompanionLimit indica que os acompanhantes somados excedem o limite de um convite
+var ErrMergeCompanionLimit = errors.New("merged guest would exceed the companion limit")
+
+// Limite de pessoas por convite (mesmo de models.Guest.IsValid)
+const maxGuestsPerInvite = 20
+
+// Merge incorpora o convidado duplicado no principal e remove (soft delete) o duplicado
+// Acompanhantes, convites e etiquetas passam para o principal; respostas do RSVP do principal
+// prevalecem e as do duplicado só preenchem perguntas ainda sem resposta
+// Se o status do principal mudar com o merge, a transição fica registrada no histórico
+// Concorrência: Os dois convidados ficam bloqueados (FOR UPDATE) durante toda a transação
+func (r *GuestRepository) Merge(primary, duplicate *models.Guest, actor models.StatusActor) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var locked []models.Guest
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND wedding_id = ?", []uint{primary.ID, duplicate.ID}, primary.WeddingID).
+			Order("id").
+			Find(&locked).Error
+		if err != nil {
+			return err
+		}
+		if len(locked) != 2 {
+			return errors.New("guest not found")
+		}
+		for i := range locked {
+			if locked[i].ID == primary.ID {
+				*primary = locked[i]
+			} else {
+				*duplicate = locked[i]
+			}
+		}
+
+		var companions int64
+		if err := tx.Model(&models.Companion{}).Where("guest_id IN ?", []uint{primary.ID, duplicate.ID}).Count(&companions).Error; err != nil {
+			return err
+		}
+		if companions+1 > maxGuestsPerInvite {
+			return ErrMergeCompanionLimit
+		}
+
+		// Vagas ocupadas antes do merge (convidados na lista de espera não ocupam vaga)
+		countedBefore := 0
+		for _, g := range []*models.Guest{primary, duplicate} {
+			if g.CountsTowardCapacity() {
+				countedBefore++
+			}
+		}
+
+		previousStatus := primary.InviteStatus
+		primary.MergeFrom(duplicate)
+		if int64(primary.MaxGuests) < companions+1 {
+			primary.MaxGuests = int(companions) + 1
+		}
+
+		if err := tx.Model(&models.Companion{}).Where("guest_id = ?", duplicate.ID).UpdateColumn("guest_id", primary.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Invite{}).Where("guest_id = ?", duplicate.ID).UpdateColumn("guest_id", primary.ID).Error; err != nil {
+			return err
+		}
+		if err := mergeTagAssignments(tx, primary, duplicate.ID); err != nil {
+			return err
+		}
+		if err := mergeRSVPAnswers(tx, primary.ID, duplicate.ID); err != nil {
+			return err
+		}
+
+		if err := tx.Omit("Wedding").Save(primary).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Guest{}, duplicate.ID).Error; err != nil {
+			return err
+		}
+		if primary.InviteStatus != previousStatus {
+			if err := recordStatusChange(tx, primary, previousStatus, actor); err != nil {
+				return err
+			}
+		}
+
+		countedAfter := 0
+		if primary.CountsTowardCapacity() {
+			countedAfter = 1
+		}
+		if countedAfter == countedBefore {
+			return nil
+		}
+		return NewWeddingRepository(tx).IncrementGuestCount(primary.WeddingID, countedAfter-countedBefore)
+	})
+}
+
+// mergeTagAssignments copia as etiquetas do duplicado para o principal (sem duplicar vínculos)
+func mergeTagAssignments(tx *gorm.DB, primary *models.Guest, duplicateID uint) error {
+	var tagIDs []uint
+	if err := tx.Model(&models.GuestTagAssignment{}).Where("guest_id = ?", duplicateID).Pluck("tag_id", &tagIDs).Error; err != nil {
+		return err
+	}
+	if len(tagIDs) > 0 {
+		assignments := make([]models.GuestTagAssignment, len(tagIDs))
+		for i, tagID := range tagIDs {
+			assignments[i] = models.GuestTagAssignment{WeddingID: primary.WeddingID, GuestID: primary.ID, TagID: tagID}
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&assignments).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Where("guest_id = ?", duplicateID).Delete(&models.GuestTagAssignment{}).Error
+}
+
+// mergeRSVPAnswers move as respostas do duplicado para perguntas que o principal não respondeu
+// As respostas restantes do duplicado são descartadas (o índice único permite uma por pergunta)
+func mergeRSVPAnswers(tx *gorm.DB, primaryID, duplicateID uint) error {
+	var answered []uint
+	if err := tx.Model(&models.RSVPAnswer{}).Where("guest_id = ?", primaryID).Pluck("question_id", &answered).Error; err != nil {
+		return err
+	}
+
+	// MySQL não permite subquery na própria tabela do UPDATE: a lista é carregada antes
+	move := tx.Model(&models.RSVPAnswer{}).Where("guest_id = ?", duplicateID)
+	if len(answered) > 0 {
+		move = move.Where("question_id NOT IN ?", answered)
+	}
+	if err := move.UpdateColumn("guest_id", primaryID).Error; err != nil {
+		return err
+	}
+	return tx.Where("guest_id = ?", duplicateID).Delete(&models.RSVPAnswer{}).Error
+}
@@ -0,0 +1,29 @@
+package repository
+
+import "github.com/matheushermes/wedding_planner_service/internal/models"
+
+// GuestStatusStats agrega os convites de um status
+type GuestStatusStats struct {
+	InviteStatus models.InviteStatus  `json:"invite_status"`
+	Guests       int64                `json:"guests"`
+	Seats        int64                `json:"seats"` // soma de max_guests (convidado + acompanhantes previstos)
+	Children     models.ChildAgeBands `gorm:"embedded;embeddedPrefix:children_" json:"children"`
+}
+
+// GuestStatsByWeddingID agrega convites, lugares e crianças por faixa etária em cada status
+// Performance: Uma agregação usando o índice composto (wedding_id, invite_status)
+func (r *GuestRepository) GuestStatsByWeddingID(weddingID uint) ([]GuestStatusStats, error) {
+	var stats []GuestStatusStats
+	err := r.db.Model(&models.Guest{}).
+		Select("invite_status, COUNT(*) AS guests, COALESCE(SUM(max_guests), 0) AS seats, "+
+			"COALESCE(SUM(children_age0_to3), 0) AS children_age0_to3, "+
+			"COALESCE(SUM(children_age4_to10), 0) AS children_age4_to10, "+
+			"COALESCE(SUM(children_age11_plus), 0) AS children_age11_plus").
+		Where("wedding_id = ?", weddingID).
+		Group("invite_status").
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// StatusHistoryEntry é uma transição do histórico com o nome de quem fez a mudança
+type StatusHistoryEntry struct {
+	models.GuestStatusHistory
+	ChangedByName string
+}
+
+// recordStatusChange registra a transição de status do convidado na transação em andamento
+func recordStatusChange(tx *gorm.DB, guest *models.Guest, from models.InviteStatus, actor models.StatusActor) error {
+	entry := models.NewGuestStatusHistory(guest, from, actor)
+	return tx.Create(&entry).Error
+}
+
+// FindStatusHistory lista as transições de status do convidado em ordem cronológica
+// O nome do autor vem da conta atual; contas removidas (LGPD) aparecem sem nome
+// Performance: Usa o índice composto (guest_id, created_at)
+func (r *GuestRepository) FindStatusHistory(guestID, weddingID uint) ([]StatusHistoryEntry, error) {
+	var entries []StatusHistoryEntry
+	err := r.db.Model(&models.GuestStatusHistory{}).
+		Select("guest_status_histories.*, COALESCE(users.name, '') AS changed_by_name").
+		Joins("LEFT JOIN users ON users.id = guest_status_histories.changed_by_user_id").
+		Where("guest_status_histories.guest_id = ? AND guest_status_histories.wedding_id = ?", guestID, weddingID).
+		Order("guest_status_histories.created_at ASC, guest_status_histories.id ASC").
+		Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrDuplicateTagName indica que o casamento já possui uma etiqueta com o mesmo nome
+var ErrDuplicateTagName = errors.New("tag name already exists")
+
+// GuestTagRepository encapsula as operações de banco de dados para etiquetas de convidados
+type GuestTagRepository struct {
+	db *gorm.DB
+}
+
+// NewGuestTagRepository cria uma nova instância do GuestTagRepository
+func NewGuestTagRepository(db *gorm.DB) *GuestTagRepository {
+	return &GuestTagRepository{db: db}
+}
+
+// GuestTagWithCount é uma etiqueta com o número de convidados vinculados
+type GuestTagWithCount struct {
+	models.GuestTag
+	GuestCount int64
+}
+
+// FindByWeddingID lista as etiquetas de um casamento com a contagem de convidados
+// Performance: Contagem em uma única query agregada (sem N+1)
+func (r *GuestTagRepository) FindByWeddingID(weddingID uint) ([]GuestTagWithCount, error) {
+	var tags []GuestTagWithCount
+	err := r.db.Model(&models.GuestTag{}).
+		Select("guest_tags.*, COUNT(guests.id) AS guest_count").
+		Joins("LEFT JOIN guest_tag_assignments ON guest_tag_assignments.tag_id = guest_tags.id").
+		Joins("LEFT JOIN guests ON guests.id = guest_tag_assignments.guest_id AND guests.deleted_at IS NULL").
+		Where("guest_tags.wedding_id = ?", weddingID).
+		Group("guest_tags.id").
+		Order("guest_tags.name ASC").
+		Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// FindByIDAndWeddingID busca uma etiqueta de um casamento
+// Segurança: Garante que a etiqueta pertence ao casamento já validado
+func (r *GuestTagRepository) FindByIDAndWeddingID(id, weddingID uint) (*models.GuestTag, error) {
+	var tag models.GuestTag
+	err := r.db.Where("id = ? AND wedding_id = ?", id, weddingID).First(&tag).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("guest tag not found")
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// Create insere uma etiqueta
+func (r *GuestTagRepository) Create(tag *models.GuestTag) error {
+	if err := r.checkDuplicateName(tag); err != nil {
+		return err
+	}
+	return r.db.Omit("Wedding").Create(tag).Error
+}
+
+// Update atualiza os dados de uma etiqueta
+func (r *GuestTagRepository) Update(tag *models.GuestTag) error {
+	if err := r.checkDuplicateName(tag); err != nil {
+		return err
+	}
+	return r.db.Omit("Wedding").Save(tag).Error
+}
+
+// checkDuplicateName impede duas etiquetas com o mesmo nome no casamento (sem diferenciar maiúsculas)
+// Verificação em código: um índice único conflitaria com etiquetas removidas (soft delete)
+func (r *GuestTagRepository) checkDuplicateName(tag *models.GuestTag) error {
+	var count int64
+	err := r.db.Model(&models.GuestTag{}).
+		Where("wedding_id = ? AND LOWER(name) = ? AND id <> ?", tag.WeddingID, strings.ToLower(tag.Name), tag.ID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrDuplicateTagName
+	}
+	return nil
+}
+
+// Delete remove (soft delete) uma etiqueta e seus vínculos na mesma transação
+func (r *GuestTagRepository) Delete(tag *models.GuestTag) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("tag_id = ? AND wedding_id = ?", tag.ID, tag.WeddingID).
+			Delete(&models.GuestTagAssignment{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&models.GuestTag{}, tag.ID).Error
+	})
+}
+
+// AssignGuests vincula convidados à etiqueta e retorna quantos vínculos novos foram criados
+// Convidados já etiquetados são ignorados (idempotente)
+// Segurança: Apenas IDs de convidados do mesmo casamento são vinculados
+func (r *GuestTagRepository) AssignGuests(tag *models.GuestTag, guestIDs []uint) (int64, error) {
+	var ownedIDs []uint
+	err := r.db.Model(&models.Guest{}).
+		Where("id IN ? AND wedding_id = ?", guestIDs, tag.WeddingID).
+		Pluck("id", &ownedIDs).Error
+	if err != nil {
+		return 0, err
+	}
+	if len(ownedIDs) == 0 {
+		return 0, nil
+	}
+
+	assignments := make([]models.GuestTagAssignment, len(ownedIDs))
+	for i, guestID := range ownedIDs {
+		assignments[i] = models.GuestTagAssignment{
+			WeddingID: tag.WeddingID,
+			GuestID:   guestID,
+			TagID:     tag.ID,
+		}
+	}
+
+	result := r.db.Omit("Guest", "Tag").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&assignments)
+	return result.RowsAffected, result.Error
+}
+
+// UnassignGuest remove a etiqueta do convidado
+func (r *GuestTagRepository) UnassignGuest(tag *models.GuestTag, guestID uint) error {
+	result := r.db.
+		Where("tag_id = ? AND guest_id = ? AND wedding_id = ?", tag.ID, guestID, tag.WeddingID).
+		Delete(&models.GuestTagAssignment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("guest not found in tag")
+	}
+	return nil
+}
+
+// TagIDsByGuestIDs retorna as etiquetas de cada convidado
+// Performance: Uma única query para a página inteira da listagem
+func (r *GuestTagRepository) TagIDsByGuestIDs(weddingID uint, guestIDs []uint) (map[uint][]uint, error) {
+	result := make(map[uint][]uint, len(guestIDs))
+	if len(guestIDs) == 0 {
+		return result, nil
+	}
+
+	var assignments []models.GuestTagAssignment
+	err := r.db.Select("guest_id", "tag_id").
+		Where("wedding_id = ? AND guest_id IN ?", weddingID, guestIDs).
+		Order("tag_id ASC").
+		Find(&assignments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range assignments {
+		result[a.GuestID] = append(result[a.GuestID], a.TagID)
+	}
+	return result, nil
+}
+
+// CopyAssignments recria no casamento de destino as etiquetas de convidados importados
+// sourceTags[i] são as etiquetas de origem de guests[i] (convidados já criados, com ID)
+// Etiquetas com o mesmo nome (sem diferenciar maiúsculas) são reaproveitadas; as demais são criadas
+// com o nome e a cor da origem. Retorna quantas etiquetas foram criadas
+func (r *GuestTagRepository) CopyAssignments(weddingID uint, guests []models.Guest, sourceTags [][]models.GuestTag) (int, error) {
+	var existing []models.GuestTag
+	if err := r.db.Where("wedding_id = ?", weddingID).Find(&existing).Error; err != nil {
+		return 0, err
+	}
+	byName := make(map[string]uint, len(existing))
+	for _, tag := range existing {
+		byName[strings.ToLower(tag.Name)] = tag.ID
+	}
+
+	created := 0
+	var assignments []models.GuestTagAssignment
+	for i := range guests {
+		for _, source := range sourceTags[i] {
+			key := strings.ToLower(source.Name)
+			tagID, ok := byName[key]
+			if !ok {
+				tag := models.GuestTag{WeddingID: weddingID, Name: source.Name, Color: source.Color}
+				if err := r.db.Omit("Wedding").Create(&tag).Error; err != nil {
+					return 0, err
+				}
+				tagID = tag.ID
+				byName[key] = tagID
+				created++
+			}
+			assignments = append(assignments, models.GuestTagAssignment{
+				WeddingID: weddingID,
+				GuestID:   guests[i].ID,
+				TagID:     tagID,
+			})
+		}
+	}
+	if len(assignments) == 0 {
+		return created, nil
+	}
+
+	err := r.db.Omit("Guest", "Tag").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(&assignments, 100).Error
+	return created, err
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FindDeletedByWeddingID lista os convidados removidos (lixeira), os mais recentes primeiro
+func (r *GuestRepository) FindDeletedByWeddingID(weddingID uint) ([]models.Guest, error) {
+	var guests []models.Guest
+	err := r.db.Unscoped().
+		Where("wedding_id = ? AND deleted_at IS NOT NULL", weddingID).
+		Order("deleted_at DESC, id DESC").
+		Find(&guests).Error
+	if err != nil {
+		return nil, err
+	}
+	return guests, nil
+}
+
+// FindDeletedByIDAndWeddingID busca um convidado da lixeira do casamento
+func (r *GuestRepository) FindDeletedByIDAndWeddingID(id, weddingID uint) (*models.Guest, error) {
+	var guest models.Guest
+	err := r.db.Unscoped().
+		Where("id = ? AND wedding_id = ? AND deleted_at IS NOT NULL", id, weddingID).
+		First(&guest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("guest not found")
+		}
+		return nil, err
+	}
+	return &guest, nil
+}
+
+// Restore tira o convidado da lixeira junto com os acompanhantes removidos com ele
+// Retorna ErrWeddingFull se o convidado ocupava vaga e o casamento já está lotado
+// Concorrência: A vaga é reservada com a linha do casamento bloqueada e a condição no
+// deleted_at garante que restaurações simultâneas devolvam o convidado uma única vez
+func (r *GuestRepository) Restore(guest *models.Guest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if guest.CountsTowardCapacity() {
+			if err := NewWeddingRepository(tx).ReserveGuestCapacity(guest.WeddingID, 1); err != nil {
+				return err
+			}
+		}
+
+		deletedAt := guest.DeletedAt.Time
+		restored := tx.Unscoped().Model(&models.Guest{}).
+			Where("id = ? AND deleted_at IS NOT NULL", guest.ID).
+			Update("deleted_at", nil)
+		if restored.Error != nil {
+			return restored.Error
+		}
+		if restored.RowsAffected == 0 {
+			return errors.New("guest not found")
+		}
+		guest.DeletedAt = gorm.DeletedAt{}
+
+		// Acompanhantes removidos antes do convidado continuam na lixeira
+		var confirmed int64
+		err := tx.Unscoped().Model(&models.Companion{}).
+			Where("guest_id = ? AND deleted_at >= ? AND confirmed = ?", guest.ID, deletedAt, true).
+			Count(&confirmed).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Unscoped().Model(&models.Companion{}).
+			Where("guest_id = ? AND deleted_at >= ?", guest.ID, deletedAt).
+			Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		if confirmed == 0 {
+			return nil
+		}
+		return NewWeddingRepository(tx).IncrementConfirmedCompanionCount(guest.WeddingID, int(confirmed))
+	})
+}
+
+// Purge apaga definitivamente convidados da lixeira com acompanhantes, convites, respostas,
+// etiquetas e histórico de status
+// LGPD: atende pedidos de exclusão dos dados pessoais do convidado
+// Convidados ativos nunca são apagados: passam pela lixeira antes (DELETE /guests/:guestId)
+func (r *GuestRepository) Purge(weddingID uint, guestIDs []uint) (int64, error) {
+	if len(guestIDs) == 0 {
+		return 0, nil
+	}
+
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		err := tx.Unscoped().Model(&models.Guest{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND wedding_id = ? AND deleted_at IS NOT NULL", guestIDs, weddingID).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		dependents := []any{
+			&models.Companion{},
+			&models.Invite{},
+			&models.RSVPAnswer{},
+			&models.GuestTagAssignment{},
+			&models.GuestStatusHistory{},
+		}
+		for _, model := range dependents {
+			if err := tx.Unscoped().Where("guest_id IN ?", ids).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&models.Guest{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// InviteRepository encapsula as operações de banco de dados para os convites enviados
+type InviteRepository struct {
+	db *gorm.DB
+}
+
+// NewInviteRepository cria uma nova instância do InviteRepository
+func NewInviteRepository(db *gorm.DB) *InviteRepository {
+	return &InviteRepository{db: db}
+}
+
+// FindByIDAndWeddingID busca um convite do casamento com o convidado
+func (r *InviteRepository) FindByIDAndWeddingID(id, weddingID uint) (*models.Invite, error) {
+	var invite models.Invite
+	err := r.db.Preload("Guest").
+		Where("id = ? AND wedding_id = ?", id, weddingID).
+		First(&invite).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invite not found")
+		}
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// FindByID busca um convite pelo ID, sem filtrar pelo casamento
+// Segurança: usado apenas pelos webhooks de entrega, que chegam com o ID assinado
+func (r *InviteRepository) FindByID(id uint) (*models.Invite, error) {
+	var invite models.Invite
+	if err := r.db.First(&invite, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invite not found")
+		}
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// FindByWeddingID lista os convites do casamento com o convidado (mais recentes primeiro)
+// deliveryStatus vazio não filtra
+func (r *InviteRepository) FindByWeddingID(weddingID uint, deliveryStatus models.InviteDeliveryStatus, page, perPage int) ([]models.Invite, int64, error) {
+	query := r.db.Model(&models.Invite{}).Where("wedding_id = ?", weddingID)
+	if deliveryStatus != "" {
+		query = query.Where("delivery_status = ?", deliveryStatus)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var invites []models.Invite
+	err := query.Preload("Guest").
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * perPage).
+		Limit(perPage).
+		Find(&invites).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return invites, total, nil
+}
+
+// InviteEngagement resume os convites por email enviados a um convidado
+type InviteEngagement struct {
+	GuestID    uint
+	FullName   string
+	Invites    int64
+	LastSentAt time.Time
+	OpenedAt   *time.Time // primeira abertura entre os convites do convidado
+	ClickedAt  *time.Time
+}
+
+// EngagementByWeddingID agrega por convidado os convites por email já enviados do casamento
+// Performance: uma única query agregada (sem N+1)
+func (r *InviteRepository) EngagementByWeddingID(weddingID uint) ([]InviteEngagement, error) {
+	var rows []InviteEngagement
+	err := r.db.Model(&models.Invite{}).
+		Select("invites.guest_id, guests.full_name, COUNT(invites.id) AS invites, MAX(invites.sent_at) AS last_sent_at, "+
+			"MIN(invites.opened_at) AS opened_at, MIN(invites.clicked_at) AS clicked_at").
+		Joins("JOIN guests ON guests.id = invites.guest_id AND guests.deleted_at IS NULL").
+		Where("invites.wedding_id = ? AND invites.sent_via = ? AND invites.sent_at IS NOT NULL", weddingID, models.InviteViaEmail).
+		Group("invites.guest_id, guests.full_name").
+		Order("guests.full_name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// RecordOpened registra a primeira abertura do convite
+// Concorrência: A condição em opened_at mantém o horário da primeira abertura
+func (r *InviteRepository) RecordOpened(id uint, at time.Time) error {
+	return r.db.Model(&models.Invite{}).
+		Where("id = ? AND opened_at IS NULL", id).
+		UpdateColumn("opened_at", at).Error
+}
+
+// RecordClicked registra o primeiro clique no link de RSVP do convite
+// O clique também conta como abertura: clientes que bloqueiam imagens não carregam o pixel
+func (r *InviteRepository) RecordClicked(id uint, at time.Time) error {
+	return r.db.Model(&models.Invite{}).
+		Where("id = ? AND clicked_at IS NULL", id).
+		UpdateColumns(map[string]interface{}{
+			"clicked_at": at,
+			"opened_at":  gorm.Expr("COALESCE(opened_at, ?)", at),
+		}).Error
+}
+
+// SentGuestIDsSince retorna os convidados do casamento com convite enviado a partir de since
+// Usado para não reenviar na reexecução de um envio em massa interrompido
+func (r *InviteRepository) SentGuestIDsSince(weddingID uint, since time.Time) (map[uint]bool, error) {
+	var ids []uint
+	err := r.db.Model(&models.Invite{}).
+		Where("wedding_id = ? AND sent_at >= ?", weddingID, since).
+		Distinct().
+		Pluck("guest_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sent := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		sent[id] = true
+	}
+	return sent, nil
+}
+
+// Queue registra o convite como na fila antes de chamar o provedor
+// Convites novos são criados; convites já cadastrados (reenvio) voltam para queued
+func (r *InviteRepository) Queue(guest *models.Guest, invite *models.Invite) error {
+	now := time.Now()
+	invite.GuestID = guest.ID
+	invite.WeddingID = guest.WeddingID
+	invite.DeliveryStatus = models.InviteDeliveryQueued
+	invite.DeliveryError = ""
+	invite.DeliveryUpdatedAt = &now
+	if invite.ID == 0 {
+		return r.db.Omit("Guest", "Wedding").Create(invite).Error
+	}
+	return r.db.Model(invite).
+		Select("sent_via", "delivery_status", "delivery_error", "delivery_updated_at").
+		Updates(invite).Error
+}
+
+// UpdateDelivery avança a entrega do convite para o status do evento e registra a transição na auditoria
+// Só aplica a partir dos status anteriores permitidos: eventos repetidos ou fora de ordem são ignorados
+// (sem registro na auditoria) e updated retorna false
+func (r *InviteRepository) UpdateDelivery(id uint, event models.InviteDelivery) (bool, error) {
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Invite{}).
+			Where("id = ? AND delivery_status IN ?", id, event.Status.PreviousDeliveryStatuses()).
+			Updates(map[string]interface{}{
+				"delivery_status":     event.Status,
+				"delivery_error":      event.Reason,
+				"delivery_updated_at": event.OccurredAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		updated = true
+
+		var invite models.Invite
+		if err := tx.Select("id", "wedding_id", "sent_via").First(&invite, id).Error; err != nil {
+			return err
+		}
+		event.InviteID = invite.ID
+		event.WeddingID = invite.WeddingID
+		if event.Channel == "" {
+			event.Channel = invite.SentVia
+		}
+		return recordDelivery(tx, &event)
+	})
+	return updated, err
+}
+
+// RecordSent registra o envio de um convite na fila (Queue) e move o convidado de pendente para enviado
+// A tentativa (delivery, com o conteúdo e o ID da mensagem no provedor) entra na auditoria na mesma transação
+// A entrega passa para sent apenas se ainda estiver na fila: o webhook do provedor pode chegar antes
+// Convidados que já responderam mantêm o status (reenvio do convite)
+func (r *InviteRepository) RecordSent(guest *models.Guest, invite *models.Invite, delivery *models.InviteDelivery, actor models.StatusActor) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := recordDelivery(tx, delivery); err != nil {
+			return err
+		}
+
+		// sent_via muda quando o convite saiu pelo fallback (SMS no lugar do WhatsApp)
+		err := tx.Model(invite).
+			Select("sent_at", "sent_via").
+			Updates(invite).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		delivery := tx.Model(&models.Invite{}).
+			Where("id = ? AND delivery_status = ?", invite.ID, models.InviteDeliveryQueued).
+			Updates(map[string]interface{}{
+				"delivery_status":     models.InviteDeliverySent,
+				"delivery_updated_at": now,
+			})
+		if delivery.Error != nil {
+			return delivery.Error
+		}
+		if delivery.RowsAffected > 0 {
+			invite.DeliveryStatus = models.InviteDeliverySent
+			invite.DeliveryUpdatedAt = &now
+		}
+
+		result := tx.Model(&models.Guest{}).
+			Where("id = ? AND invite_status = ?", guest.ID, models.InviteStatusPending).
+			Update("invite_status", models.InviteStatusSent)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		guest.InviteStatus = models.InviteStatusSent
+		return recordStatusChange(tx, guest, models.InviteStatusPending, actor)
+	})
+}
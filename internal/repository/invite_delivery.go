@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// RecordDelivery grava um registro da auditoria de envios do convite
+func (r *InviteRepository) RecordDelivery(delivery *models.InviteDelivery) error {
+	return recordDelivery(r.db, delivery)
+}
+
+// RecordFallbackSent registra o convite reenviado por outro canal depois de não ser entregue
+// (SMS no lugar do WhatsApp): sent_via e a entrega mudam e a tentativa entra na auditoria na mesma transação
+// Concorrência: a entrega só volta para sent a partir de bounced ou failed
+func (r *InviteRepository) RecordFallbackSent(invite *models.Invite, delivery *models.InviteDelivery) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.Invite{}).
+			Where("id = ? AND delivery_status IN ?", invite.ID, []models.InviteDeliveryStatus{models.InviteDeliveryBounced, models.InviteDeliveryFailed}).
+			Updates(map[string]interface{}{
+				"sent_via":            delivery.Channel,
+				"delivery_status":     models.InviteDeliverySent,
+				"delivery_error":      "",
+				"delivery_updated_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			invite.SentVia = delivery.Channel
+			invite.DeliveryStatus = models.InviteDeliverySent
+			invite.DeliveryError = ""
+			invite.DeliveryUpdatedAt = &now
+		}
+		delivery.OccurredAt = now
+		return recordDelivery(tx, delivery)
+	})
+}
+
+// FindDeliveries lista a auditoria de envios do convite em ordem cronológica
+// Performance: Usa o índice composto (invite_id, created_at)
+func (r *InviteRepository) FindDeliveries(inviteID, weddingID uint) ([]models.InviteDelivery, error) {
+	var deliveries []models.InviteDelivery
+	err := r.db.Where("invite_id = ? AND wedding_id = ?", inviteID, weddingID).
+		Order("created_at ASC, id ASC").
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// recordDelivery grava o registro na transação de quem chama
+func recordDelivery(tx *gorm.DB, delivery *models.InviteDelivery) error {
+	if delivery.OccurredAt.IsZero() {
+		delivery.OccurredAt = time.Now()
+	}
+	return tx.Create(delivery).Error
+}
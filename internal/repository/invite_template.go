@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// InviteTemplateRepository encapsula as operações de banco de dados para os templates de mensagem
+type InviteTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewInviteTemplateRepository cria uma nova instância do InviteTemplateRepository
+func NewInviteTemplateRepository(db *gorm.DB) *InviteTemplateRepository {
+	return &InviteTemplateRepository{db: db}
+}
+
+// FindByWeddingID lista os templates de um casamento ordenados por nome
+func (r *InviteTemplateRepository) FindByWeddingID(weddingID uint) ([]models.InviteTemplate, error) {
+	var templates []models.InviteTemplate
+	err := r.db.Where("wedding_id = ?", weddingID).
+		Order("name ASC").
+		Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// FindByIDAndWeddingID busca um template de um casamento
+// Segurança: Garante que o template pertence ao casamento já validado
+func (r *InviteTemplateRepository) FindByIDAndWeddingID(id, weddingID uint) (*models.InviteTemplate, error) {
+	var template models.InviteTemplate
+	err := r.db.Where("id = ? AND wedding_id = ?", id, weddingID).First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invite template not found")
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+// CountByWeddingID conta os templates de um casamento
+func (r *InviteTemplateRepository) CountByWeddingID(weddingID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.InviteTemplate{}).Where("wedding_id = ?", weddingID).Count(&count).Error
+	return count, err
+}
+
+// Create insere um template
+func (r *InviteTemplateRepository) Create(template *models.InviteTemplate) error {
+	return r.db.Omit("Wedding").Create(template).Error
+}
+
+// Update atualiza os dados de um template
+func (r *InviteTemplateRepository) Update(template *models.InviteTemplate) error {
+	return r.db.Omit("Wedding").Save(template).Error
+}
+
+// Delete remove (soft delete) um template
+func (r *InviteTemplateRepository) Delete(template *models.InviteTemplate) error {
+	return r.db.Delete(&models.InviteTemplate{}, template.ID).Error
+}
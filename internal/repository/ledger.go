@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// LedgerRepository encapsula as operações de banco de dados para o livro-razão
+// Só permite inserções e leituras: lançamentos são imutáveis
+type LedgerRepository struct {
+	db *gorm.DB
+}
+
+// NewLedgerRepository cria uma nova instância do LedgerRepository
+func NewLedgerRepository(db *gorm.DB) *LedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+// Append insere um novo lançamento
+func (r *LedgerRepository) Append(entry *models.LedgerEntry) error {
+	return r.db.Create(entry).Error
+}
+
+// FindByWeddingID lista os lançamentos de um casamento em um período (limites opcionais)
+// Performance: Usa o índice (wedding_id, occurred_at)
+func (r *LedgerRepository) FindByWeddingID(weddingID uint, from, to *time.Time) ([]models.LedgerEntry, error) {
+	query := r.db.Where("wedding_id = ?", weddingID)
+	if from != nil {
+		query = query.Where("occurred_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("occurred_at < ?", *to)
+	}
+
+	var entries []models.LedgerEntry
+	if err := query.Order("occurred_at ASC, id ASC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// LedgerBalance representa o saldo derivado de uma conta do livro-razão
+type LedgerBalance struct {
+	Account models.LedgerAccount `json:"account"`
+	Total   float64              `json:"total"`
+	Entries int64                `json:"entries"`
+}
+
+// BalancesByWeddingID soma os lançamentos de um casamento por conta
+// Performance: Agregação no banco ao invés de carregar todos os lançamentos
+func (r *LedgerRepository) BalancesByWeddingID(weddingID uint) ([]LedgerBalance, error) {
+	var balances []LedgerBalance
+	err := r.db.Model(&models.LedgerEntry{}).
+		Select("account, SUM(amount) AS total, COUNT(*) AS entries").
+		Where("wedding_id = ?", weddingID).
+		Group("account").
+		Order("account ASC").
+		Scan(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// SourceCategories retorna a categoria de origem de cada lançamento do casamento
+// Chave "expense:<id>" -> categoria do gasto e "fundraising:<id>" -> tipo da contribuição
+// Unscoped: registros removidos continuam referenciados pelo livro-razão
+func (r *LedgerRepository) SourceCategories(weddingID uint) (map[string]string, error) {
+	var rows []struct {
+		ID       uint
+		Category string
+	}
+
+	categories := map[string]string{}
+
+	if err := r.db.Unscoped().Model(&models.Expense{}).
+		Select("id, category").
+		Where("wedding_id = ?", weddingID).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		categories[fmt.Sprintf("expense:%d", row.ID)] = row.Category
+	}
+
+	rows = nil
+	if err := r.db.Unscoped().Model(&models.Fundraising{}).
+		Select("id, type AS category").
+		Where("wedding_id = ?", weddingID).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		categories[fmt.Sprintf("fundraising:%d", row.ID)] = row.Category
+	}
+
+	return categories, nil
+}
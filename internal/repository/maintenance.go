@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaintenanceRepository encapsula as operações de banco de dados para os modos de manutenção
+type MaintenanceRepository struct {
+	db *gorm.DB
+}
+
+// NewMaintenanceRepository cria uma nova instância do MaintenanceRepository
+func NewMaintenanceRepository(db *gorm.DB) *MaintenanceRepository {
+	return &MaintenanceRepository{db: db}
+}
+
+// FindAll lista as áreas em manutenção
+func (r *MaintenanceRepository) FindAll() ([]models.MaintenanceFlag, error) {
+	var flags []models.MaintenanceFlag
+	if err := r.db.Order("feature ASC").Find(&flags).Error; err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// Save ativa a manutenção de uma área ou atualiza a mensagem de uma já ativa
+func (r *MaintenanceRepository) Save(flag *models.MaintenanceFlag) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "feature"}},
+		DoUpdates: clause.AssignmentColumns([]string{"message", "retry_after_seconds", "updated_at"}),
+	}).Create(flag).Error
+}
+
+// Delete encerra a manutenção de uma área
+func (r *MaintenanceRepository) Delete(feature models.MaintenanceFeature) error {
+	result := r.db.Where("feature = ?", feature).Delete(&models.MaintenanceFlag{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("maintenance flag not found")
+	}
+	return nil
+}
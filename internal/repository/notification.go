@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// NotificationRepository encapsula as operações de banco de dados para a caixa de entrada dos usuários
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository cria uma nova instância do NotificationRepository
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// FindByUserID lista as notificações do usuário com o comunicado (mais recentes primeiro)
+// Com unreadOnly, apenas as ainda não lidas
+func (r *NotificationRepository) FindByUserID(userID uint, unreadOnly bool, page, perPage int) ([]models.Notification, int64, error) {
+	query := r.db.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []models.Notification
+	err := query.Preload("Announcement").
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * perPage).
+		Limit(perPage).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// CountUnread conta as notificações não lidas do usuário
+func (r *NotificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead marca uma notificação do usuário como lida (mantém a data da primeira leitura)
+func (r *NotificationRepository) MarkRead(id, userID uint, at time.Time) error {
+	var notification models.Notification
+	if err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("notification not found")
+		}
+		return err
+	}
+
+	return r.db.Model(&models.Notification{}).
+		Where("id = ? AND read_at IS NULL", id).
+		UpdateColumn("read_at", at).Error
+}
+
+// MarkAllRead marca como lidas todas as notificações do usuário e retorna quantas foram marcadas
+func (r *NotificationRepository) MarkAllRead(userID uint, at time.Time) (int64, error) {
+	result := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		UpdateColumn("read_at", at)
+	return result.RowsAffected, result.Error
+}
+
+// PendingNotificationEmail é uma notificação com email a enviar, com os dados do destinatário
+type PendingNotificationEmail struct {
+	ID             uint
+	UserID         uint
+	Email          string
+	Name           string
+	AnnouncementID uint
+	Kind           models.AnnouncementKind
+	Title          string
+	Body           string
+}
+
+// FindPendingEmails lista as notificações com email pendente (mais antigas primeiro)
+// Segurança: contas removidas e opt-out feito depois da publicação ficam de fora
+func (r *NotificationRepository) FindPendingEmails(limit int) ([]PendingNotificationEmail, error) {
+	var pending []PendingNotificationEmail
+	err := r.db.Model(&models.Notification{}).
+		Select(`notifications.id, notifications.user_id, users.email, users.name, notifications.announcement_id,
+			announcements.kind, announcements.title, announcements.body`).
+		Joins("JOIN users ON users.id = notifications.user_id AND users.deleted_at IS NULL").
+		Joins("JOIN announcements ON announcements.id = notifications.announcement_id").
+		Where("notifications.email_pending = ? AND users.lifecycle_opt_out_at IS NULL", true).
+		Order("notifications.id ASC").
+		Limit(limit).
+		Scan(&pending).Error
+	return pending, err
+}
+
+// ClaimEmail retira o email da fila antes do envio e retorna false se outra execução já o retirou
+// Concorrência: UPDATE condicional, réplicas nunca enviam o mesmo email em dobro
+func (r *NotificationRepository) ClaimEmail(id uint) (bool, error) {
+	result := r.db.Model(&models.Notification{}).
+		Where("id = ? AND email_pending = ?", id, true).
+		UpdateColumn("email_pending", false)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ReleaseEmail devolve o email à fila após uma falha temporária no envio
+func (r *NotificationRepository) ReleaseEmail(id uint) error {
+	return r.db.Model(&models.Notification{}).
+		Where("id = ?", id).
+		UpdateColumn("email_pending", true).Error
+}
+
+// MarkEmailed registra o envio do email da notificação
+func (r *NotificationRepository) MarkEmailed(id uint, at time.Time) error {
+	return r.db.Model(&models.Notification{}).
+		Where("id = ?", id).
+		UpdateColumn("emailed_at", at).Error
+}
+
+// ClearPendingEmails retira da fila os emails de usuários que pediram opt-out ou removeram a conta
+func (r *NotificationRepository) ClearPendingEmails() (int64, error) {
+	result := r.db.Model(&models.Notification{}).
+		Where("email_pending = ?", true).
+		Where("NOT EXISTS (?)", r.db.Model(&models.User{}).
+			Select("1").
+			Where("users.id = notifications.user_id AND users.lifecycle_opt_out_at IS NULL")).
+		UpdateColumn("email_pending", false)
+	return result.RowsAffected, result.Error
+}
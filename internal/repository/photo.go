@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// PhotoRepository gerencia o portal de fotos e as fotos enviadas pelos convidados
+type PhotoRepository struct {
+	db *gorm.DB
+}
+
+// PhotoCounts resume as fotos do casamento por status de moderação
+type PhotoCounts struct {
+	Pending  int64 `json:"pending"`
+	Approved int64 `json:"approved"`
+	Rejected int64 `json:"rejected"`
+}
+
+// Total retorna o número de fotos enviadas
+func (c PhotoCounts) Total() int64 {
+	return c.Pending + c.Approved + c.Rejected
+}
+
+func NewPhotoRepository(db *gorm.DB) *PhotoRepository {
+	return &PhotoRepository{db}
+}
+
+// FindPortalByWeddingID busca o portal de fotos do casamento
+func (r *PhotoRepository) FindPortalByWeddingID(weddingID uint) (*models.PhotoPortal, error) {
+	var portal models.PhotoPortal
+	if err := r.db.Where("wedding_id = ?", weddingID).First(&portal).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("photo portal not found")
+		}
+		return nil, err
+	}
+	return &portal, nil
+}
+
+// FindPortalByToken busca o portal pelo token do link enviado aos convidados
+func (r *PhotoRepository) FindPortalByToken(token string) (*models.PhotoPortal, error) {
+	var portal models.PhotoPortal
+	if err := r.db.Where("token = ?", token).First(&portal).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("photo portal not found")
+		}
+		return nil, err
+	}
+	return &portal, nil
+}
+
+// SavePortal cria ou atualiza o portal de fotos
+func (r *PhotoRepository) SavePortal(portal *models.PhotoPortal) error {
+	return r.db.Save(portal).Error
+}
+
+// CountByWeddingID conta as fotos do casamento por status
+func (r *PhotoRepository) CountByWeddingID(weddingID uint) (PhotoCounts, error) {
+	var counts PhotoCounts
+	err := r.db.Model(&models.GuestPhoto{}).
+		Select(`COALESCE(SUM(status = ?), 0) AS pending,
+			COALESCE(SUM(status = ?), 0) AS approved,
+			COALESCE(SUM(status = ?), 0) AS rejected`,
+			models.PhotoStatusPending, models.PhotoStatusApproved, models.PhotoStatusRejected).
+		Where("wedding_id = ?", weddingID).
+		Scan(&counts).Error
+	return counts, err
+}
+
+// FindByWeddingID lista as fotos do casamento (mais recentes primeiro), opcionalmente por status
+func (r *PhotoRepository) FindByWeddingID(weddingID uint, status models.PhotoStatus, page, perPage int) ([]models.GuestPhoto, int64, error) {
+	query := r.db.Model(&models.GuestPhoto{}).Where("wedding_id = ?", weddingID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	// Session: reaproveita os filtros na contagem e na busca sem compartilhar o statement
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var photos []models.GuestPhoto
+	err := query.
+		Order("created_at DESC").Order("id DESC").
+		Offset((page - 1) * perPage).
+		Limit(perPage).
+		Find(&photos).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return photos, total, nil
+}
+
+// FindByIDAndWeddingID busca uma foto garantindo que pertence ao casamento
+func (r *PhotoRepository) FindByIDAndWeddingID(id, weddingID uint) (*models.GuestPhoto, error) {
+	var photo models.GuestPhoto
+	if err := r.db.Where("id = ? AND wedding_id = ?", id, weddingID).First(&photo).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("photo not found")
+		}
+		return nil, err
+	}
+	return &photo, nil
+}
+
+// FindByFileNameAndWeddingID busca uma foto pelo nome do arquivo (galeria pública)
+func (r *PhotoRepository) FindByFileNameAndWeddingID(fileName string, weddingID uint) (*models.GuestPhoto, error) {
+	var photo models.GuestPhoto
+	if err := r.db.Where("file_name = ? AND wedding_id = ?", fileName, weddingID).First(&photo).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("photo not found")
+		}
+		return nil, err
+	}
+	return &photo, nil
+}
+
+// Create registra uma foto enviada
+func (r *PhotoRepository) Create(photo *models.GuestPhoto) error {
+	return r.db.Create(photo).Error
+}
+
+// UpdateStatus registra a moderação de uma foto
+func (r *PhotoRepository) UpdateStatus(photo *models.GuestPhoto, status models.PhotoStatus, at time.Time) error {
+	err := r.db.Model(photo).Updates(map[string]interface{}{
+		"status":       status,
+		"moderated_at": at,
+	}).Error
+	if err != nil {
+		return err
+	}
+	photo.Status = status
+	photo.ModeratedAt = &at
+	return nil
+}
+
+// Delete remove o registro da foto (o arquivo é removido pelo chamador)
+func (r *PhotoRepository) Delete(photo *models.GuestPhoto) error {
+	return r.db.Delete(photo).Error
+}
+
+// RequestArchive enfileira a geração do ZIP e indica se o pedido é novo
+// Concorrência: Um pedido já pendente não é duplicado
+func (r *PhotoRepository) RequestArchive(portal *models.PhotoPortal, at time.Time) (bool, error) {
+	result := r.db.Model(&models.PhotoPortal{}).
+		Where("id = ? AND (archive_status IS NULL OR archive_status <> ?)", portal.ID, models.ArchiveStatusPending).
+		Updates(map[string]interface{}{
+			"archive_status":       models.ArchiveStatusPending,
+			"archive_requested_at": at,
+			"archive_error":        "",
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	portal.ArchiveStatus = models.ArchiveStatusPending
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	portal.ArchiveRequestedAt = &at
+	portal.ArchiveError = ""
+	return true, nil
+}
+
+// SaveArchiveResult grava apenas o resultado da geração do ZIP
+// Concorrência: Não sobrescreve alterações do casal no portal feitas durante a geração
+func (r *PhotoRepository) SaveArchiveResult(portal *models.PhotoPortal) error {
+	return r.db.Model(portal).
+		Select("archive_status", "archive_file", "archive_photos", "archive_ready_at", "archive_error").
+		Updates(portal).Error
+}
+
+// FindPendingArchives lista os portais com ZIP aguardando geração (mais antigos primeiro)
+func (r *PhotoRepository) FindPendingArchives(limit int) ([]models.PhotoPortal, error) {
+	var portals []models.PhotoPortal
+	err := r.db.Where("archive_status = ?", models.ArchiveStatusPending).
+		Order("archive_requested_at ASC").
+		Limit(limit).
+		Find(&portals).Error
+	return portals, err
+}
+
+// FindApprovedInBatches percorre as fotos aprovadas do casamento em lotes (ordem de ID)
+// Performance: Evita carregar todas as fotos de uma vez ao montar o ZIP
+func (r *PhotoRepository) FindApprovedInBatches(weddingID uint, batchSize int, fn func([]models.GuestPhoto) error) error {
+	var batch []models.GuestPhoto
+	return r.db.Where("wedding_id = ? AND status = ?", weddingID, models.PhotoStatusApproved).
+		FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PrintRepository encapsula as operações de banco de dados para os lotes de convites impressos
+type PrintRepository struct {
+	db *gorm.DB
+}
+
+// NewPrintRepository cria uma nova instância do PrintRepository
+func NewPrintRepository(db *gorm.DB) *PrintRepository {
+	return &PrintRepository{db: db}
+}
+
+// CreateOrder insere o lote com todas as peças na mesma transação
+func (r *PrintRepository) CreateOrder(order *models.PrintOrder, items []models.PrintOrderItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		order.Pieces = len(items)
+		if err := tx.Omit("Wedding").Create(order).Error; err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].PrintOrderID = order.ID
+			items[i].WeddingID = order.WeddingID
+		}
+		return tx.Omit("PrintOrder").CreateInBatches(items, 100).Error
+	})
+}
+
+// FindOrdersByWeddingID lista os lotes do casamento (mais recentes primeiro)
+func (r *PrintRepository) FindOrdersByWeddingID(weddingID uint, page, perPage int) ([]models.PrintOrder, int64, error) {
+	query := r.db.Model(&models.PrintOrder{}).Where("wedding_id = ?", weddingID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var orders []models.PrintOrder
+	err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * perPage).
+		Limit(perPage).
+		Find(&orders).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+// FindOrderByIDAndWeddingID busca um lote do casamento
+// Segurança: Garante que o lote pertence ao casamento já validado
+func (r *PrintRepository) FindOrderByIDAndWeddingID(id, weddingID uint) (*models.PrintOrder, error) {
+	var order models.PrintOrder
+	if err := r.db.Where("id = ? AND wedding_id = ?", id, weddingID).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("print order not found")
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+// FindItemsByOrderID lista as peças de um lote
+func (r *PrintRepository) FindItemsByOrderID(orderID uint) ([]models.PrintOrderItem, error) {
+	var items []models.PrintOrderItem
+	if err := r.db.Where("print_order_id = ?", orderID).Order("id ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// FindItemsToSubmit lista as peças ainda não enviadas ao provedor (mais antigas primeiro)
+func (r *PrintRepository) FindItemsToSubmit(limit int) ([]models.PrintOrderItem, error) {
+	var items []models.PrintOrderItem
+	err := r.db.Preload("PrintOrder").
+		Where("status = ?", models.PrintItemPending).
+		Order("id ASC").
+		Limit(limit).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// FindItemsToTrack lista as peças em andamento cujo rastreio não é consultado desde checkedBefore
+func (r *PrintRepository) FindItemsToTrack(checkedBefore time.Time, limit int) ([]models.PrintOrderItem, error) {
+	var items []models.PrintOrderItem
+	err := r.db.Preload("PrintOrder").
+		Where("status IN ?", []models.PrintItemStatus{models.PrintItemSubmitted, models.PrintItemMailed, models.PrintItemInTransit}).
+		Where("checked_at IS NULL OR checked_at < ?", checkedBefore).
+		Order("checked_at ASC, id ASC").
+		Limit(limit).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// SaveItemStatus grava o resultado do envio ou do rastreio de uma peça
+func (r *PrintRepository) SaveItemStatus(item *models.PrintOrderItem) error {
+	return r.db.Model(item).
+		Select("status", "provider_piece_id", "error", "checked_at").
+		Updates(item).Error
+}
+
+// RefreshOrder recalcula os totais e o status do lote a partir das peças
+// Quando todas as peças foram enviadas, registra o gasto (peças aceitas x custo por peça) uma única vez
+// Concorrência: O lote fica bloqueado durante o recálculo (FOR UPDATE), evitando gasto duplicado
+func (r *PrintRepository) RefreshOrder(orderID uint, unitPrice float64, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var order models.PrintOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderID).Error; err != nil {
+			return err
+		}
+
+		var rows []struct {
+			Status models.PrintItemStatus
+			Total  int
+		}
+		err := tx.Model(&models.PrintOrderItem{}).
+			Select("status, COUNT(*) AS total").
+			Where("print_order_id = ?", orderID).
+			Group("status").
+			Scan(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		counts := map[models.PrintItemStatus]int{}
+		final := 0
+		for _, row := range rows {
+			counts[row.Status] = row.Total
+			if row.Status.IsFinal() {
+				final += row.Total
+			}
+		}
+		order.PiecesFailed = counts[models.PrintItemFailed]
+		order.PiecesDelivered = counts[models.PrintItemDelivered]
+		// Peças aceitas pelo provedor (em produção, postadas, entregues ou devolvidas)
+		order.PiecesSubmitted = order.Pieces - counts[models.PrintItemPending] - order.PiecesFailed
+
+		switch {
+		case counts[models.PrintItemPending] > 0:
+			order.Status = models.PrintOrderPending
+		case order.PiecesFailed == order.Pieces:
+			order.Status = models.PrintOrderFailed
+		case final == order.Pieces:
+			order.Status = models.PrintOrderCompleted
+		default:
+			order.Status = models.PrintOrderProcessing
+		}
+
+		if order.Status != models.PrintOrderPending && order.SubmittedAt == nil {
+			order.SubmittedAt = &now
+		}
+		if order.Status == models.PrintOrderCompleted || order.Status == models.PrintOrderFailed {
+			if order.CompletedAt == nil {
+				order.CompletedAt = &now
+			}
+		}
+
+		if order.Status != models.PrintOrderPending && order.ExpenseID == nil && order.PiecesSubmitted > 0 {
+			expense := models.Expense{
+				WeddingID:   order.WeddingID,
+				Category:    models.ExpenseCategoryStationery,
+				Description: fmt.Sprintf("Convites impressos: lote #%d (%d peças via %s)", order.ID, order.PiecesSubmitted, order.Provider),
+				Amount:      math.Round(float64(order.PiecesSubmitted)*unitPrice*100) / 100,
+				Status:      models.ExpenseStatusPlanned,
+			}
+			if err := tx.Omit("Wedding").Create(&expense).Error; err != nil {
+				return err
+			}
+			if err := NewBudgetRepository(tx).IncrementTotalPlanned(order.WeddingID, expense.Amount); err != nil {
+				return err
+			}
+			order.ExpenseID = &expense.ID
+		}
+
+		return tx.Model(&order).
+			Select("status", "pieces_submitted", "pieces_delivered", "pieces_failed", "expense_id", "submitted_at", "completed_at").
+			Updates(&order).Error
+	})
+}
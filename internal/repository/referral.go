@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Erros customizados para melhor tratamento
+var ErrInvalidReferralCode = errors.New("invalid referral code")
+
+// Tentativas de gerar um código de indicação único
+const referralCodeAttempts = 5
+
+// ReferralRepository encapsula as operações de banco de dados do programa de indicação
+type ReferralRepository struct {
+	db *gorm.DB
+}
+
+// NewReferralRepository cria uma nova instância do ReferralRepository
+func NewReferralRepository(db *gorm.DB) *ReferralRepository {
+	return &ReferralRepository{db: db}
+}
+
+// FindReferrerByCode busca o dono de um código de indicação
+func (r *ReferralRepository) FindReferrerByCode(code string) (*models.User, error) {
+	var user models.User
+	err := r.db.Where("referral_code = ?", code).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidReferralCode
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// EnsureCode retorna o código de indicação do usuário, gerando um na primeira chamada
+// Concorrência: O UPDATE condicional mantém o primeiro código gravado em chamadas simultâneas
+func (r *ReferralRepository) EnsureCode(user *models.User) (string, error) {
+	if user.ReferralCode != nil {
+		return *user.ReferralCode, nil
+	}
+
+	for attempt := 0; attempt < referralCodeAttempts; attempt++ {
+		code, err := models.NewReferralCode()
+		if err != nil {
+			return "", err
+		}
+
+		err = r.db.Model(&models.User{}).
+			Where("id = ? AND referral_code IS NULL", user.ID).
+			UpdateColumn("referral_code", code).Error
+		if err != nil {
+			if strings.Contains(err.Error(), "Duplicate entry") {
+				continue // colisão com o código de outro usuário
+			}
+			return "", err
+		}
+
+		var current models.User
+		if err := r.db.Select("referral_code").First(&current, user.ID).Error; err != nil {
+			return "", err
+		}
+		if current.ReferralCode != nil {
+			user.ReferralCode = current.ReferralCode
+			return *current.ReferralCode, nil
+		}
+	}
+	return "", errors.New("unable to generate a unique referral code")
+}
+
+// CreateReferredUser cadastra o usuário e registra a indicação na mesma transação
+func (r *ReferralRepository) CreateReferredUser(user *models.User, referrerID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return tx.Omit("User", "ReferredUser").Create(&models.Referral{
+			UserID:         referrerID,
+			ReferredUserID: user.ID,
+			Status:         models.ReferralStatusSignedUp,
+		}).Error
+	})
+}
+
+// ConvertReferral marca a indicação do usuário como convertida e recompensa quem indicou
+// com rewardDays de Pro, até maxRewards recompensas por indicador
+// Sem indicação pendente não faz nada (idempotente)
+// Concorrência: SELECT ... FOR UPDATE impede recompensar a mesma indicação duas vezes
+func (r *ReferralRepository) ConvertReferral(referredUserID uint, rewardDays, maxRewards int, now time.Time) (*models.Referral, error) {
+	var converted *models.Referral
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var referral models.Referral
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("referred_user_id = ? AND status = ?", referredUserID, models.ReferralStatusSignedUp).
+			First(&referral).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		referral.Status = models.ReferralStatusConverted
+		referral.ConvertedAt = &now
+
+		var referrer models.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&referrer, referral.UserID).Error; err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+		} else {
+			var rewarded int64
+			err := tx.Model(&models.Referral{}).
+				Where("user_id = ? AND status = ?", referrer.ID, models.ReferralStatusRewarded).
+				Count(&rewarded).Error
+			if err != nil {
+				return err
+			}
+
+			if rewarded < int64(maxRewards) {
+				// A recompensa estende o benefício vigente em vez de sobrepor
+				start := now
+				if referrer.ProUntil != nil && referrer.ProUntil.After(now) {
+					start = *referrer.ProUntil
+				}
+				err := tx.Model(&models.User{}).
+					Where("id = ?", referrer.ID).
+					UpdateColumn("pro_until", start.AddDate(0, 0, rewardDays)).Error
+				if err != nil {
+					return err
+				}
+				referral.Status = models.ReferralStatusRewarded
+				referral.RewardedAt = &now
+			}
+		}
+
+		if err := tx.Omit("User", "ReferredUser").Save(&referral).Error; err != nil {
+			return err
+		}
+		converted = &referral
+		return nil
+	})
+	return converted, err
+}
+
+// ReferredSignup é uma indicação feita pelo usuário, com o primeiro nome do indicado
+type ReferredSignup struct {
+	ID          uint
+	Name        string
+	Status      models.ReferralStatus
+	CreatedAt   time.Time
+	ConvertedAt *time.Time
+	RewardedAt  *time.Time
+}
+
+// FindByReferrerID lista as indicações de um usuário (mais recentes primeiro)
+func (r *ReferralRepository) FindByReferrerID(userID uint) ([]ReferredSignup, error) {
+	var signups []ReferredSignup
+	err := r.db.Model(&models.Referral{}).
+		Select("referrals.id, users.name, referrals.status, referrals.created_at, referrals.converted_at, referrals.rewarded_at").
+		Joins("JOIN users ON users.id = referrals.referred_user_id").
+		Where("referrals.user_id = ?", userID).
+		Order("referrals.created_at DESC").
+		Scan(&signups).Error
+	if err != nil {
+		return nil, err
+	}
+	return signups, nil
+}
+
+// ReferralTotals agrega indicações por status
+type ReferralTotals struct {
+	SignedUp  int64
+	Converted int64
+	Rewarded  int64
+}
+
+// TopReferrer resume as indicações de um usuário no relatório administrativo
+type TopReferrer struct {
+	UserID    uint
+	Name      string
+	Email     string
+	Signups   int64
+	Converted int64
+	Rewarded  int64
+}
+
+// Totals agrega as indicações por status, opcionalmente a partir de uma data
+func (r *ReferralRepository) Totals(since *time.Time) (*ReferralTotals, error) {
+	query := r.db.Model(&models.Referral{}).
+		Select(`COUNT(*) AS signed_up,
+			COALESCE(SUM(status IN ('converted', 'rewarded')), 0) AS converted,
+			COALESCE(SUM(status = 'rewarded'), 0) AS rewarded`)
+	if since != nil {
+		query = query.Where("created_at >= ?", *since)
+	}
+
+	var totals ReferralTotals
+	if err := query.Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	return &totals, nil
+}
+
+// TopReferrers lista os usuários com mais indicações convertidas
+func (r *ReferralRepository) TopReferrers(since *time.Time, limit int) ([]TopReferrer, error) {
+	query := r.db.Model(&models.Referral{}).
+		Select(`referrals.user_id, users.name, users.email,
+			COUNT(*) AS signups,
+			COALESCE(SUM(referrals.status IN ('converted', 'rewarded')), 0) AS converted,
+			COALESCE(SUM(referrals.status = 'rewarded'), 0) AS rewarded`).
+		Joins("JOIN users ON users.id = referrals.user_id")
+	if since != nil {
+		query = query.Where("referrals.created_at >= ?", *since)
+	}
+
+	var top []TopReferrer
+	err := query.Group("referrals.user_id, users.name, users.email").
+		Order("converted DESC").Order("signups DESC").
+		Limit(limit).
+		Scan(&top).Error
+	if err != nil {
+		return nil, err
+	}
+	return top, nil
+}
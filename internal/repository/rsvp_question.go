@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RSVPQuestionRepository encapsula as operações de banco de dados para perguntas e respostas de RSVP
+type RSVPQuestionRepository struct {
+	db *gorm.DB
+}
+
+// NewRSVPQuestionRepository cria uma nova instância do RSVPQuestionRepository
+func NewRSVPQuestionRepository(db *gorm.DB) *RSVPQuestionRepository {
+	return &RSVPQuestionRepository{db: db}
+}
+
+// FindByWeddingID lista as perguntas de um casamento na ordem de exibição
+func (r *RSVPQuestionRepository) FindByWeddingID(weddingID uint) ([]models.RSVPQuestion, error) {
+	var questions []models.RSVPQuestion
+	err := r.db.Where("wedding_id = ?", weddingID).
+		Order("position ASC").Order("id ASC").
+		Find(&questions).Error
+	if err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// CountByWeddingID conta as perguntas de um casamento
+func (r *RSVPQuestionRepository) CountByWeddingID(weddingID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.RSVPQuestion{}).Where("wedding_id = ?", weddingID).Count(&count).Error
+	return count, err
+}
+
+// FindByIDAndWeddingID busca uma pergunta de um casamento
+// Segurança: Garante que a pergunta pertence ao casamento já validado
+func (r *RSVPQuestionRepository) FindByIDAndWeddingID(id, weddingID uint) (*models.RSVPQuestion, error) {
+	var question models.RSVPQuestion
+	err := r.db.Where("id = ? AND wedding_id = ?", id, weddingID).First(&question).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("rsvp question not found")
+		}
+		return nil, err
+	}
+	return &question, nil
+}
+
+// Create insere uma pergunta
+func (r *RSVPQuestionRepository) Create(question *models.RSVPQuestion) error {
+	return r.db.Omit("Wedding").Create(question).Error
+}
+
+// Update atualiza os dados de uma pergunta e remove, na mesma transação,
+// as respostas que deixaram de ser válidas (mudança de tipo ou de opções)
+func (r *RSVPQuestionRepository) Update(question *models.RSVPQuestion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Wedding").Save(question).Error; err != nil {
+			return err
+		}
+
+		var valid []string
+		switch question.Type {
+		case models.RSVPQuestionYesNo:
+			valid = []string{models.RSVPAnswerYes, models.RSVPAnswerNo}
+		case models.RSVPQuestionSingleChoice:
+			valid = question.Options
+		default:
+			return nil // qualquer texto continua válido
+		}
+
+		return tx.Where("question_id = ? AND wedding_id = ? AND answer NOT IN ?", question.ID, question.WeddingID, valid).
+			Delete(&models.RSVPAnswer{}).Error
+	})
+}
+
+// Delete remove (soft delete) uma pergunta e suas respostas na mesma transação
+func (r *RSVPQuestionRepository) Delete(question *models.RSVPQuestion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("question_id = ? AND wedding_id = ?", question.ID, question.WeddingID).
+			Delete(&models.RSVPAnswer{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&models.RSVPQuestion{}, question.ID).Error
+	})
+}
+
+// FindAnswersByGuestID lista as respostas de um convidado
+func (r *RSVPQuestionRepository) FindAnswersByGuestID(weddingID, guestID uint) ([]models.RSVPAnswer, error) {
+	var answers []models.RSVPAnswer
+	err := r.db.Where("wedding_id = ? AND guest_id = ?", weddingID, guestID).
+		Order("question_id ASC").
+		Find(&answers).Error
+	if err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// SaveAnswers grava as respostas de um convidado em uma transação
+// Respostas vazias removem a resposta anterior; as demais são inseridas ou substituídas (upsert)
+func (r *RSVPQuestionRepository) SaveAnswers(weddingID, guestID uint, answers map[uint]string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for questionID, answer := range answers {
+			if answer == "" {
+				err := tx.Where("wedding_id = ? AND guest_id = ? AND question_id = ?", weddingID, guestID, questionID).
+					Delete(&models.RSVPAnswer{}).Error
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			record := models.RSVPAnswer{
+				WeddingID:  weddingID,
+				GuestID:    guestID,
+				QuestionID: questionID,
+				Answer:     answer,
+			}
+			err := tx.Omit("Guest", "Question").
+				Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"answer", "updated_at"})}).
+				Create(&record).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RSVPAnswerRow é uma resposta com o nome do convidado (relatório)
+type RSVPAnswerRow struct {
+	QuestionID uint
+	GuestID    uint
+	FullName   string
+	Answer     string
+}
+
+// FindAnswersReport lista as respostas do casamento com o nome dos convidados
+// Convidados removidos ficam de fora; status vazio considera todos os convidados
+// Performance: Uma única query para todas as perguntas, agrupamento em memória
+func (r *RSVPQuestionRepository) FindAnswersReport(weddingID uint, status models.InviteStatus) ([]RSVPAnswerRow, error) {
+	query := r.db.Model(&models.RSVPAnswer{}).
+		Select("rsvp_answers.question_id, rsvp_answers.guest_id, guests.full_name, rsvp_answers.answer").
+		Joins("JOIN guests ON guests.id = rsvp_answers.guest_id AND guests.deleted_at IS NULL").
+		Where("rsvp_answers.wedding_id = ?", weddingID)
+	if status != "" {
+		query = query.Where("guests.invite_status = ?", status)
+	}
+
+	var rows []RSVPAnswerRow
+	err := query.Order("guests.full_name ASC").Order("guests.id ASC").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
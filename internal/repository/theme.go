@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ThemeRepository encapsula as operações de banco de dados para temas da página pública
+type ThemeRepository struct {
+	db *gorm.DB
+}
+
+// NewThemeRepository cria uma nova instância do ThemeRepository
+func NewThemeRepository(db *gorm.DB) *ThemeRepository {
+	return &ThemeRepository{db: db}
+}
+
+// FindByWeddingID busca o tema de um casamento
+// Performance: Usa o uniqueIndex em wedding_id
+func (r *ThemeRepository) FindByWeddingID(weddingID uint) (*models.WeddingTheme, error) {
+	var theme models.WeddingTheme
+	err := r.db.Where("wedding_id = ?", weddingID).First(&theme).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("theme not found")
+		}
+		return nil, err
+	}
+	return &theme, nil
+}
+
+// FindOrDefault busca o tema de um casamento ou retorna o tema padrão (não persistido)
+func (r *ThemeRepository) FindOrDefault(weddingID uint) (*models.WeddingTheme, error) {
+	var theme models.WeddingTheme
+	err := r.db.Where("wedding_id = ?", weddingID).First(&theme).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &models.WeddingTheme{
+				WeddingID:      weddingID,
+				Template:       models.ThemeTemplateClassic,
+				PrimaryColor:   "#ffffff",
+				SecondaryColor: "#000000",
+			}, nil
+		}
+		return nil, err
+	}
+	return &theme, nil
+}
+
+// Save cria ou atualiza o tema
+func (r *ThemeRepository) Save(theme *models.WeddingTheme) error {
+	return r.db.Save(theme).Error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"github.com/matheushermes/wedding_planner_service/internal/models"
 	"gorm.io/gorm"
@@ -48,6 +49,34 @@ func (r *UserRepository) FindByID(id uint) (*models.User, error) {
 	return &user, nil
 }
 
+// FindByCalendarToken busca um usuário pelo token do feed iCal
+func (r *UserRepository) FindByCalendarToken(token string) (*models.User, error) {
+	var user models.User
+	err := r.db.Where("calendar_token = ?", token).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetDefaultWedding define (ou limpa, com nil) o casamento padrão do usuário
+// Performance: UPDATE de um único campo é mais eficiente que Save() completo
+func (r *UserRepository) SetDefaultWedding(userID uint, weddingID *uint) error {
+	return r.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("default_wedding_id", weddingID).Error
+}
+
+// ClearDefaultWeddingIf limpa o casamento padrão caso ele seja o casamento informado
+func (r *UserRepository) ClearDefaultWeddingIf(userID, weddingID uint) error {
+	return r.db.Model(&models.User{}).
+		Where("id = ? AND default_wedding_id = ?", userID, weddingID).
+		Update("default_wedding_id", nil).Error
+}
+
 // Update atualiza os dados de um usuário
 func (r *UserRepository) Update(user *models.User) error {
 	return r.db.Save(user).Error
@@ -62,3 +91,79 @@ func (r *UserRepository) Delete(id uint) error {
 func (r *UserRepository) HardDelete(id uint) error {
 	return r.db.Unscoped().Delete(&models.User{}, id).Error
 }
+
+// TouchLastSeen registra a atividade do usuário se a última gravação for mais antiga que throttle
+// UpdateColumn: não altera updated_at nem dispara hooks
+// Concorrência: A condição no WHERE evita escritas repetidas entre réplicas
+func (r *UserRepository) TouchLastSeen(userID uint, at time.Time, throttle time.Duration) error {
+	return r.db.Model(&models.User{}).
+		Where("id = ? AND (last_seen_at IS NULL OR last_seen_at < ?)", userID, at.Add(-throttle)).
+		UpdateColumn("last_seen_at", at).Error
+}
+
+// UserActivity resume a atividade de um usuário para ferramentas administrativas e campanhas de reengajamento
+type UserActivity struct {
+	ID            uint
+	Name          string
+	Email         string
+	CreatedAt     time.Time
+	LastSeenAt    *time.Time
+	WeddingCount  int64
+	GuestCount    int64
+	NextEventDate *time.Time // próximo casamento da conta (nil se nenhum futuro)
+}
+
+// UserActivityFilter define os filtros da listagem de atividade
+type UserActivityFilter struct {
+	InactiveSince *time.Time // apenas usuários sem atividade desde a data (inclui quem nunca acessou)
+	NoGuests      bool       // apenas contas sem nenhum convidado cadastrado
+}
+
+// FindActivityPage lista uma página de usuários com última atividade e números da conta
+// Performance: Agregação dos casamentos em uma única query com LEFT JOIN (sem N+1)
+func (r *UserRepository) FindActivityPage(filter UserActivityFilter, now time.Time, page, perPage int) ([]UserActivity, int64, error) {
+	query := r.db.Model(&models.User{})
+	if filter.InactiveSince != nil {
+		query = query.Where("users.last_seen_at IS NULL OR users.last_seen_at < ?", *filter.InactiveSince)
+	}
+	if filter.NoGuests {
+		query = query.Where("NOT EXISTS (?)", r.db.Model(&models.Wedding{}).
+			Select("1").
+			Where("weddings.user_id = users.id AND weddings.current_guest_count > 0"))
+	}
+
+	// Session: reaproveita os filtros na contagem e na busca sem compartilhar o statement
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var activity []UserActivity
+	err := query.
+		Select(`users.id, users.name, users.email, users.created_at, users.last_seen_at,
+			COUNT(weddings.id) AS wedding_count,
+			COALESCE(SUM(weddings.current_guest_count), 0) AS guest_count,
+			MIN(CASE WHEN weddings.event_date >= ? THEN weddings.event_date END) AS next_event_date`, now).
+		Joins("LEFT JOIN weddings ON weddings.user_id = users.id AND weddings.deleted_at IS NULL").
+		Group("users.id").
+		Order("users.last_seen_at DESC").Order("users.id ASC").
+		Offset((page - 1) * perPage).
+		Limit(perPage).
+		Scan(&activity).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return activity, total, nil
+}
+
+// SetLifecycleOptOut registra (at != nil) ou remove (nil) o opt-out dos emails de ciclo de vida
+// Registrar é idempotente e mantém a data original
+func (r *UserRepository) SetLifecycleOptOut(userID uint, at *time.Time) error {
+	query := r.db.Model(&models.User{}).Where("id = ?", userID)
+	if at != nil {
+		query = query.Where("lifecycle_opt_out_at IS NULL")
+	}
+	return query.UpdateColumn("lifecycle_opt_out_at", at).Error
+}
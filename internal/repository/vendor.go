@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// VendorRepository encapsula as operações de banco de dados para fornecedores
+type VendorRepository struct {
+	db *gorm.DB
+}
+
+// NewVendorRepository cria uma nova instância do VendorRepository
+func NewVendorRepository(db *gorm.DB) *VendorRepository {
+	return &VendorRepository{db: db}
+}
+
+// Create cria um novo fornecedor no catálogo da conta
+func (r *VendorRepository) Create(vendor *models.Vendor) error {
+	return r.db.Create(vendor).Error
+}
+
+// FindByUserID lista o catálogo de fornecedores de um usuário
+// Performance: Usa índice em user_id
+func (r *VendorRepository) FindByUserID(userID uint) ([]models.Vendor, error) {
+	var vendors []models.Vendor
+	err := r.db.Where("user_id = ?", userID).
+		Order("name ASC").
+		Find(&vendors).Error
+	if err != nil {
+		return nil, err
+	}
+	return vendors, nil
+}
+
+// Update atualiza os dados de um fornecedor
+func (r *VendorRepository) Update(vendor *models.Vendor) error {
+	return r.db.Save(vendor).Error
+}
+
+// Delete remove um fornecedor (soft delete) e suas associações com casamentos
+func (r *VendorRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("vendor_id = ?", id).Delete(&models.WeddingVendor{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Vendor{}, id).Error
+	})
+}
+
+// Attach associa um fornecedor a um casamento
+func (r *VendorRepository) Attach(weddingVendor *models.WeddingVendor) error {
+	return r.db.Omit("Vendor").Create(weddingVendor).Error
+}
+
+// FindByWeddingID lista os fornecedores associados a um casamento com os dados de contato
+// Performance: Preload evita N+1 queries ao carregar os contatos
+func (r *VendorRepository) FindByWeddingID(weddingID uint) ([]models.WeddingVendor, error) {
+	var weddingVendors []models.WeddingVendor
+	err := r.db.Preload("Vendor").
+		Where("wedding_id = ?", weddingID).
+		Order("created_at ASC").
+		Find(&weddingVendors).Error
+	if err != nil {
+		return nil, err
+	}
+	return weddingVendors, nil
+}
+
+// FindAttachment busca a associação de um fornecedor com um casamento
+func (r *VendorRepository) FindAttachment(weddingID, vendorID uint) (*models.WeddingVendor, error) {
+	var weddingVendor models.WeddingVendor
+	err := r.db.Preload("Vendor").
+		Where("wedding_id = ? AND vendor_id = ?", weddingID, vendorID).
+		First(&weddingVendor).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("vendor is not attached to this wedding")
+		}
+		return nil, err
+	}
+	return &weddingVendor, nil
+}
+
+// FindDueByUserID lista pagamentos a fornecedores com vencimento em todos os casamentos do usuário
+// Performance: JOIN + Preload evita N+1 queries
+func (r *VendorRepository) FindDueByUserID(userID uint) ([]models.WeddingVendor, error) {
+	var weddingVendors []models.WeddingVendor
+	err := r.db.Preload("Vendor").Preload("Wedding").
+		Joins("JOIN weddings ON weddings.id = wedding_vendors.wedding_id AND weddings.deleted_at IS NULL").
+		Where("weddings.user_id = ? AND wedding_vendors.due_date IS NOT NULL", userID).
+		Order("wedding_vendors.due_date ASC").
+		Find(&weddingVendors).Error
+	if err != nil {
+		return nil, err
+	}
+	return weddingVendors, nil
+}
+
+// UpdateAttachment atualiza preço, observações e vencimento de um fornecedor no casamento
+func (r *VendorRepository) UpdateAttachment(weddingVendor *models.WeddingVendor) error {
+	return r.db.Model(weddingVendor).
+		Select("price", "notes", "due_date", "needs_date_confirmation").
+		Updates(weddingVendor).Error
+}
+
+// Detach remove a associação de um fornecedor com um casamento
+func (r *VendorRepository) Detach(weddingID, vendorID uint) error {
+	return r.db.Where("wedding_id = ? AND vendor_id = ?", weddingID, vendorID).
+		Delete(&models.WeddingVendor{}).Error
+}
@@ -2,11 +2,22 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"github.com/matheushermes/wedding_planner_service/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrWeddingFull indica que o casamento atingiu o limite de convidados (MaxGuests)
+var ErrWeddingFull = errors.New("wedding has reached its max guests")
+
+// ErrVenueAtCapacity indica que uma nova confirmação ultrapassaria a lotação do local (EnforceCapacity)
+var ErrVenueAtCapacity = errors.New("venue has reached its capacity")
+
+// ErrCustomDomainChanged indica que o domínio pendente mudou (ou foi removido) durante a verificação
+var ErrCustomDomainChanged = errors.New("pending custom domain changed, request verification again")
+
 type WeddingRepository struct {
 	db *gorm.DB
 }
@@ -49,25 +60,150 @@ func (r *WeddingRepository) FindByUserID(userID uint) ([]models.Wedding, error)
 	return weddings, nil
 }
 
-// FindByIDAndUserID busca um casamento específico de um usuário
-// Performance: Usa índices compostos para verificação de ownership em O(log n)
-// Segurança: Garante que usuário só acesse seus próprios dados
-func (r *WeddingRepository) FindByIDAndUserID(weddingID, userID uint) (*models.Wedding, error) {
+// FindOnDateByUserID lista os outros casamentos do usuário no mesmo dia (excludeID ignora o próprio casamento)
+// O dia é o calendário do fuso de date
+// Performance: Usa o índice em user_id
+func (r *WeddingRepository) FindOnDateByUserID(userID uint, date time.Time, excludeID uint) ([]models.Wedding, error) {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	end := start.AddDate(0, 0, 1)
+
+	var weddings []models.Wedding
+	err := r.db.Where("user_id = ? AND id <> ? AND event_date >= ? AND event_date < ?", userID, excludeID, start, end).
+		Order("event_time ASC").
+		Find(&weddings).Error
+	if err != nil {
+		return nil, err
+	}
+	return weddings, nil
+}
+
+// FindCurrentByUserID escolhe o casamento "atual" quando não há um padrão definido
+// Prioriza o próximo evento; se todos já passaram, retorna o mais recente
+func (r *WeddingRepository) FindCurrentByUserID(userID uint) (*models.Wedding, error) {
+	var wedding models.Wedding
+	today := time.Now().Truncate(24 * time.Hour)
+
+	err := r.db.Where("user_id = ? AND event_date >= ?", userID, today).
+		Order("event_date ASC").
+		First(&wedding).Error
+	if err == nil {
+		return &wedding, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	err = r.db.Where("user_id = ?", userID).
+		Order("event_date DESC").
+		First(&wedding).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("wedding not found")
+		}
+		return nil, err
+	}
+	return &wedding, nil
+}
+
+// FindBySlug busca um casamento pelo slug da página pública
+// Performance: Usa o uniqueIndex em slug
+func (r *WeddingRepository) FindBySlug(slug string) (*models.Wedding, error) {
+	var wedding models.Wedding
+	err := r.db.Where("slug = ?", slug).First(&wedding).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("wedding not found")
+		}
+		return nil, err
+	}
+	return &wedding, nil
+}
+
+// FindByCustomDomain busca um casamento pelo domínio customizado
+// Segurança: Apenas domínios verificados (registro TXT) de contas com o plano Pro ativo são resolvidos
+// Performance: Usa o uniqueIndex em custom_domain
+func (r *WeddingRepository) FindByCustomDomain(domain string, now time.Time) (*models.Wedding, error) {
+	var wedding models.Wedding
+	err := r.db.Joins("JOIN users ON users.id = weddings.user_id AND users.pro_until > ?", now).
+		Where("weddings.custom_domain = ? AND weddings.custom_domain_verified_at IS NOT NULL", domain).
+		First(&wedding).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("wedding not found")
+		}
+		return nil, err
+	}
+	return &wedding, nil
+}
+
+// FindByEmbedToken busca um casamento pelo token do widget embutível
+// Performance: Usa o uniqueIndex em embed_token
+func (r *WeddingRepository) FindByEmbedToken(token string) (*models.Wedding, error) {
 	var wedding models.Wedding
-	err := r.db.Where("id = ? AND user_id = ?", weddingID, userID).First(&wedding).Error
+	err := r.db.Where("embed_token = ?", token).First(&wedding).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("wedding not found or access denied")
+			return nil, errors.New("wedding not found")
 		}
 		return nil, err
 	}
 	return &wedding, nil
 }
 
+// IsSlugTaken verifica se o slug já pertence a outro casamento
+// Considera também registros soft-deleted, pois o uniqueIndex continua valendo para eles
+func (r *WeddingRepository) IsSlugTaken(slug string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&models.Wedding{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// IsCustomDomainTaken verifica se o domínio já pertence a outro casamento
+func (r *WeddingRepository) IsCustomDomainTaken(domain string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&models.Wedding{}).
+		Where("custom_domain = ? AND id <> ?", domain, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// VerifyCustomDomain ativa o domínio pendente depois da verificação do registro TXT
+// Concorrência: Só ativa se o pedido e o token ainda forem os verificados (outro request pode ter trocado o domínio)
+func (r *WeddingRepository) VerifyCustomDomain(wedding *models.Wedding, now time.Time) error {
+	if wedding.PendingCustomDomain == nil || wedding.CustomDomainToken == nil {
+		return ErrCustomDomainChanged
+	}
+	domain := *wedding.PendingCustomDomain
+
+	result := r.db.Model(&models.Wedding{}).
+		Where("id = ? AND pending_custom_domain = ? AND custom_domain_token = ?", wedding.ID, domain, *wedding.CustomDomainToken).
+		Updates(map[string]interface{}{
+			"custom_domain":             domain,
+			"custom_domain_verified_at": now,
+			"pending_custom_domain":     nil,
+			"custom_domain_token":       nil,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCustomDomainChanged
+	}
+
+	wedding.CustomDomain = &domain
+	wedding.CustomDomainVerifiedAt = &now
+	wedding.PendingCustomDomain = nil
+	wedding.CustomDomainToken = nil
+	return nil
+}
+
 // Update atualiza os dados de um casamento
-// Performance: Usa Save() que otimiza apenas campos alterados
+// Concorrência: Contadores mantidos por UPDATE atômico (e o ano do último lembrete de bodas, gravado
+// pelo job) ficam fora do Save: o valor lido no início do request sobrescreveria incrementos concorrentes
 func (r *WeddingRepository) Update(wedding *models.Wedding) error {
-	return r.db.Save(wedding).Error
+	return r.db.Omit("current_guest_count", "confirmed_companion_count", "last_anniversary_reminder").Save(wedding).Error
 }
 
 // Delete remove um casamento (soft delete)
@@ -90,10 +226,115 @@ func (r *WeddingRepository) CountByUserID(userID uint) (int64, error) {
 	return count, err
 }
 
-// UpdateGuestCount atualiza apenas o contador de convidados
-// Performance: UPDATE de um único campo é mais eficiente que Save() completo
-func (r *WeddingRepository) UpdateGuestCount(weddingID uint, count int) error {
+// IncrementConfirmedCompanionCount ajusta o contador de acompanhantes confirmados de forma atômica
+// delta pode ser negativo para decrementar
+func (r *WeddingRepository) IncrementConfirmedCompanionCount(weddingID uint, delta int) error {
+	if delta == 0 {
+		return nil
+	}
+	return r.db.Model(&models.Wedding{}).
+		Where("id = ?", weddingID).
+		Update("confirmed_companion_count", gorm.Expr("confirmed_companion_count + ?", delta)).Error
+}
+
+// ReserveGuestCapacity ocupa n vagas de convidados ou retorna ErrWeddingFull
+// Deve rodar dentro de uma transação: o SELECT ... FOR UPDATE serializa criações simultâneas
+// no mesmo casamento até o commit, então o limite nunca é ultrapassado
+func (r *WeddingRepository) ReserveGuestCapacity(weddingID uint, n int) error {
+	wedding, err := r.LockGuestCapacity(weddingID)
+	if err != nil {
+		return err
+	}
+	if wedding.CurrentGuestCount+n > wedding.MaxGuests {
+		return ErrWeddingFull
+	}
+	return r.IncrementGuestCount(weddingID, n)
+}
+
+// LockGuestCapacity bloqueia a linha do casamento (até o fim da transação) e retorna as vagas atuais
+// Concorrência: Toda alteração de vagas bloqueia o casamento antes dos convidados (ordem única de locks)
+func (r *WeddingRepository) LockGuestCapacity(weddingID uint) (*models.Wedding, error) {
+	var wedding models.Wedding
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "max_guests", "current_guest_count", "confirmed_companion_count", "auto_promote_guests", "enforce_capacity").
+		First(&wedding, weddingID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("wedding not found")
+		}
+		return nil, err
+	}
+	return &wedding, nil
+}
+
+// ConfirmedHeadcount retorna as pessoas confirmadas no casamento (convidados + acompanhantes confirmados)
+// Performance: COUNT no índice (wedding_id, invite_status) somado ao contador de acompanhantes
+func (r *WeddingRepository) ConfirmedHeadcount(wedding *models.Wedding) (int, error) {
+	var confirmed int64
+	err := r.db.Model(&models.Guest{}).
+		Where("wedding_id = ? AND invite_status = ?", wedding.ID, models.InviteStatusConfirmed).
+		Count(&confirmed).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(confirmed) + wedding.ConfirmedCompanionCount, nil
+}
+
+// EventHeadcount traz as pessoas confirmadas em cada parte do casamento
+type EventHeadcount struct {
+	Ceremony  int `json:"ceremony"`
+	Reception int `json:"reception"`
+}
+
+// ConfirmedHeadcountByEvent conta as pessoas confirmadas (convidados + acompanhantes) na cerimônia e na recepção
+// Acompanhantes seguem o convite do convidado principal
+// Performance: Duas agregações agrupadas pela lista do convite, sem carregar registros
+func (r *WeddingRepository) ConfirmedHeadcountByEvent(weddingID uint) (*EventHeadcount, error) {
+	var guests []struct {
+		Events models.WeddingEvent
+		Total  int
+	}
+	err := r.db.Model(&models.Guest{}).
+		Select("events, COUNT(*) AS total").
+		Where("wedding_id = ? AND invite_status = ?", weddingID, models.InviteStatusConfirmed).
+		Group("events").
+		Scan(&guests).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var companions []struct {
+		Events models.WeddingEvent
+		Total  int
+	}
+	err = r.db.Model(&models.Companion{}).
+		Select("guests.events AS events, COUNT(*) AS total").
+		Joins("JOIN guests ON guests.id = companions.guest_id AND guests.deleted_at IS NULL").
+		Where("companions.wedding_id = ? AND companions.confirmed = ? AND guests.invite_status = ?",
+			weddingID, true, models.InviteStatusConfirmed).
+		Group("guests.events").
+		Scan(&companions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var headcount EventHeadcount
+	for _, row := range append(guests, companions...) {
+		if row.Events.Includes(models.WeddingEventCeremony) {
+			headcount.Ceremony += row.Total
+		}
+		if row.Events.Includes(models.WeddingEventReception) {
+			headcount.Reception += row.Total
+		}
+	}
+	return &headcount, nil
+}
+
+// IncrementGuestCount ajusta o contador de convidados de forma atômica
+// Concorrência: UPDATE ... SET x = x + ? evita lost updates entre requests simultâneos
+// delta pode ser negativo para decrementar
+func (r *WeddingRepository) IncrementGuestCount(weddingID uint, delta int) error {
 	return r.db.Model(&models.Wedding{}).
 		Where("id = ?", weddingID).
-		Update("current_guest_count", count).Error
+		Update("current_guest_count", gorm.Expr("current_guest_count + ?", delta)).Error
 }
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// RescheduleResult resume o que a remarcação do casamento alterou
+type RescheduleResult struct {
+	ShiftedExpenses int   `json:"shifted_expenses"` // despesas planejadas com vencimento recalculado
+	FlaggedVendors  int64 `json:"flagged_vendors"`  // fornecedores que precisam confirmar a nova data
+	InvitedGuests   int64 `json:"invited_guests"`   // convidados que já receberam o convite com a data antiga
+}
+
+// Reschedule grava a nova data do casamento, recalcula os vencimentos das despesas planejadas
+// e marca os fornecedores para confirmar a nova data
+// Concorrência: tudo na mesma transação para não deixar prazos calculados sobre a data errada
+func (r *WeddingRepository) Reschedule(wedding *models.Wedding, previous, now time.Time) (*RescheduleResult, error) {
+	var result RescheduleResult
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(wedding).
+			Select("event_date", "event_time").
+			Updates(wedding).Error
+		if err != nil {
+			return err
+		}
+
+		// Despesas pagas e prazos vencidos não mudam (ShiftDueDate mantém os anteriores a agora)
+		var expenses []models.Expense
+		err = tx.Select("id", "due_date").
+			Where("wedding_id = ? AND status = ? AND due_date >= ?", wedding.ID, models.ExpenseStatusPlanned, now).
+			Find(&expenses).Error
+		if err != nil {
+			return err
+		}
+		for _, expense := range expenses {
+			shifted := models.ShiftDueDate(*expense.DueDate, now, previous, wedding.EventDate)
+			if shifted.Equal(*expense.DueDate) {
+				continue
+			}
+			err := tx.Model(&models.Expense{}).
+				Where("id = ?", expense.ID).
+				Update("due_date", shifted).Error
+			if err != nil {
+				return err
+			}
+			result.ShiftedExpenses++
+		}
+
+		flagged := tx.Model(&models.WeddingVendor{}).
+			Where("wedding_id = ?", wedding.ID).
+			Update("needs_date_confirmation", true)
+		if flagged.Error != nil {
+			return flagged.Error
+		}
+		result.FlaggedVendors = flagged.RowsAffected
+
+		return tx.Model(&models.Guest{}).
+			Where("wedding_id = ? AND invite_status IN ?", wedding.ID, []models.InviteStatus{
+				models.InviteStatusSent, models.InviteStatusConfirmed, models.InviteStatusDeclined,
+			}).
+			Count(&result.InvitedGuests).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FindConfirmedReachable lista os convidados confirmados com email que aceitam receber mensagens
+// LGPD: quem fez opt-out fica de fora dos avisos do casamento
+// Segurança: o email é cifrado no banco, então o filtro de email vazio roda depois de decifrar
+func (r *GuestRepository) FindConfirmedReachable(weddingID uint) ([]models.Guest, error) {
+	var guests []models.Guest
+	err := r.db.Where("wedding_id = ? AND invite_status = ? AND opted_out_at IS NULL", weddingID, models.InviteStatusConfirmed).
+		Order("id ASC").
+		Find(&guests).Error
+	if err != nil {
+		return nil, err
+	}
+
+	reachable := guests[:0]
+	for _, g := range guests {
+		if g.Email != "" {
+			reachable = append(reachable, g)
+		}
+	}
+	return reachable, nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"gorm.io/gorm"
+)
+
+// WhatsAppRepository encapsula as operações de banco de dados para as contas de WhatsApp dos casamentos
+type WhatsAppRepository struct {
+	db *gorm.DB
+}
+
+// NewWhatsAppRepository cria uma nova instância do WhatsAppRepository
+func NewWhatsAppRepository(db *gorm.DB) *WhatsAppRepository {
+	return &WhatsAppRepository{db: db}
+}
+
+// FindByWeddingID busca a conta de WhatsApp de um casamento
+// Performance: Usa o uniqueIndex em wedding_id
+func (r *WhatsAppRepository) FindByWeddingID(weddingID uint) (*models.WhatsAppAccount, error) {
+	var account models.WhatsAppAccount
+	err := r.db.Where("wedding_id = ?", weddingID).First(&account).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("whatsapp account not found")
+		}
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Save cria ou atualiza a conta de WhatsApp
+func (r *WhatsAppRepository) Save(account *models.WhatsAppAccount) error {
+	return r.db.Save(account).Error
+}
+
+// Delete remove a conta de WhatsApp do casamento
+// Segurança: remoção definitiva para não manter o token do casal após a desconexão
+func (r *WhatsAppRepository) Delete(weddingID uint) error {
+	result := r.db.Unscoped().Where("wedding_id = ?", weddingID).Delete(&models.WhatsAppAccount{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("whatsapp account not found")
+	}
+	return nil
+}
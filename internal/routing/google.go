@@ -0,0 +1,116 @@
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	googleDistanceMatrixURL = "https://maps.googleapis.com/maps/api/distancematrix/json"
+
+	// Limite das respostas da API (proteção contra respostas inesperadamente grandes)
+	maxGoogleResponseSize = 1 << 20 // 1MB
+)
+
+// googleProvider implementa Provider com a Distance Matrix API do Google Maps (API REST, sem SDK)
+type googleProvider struct {
+	apiKey string
+	client *http.Client
+}
+
+func newGoogleProvider(apiKey string) *googleProvider {
+	return &googleProvider{
+		apiKey: apiKey,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (g *googleProvider) Name() string {
+	return "google"
+}
+
+// TravelTime consulta o tempo de carro entre os endereços
+// Com horário de saída futuro, usa a previsão de trânsito (duration_in_traffic)
+func (g *googleProvider) TravelTime(ctx context.Context, origin, destination string, departure time.Time) (time.Duration, error) {
+	query := url.Values{
+		"origins":      {origin},
+		"destinations": {destination},
+		"mode":         {"driving"},
+		"key":          {g.apiKey},
+	}
+	// A API recusa horários de saída no passado
+	if departure.After(time.Now()) {
+		query.Set("departure_time", strconv.FormatInt(departure.Unix(), 10))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, googleDistanceMatrixURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		// Segurança: url.Error inclui a URL com a chave da API; apenas a causa é repassada
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, fmt.Errorf("erro ao chamar google maps: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxGoogleResponseSize))
+	if err != nil {
+		return 0, fmt.Errorf("erro ao ler resposta do google maps: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("google maps retornou %d", resp.StatusCode)
+	}
+
+	var matrix struct {
+		Status       string `json:"status"`
+		ErrorMessage string `json:"error_message"`
+		Rows         []struct {
+			Elements []struct {
+				Status   string `json:"status"`
+				Duration struct {
+					Value int64 `json:"value"` // segundos
+				} `json:"duration"`
+				DurationInTraffic *struct {
+					Value int64 `json:"value"`
+				} `json:"duration_in_traffic"`
+			} `json:"elements"`
+		} `json:"rows"`
+	}
+	if err := json.Unmarshal(body, &matrix); err != nil {
+		return 0, fmt.Errorf("resposta inválida do google maps: %w", err)
+	}
+	if matrix.Status != "OK" {
+		return 0, fmt.Errorf("google maps retornou %s: %s", matrix.Status, matrix.ErrorMessage)
+	}
+	if len(matrix.Rows) == 0 || len(matrix.Rows[0].Elements) == 0 {
+		return 0, ErrRouteNotFound
+	}
+
+	element := matrix.Rows[0].Elements[0]
+	switch element.Status {
+	case "OK":
+	case "NOT_FOUND", "ZERO_RESULTS":
+		return 0, ErrRouteNotFound
+	default:
+		return 0, fmt.Errorf("google maps retornou %s para a rota", element.Status)
+	}
+
+	seconds := element.Duration.Value
+	if element.DurationInTraffic != nil && element.DurationInTraffic.Value > 0 {
+		seconds = element.DurationInTraffic.Value
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
@@ -0,0 +1,61 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+)
+
+// Erros customizados para melhor tratamento
+var (
+	ErrNoProviderConfigured = errors.New("no routing provider configured")
+	ErrRouteNotFound        = errors.New("no route found between the addresses")
+)
+
+// Provider abstrai uma API de rotas usada para estimar o deslocamento entre os locais do casamento
+type Provider interface {
+	Name() string
+	// TravelTime estima o tempo de carro entre dois endereços; departure zero usa o trânsito típico
+	TravelTime(ctx context.Context, origin, destination string, departure time.Time) (time.Duration, error)
+}
+
+var (
+	mu      sync.RWMutex
+	current Provider
+)
+
+// Setup registra o provedor configurado (re-registrado a cada rotação de credenciais)
+// Deve ser chamado após configs.LoadEnv
+func Setup() {
+	registerConfigured()
+	configs.OnSecretsRotated(registerConfigured)
+}
+
+// registerConfigured registra o provedor com as credenciais atuais
+func registerConfigured() {
+	var p Provider
+	if key := configs.CurrentSecrets().GoogleMapsAPIKey; key != "" {
+		p = newGoogleProvider(key)
+	}
+	Register(p)
+}
+
+// Register define o provedor de rotas (nil desativa o cálculo)
+func Register(p Provider) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = p
+}
+
+// Default retorna o provedor de rotas registrado
+func Default() (Provider, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		return nil, ErrNoProviderConfigured
+	}
+	return current, nil
+}
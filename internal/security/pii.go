@@ -0,0 +1,161 @@
+package security
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"gorm.io/gorm/schema"
+)
+
+// Prefixo dos valores criptografados; valores sem prefixo são legados em texto puro (antes do backfill)
+const encryptedPrefix = "enc:v1:"
+
+// ErrDecryptPII indica valor criptografado que nenhuma chave conhecida consegue abrir
+var ErrDecryptPII = errors.New("unable to decrypt personal data")
+
+func init() {
+	// Uso nos models: `gorm:"serializer:encrypted"`
+	schema.RegisterSerializer("encrypted", EncryptedSerializer{})
+}
+
+// EncryptPII criptografa um dado pessoal com AES-256-GCM (nonce aleatório por valor)
+// Sem chave configurada (apenas fora de produção) o valor é mantido em texto puro
+func EncryptPII(plaintext string) (string, error) {
+	key := configs.CurrentSecrets().PIIKey
+	if plaintext == "" || len(key) == 0 {
+		return plaintext, nil
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptPII abre um valor gerado por EncryptPII, tentando a chave atual e a anterior
+func DecryptPII(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, encryptedPrefix)
+	if !ok {
+		return value, nil
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrDecryptPII
+	}
+
+	secrets := configs.CurrentSecrets()
+	for _, key := range [][]byte{secrets.PIIKey, secrets.PreviousPIIKey} {
+		if len(key) == 0 {
+			continue
+		}
+		gcm, err := newGCM(key)
+		if err != nil {
+			return "", err
+		}
+		if len(sealed) < gcm.NonceSize() {
+			return "", ErrDecryptPII
+		}
+		nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+		if plaintext, err := gcm.Open(nil, nonce, ciphertext, nil); err == nil {
+			return string(plaintext), nil
+		}
+	}
+	return "", ErrDecryptPII
+}
+
+// IsEncryptedPII indica se o valor já está criptografado
+func IsEncryptedPII(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// BlindIndex gera o hash pesquisável (HMAC-SHA256) de um dado pessoal já normalizado
+// Permite buscas por igualdade sem descriptografar a coluna; vazio sem chave configurada
+func BlindIndex(value string) string {
+	return blindIndexWith(configs.CurrentSecrets().PIIKey, value)
+}
+
+// BlindIndexes retorna os hashes com a chave atual e a anterior
+// Buscas devem usar IN para continuar encontrando registros ainda não reprocessados após uma rotação
+func BlindIndexes(value string) []string {
+	secrets := configs.CurrentSecrets()
+	indexes := []string{}
+	for _, key := range [][]byte{secrets.PIIKey, secrets.PreviousPIIKey} {
+		if idx := blindIndexWith(key, value); idx != "" {
+			indexes = append(indexes, idx)
+		}
+	}
+	return indexes
+}
+
+func blindIndexWith(key []byte, value string) string {
+	if value == "" || len(key) == 0 {
+		return ""
+	}
+	// Segurança: chave derivada separada da chave de criptografia
+	indexKey := hmac.New(sha256.New, key)
+	indexKey.Write([]byte("blind-index"))
+
+	mac := hmac.New(sha256.New, indexKey.Sum(nil))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptedSerializer criptografa campos string na escrita e descriptografa na leitura
+type EncryptedSerializer struct{}
+
+// Scan implementa schema.SerializerInterface
+func (EncryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case []byte:
+		value = string(v)
+	case string:
+		value = v
+	default:
+		return fmt.Errorf("unsupported type %T for encrypted field %s", dbValue, field.Name)
+	}
+
+	plaintext, err := DecryptPII(value)
+	if err != nil {
+		return err
+	}
+	return field.Set(ctx, dst, plaintext)
+}
+
+// Value implementa schema.SerializerInterface
+func (EncryptedSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("encrypted field %s must be a string", field.Name)
+	}
+	return EncryptPII(value)
+}
@@ -1,6 +1,21 @@
 package security
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrInvalidSignature indica token assinado inválido ou adulterado
+var ErrInvalidSignature = errors.New("invalid or tampered token")
 
 func EncryptPassword(password string) ([]byte, error) {
 	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -8,4 +23,55 @@ func EncryptPassword(password string) ([]byte, error) {
 
 func CheckPassword(hash string, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-}
\ No newline at end of file
+}
+
+// RandomToken gera um token aleatório criptograficamente seguro codificado em hex
+func RandomToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// SignID gera um token assinado (HMAC-SHA256) que identifica um registro para um propósito
+// O propósito impede que um token de opt-out seja reutilizado em outra rota pública
+func SignID(purpose string, id uint) string {
+	payload := strconv.FormatUint(uint64(id), 10)
+	return payload + "." + sign(purpose, payload)
+}
+
+// VerifySignedID valida um token gerado por SignID e retorna o ID
+func VerifySignedID(purpose, token string) (uint, error) {
+	payload, signature, found := strings.Cut(token, ".")
+	if !found {
+		return 0, ErrInvalidSignature
+	}
+
+	// Segurança: comparação em tempo constante previne timing attacks
+	// Links já enviados continuam válidos após uma rotação da chave
+	secrets := configs.CurrentSecrets()
+	valid := hmac.Equal([]byte(signature), []byte(signWith(secrets.JWTSecret, purpose, payload)))
+	if !valid && len(secrets.PreviousJWTSecret) > 0 {
+		valid = hmac.Equal([]byte(signature), []byte(signWith(secrets.PreviousJWTSecret, purpose, payload)))
+	}
+	if !valid {
+		return 0, ErrInvalidSignature
+	}
+
+	id, err := strconv.ParseUint(payload, 10, 32)
+	if err != nil || id == 0 {
+		return 0, ErrInvalidSignature
+	}
+	return uint(id), nil
+}
+
+func sign(purpose, payload string) string {
+	return signWith(configs.CurrentSecrets().JWTSecret, purpose, payload)
+}
+
+func signWith(key []byte, purpose, payload string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose + ":" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,52 @@
+package selfcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/mailer"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/payments"
+	"github.com/matheushermes/wedding_planner_service/internal/sms"
+	"github.com/matheushermes/wedding_planner_service/internal/storage"
+)
+
+// Tempo máximo total das verificações de boot (chamadas externas incluídas)
+const timeout = 30 * time.Second
+
+// check representa uma verificação de boot
+type check struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Run executa as verificações de boot e retorna todas as falhas encontradas
+// Deve ser chamado após a inicialização do banco e dos provedores de pagamento
+func Run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	checks := []check{
+		{name: "schema do banco", run: func(context.Context) error { return database.VerifySchema() }},
+		{name: "provedores de pagamento", run: payments.SelfCheck},
+		{name: "provedor de emails", run: mailer.SelfCheck},
+		{name: "provedor de SMS", run: sms.SelfCheck},
+		{name: "armazenamento de uploads", run: func(context.Context) error { return storage.SelfCheck() }},
+		{name: "país padrão dos telefones", run: func(context.Context) error { return models.PhoneSelfCheck() }},
+	}
+
+	var problems []error
+	for _, c := range checks {
+		if err := c.run(ctx); err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", c.name, err))
+			continue
+		}
+		log.Printf("  ✅ Verificação OK: %s", c.name)
+	}
+
+	return errors.Join(problems...)
+}
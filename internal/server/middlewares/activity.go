@@ -0,0 +1,77 @@
+package middlewares
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+const (
+	// Intervalo mínimo entre gravações de last_seen_at do mesmo usuário
+	lastSeenThrottle = 5 * time.Minute
+
+	// Tamanho do cache local a partir do qual entradas expiradas são descartadas
+	lastSeenCacheLimit = 10000
+)
+
+var (
+	lastSeenMu    sync.Mutex
+	lastSeenCache = map[uint]time.Time{}
+)
+
+// ActivityMiddleware registra a última atividade do usuário autenticado (last_seen_at)
+// Deve ser usado após AuthMiddleware
+// Performance: No máximo uma gravação por usuário a cada lastSeenThrottle por instância,
+// e o UPDATE condicional evita escritas repetidas vindas de outras réplicas
+func ActivityMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		userID, exists := c.Get("user_id")
+		if !exists {
+			return
+		}
+		id, ok := userID.(uint)
+		if !ok || !shouldTouchLastSeen(id, time.Now()) {
+			return
+		}
+
+		// Concorrência: Gravação fora do request, a resposta não espera pelo banco
+		go touchLastSeen(id)
+	}
+}
+
+// shouldTouchLastSeen aplica o throttle local e reserva a gravação para este request
+func shouldTouchLastSeen(userID uint, now time.Time) bool {
+	lastSeenMu.Lock()
+	defer lastSeenMu.Unlock()
+
+	if last, ok := lastSeenCache[userID]; ok && now.Sub(last) < lastSeenThrottle {
+		return false
+	}
+
+	if len(lastSeenCache) >= lastSeenCacheLimit {
+		for id, last := range lastSeenCache {
+			if now.Sub(last) >= lastSeenThrottle {
+				delete(lastSeenCache, id)
+			}
+		}
+	}
+	lastSeenCache[userID] = now
+	return true
+}
+
+func touchLastSeen(userID uint) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	err := repository.NewUserRepository(database.WithContext(ctx)).TouchLastSeen(userID, time.Now(), lastSeenThrottle)
+	if err != nil {
+		log.Printf("[WARN] Failed to update last_seen_at of user %d: %v", userID, err)
+	}
+}
@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/configs"
+)
+
+// AdminAuthMiddleware protege os endpoints operacionais em /admin
+// Exige "Authorization: Bearer <ADMIN_TOKEN>"; sem ADMIN_TOKEN os endpoints ficam desabilitados
+func AdminAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if configs.ADMIN_TOKEN == "" {
+			c.JSON(404, gin.H{
+				"error": "not found",
+			})
+			c.Abort()
+			return
+		}
+
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+		// Segurança: Comparação em tempo constante evita timing attacks no token
+		if subtle.ConstantTimeCompare([]byte(token), []byte(configs.ADMIN_TOKEN)) != 1 {
+			c.JSON(401, gin.H{
+				"error": "invalid admin token",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -6,10 +6,12 @@ import (
 )
 
 // AuthMiddleware é um middleware para proteger rotas que requerem autenticação
-func AuthMiddleware() gin.HandlerFunc {
+// Sem audiences aceita tokens de qualquer tipo de cliente; com audiences, apenas dos informados
+func AuthMiddleware(audiences ...auth.Audience) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Verifica o token
-		if err := auth.VerifyToken(c); err != nil {
+		// Verifica o token (assinatura, validade, tipo de cliente e uso)
+		claims, err := auth.Authenticate(c, audiences...)
+		if err != nil {
 			c.JSON(401, gin.H{
 				"error": err.Error(),
 			})
@@ -17,17 +19,8 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 		
-		userID, err := auth.ExtractUserID(c)
-		if err != nil {
-			c.JSON(401, gin.H{
-				"error": "failed to extract user information",
-			})
-			c.Abort()
-			return
-		}
-
 		// Armazena o user_id no contexto para uso nos handlers
-		c.Set("user_id", userID)
+		c.Set("user_id", claims.UserID)
 
 		// Continua para o próximo handler
 		c.Next()
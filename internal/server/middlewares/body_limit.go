@@ -0,0 +1,67 @@
+package middlewares
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/configs"
+)
+
+// BodyClass agrupa rotas com o mesmo limite de body
+type BodyClass int
+
+const (
+	BodyDefault BodyClass = iota
+	BodySmall             // login e cadastro: credenciais nunca passam de alguns KB
+	BodyImport            // importação de agendas
+	BodyUpload            // fotos em multipart
+)
+
+// Chave do body original no contexto: limites de rota substituem o do grupo em vez de se acumularem
+const rawBodyKey = "raw_body"
+
+// BodyLimitMiddleware limita o tamanho do body de acordo com a classe da rota (configurável em BODY_LIMIT_*_KB)
+// Bodies com Content-Length acima do limite recebem 413 sem serem lidos; os demais são cortados no limite
+// e o handler responde 413 ao ler além dele
+// Proteção contra DoS: aplicado nos grupos da API, com classes maiores apenas nas rotas de imports e uploads
+func BodyLimitMiddleware(class BodyClass) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := bodyLimit(class)
+
+		if c.Request.ContentLength > limit {
+			c.JSON(413, gin.H{
+				"error":       "request body too large",
+				"limit_bytes": limit,
+			})
+			c.Abort()
+			return
+		}
+
+		raw, exists := c.Get(rawBodyKey)
+		if !exists {
+			raw = c.Request.Body
+			c.Set(rawBodyKey, raw)
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, raw.(io.ReadCloser), limit)
+		c.Set("body_limit", limit)
+
+		c.Next()
+	}
+}
+
+// bodyLimit retorna o limite da classe em bytes
+func bodyLimit(class BodyClass) int64 {
+	settings := configs.Runtime()
+
+	kb := settings.BodyLimitDefaultKB
+	switch class {
+	case BodySmall:
+		kb = settings.BodyLimitSmallKB
+	case BodyImport:
+		kb = settings.BodyLimitImportKB
+	case BodyUpload:
+		kb = settings.BodyLimitUploadKB
+	}
+	return int64(kb) << 10
+}
@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/maintenance"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+)
+
+// Mensagem exibida quando o admin não informa uma
+const defaultMaintenanceMessage = "This feature is temporarily unavailable for maintenance. Please try again shortly."
+
+// MaintenanceMiddleware responde 503 com Retry-After enquanto a API toda ou uma das áreas estiver em manutenção
+// Sem áreas, checa apenas a manutenção global
+func MaintenanceMiddleware(features ...models.MaintenanceFeature) gin.HandlerFunc {
+	features = append([]models.MaintenanceFeature{models.MaintenanceAll}, features...)
+
+	return func(c *gin.Context) {
+		for _, feature := range features {
+			flag, active := maintenance.Check(c.Request.Context(), feature)
+			if !active {
+				continue
+			}
+
+			message := flag.Message
+			if message == "" {
+				message = defaultMaintenanceMessage
+			}
+			if flag.RetryAfterSeconds > 0 {
+				c.Header("Retry-After", strconv.Itoa(flag.RetryAfterSeconds))
+			}
+			c.JSON(503, gin.H{
+				"error":               "service temporarily unavailable for maintenance",
+				"message":             message,
+				"feature":             flag.Feature,
+				"retry_after_seconds": flag.RetryAfterSeconds,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/configs"
+)
+
+// MetricsAuthMiddleware protege o endpoint de métricas internas
+// Com METRICS_TOKEN definido exige "Authorization: Bearer <token>"; sem token, bloqueia em produção
+func MetricsAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		expected := configs.Runtime().MetricsToken
+		if expected == "" {
+			if configs.ENV == "production" {
+				c.JSON(404, gin.H{
+					"error": "not found",
+				})
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+		// Segurança: Comparação em tempo constante evita timing attacks no token
+		if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			c.JSON(401, gin.H{
+				"error": "invalid metrics token",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+	"github.com/matheushermes/wedding_planner_service/internal/repository"
+)
+
+// PublicWeddingMiddleware resolve o casamento de uma página pública
+// Usa o parâmetro :slug quando presente, senão o header Host (domínio customizado verificado, plano Pro)
+func PublicWeddingMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		repo := repository.NewWeddingRepository(database.WithContext(c.Request.Context()))
+
+		var (
+			wedding *models.Wedding
+			err     error
+		)
+
+		if slug := c.Param("slug"); slug != "" {
+			wedding, err = repo.FindBySlug(strings.ToLower(slug))
+		} else {
+			wedding, err = repo.FindByCustomDomain(requestHost(c), time.Now())
+		}
+
+		if err != nil {
+			c.JSON(404, gin.H{
+				"error": "page not found",
+			})
+			c.Abort()
+			return
+		}
+
+		// Armazena o casamento no contexto para uso nos handlers
+		c.Set("public_wedding", wedding)
+
+		c.Next()
+	}
+}
+
+// requestHost retorna o host da requisição normalizado (sem porta)
+func requestHost(c *gin.Context) string {
+	host := c.Request.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
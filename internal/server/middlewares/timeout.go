@@ -0,0 +1,184 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/metrics"
+)
+
+// TimeoutClass agrupa rotas com o mesmo tempo máximo de resposta
+type TimeoutClass int
+
+const (
+	TimeoutDefault TimeoutClass = iota
+	TimeoutLong                 // importações de agenda, chamadas a provedores externos e backups
+)
+
+// Chave do estado do timeout no contexto: o timeout da rota substitui o do grupo em vez de se acumular
+const timeoutStateKey = "route_timeout"
+
+// Margem para a resposta 504 ser escrita depois do deadline da rota
+const timeoutWriteGrace = 5 * time.Second
+
+// timeoutState guarda o contexto original da requisição e o deadline vigente
+type timeoutState struct {
+	base    context.Context
+	ctx     context.Context
+	cancel  context.CancelFunc
+	timeout time.Duration
+}
+
+// reset troca o deadline vigente por um novo a partir do contexto original
+func (s *timeoutState) reset(timeout time.Duration) {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.timeout = timeout
+	s.ctx, s.cancel = context.WithTimeout(s.base, timeout)
+}
+
+// expired indica se o deadline da rota expirou (cancelamento pelo cliente não conta)
+func (s *timeoutState) expired() bool {
+	return errors.Is(s.ctx.Err(), context.DeadlineExceeded)
+}
+
+// TimeoutMiddleware aplica o timeout da classe da rota (configurável em ROUTE_TIMEOUT_*_MS)
+// O contexto da requisição é cancelado no deadline: queries (database.WithContext) e chamadas externas
+// que usam c.Request.Context() são interrompidas, e o DB_QUERY_TIMEOUT_MS só vale se for menor que o restante
+// Respostas que o handler tenta escrever depois do deadline são descartadas e o cliente recebe 504
+// Concorrência: o handler roda na mesma goroutine (gin.Context não é seguro entre goroutines),
+// por isso o trabalho para no próximo ponto que respeita o contexto e não no instante do deadline
+func TimeoutMiddleware(class TimeoutClass) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := routeTimeout(class)
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		// Timeout da rota dentro do grupo: troca o deadline e deixa a resposta 504 com o middleware do grupo
+		if v, exists := c.Get(timeoutStateKey); exists {
+			state := v.(*timeoutState)
+			state.reset(timeout)
+			c.Request = c.Request.WithContext(state.ctx)
+			extendWriteDeadline(c.Writer, timeout)
+			c.Next()
+			return
+		}
+
+		state := &timeoutState{base: c.Request.Context()}
+		state.reset(timeout)
+		defer func() { state.cancel() }()
+		c.Set(timeoutStateKey, state)
+
+		writer := &timeoutWriter{ResponseWriter: c.Writer, state: state}
+		extendWriteDeadline(c.Writer, timeout)
+		c.Writer = writer
+		c.Request = c.Request.WithContext(state.ctx)
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		if !writer.timedOut && (writer.wroteHeader || c.Writer.Written() || !state.expired()) {
+			return
+		}
+
+		log.Printf("[WARN] Route %s %s timed out after %s", c.Request.Method, c.FullPath(), state.timeout)
+		metrics.RecordRouteTimeout(c.Request.Method + " " + c.FullPath())
+
+		// Cabeçalhos de download definidos pelo handler não valem para a resposta de erro
+		c.Writer.Header().Del("Content-Disposition")
+		c.Writer.Header().Del("Content-Length")
+		c.JSON(504, gin.H{
+			"error":      "request timed out",
+			"timeout_ms": state.timeout.Milliseconds(),
+		})
+	}
+}
+
+// routeTimeout retorna o timeout da classe
+func routeTimeout(class TimeoutClass) time.Duration {
+	settings := configs.Runtime()
+
+	ms := settings.RouteTimeoutDefaultMS
+	if class == TimeoutLong {
+		ms = settings.RouteTimeoutLongMS
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// extendWriteDeadline estende o WRITE_TIMEOUT_SECS do servidor para rotas com timeout maior
+// Sem isso a conexão seria encerrada antes do deadline da rota e o cliente não receberia o 504
+func extendWriteDeadline(w http.ResponseWriter, timeout time.Duration) {
+	if timeout+timeoutWriteGrace <= time.Duration(configs.WRITE_TIMEOUT_SECS)*time.Second {
+		return
+	}
+	err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout + timeoutWriteGrace))
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("[WARN] Failed to extend write deadline: %v", err)
+	}
+}
+
+// timeoutWriter descarta o que o handler escrever depois do deadline
+// Respostas já iniciadas antes do deadline (ex: exportação em stream) seguem até o fim
+type timeoutWriter struct {
+	gin.ResponseWriter
+	state       *timeoutState
+	wroteHeader bool
+	timedOut    bool
+}
+
+// discard marca a requisição como expirada se o deadline passou antes de qualquer escrita
+func (w *timeoutWriter) discard() bool {
+	if !w.timedOut && !w.wroteHeader && !w.ResponseWriter.Written() && w.state.expired() {
+		w.timedOut = true
+	}
+	return w.timedOut
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	if w.discard() {
+		return
+	}
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	if w.discard() {
+		return
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	if w.discard() {
+		return len(data), nil
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	if w.discard() {
+		return len(s), nil
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *timeoutWriter) Flush() {
+	if w.discard() {
+		return
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap permite ao http.ResponseController alcançar a conexão original
+func (w *timeoutWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,57 @@
+package middlewares
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/internal/authz"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+)
+
+// WeddingScopeMiddleware carrega e autoriza o casamento do parâmetro :id uma única vez por request
+// Deve ser usado após AuthMiddleware nas rotas aninhadas em /weddings/:id
+// Performance: Handlers reutilizam o casamento do contexto ao invés de repetir a query
+func WeddingScopeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("user_id")
+		if !exists {
+			c.JSON(401, gin.H{
+				"error": "authentication required",
+			})
+			c.Abort()
+			return
+		}
+
+		weddingID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil || weddingID == 0 {
+			c.JSON(400, gin.H{
+				"error": "invalid ID parameter",
+			})
+			c.Abort()
+			return
+		}
+
+		// Segurança: A ação é derivada do método HTTP e checada em authz.Can
+		wedding, err := authz.LoadWedding(database.WithContext(c.Request.Context()), authz.Subject{UserID: userID.(uint)}, uint(weddingID), authz.ActionForMethod(c.Request.Method))
+		if err != nil {
+			if authz.IsNotFound(err) {
+				c.JSON(404, gin.H{
+					"error": err.Error(),
+				})
+			} else {
+				log.Printf("[ERROR] Failed to load wedding %d for user %d: %v", weddingID, userID, err)
+				c.JSON(500, gin.H{
+					"error": "internal server error",
+				})
+			}
+			c.Abort()
+			return
+		}
+
+		// Armazena o casamento no contexto para uso nos handlers
+		c.Set("wedding", wedding)
+
+		c.Next()
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/matheushermes/wedding_planner_service/configs"
 	"github.com/matheushermes/wedding_planner_service/internal/controllers"
+	"github.com/matheushermes/wedding_planner_service/internal/health"
+	"github.com/matheushermes/wedding_planner_service/internal/metrics"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
 	"github.com/matheushermes/wedding_planner_service/internal/server/middlewares"
 )
 
@@ -19,8 +22,33 @@ func ConfigRoutes(router *gin.Engine) *gin.Engine {
 		router.Use(corsMiddleware())
 	}
 
+	// Páginas públicas: por slug (/w/ana-e-joao) ou domínio customizado (raiz do host)
+	router.GET("/w/:slug", middlewares.PublicWeddingMiddleware(), controllers.GetPublicPage)
+	router.GET("/", middlewares.PublicWeddingMiddleware(), controllers.GetPublicPage)
+
+	// Métricas internas (expvar): queries lentas, timeouts, etc
+	router.GET("/debug/vars", middlewares.MetricsAuthMiddleware(), gin.WrapH(metrics.Handler()))
+
+	// Endpoints operacionais (token ADMIN_TOKEN)
+	admin := router.Group("/admin", middlewares.AdminAuthMiddleware(), middlewares.TimeoutMiddleware(middlewares.TimeoutDefault), middlewares.BodyLimitMiddleware(middlewares.BodyDefault))
+	{
+		admin.POST("/config/reload", controllers.ReloadConfig)
+		admin.POST("/backups/users/:userId", middlewares.TimeoutMiddleware(middlewares.TimeoutLong), controllers.ExportTenantBackup)
+		admin.GET("/users", controllers.GetUsersActivity)
+		admin.GET("/referrals/report", controllers.GetReferralReport)
+		admin.POST("/announcements", controllers.PublishAnnouncement)
+		admin.GET("/announcements", controllers.GetAnnouncements)
+
+		// Manutenção global ("all") ou por área, ex: envio de convites pausado durante a troca de provedor
+		admin.GET("/maintenance", controllers.GetMaintenanceFlags)
+		admin.PUT("/maintenance/:feature", controllers.SetMaintenanceFlag)
+		admin.DELETE("/maintenance/:feature", controllers.ClearMaintenanceFlag)
+	}
+
 	// Grupo principal da API
-	api := router.Group("/api/v1")
+	// Health e /admin ficam fora da manutenção: os demais grupos respondem 503 com a manutenção global
+	// Bodies e timeouts limitados por classe de rota: padrão no grupo, classes maiores ou menores na própria rota
+	api := router.Group("/api/v1", middlewares.TimeoutMiddleware(middlewares.TimeoutDefault), middlewares.BodyLimitMiddleware(middlewares.BodyDefault))
 	{
 		// Health detalhado
 		health := api.Group("/health")
@@ -28,58 +56,295 @@ func ConfigRoutes(router *gin.Engine) *gin.Engine {
 			health.GET("/status", healthCheck)
 		}
 
+		// Public - Rotas públicas (sem autenticação)
+		public := api.Group("/public", middlewares.MaintenanceMiddleware())
+		{
+			public.GET("/covers/:name", controllers.GetCoverPhoto)
+			public.GET("/calendar/:token/payments.ics", controllers.GetPaymentsFeed)
+			public.GET("/opt-out/:token", controllers.GetOptOut)
+			public.POST("/opt-out/:token", controllers.OptOutGuest)
+			public.GET("/unsubscribe/:token", controllers.UnsubscribeLifecycleEmails)
+			public.POST("/unsubscribe/:token", controllers.UnsubscribeLifecycleEmails)
+
+			// Link pessoal do convidado (token assinado): resposta do RSVP e endereço para o convite impresso
+			public.POST("/rsvp/:token", controllers.SubmitPublicRSVP)
+			public.GET("/rsvp/:token/address", controllers.GetPublicRSVPAddress)
+			public.PUT("/rsvp/:token/address", controllers.SubmitPublicRSVPAddress)
+			// Informações do evento que o casal escolheu compartilhar (local, traje, programação, mesa)
+			public.GET("/rsvp/:token/event", controllers.GetPublicRSVPEvent)
+
+			// Convite por email (token assinado): pixel de abertura e link de RSVP com registro do clique
+			public.GET("/invites/:token/open.gif", controllers.TrackInviteOpen)
+			public.GET("/invites/:token/click", controllers.TrackInviteClick)
+
+			// Portal de fotos dos convidados (link com token e validade)
+			public.GET("/photos/:token", controllers.GetPublicPhotoPortal)
+			public.POST("/photos/:token", middlewares.MaintenanceMiddleware(models.MaintenancePhotos), middlewares.BodyLimitMiddleware(middlewares.BodyUpload), controllers.UploadPublicPhoto)
+			public.GET("/photos/:token/files/:name", controllers.GetPublicPhotoFile)
+
+			// Widget embutível: CORS aberto para qualquer origem
+			embed := public.Group("/embed/:token", embedCorsMiddleware())
+			{
+				embed.GET("/countdown", controllers.GetEmbedCountdown)
+				embed.GET("/countdown.svg", controllers.GetEmbedCountdownSVG)
+				embed.OPTIONS("/countdown", nil)
+			}
+		}
+
+		// Webhooks - Callbacks de provedores externos (autenticados por assinatura)
+		webhooks := api.Group("/webhooks", middlewares.MaintenanceMiddleware())
+		{
+			// Provedores reenviam o webhook depois do 503
+			webhooks.POST("/payments/:provider", middlewares.MaintenanceMiddleware(models.MaintenancePayments), controllers.HandlePaymentWebhook)
+			webhooks.POST("/mail/sendgrid", middlewares.MaintenanceMiddleware(models.MaintenanceInvites), controllers.HandleSendGridEvents)
+			webhooks.POST("/whatsapp/twilio/:token", middlewares.MaintenanceMiddleware(models.MaintenanceInvites), controllers.HandleTwilioStatus)
+		}
+
 		// User - Autenticação
-		user := api.Group("/user")
+		user := api.Group("/user", middlewares.MaintenanceMiddleware())
 		{
 			// 🌐 públicas
-			user.POST("/register", controllers.RegisterUser)
-			user.POST("/login", controllers.Login)
+			user.POST("/register", middlewares.BodyLimitMiddleware(middlewares.BodySmall), controllers.RegisterUser)
+			user.POST("/login", middlewares.BodyLimitMiddleware(middlewares.BodySmall), controllers.Login)
+			user.POST("/token/refresh", middlewares.BodyLimitMiddleware(middlewares.BodySmall), controllers.RefreshToken)
 
 			// 🔐 privadas
-			user.Use(middlewares.AuthMiddleware())
+			user.Use(middlewares.AuthMiddleware(), middlewares.ActivityMiddleware())
 			{
 				user.GET("/profile", controllers.GetProfile)
 				user.PATCH("/update", controllers.UpdateProfile)
 				user.DELETE("/delete", controllers.DeleteUser)
+				user.POST("/calendar/payments-feed", controllers.RotatePaymentsFeedToken)
+				user.PUT("/default-wedding", controllers.SetDefaultWedding)
+				user.PUT("/email-preferences", controllers.UpdateEmailPreferences)
+				user.GET("/referral", controllers.GetMyReferral)
+				user.GET("/notifications", controllers.GetNotifications)
+				user.POST("/notifications/read-all", controllers.MarkAllNotificationsRead)
+				user.POST("/notifications/:notificationId/read", controllers.MarkNotificationRead)
 				user.POST("/logout", nil)
 			}
 		}
 
+		// Vendors - Catálogo de fornecedores da conta (reutilizável entre casamentos)
+		vendors := api.Group("/vendors", middlewares.MaintenanceMiddleware(), middlewares.AuthMiddleware(), middlewares.ActivityMiddleware())
+		{
+			vendors.POST("", controllers.CreateVendor)
+			vendors.GET("", controllers.GetVendors)
+			vendors.GET("/:vendorId", controllers.GetVendor)
+			vendors.PUT("/:vendorId", controllers.UpdateVendor)
+			vendors.DELETE("/:vendorId", controllers.DeleteVendor)
+		}
+
+		// Jobs - Operações longas (exportações, importações, envios em massa) pedidas com resposta 202
+		jobs := api.Group("/jobs", middlewares.MaintenanceMiddleware(), middlewares.AuthMiddleware(), middlewares.ActivityMiddleware())
+		{
+			jobs.GET("/:jobId", controllers.GetJob)
+			jobs.GET("/:jobId/download", controllers.DownloadJobResult)
+		}
+
 		// Wedding - Dados do Casamento
-		weddings := api.Group("/weddings", middlewares.AuthMiddleware())
+		weddings := api.Group("/weddings", middlewares.MaintenanceMiddleware(), middlewares.AuthMiddleware(), middlewares.ActivityMiddleware())
 		{
 			weddings.POST("/", controllers.CreateWedding)
 			weddings.GET("/", controllers.GetWeddings)
+			weddings.GET("/current", controllers.GetCurrentWedding)
 			weddings.GET("/:id", controllers.GetWedding)
 			weddings.PUT("/:id", controllers.UpdateWedding)
 			weddings.DELETE("/:id", controllers.DeleteWedding)
 
 			// Recursos aninhados dentro do wedding
-			wedding := weddings.Group("/:id")
+			// Performance: O casamento é carregado e autorizado uma vez pelo middleware
+			wedding := weddings.Group("/:id", middlewares.WeddingScopeMiddleware())
 			{
 				// Contagem regressiva
 				wedding.GET("/countdown", controllers.GetCountdown)
 
+				// Remarcação: nova data, prazos recalculados e aviso aos confirmados
+				wedding.POST("/reschedule", controllers.RescheduleWedding)
+				wedding.POST("/reschedule/announce", middlewares.MaintenanceMiddleware(models.MaintenanceInvites), controllers.AnnounceReschedule)
+
+				// Vendors - Fornecedores do casamento (preço específico por casamento)
+				weddingVendors := wedding.Group("/vendors")
+				{
+					weddingVendors.POST("", controllers.AttachVendor)
+					weddingVendors.GET("", controllers.GetWeddingVendors)
+					weddingVendors.PUT("/:vendorId", controllers.UpdateWeddingVendor)
+					weddingVendors.DELETE("/:vendorId", controllers.DetachVendor)
+				}
+
+				// Ledger - Livro-razão financeiro (somente leitura)
+				wedding.GET("/ledger", controllers.GetLedger)
+				wedding.GET("/ledger/export", controllers.ExportLedger)
+
+				// Reports - Relatórios para o dia do evento
+				wedding.GET("/reports/full.pdf", controllers.GetFullReportPDF)
+
+				// Widget de contagem regressiva embutível
+				wedding.POST("/embed-token", controllers.RotateEmbedToken)
+
+				// Endereço público (slug e domínio customizado)
+				wedding.PUT("/public-address", controllers.UpdatePublicAddress)
+				wedding.POST("/public-address/verify", controllers.VerifyCustomDomain)
+
+				// Theme - Página pública do casamento
+				theme := wedding.Group("/theme")
+				{
+					theme.GET("", controllers.GetTheme)
+					theme.PUT("", controllers.UpdateTheme)
+					theme.POST("/cover", middlewares.BodyLimitMiddleware(middlewares.BodyUpload), controllers.UploadCoverPhoto)
+					theme.GET("/preview", controllers.PreviewPublicPage)
+					theme.POST("/publish", controllers.PublishPublicPage)
+				}
+
+				// Convites impressos: templates do provedor e lotes com rastreio por peça
+				printing := wedding.Group("/print", middlewares.MaintenanceMiddleware(models.MaintenancePrinting))
+				{
+					printing.GET("/templates", controllers.GetPrintTemplates)
+					printing.POST("/orders", middlewares.TimeoutMiddleware(middlewares.TimeoutLong), controllers.CreatePrintOrder)
+					printing.GET("/orders", controllers.GetPrintOrders)
+					printing.GET("/orders/:orderId", controllers.GetPrintOrder)
+				}
+
+				// Informações do evento exibidas aos convidados pelo link pessoal
+				wedding.GET("/event-info", controllers.GetEventInfo)
+				wedding.PUT("/event-info", controllers.UpdateEventInfo)
+
 				// Guests - Módulo de Convidados
 				guests := wedding.Group("/guests")
 				{
-					guests.POST("", nil)            // TODO: Implementar controller - Cadastrar convidado
-					guests.POST("/batch", nil)      // TODO: Implementar controller - Cadastrar convidados em lote
-					guests.GET("", nil)             // TODO: Implementar controller - Listar todos os convidados
-					guests.GET("/stats", nil)       // TODO: Implementar controller - Estatísticas de convidados
-					guests.GET("/:guestId", nil)    // TODO: Implementar controller - Obter convidado específico
-					guests.PUT("/:guestId", nil)    // TODO: Implementar controller - Editar convidado
-					guests.DELETE("/:guestId", nil) // TODO: Implementar controller - Remover convidado
+					guests.POST("", controllers.CreateGuest)
+					guests.POST("/batch", nil) // TODO: Implementar controller - Cadastrar convidados em lote
+					guests.GET("", controllers.GetGuests)
+					guests.GET("/stats", controllers.GetGuestStats)
+					guests.GET("/dietary-report", controllers.GetDietaryReport)
+					guests.GET("/transport-report", controllers.GetTransportReport)
+					guests.GET("/duplicates", controllers.GetDuplicateGuests)
+					guests.GET("/quality", controllers.GetGuestQualityReport)
+					guests.GET("/waitlist/next", controllers.GetWaitlistSuggestions)
+					guests.POST("/waitlist/promote", middlewares.TimeoutMiddleware(middlewares.TimeoutLong), controllers.PromoteWaitlist)
+					guests.PATCH("/status", controllers.BulkUpdateGuestStatus)
+					guests.GET("/addresses", controllers.ExportGuestAddresses)
+					guests.GET("/:guestId", controllers.GetGuest)
+					guests.PUT("/:guestId", controllers.UpdateGuest)
+					guests.DELETE("/:guestId", controllers.DeleteGuest)
+					guests.POST("/:guestId/merge", controllers.MergeGuest)
+					guests.POST("/:guestId/promote", controllers.PromoteWaitlistedGuest)
+					guests.POST("/:guestId/invite", middlewares.MaintenanceMiddleware(models.MaintenanceInvites), controllers.SendGuestInvite)
+					guests.GET("/:guestId/rsvp-link", controllers.GetGuestRSVPLink)
+					guests.GET("/:guestId/history", controllers.GetGuestStatusHistory)
+					guests.POST("/import", controllers.ImportGuests)
+
+					// Lixeira: convidados removidos podem ser restaurados ou apagados definitivamente
+					guests.GET("/trash", controllers.GetGuestTrash)
+					guests.DELETE("/trash", controllers.EmptyGuestTrash)
+					guests.DELETE("/trash/:guestId", controllers.PurgeGuest)
+					guests.POST("/:guestId/restore", controllers.RestoreGuest)
+
+					// Importação da agenda: prévia (.vcf ou Google Contacts) e confirmação da lista revisada
+					guests.POST("/import/vcard", middlewares.TimeoutMiddleware(middlewares.TimeoutLong), middlewares.MaintenanceMiddleware(models.MaintenanceContactsImport), middlewares.BodyLimitMiddleware(middlewares.BodyImport), controllers.PreviewVCardImport)
+					guests.POST("/import/google", middlewares.TimeoutMiddleware(middlewares.TimeoutLong), middlewares.MaintenanceMiddleware(models.MaintenanceContactsImport), controllers.PreviewGoogleContactsImport)
+					guests.POST("/import/contacts", middlewares.TimeoutMiddleware(middlewares.TimeoutLong), middlewares.BodyLimitMiddleware(middlewares.BodyImport), controllers.ConfirmContactsImport)
+
+					// Acompanhantes nomeados do convidado (dentro do limite max_guests)
+					guests.POST("/:guestId/companions", controllers.CreateCompanion)
+					guests.GET("/:guestId/companions", controllers.GetCompanions)
+					guests.PUT("/:guestId/companions/:companionId", controllers.UpdateCompanion)
+					guests.DELETE("/:guestId/companions/:companionId", controllers.DeleteCompanion)
+
+					// Respostas do convidado às perguntas personalizadas do RSVP
+					guests.GET("/:guestId/rsvp-answers", controllers.GetRSVPAnswers)
+					guests.PUT("/:guestId/rsvp-answers", controllers.SaveRSVPAnswers)
+
+					// QR Code de check-in do convidado confirmado (enviado junto ao convite)
+					guests.GET("/:guestId/checkin-qr.png", controllers.GetGuestCheckInQRCode)
+				}
+
+				// Photo portal - Fotos enviadas pelos convidados após o evento
+				wedding.POST("/photo-portal", controllers.OpenPhotoPortal)
+				wedding.GET("/photo-portal", controllers.GetPhotoPortal)
+				wedding.DELETE("/photo-portal", controllers.ClosePhotoPortal)
+				photos := wedding.Group("/photos", middlewares.MaintenanceMiddleware(models.MaintenancePhotos))
+				{
+					photos.GET("", controllers.GetGuestPhotos)
+					photos.POST("/archive", controllers.RequestPhotoArchive)
+					photos.GET("/archive.zip", controllers.DownloadPhotoArchive)
+					photos.PUT("/:photoId", controllers.ModerateGuestPhoto)
+					photos.DELETE("/:photoId", controllers.DeleteGuestPhoto)
+					photos.GET("/:photoId/file", controllers.GetGuestPhotoFile)
+				}
+
+				// Check-in - Lista da portaria no dia do evento
+				wedding.POST("/checkin", controllers.CheckInGuest)
+				checkin := wedding.Group("/checkin")
+				{
+					checkin.GET("/stats", controllers.GetCheckInStats)
+					checkin.DELETE("/:guestId", controllers.UndoCheckIn)
+				}
+
+				// Event day - Painel do dia do evento (portaria e programação), consultado em polling
+				wedding.GET("/event-day", controllers.GetEventDayDashboard)
+
+				// RSVP questions - Perguntas personalizadas do casal (transporte, pedido de música...)
+				rsvpQuestions := wedding.Group("/rsvp-questions")
+				{
+					rsvpQuestions.POST("", controllers.CreateRSVPQuestion)
+					rsvpQuestions.GET("", controllers.GetRSVPQuestions)
+					rsvpQuestions.GET("/report", controllers.GetRSVPAnswersReport)
+					rsvpQuestions.PUT("/:questionId", controllers.UpdateRSVPQuestion)
+					rsvpQuestions.DELETE("/:questionId", controllers.DeleteRSVPQuestion)
+				}
+
+				// Guest groups - Famílias/grupos de convidados (RSVP e mesas por grupo)
+				guestGroups := wedding.Group("/guest-groups")
+				{
+					guestGroups.POST("", controllers.CreateGuestGroup)
+					guestGroups.GET("", controllers.GetGuestGroups)
+					guestGroups.PUT("/:groupId", controllers.UpdateGuestGroup)
+					guestGroups.DELETE("/:groupId", controllers.DeleteGuestGroup)
+					guestGroups.POST("/:groupId/guests", controllers.AssignGuestsToGroup)
+					guestGroups.DELETE("/:groupId/guests/:guestId", controllers.RemoveGuestFromGroup)
+				}
+
+				// Guest tags - Etiquetas livres (lado da noiva/noivo, trabalho...), várias por convidado
+				guestTags := wedding.Group("/guest-tags")
+				{
+					guestTags.POST("", controllers.CreateGuestTag)
+					guestTags.GET("", controllers.GetGuestTags)
+					guestTags.PUT("/:tagId", controllers.UpdateGuestTag)
+					guestTags.DELETE("/:tagId", controllers.DeleteGuestTag)
+					guestTags.POST("/:tagId/guests", controllers.AssignGuestsToTag)
+					guestTags.DELETE("/:tagId/guests/:guestId", controllers.RemoveGuestFromTag)
+				}
+
+				// WhatsApp do casal para o envio dos convites (credenciais por casamento)
+				wedding.GET("/whatsapp", controllers.GetWhatsAppAccount)
+				wedding.PUT("/whatsapp", controllers.UpdateWhatsAppAccount)
+				wedding.DELETE("/whatsapp", controllers.DeleteWhatsAppAccount)
+
+				// Invite templates - Mensagens salvas do casal (save-the-date, convite, lembrete) e padrões
+				inviteTemplates := wedding.Group("/invite-templates")
+				{
+					inviteTemplates.POST("", controllers.CreateInviteTemplate)
+					inviteTemplates.GET("", controllers.GetInviteTemplates)
+					inviteTemplates.GET("/:templateId", controllers.GetInviteTemplate)
+					inviteTemplates.PUT("/:templateId", controllers.UpdateInviteTemplate)
+					inviteTemplates.DELETE("/:templateId", controllers.DeleteInviteTemplate)
 				}
 
 				// Invites - Módulo de Convites Automáticos
 				invites := wedding.Group("/invites")
 				{
-					invites.POST("", nil)                  // TODO: Implementar controller - Criar convite
-					invites.GET("", nil)                   // TODO: Implementar controller - Listar convites
-					invites.GET("/:inviteId", nil)         // TODO: Implementar controller - Obter convite específico
-					invites.PUT("/:inviteId", nil)         // TODO: Implementar controller - Atualizar convite
-					invites.POST("/:inviteId/send", nil)   // TODO: Implementar controller - Enviar convite
+					invites.POST("", nil) // TODO: Implementar controller - Criar convite
+					invites.GET("", controllers.GetInvites)
+					invites.GET("/engagement", controllers.GetInviteEngagement)
+					invites.GET("/:inviteId/qrcode.png", controllers.GetInviteQRCode)
+					invites.GET("/:inviteId/deliveries", controllers.GetInviteDeliveries)
+					invites.GET("/:inviteId", nil) // TODO: Implementar controller - Obter convite específico
+					invites.PUT("/:inviteId", nil) // TODO: Implementar controller - Atualizar convite
+					invites.POST("/preview", controllers.PreviewInvite)
+					invites.POST("/dry-run", controllers.DryRunInvites)
+					invites.POST("/bulk", middlewares.MaintenanceMiddleware(models.MaintenanceInvites), controllers.BulkSendInvites)
+					invites.POST("/:inviteId/send", middlewares.MaintenanceMiddleware(models.MaintenanceInvites), controllers.SendInvite)
 					invites.POST("/:inviteId/resend", nil) // TODO: Implementar controller - Reenviar convite
 				}
 
@@ -109,11 +374,13 @@ func ConfigRoutes(router *gin.Engine) *gin.Engine {
 				{
 					fundraising.POST("", nil)                  // TODO: Implementar controller - Registrar arrecadação
 					fundraising.GET("", nil)                   // TODO: Implementar controller - Listar arrecadações
-					fundraising.GET("/summary", nil)           // TODO: Implementar controller - Resumo de arrecadações
 					fundraising.GET("/by-type", nil)           // TODO: Implementar controller - Arrecadações por tipo
 					fundraising.GET("/:fundraisingId", nil)    // TODO: Implementar controller - Obter arrecadação específica
 					fundraising.PUT("/:fundraisingId", nil)    // TODO: Implementar controller - Atualizar arrecadação
 					fundraising.DELETE("/:fundraisingId", nil) // TODO: Implementar controller - Deletar arrecadação
+					fundraising.GET("/summary", controllers.GetFundraisingSummary)
+					fundraising.POST("/:fundraisingId/refund", middlewares.MaintenanceMiddleware(models.MaintenancePayments), controllers.RefundFundraising)
+					fundraising.POST("/:fundraisingId/dispute/resolve", middlewares.MaintenanceMiddleware(models.MaintenancePayments), controllers.ResolveFundraisingDispute)
 				}
 			}
 		}
@@ -123,10 +390,13 @@ func ConfigRoutes(router *gin.Engine) *gin.Engine {
 }
 
 // healthCheck handler de health check
+// Integrações opcionais (email, WhatsApp, pagamentos, uploads) aparecem degradadas ou indisponíveis
+// sem mudar o status: o serviço segue atendendo e o balanceador não o retira de rotação
 func healthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
-		"status": "healthy",
-		"env":    configs.ENV,
+		"status":       "healthy",
+		"env":          configs.ENV,
+		"dependencies": health.Report(),
 	})
 }
 
@@ -145,3 +415,20 @@ func corsMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// embedCorsMiddleware libera leitura por qualquer origem (widget embutido em sites externos)
+// Segurança: Apenas GET, sem credenciais; os dados expostos são públicos por definição
+func embedCorsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
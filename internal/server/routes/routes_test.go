@@ -0,0 +1,346 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/auth"
+	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/database/dbtest"
+)
+
+const (
+	ownerID    uint = 1
+	intruderID uint = 2
+
+	ownerWeddingID    = "10"
+	intruderWeddingID = "20"
+	missingWeddingID  = "999"
+)
+
+// Prefixo das rotas aninhadas autorizadas pelo WeddingScopeMiddleware
+const nestedPrefix = "/api/v1/weddings/:id/"
+
+var (
+	paramRegex = regexp.MustCompile(`:\w+|\*\w+`)
+
+	// Banco em memória compartilhado pelos testes: as queries registradas são conferidas por rota
+	fakeDB *dbtest.DB
+
+	statementTableRegex = regexp.MustCompile("(?is)^\\s*(?:SELECT\\b.*?\\bFROM|INSERT\\s+INTO|UPDATE|DELETE\\s+FROM)\\s+`?(\\w+)`?")
+	insertColumnsRegex  = regexp.MustCompile("(?is)^\\s*INSERT\\s+INTO\\s+`?\\w+`?\\s*\\(([^)]*)\\)")
+	statementWhereRegex = regexp.MustCompile("(?is)\\bWHERE\\b")
+	tenantColumnRegex   = regexp.MustCompile("(?:`?(\\w+)`?\\.)?`?(wedding_id|user_id|id)`?\\s*=\\s*\\?")
+	orRegex             = regexp.MustCompile("(?i)\\bOR\\b")
+)
+
+// Tabelas globais, sem dono: não precisam de filtro por casamento ou usuário
+var globalTables = map[string]bool{
+	"maintenance_flags": true,
+}
+
+func TestMain(m *testing.M) {
+	os.Setenv("ENV", "test")
+	os.Setenv("GIN_MODE", "release")
+	os.Setenv("DATABASE_URL", "test:test@tcp(127.0.0.1:3306)/test")
+	os.Setenv("JWT_SECRET", "routes-test-secret")
+	configs.LoadEnv()
+	gin.SetMode(gin.TestMode)
+
+	db, fake := dbtest.Open()
+	fakeDB = fake
+	fake.Insert("users",
+		dbtest.Row{"id": ownerID, "name": "Owner", "email": "owner@example.com"},
+		dbtest.Row{"id": intruderID, "name": "Intruder", "email": "intruder@example.com"},
+	)
+	fake.Insert("weddings",
+		dbtest.Row{"id": 10, "user_id": ownerID, "venue_name": "Espaço do Owner", "max_guests": 100},
+		dbtest.Row{"id": 20, "user_id": intruderID, "venue_name": "Espaço do Intruder", "max_guests": 100},
+	)
+	database.DB = db
+
+	os.Exit(m.Run())
+}
+
+// newTestRouter monta o router real da API
+func newTestRouter() *gin.Engine {
+	return ConfigRoutes(gin.New())
+}
+
+// tokenFor emite um token de acesso web para o usuário
+func tokenFor(t *testing.T, userID uint) string {
+	t.Helper()
+	token, err := auth.CreateToken(userID, "user@example.com", auth.AudienceWeb)
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+	return token
+}
+
+// nestedRequest monta a URL da rota aninhada para o casamento, com IDs fictícios nos demais parâmetros
+func nestedRequest(method, routePath, weddingID string) *http.Request {
+	path := strings.Replace(routePath, ":id", weddingID, 1)
+	path = paramRegex.ReplaceAllString(path, "1")
+	return httptest.NewRequest(method, path, strings.NewReader("{}"))
+}
+
+// TestCrossTenantNestedRoutesReturnNotFound percorre todas as rotas aninhadas em /weddings/:id
+// O casamento de outro usuário deve responder exatamente como um casamento inexistente: 404, nunca 403
+// Segurança: além da resposta, confere as queries: antes da autorização só há a busca do casamento
+// pela chave e o registro de atividade do próprio usuário; nada é lido ou gravado nas demais tabelas
+func TestCrossTenantNestedRoutesReturnNotFound(t *testing.T) {
+	router := newTestRouter()
+	token := tokenFor(t, intruderID)
+
+	covered := 0
+	for _, route := range router.Routes() {
+		if !strings.HasPrefix(route.Path, nestedPrefix) {
+			continue
+		}
+		covered++
+
+		t.Run(route.Method+" "+route.Path, func(t *testing.T) {
+			before := len(fakeDB.Statements())
+			foreign := serve(router, nestedRequest(route.Method, route.Path, ownerWeddingID), token)
+			statements := fakeDB.Statements()[before:]
+			missing := serve(router, nestedRequest(route.Method, route.Path, missingWeddingID), token)
+
+			if foreign.Code != http.StatusNotFound {
+				t.Fatalf("status = %d, want 404 (body %s)", foreign.Code, foreign.Body.String())
+			}
+			if foreign.Body.String() != missing.Body.String() || foreign.Code != missing.Code {
+				t.Errorf("foreign wedding response %d %s differs from missing wedding %d %s",
+					foreign.Code, foreign.Body.String(), missing.Code, missing.Body.String())
+			}
+
+			for _, stmt := range statements {
+				table := statementTable(stmt)
+				if globalTables[table] {
+					continue
+				}
+				if (table != "weddings" || stmt.IsWrite()) && table != "users" {
+					t.Errorf("query before the ownership check: %s %v", stmt.SQL, stmt.Args)
+					continue
+				}
+				if err := tenantPredicate(stmt, ownerWeddingID, intruderID); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+
+	if covered == 0 {
+		t.Fatal("no nested wedding routes found")
+	}
+}
+
+// TestNestedRoutesScopeQueriesToWedding percorre as rotas aninhadas como dono do casamento
+// Segurança: toda query sobre dados da conta (leitura ou escrita) precisa filtrar pelo casamento da
+// rota (wedding_id, ou id na tabela weddings) ou pelo usuário autenticado (user_id, ou id em users),
+// fora de qualquer OR; uma busca só pela chave do registro vazaria dados de outros casamentos
+func TestNestedRoutesScopeQueriesToWedding(t *testing.T) {
+	router := newTestRouter()
+	token := tokenFor(t, ownerID)
+
+	for _, route := range router.Routes() {
+		if !strings.HasPrefix(route.Path, nestedPrefix) {
+			continue
+		}
+
+		t.Run(route.Method+" "+route.Path, func(t *testing.T) {
+			before := len(fakeDB.Statements())
+			serve(router, nestedRequest(route.Method, route.Path, ownerWeddingID), token)
+
+			for _, stmt := range fakeDB.Statements()[before:] {
+				if globalTables[statementTable(stmt)] {
+					continue
+				}
+				if err := tenantPredicate(stmt, ownerWeddingID, ownerID); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
+
+// statementTable retorna a tabela principal da query (FROM, INSERT INTO, UPDATE ou DELETE FROM)
+func statementTable(stmt dbtest.Statement) string {
+	match := statementTableRegex.FindStringSubmatch(stmt.SQL)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// tenantPredicate verifica se a query está limitada ao casamento ou ao usuário esperados
+// INSERT precisa gravar wedding_id ou user_id com o valor esperado em todas as linhas;
+// as demais queries precisam de uma igualdade da tabela principal no WHERE, fora de OR
+func tenantPredicate(stmt dbtest.Statement, weddingID string, userID uint) error {
+	table := statementTable(stmt)
+	expected := func(column string) string {
+		switch {
+		case column == "wedding_id", column == "id" && table == "weddings":
+			return weddingID
+		case column == "user_id", column == "id" && table == "users":
+			return fmt.Sprint(userID)
+		}
+		return ""
+	}
+
+	if columns := insertColumnsRegex.FindStringSubmatch(stmt.SQL); columns != nil {
+		names := strings.Split(columns[1], ",")
+		for i, name := range names {
+			want := expected(strings.Trim(strings.TrimSpace(name), "`"))
+			if want == "" || strings.Trim(strings.TrimSpace(name), "`") == "id" {
+				continue
+			}
+			for row := i; row < len(stmt.Args); row += len(names) {
+				if fmt.Sprint(stmt.Args[row]) != want {
+					return fmt.Errorf("insert into %s writes %s = %v, want %s: %s", table, name, stmt.Args[row], want, stmt.SQL)
+				}
+			}
+			return nil
+		}
+		return fmt.Errorf("insert into %s without wedding_id or user_id: %s", table, stmt.SQL)
+	}
+
+	where := statementWhereRegex.FindStringIndex(stmt.SQL)
+	if where == nil {
+		return fmt.Errorf("query on %s without WHERE: %s %v", table, stmt.SQL, stmt.Args)
+	}
+	for _, cond := range tenantColumnRegex.FindAllStringSubmatchIndex(stmt.SQL[where[1]:], -1) {
+		start, end := where[1]+cond[0], where[1]+cond[1]
+		qualifier, column := "", stmt.SQL[where[1]+cond[4]:where[1]+cond[5]]
+		if cond[2] >= 0 {
+			qualifier = stmt.SQL[where[1]+cond[2] : where[1]+cond[3]]
+		}
+		want := expected(column)
+		if want == "" || (qualifier != "" && qualifier != table) || insideOr(stmt.SQL[where[1]:], start-where[1]) {
+			continue
+		}
+		position := strings.Count(stmt.SQL[:end], "?") - 1
+		if position < len(stmt.Args) && fmt.Sprint(stmt.Args[position]) == want {
+			return nil
+		}
+	}
+	return fmt.Errorf("query on %s not scoped to wedding %s or user %d: %s %v", table, weddingID, userID, stmt.SQL, stmt.Args)
+}
+
+// insideOr indica se a condição na posição pos participa de um OR (no mesmo grupo de parênteses
+// ou em um grupo que a contém), caso em que ela sozinha não restringe o resultado
+func insideOr(clause string, pos int) bool {
+	groupsAt := func(target int) []int {
+		var stack []int
+		for i := 0; i < target && i < len(clause); i++ {
+			switch clause[i] {
+			case '(':
+				stack = append(stack, i)
+			case ')':
+				if len(stack) > 0 {
+					stack = stack[:len(stack)-1]
+				}
+			}
+		}
+		return stack
+	}
+
+	condition := groupsAt(pos)
+	for _, or := range orRegex.FindAllStringIndex(clause, -1) {
+		groups := groupsAt(or[0])
+		if len(groups) > len(condition) {
+			continue
+		}
+		encloses := true
+		for i := range groups {
+			if groups[i] != condition[i] {
+				encloses = false
+				break
+			}
+		}
+		if encloses {
+			return true
+		}
+	}
+	return false
+}
+
+// TestCrossTenantResourceExamples fixa os recursos pedidos explicitamente (convidados, fornecedores,
+// orçamento e fotos), para que a cobertura não dependa apenas da listagem das rotas
+func TestCrossTenantResourceExamples(t *testing.T) {
+	router := newTestRouter()
+	token := tokenFor(t, intruderID)
+
+	tests := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/api/v1/weddings/%s/guests"},
+		{http.MethodPost, "/api/v1/weddings/%s/guests"},
+		{http.MethodGet, "/api/v1/weddings/%s/guests/1"},
+		{http.MethodPut, "/api/v1/weddings/%s/guests/1"},
+		{http.MethodDelete, "/api/v1/weddings/%s/guests/1"},
+		{http.MethodGet, "/api/v1/weddings/%s/vendors"},
+		{http.MethodPost, "/api/v1/weddings/%s/vendors"},
+		{http.MethodPut, "/api/v1/weddings/%s/vendors/1"},
+		{http.MethodDelete, "/api/v1/weddings/%s/vendors/1"},
+		{http.MethodGet, "/api/v1/weddings/%s/budget"},
+		{http.MethodPut, "/api/v1/weddings/%s/budget"},
+		{http.MethodGet, "/api/v1/weddings/%s/budget/summary"},
+		{http.MethodGet, "/api/v1/weddings/%s/photos"},
+		{http.MethodGet, "/api/v1/weddings/%s/photos/1/file"},
+		{http.MethodDelete, "/api/v1/weddings/%s/photos/1"},
+		{http.MethodGet, "/api/v1/weddings/%s/photo-portal"},
+	}
+
+	for _, tt := range tests {
+		path := strings.Replace(tt.path, "%s", ownerWeddingID, 1)
+		t.Run(tt.method+" "+path, func(t *testing.T) {
+			rec := serve(router, httptest.NewRequest(tt.method, path, strings.NewReader("{}")), token)
+			if rec.Code == http.StatusForbidden {
+				t.Fatalf("got 403: the response must not confirm that the wedding exists")
+			}
+			if rec.Code != http.StatusNotFound {
+				t.Fatalf("status = %d, want 404 (body %s)", rec.Code, rec.Body.String())
+			}
+			if want := `{"error":"wedding not found"}`; rec.Body.String() != want {
+				t.Errorf("body = %s, want %s", rec.Body.String(), want)
+			}
+		})
+	}
+}
+
+// TestOwnWeddingIsAuthorized garante que o 404 acima vem da autorização e não de uma falha geral
+func TestOwnWeddingIsAuthorized(t *testing.T) {
+	router := newTestRouter()
+
+	rec := serve(router, httptest.NewRequest(http.MethodGet, "/api/v1/weddings/"+intruderWeddingID+"/countdown", nil), tokenFor(t, intruderID))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("owner countdown status = %d, want 200 (body %s)", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGetWeddingCrossTenant(t *testing.T) {
+	router := newTestRouter()
+	token := tokenFor(t, intruderID)
+
+	foreign := serve(router, httptest.NewRequest(http.MethodGet, "/api/v1/weddings/"+ownerWeddingID, nil), token)
+	missing := serve(router, httptest.NewRequest(http.MethodGet, "/api/v1/weddings/"+missingWeddingID, nil), token)
+	if foreign.Code != http.StatusNotFound || foreign.Body.String() != missing.Body.String() {
+		t.Fatalf("foreign wedding = %d %s, missing wedding = %d %s", foreign.Code, foreign.Body.String(), missing.Code, missing.Body.String())
+	}
+}
+
+func serve(router *gin.Engine, req *http.Request, token string) *httptest.ResponseRecorder {
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/matheushermes/wedding_planner_service/configs"
 	"github.com/matheushermes/wedding_planner_service/internal/database"
+	"github.com/matheushermes/wedding_planner_service/internal/jobs"
 	"github.com/matheushermes/wedding_planner_service/internal/server/routes"
 )
 
@@ -55,6 +56,19 @@ func (s *Server) RunServer() error {
 		serverErrors <- srv.ListenAndServe()
 	}()
 
+	// SIGHUP recarrega configurações não críticas sem reiniciar
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+	go func() {
+		for range reload {
+			log.Println("🔄 SIGHUP recebido, recarregando configurações...")
+			if _, err := configs.Reload(); err != nil {
+				log.Printf("⚠️  Erro ao recarregar configurações: %v", err)
+			}
+		}
+	}()
+
 	// Canal para sinais de shutdown
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
@@ -80,6 +94,12 @@ func (s *Server) RunServer() error {
 			}
 		}
 
+		// Drena jobs agendados antes de fechar o banco (execuções em andamento ainda usam conexões)
+		log.Println("🔄 Encerrando jobs agendados...")
+		if err := jobs.Shutdown(ctx); err != nil {
+			log.Printf("⚠️  Jobs interrompidos no shutdown: %v", err)
+		}
+
 		// Fecha conexões do banco
 		log.Println("🔄 Fechando conexões com o banco...")
 		if err := database.CloseDatabase(); err != nil {
@@ -0,0 +1,139 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/health"
+)
+
+// ErrInvalidMessage indica destinatário fora do formato E.164 ou mensagem sem texto
+var ErrInvalidMessage = errors.New("invalid sms message")
+
+// Limite das respostas de erro lidas das APIs
+const maxResponseSize = 64 << 10 // 64KB
+
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// Message representa um SMS em texto puro
+type Message struct {
+	To   string // E.164, ex: +5511987654321
+	Text string
+}
+
+// Sender abstrai o envio de SMS, no mesmo formato do mailer.Mailer
+// Send retorna o ID da mensagem no provedor (vazio quando o provedor não informa)
+type Sender interface {
+	Name() string
+	Send(ctx context.Context, msg Message) (string, error)
+}
+
+var (
+	mu      sync.RWMutex
+	current Sender
+)
+
+func init() {
+	// Sender de log: mensagens só registradas, nada a acompanhar
+	health.Register(health.SMS, func() bool { return Default().Name() != "log" })
+}
+
+// Setup registra o provedor de SMS_PROVIDER (re-registrado a cada rotação de credenciais)
+// Deve ser chamado após configs.LoadEnv
+func Setup() {
+	registerConfigured()
+	configs.OnSecretsRotated(registerConfigured)
+}
+
+// registerConfigured registra o provedor com as credenciais atuais
+// Provedor sem credenciais cai no sender de log com aviso, como no mailer
+func registerConfigured() {
+	var s Sender
+	switch configs.SMS_PROVIDER {
+	case "twilio":
+		token := configs.CurrentSecrets().TwilioSMSAuthToken
+		if token == "" {
+			log.Println("[WARN] SMS_PROVIDER=twilio sem TWILIO_SMS_AUTH_TOKEN, SMS serão apenas registrados no log")
+			break
+		}
+		s = &twilioSender{accountSID: configs.TWILIO_SMS_ACCOUNT_SID, authToken: token, from: configs.SMS_FROM}
+	}
+	Register(s)
+}
+
+// SelfCheck confere o remetente e se o provedor de SMS_PROVIDER foi registrado com credenciais
+// Deve ser chamado após Setup
+func SelfCheck(context.Context) error {
+	if configs.SMS_PROVIDER == "log" {
+		return nil
+	}
+	if !validNumber(configs.SMS_FROM) {
+		return fmt.Errorf("SMS_FROM inválido: use o formato E.164")
+	}
+	if Default().Name() != configs.SMS_PROVIDER {
+		return fmt.Errorf("SMS_PROVIDER=%s não está configurado (verifique as credenciais do provedor)", configs.SMS_PROVIDER)
+	}
+	return nil
+}
+
+// Register define o sender usado nos envios (nil volta para o sender de log)
+func Register(s Sender) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = s
+}
+
+// Default retorna o sender configurado
+// Sem provedor configurado, as mensagens são apenas registradas no log
+func Default() Sender {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil {
+		return logSender{}
+	}
+	return current
+}
+
+// logSender registra as mensagens no log sem enviá-las (desenvolvimento)
+type logSender struct{}
+
+func (logSender) Name() string {
+	return "log"
+}
+
+// Send registra apenas o destinatário mascarado
+// LGPD: o texto do convite não é registrado
+func (logSender) Send(_ context.Context, msg Message) (string, error) {
+	if err := validate(msg); err != nil {
+		return "", err
+	}
+	log.Printf("[INFO] SMS not sent (log sender) to %s", maskNumber(msg.To))
+	return "", nil
+}
+
+// validate confere o destinatário e o texto antes de chamar o provedor
+func validate(msg Message) error {
+	if !validNumber(msg.To) || strings.TrimSpace(msg.Text) == "" {
+		return ErrInvalidMessage
+	}
+	return nil
+}
+
+func validNumber(number string) bool {
+	return strings.HasPrefix(number, "+") && len(number) >= 9 && strings.Trim(number[1:], "0123456789") == ""
+}
+
+// maskNumber mantém apenas os 4 últimos dígitos (+*******4321)
+func maskNumber(number string) string {
+	if len(number) <= 4 {
+		return "***"
+	}
+	return "+***" + number[len(number)-4:]
+}
@@ -0,0 +1,65 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/matheushermes/wedding_planner_service/internal/health"
+)
+
+const twilioBaseURL = "https://api.twilio.com/2010-04-01/Accounts/"
+
+// twilioSender envia pela API de mensagens da Twilio (API REST, sem SDK)
+// Conta do serviço: diferente do WhatsApp, o SMS não depende de uma conta por casamento
+type twilioSender struct {
+	accountSID string
+	authToken  string
+	from       string
+}
+
+func (s *twilioSender) Name() string {
+	return "twilio"
+}
+
+func (s *twilioSender) Send(ctx context.Context, msg Message) (string, error) {
+	if err := validate(msg); err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("From", s.from)
+	form.Set("To", msg.To)
+	form.Set("Body", msg.Text)
+
+	endpoint := twilioBaseURL + url.PathEscape(s.accountSID) + "/Messages.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		health.Record(health.SMS, err)
+		return "", fmt.Errorf("erro ao chamar twilio: %w", err)
+	}
+	defer resp.Body.Close()
+	health.RecordHTTP(health.SMS, resp.StatusCode, nil)
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+		return "", fmt.Errorf("twilio retornou %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	var result struct {
+		SID string `json:"sid"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&result)
+	return result.SID, nil
+}
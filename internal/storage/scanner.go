@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// Tamanho de cada chunk enviado ao clamd no protocolo INSTREAM
+const clamdChunkSize = 32 << 10 // 32KB
+
+// scan envia o conteúdo ao clamd (ClamAV) via protocolo INSTREAM
+// Retorna ErrInfectedFile quando o antivírus encontra uma assinatura
+func scan(addr string, r io.Reader) error {
+	conn, err := net.DialTimeout("tcp", addr, 5*time.Second)
+	if err != nil {
+		return fmt.Errorf("antivírus indisponível: %w", err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(30 * time.Second)); err != nil {
+		return err
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return fmt.Errorf("erro ao iniciar scan: %w", err)
+	}
+
+	buf := make([]byte, clamdChunkSize)
+	size := make([]byte, 4)
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return fmt.Errorf("erro ao enviar arquivo para scan: %w", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return fmt.Errorf("erro ao enviar arquivo para scan: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	// Chunk de tamanho zero finaliza o stream
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return fmt.Errorf("erro ao finalizar scan: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\x00')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("erro ao ler resposta do antivírus: %w", err)
+	}
+
+	// Resposta esperada: "stream: OK" ou "stream: <assinatura> FOUND"
+	reply = strings.TrimRight(reply, "\x00")
+	if strings.HasSuffix(reply, "FOUND") {
+		return ErrInfectedFile
+	}
+	if !strings.HasSuffix(reply, "OK") {
+		return fmt.Errorf("resposta inesperada do antivírus: %s", reply)
+	}
+
+	return nil
+}
+
+// ping verifica se o clamd está respondendo (comando PING/PONG)
+func ping(addr string) error {
+	conn, err := net.DialTimeout("tcp", addr, 5*time.Second)
+	if err != nil {
+		return fmt.Errorf("antivírus indisponível: %w", err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		return err
+	}
+
+	if _, err := conn.Write([]byte("zPING\x00")); err != nil {
+		return fmt.Errorf("erro ao enviar ping ao antivírus: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\x00')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("erro ao ler resposta do antivírus: %w", err)
+	}
+	if strings.TrimRight(reply, "\x00") != "PONG" {
+		return fmt.Errorf("resposta inesperada do antivírus: %s", reply)
+	}
+	return nil
+}
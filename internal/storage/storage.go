@@ -0,0 +1,269 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/matheushermes/wedding_planner_service/configs"
+	"github.com/matheushermes/wedding_planner_service/internal/health"
+)
+
+// Kind representa a categoria de um upload (define limites e tipos aceitos)
+type Kind string
+
+const (
+	KindReceipt  Kind = "receipts"
+	KindPhoto    Kind = "photos"
+	KindContract Kind = "contracts"
+
+	// Fotos enviadas pelos convidados no portal pós-evento
+	KindGuestPhoto Kind = "guest-photos"
+
+	// Arquivos gerados pelo servidor (ex: ZIP de fotos), nunca recebidos por upload
+	KindArchive Kind = "archives"
+
+	// Exportações geradas pelos jobs assíncronos (CSV, OFX, PDF), removidas após a expiração
+	KindExport Kind = "exports"
+)
+
+// Erros customizados para melhor tratamento
+var (
+	ErrUnknownKind       = errors.New("unknown upload kind")
+	ErrFileTooLarge      = errors.New("file exceeds the maximum allowed size")
+	ErrEmptyFile         = errors.New("file is empty")
+	ErrContentNotAllowed = errors.New("file content type is not allowed")
+	ErrInfectedFile      = errors.New("file was rejected by the antivirus scan")
+)
+
+func init() {
+	health.Register(health.Storage, nil)
+}
+
+// policy define o tamanho máximo e os content types aceitos por categoria
+type policy struct {
+	maxSize      int64
+	allowedTypes map[string]string // content type detectado -> extensão
+}
+
+var policies = map[Kind]policy{
+	KindReceipt: {
+		maxSize: 5 << 20, // 5MB
+		allowedTypes: map[string]string{
+			"image/jpeg":      ".jpg",
+			"image/png":       ".png",
+			"application/pdf": ".pdf",
+		},
+	},
+	KindPhoto: {
+		maxSize: 10 << 20, // 10MB
+		allowedTypes: map[string]string{
+			"image/jpeg": ".jpg",
+			"image/png":  ".png",
+			"image/webp": ".webp",
+		},
+	},
+	KindContract: {
+		maxSize: 10 << 20, // 10MB
+		allowedTypes: map[string]string{
+			"application/pdf": ".pdf",
+		},
+	},
+	KindGuestPhoto: {
+		maxSize: 10 << 20, // 10MB
+		allowedTypes: map[string]string{
+			"image/jpeg": ".jpg",
+			"image/png":  ".png",
+			"image/webp": ".webp",
+		},
+	},
+	KindArchive: {
+		allowedTypes: map[string]string{
+			"application/zip": ".zip",
+		},
+	},
+	KindExport: {
+		allowedTypes: map[string]string{
+			"text/csv":          ".csv",
+			"application/x-ofx": ".ofx",
+			"application/pdf":   ".pdf",
+		},
+	},
+}
+
+// StoredFile representa um arquivo salvo com sucesso
+type StoredFile struct {
+	Name        string `json:"name"` // nome aleatório gerado pelo servidor
+	Path        string `json:"-"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+// MaxSize retorna o tamanho máximo aceito para a categoria
+func MaxSize(kind Kind) int64 {
+	return policies[kind].maxSize
+}
+
+// Save valida e persiste um upload
+// Segurança: content type é detectado pelos magic bytes (nunca pela extensão ou header do cliente)
+// e o arquivo é salvo com nome aleatório, impedindo path traversal e content spoofing
+func Save(kind Kind, r io.Reader) (*StoredFile, error) {
+	p, ok := policies[kind]
+	if !ok {
+		return nil, ErrUnknownKind
+	}
+
+	// Lê no máximo maxSize+1 bytes para detectar arquivos acima do limite sem carregar tudo
+	data, err := io.ReadAll(io.LimitReader(r, p.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler upload: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, ErrEmptyFile
+	}
+	if int64(len(data)) > p.maxSize {
+		return nil, ErrFileTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := p.allowedTypes[contentType]
+	if !ok {
+		return nil, ErrContentNotAllowed
+	}
+
+	// Scan de antivírus opcional (habilitado via CLAMAV_ADDR)
+	if addr := configs.Runtime().ClamAVAddr; addr != "" {
+		if err := scan(addr, bytes.NewReader(data)); err != nil {
+			return nil, err
+		}
+	}
+
+	name, err := randomName(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(configs.UPLOAD_DIR, string(kind))
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		health.Record(health.Storage, err)
+		return nil, fmt.Errorf("erro ao criar diretório de upload: %w", err)
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o640); err != nil {
+		health.Record(health.Storage, err)
+		return nil, fmt.Errorf("erro ao salvar upload: %w", err)
+	}
+	health.Record(health.Storage, nil)
+
+	return &StoredFile{
+		Name:        name,
+		Path:        path,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+	}, nil
+}
+
+// Create cria um arquivo gerado pelo servidor com nome aleatório
+// O chamador escreve o conteúdo e fecha o arquivo; em caso de falha deve chamar Remove
+func Create(kind Kind, ext string) (*os.File, string, error) {
+	p, ok := policies[kind]
+	if !ok {
+		return nil, "", ErrUnknownKind
+	}
+	if !hasExtension(p, ext) {
+		return nil, "", ErrContentNotAllowed
+	}
+
+	name, err := randomName(ext)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dir := filepath.Join(configs.UPLOAD_DIR, string(kind))
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		health.Record(health.Storage, err)
+		return nil, "", fmt.Errorf("erro ao criar diretório de upload: %w", err)
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		health.Record(health.Storage, err)
+		return nil, "", fmt.Errorf("erro ao criar arquivo: %w", err)
+	}
+	health.Record(health.Storage, nil)
+	return f, name, nil
+}
+
+// Open abre um arquivo salvo previamente
+// Segurança: filepath.Base descarta qualquer componente de diretório vindo do cliente
+func Open(kind Kind, name string) (*os.File, error) {
+	if _, ok := policies[kind]; !ok {
+		return nil, ErrUnknownKind
+	}
+	return os.Open(filepath.Join(configs.UPLOAD_DIR, string(kind), filepath.Base(name)))
+}
+
+// Remove apaga um arquivo salvo previamente
+func Remove(kind Kind, name string) error {
+	if _, ok := policies[kind]; !ok {
+		return ErrUnknownKind
+	}
+	err := os.Remove(filepath.Join(configs.UPLOAD_DIR, string(kind), filepath.Base(name)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// SelfCheck verifica no boot se o diretório de uploads é gravável e o antivírus responde
+// Falha cedo em vez de no primeiro upload
+func SelfCheck() error {
+	for kind := range policies {
+		dir := filepath.Join(configs.UPLOAD_DIR, string(kind))
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return fmt.Errorf("UPLOAD_DIR: não foi possível criar %s: %w", dir, err)
+		}
+
+		f, err := os.CreateTemp(dir, ".selfcheck-*")
+		if err != nil {
+			return fmt.Errorf("UPLOAD_DIR: %s não é gravável: %w", dir, err)
+		}
+		f.Close()
+		if err := os.Remove(f.Name()); err != nil {
+			return fmt.Errorf("UPLOAD_DIR: não foi possível remover arquivos em %s: %w", dir, err)
+		}
+	}
+
+	if addr := configs.Runtime().ClamAVAddr; addr != "" {
+		if err := ping(addr); err != nil {
+			return fmt.Errorf("CLAMAV_ADDR=%s: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+// hasExtension indica se a extensão pertence a algum dos tipos aceitos pela categoria
+func hasExtension(p policy, ext string) bool {
+	for _, allowed := range p.allowedTypes {
+		if allowed == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// randomName gera um nome de arquivo aleatório com a extensão do tipo detectado
+func randomName(ext string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("erro ao gerar nome de arquivo: %w", err)
+	}
+	return hex.EncodeToString(b) + ext, nil
+}
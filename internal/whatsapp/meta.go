@@ -0,0 +1,94 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/matheushermes/wedding_planner_service/internal/health"
+)
+
+const metaGraphURL = "https://graph.facebook.com/v21.0/"
+
+// metaSender envia pela WhatsApp Cloud API da Meta (API REST, sem SDK)
+type metaSender struct {
+	phoneNumberID string
+	accessToken   string
+}
+
+func (s *metaSender) Name() string {
+	return "meta"
+}
+
+type metaParameter struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func (s *metaSender) Send(ctx context.Context, msg Message) (string, error) {
+	if err := validate(msg); err != nil {
+		return "", err
+	}
+
+	payload := map[string]any{
+		"messaging_product": "whatsapp",
+		"to":                msg.To,
+	}
+	if msg.Template != "" {
+		template := map[string]any{
+			"name":     msg.Template,
+			"language": map[string]string{"code": msg.Language},
+		}
+		if len(msg.Params) > 0 {
+			parameters := make([]metaParameter, len(msg.Params))
+			for i, param := range msg.Params {
+				parameters[i] = metaParameter{Type: "text", Text: param}
+			}
+			template["components"] = []map[string]any{{"type": "body", "parameters": parameters}}
+		}
+		payload["type"] = "template"
+		payload["template"] = template
+	} else {
+		payload["type"] = "text"
+		payload["text"] = map[string]string{"body": msg.Text}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := metaGraphURL + url.PathEscape(s.phoneNumberID) + "/messages"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		health.Record(health.WhatsApp, err)
+		return "", fmt.Errorf("erro ao chamar whatsapp cloud api: %w", err)
+	}
+	defer resp.Body.Close()
+	health.RecordHTTP(health.WhatsApp, resp.StatusCode, nil)
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+		return "", fmt.Errorf("whatsapp cloud api retornou %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	var result struct {
+		Messages []struct {
+			ID string `json:"id"`
+		} `json:"messages"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&result)
+	if len(result.Messages) == 0 {
+		return "", nil
+	}
+	return result.Messages[0].ID, nil
+}
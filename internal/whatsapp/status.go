@@ -0,0 +1,96 @@
+package whatsapp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+)
+
+var (
+	// ErrInvalidWebhook indica callback de status com assinatura ausente ou inválida
+	ErrInvalidWebhook = errors.New("invalid whatsapp status callback")
+	// ErrIgnoredStatus indica status intermediário (queued, sending...) que não muda a entrega
+	ErrIgnoredStatus = errors.New("ignored whatsapp status")
+)
+
+// Limite do corpo do callback de status (proteção contra DoS)
+const maxCallbackBodySize = 64 << 10 // 64KB
+
+// Erro da Twilio para destinatário sem conta de WhatsApp ("Channel could not find To address")
+const twilioErrorNoWhatsApp = "63003"
+
+// StatusEvent representa a entrega de uma mensagem informada pelo provedor
+type StatusEvent struct {
+	MessageID string
+	Status    models.InviteDeliveryStatus
+	Reason    string
+	// RecipientUnavailable indica que o número não tem WhatsApp (a mensagem nunca vai chegar por este canal)
+	RecipientUnavailable bool
+}
+
+// ParseTwilioStatus valida o X-Twilio-Signature do StatusCallback e normaliza o status
+// callbackURL deve ser exatamente a URL enviada em Message.StatusCallback
+// Segurança: HMAC-SHA1 com o auth token da conta sobre a URL e os parâmetros do POST em ordem alfabética
+func ParseTwilioStatus(r *http.Request, authToken, callbackURL string) (*StatusEvent, error) {
+	r.Body = io.NopCloser(io.LimitReader(r.Body, maxCallbackBodySize))
+	if err := r.ParseForm(); err != nil {
+		return nil, ErrInvalidWebhook
+	}
+
+	if !validTwilioSignature(authToken, callbackURL, r.PostForm, r.Header.Get("X-Twilio-Signature")) {
+		return nil, ErrInvalidWebhook
+	}
+
+	event := &StatusEvent{MessageID: r.PostForm.Get("MessageSid")}
+	switch r.PostForm.Get("MessageStatus") {
+	case "sent":
+		event.Status = models.InviteDeliverySent
+	case "delivered", "read":
+		event.Status = models.InviteDeliveryDelivered
+	case "undelivered":
+		event.Status = models.InviteDeliveryBounced
+	case "failed":
+		event.Status = models.InviteDeliveryFailed
+	default:
+		return nil, ErrIgnoredStatus
+	}
+	if code := r.PostForm.Get("ErrorCode"); code != "" {
+		event.Reason = "twilio error " + code
+		event.RecipientUnavailable = code == twilioErrorNoWhatsApp && event.Status != models.InviteDeliveryDelivered
+	}
+	return event, nil
+}
+
+// validTwilioSignature confere a assinatura conforme a documentação da Twilio
+func validTwilioSignature(authToken, callbackURL string, form map[string][]string, signature string) bool {
+	if authToken == "" || signature == "" {
+		return false
+	}
+
+	keys := make([]string, 0, len(form))
+	for key := range form {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var data strings.Builder
+	data.WriteString(callbackURL)
+	for _, key := range keys {
+		for _, value := range form[key] {
+			data.WriteString(key)
+			data.WriteString(value)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(data.String()))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
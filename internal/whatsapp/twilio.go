@@ -0,0 +1,82 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/matheushermes/wedding_planner_service/internal/health"
+)
+
+const twilioBaseURL = "https://api.twilio.com/2010-04-01/Accounts/"
+
+// twilioSender envia pela API de mensagens da Twilio (API REST, sem SDK)
+type twilioSender struct {
+	accountSID string
+	authToken  string
+	from       string
+}
+
+func (s *twilioSender) Name() string {
+	return "twilio"
+}
+
+func (s *twilioSender) Send(ctx context.Context, msg Message) (string, error) {
+	if err := validate(msg); err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("From", "whatsapp:"+s.from)
+	form.Set("To", "whatsapp:"+msg.To)
+	if msg.Template != "" {
+		// Content API: variáveis numeradas a partir de 1, como no template aprovado
+		variables := make(map[string]string, len(msg.Params))
+		for i, param := range msg.Params {
+			variables[strconv.Itoa(i+1)] = param
+		}
+		encoded, err := json.Marshal(variables)
+		if err != nil {
+			return "", err
+		}
+		form.Set("ContentSid", msg.Template)
+		form.Set("ContentVariables", string(encoded))
+	} else {
+		form.Set("Body", msg.Text)
+	}
+	if msg.StatusCallback != "" {
+		form.Set("StatusCallback", msg.StatusCallback)
+	}
+
+	endpoint := twilioBaseURL + url.PathEscape(s.accountSID) + "/Messages.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		health.Record(health.WhatsApp, err)
+		return "", fmt.Errorf("erro ao chamar twilio: %w", err)
+	}
+	defer resp.Body.Close()
+	health.RecordHTTP(health.WhatsApp, resp.StatusCode, nil)
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+		return "", fmt.Errorf("twilio retornou %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	var result struct {
+		SID string `json:"sid"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&result)
+	return result.SID, nil
+}
@@ -0,0 +1,73 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/matheushermes/wedding_planner_service/internal/health"
+	"github.com/matheushermes/wedding_planner_service/internal/models"
+)
+
+var (
+	// ErrInvalidMessage indica destinatário fora do formato E.164 ou mensagem sem conteúdo
+	ErrInvalidMessage = errors.New("invalid whatsapp message")
+	// ErrUnsupportedProvider indica conta com provedor desconhecido
+	ErrUnsupportedProvider = errors.New("unsupported whatsapp provider")
+)
+
+// Limite das respostas de erro lidas das APIs
+const maxResponseSize = 64 << 10 // 64KB
+
+// Cliente compartilhado entre os envios: as credenciais vão em cada requisição
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+func init() {
+	// Contas por casamento: a integração conta como configurada e o estado vem dos envios
+	health.Register(health.WhatsApp, nil)
+}
+
+// Message representa uma mensagem de WhatsApp para um convidado
+// Com Template, Params preenchem as variáveis do template aprovado e Text é ignorado;
+// sem Template, Text vai como mensagem livre (aceita apenas dentro da janela de 24h da conversa)
+type Message struct {
+	To       string // E.164, ex: +5511987654321
+	Template string
+	Language string
+	Params   []string
+	Text     string
+	// StatusCallback recebe as atualizações de entrega (Twilio); a Meta usa o webhook do app e ignora
+	StatusCallback string
+}
+
+// Sender abstrai o envio por WhatsApp, no mesmo formato do mailer.Mailer
+// Send retorna o ID da mensagem no provedor (SID da Twilio, wamid da Meta)
+type Sender interface {
+	Name() string
+	Send(ctx context.Context, msg Message) (string, error)
+}
+
+// New cria o sender com as credenciais da conta do casamento
+// Concorrência: sem estado compartilhado além do http.Client, um sender por envio é barato
+func New(account *models.WhatsAppAccount) (Sender, error) {
+	switch account.Provider {
+	case models.WhatsAppProviderTwilio:
+		return &twilioSender{accountSID: account.AccountID, authToken: account.Token, from: account.FromNumber}, nil
+	case models.WhatsAppProviderMeta:
+		return &metaSender{phoneNumberID: account.AccountID, accessToken: account.Token}, nil
+	}
+	return nil, ErrUnsupportedProvider
+}
+
+// validate confere o destinatário e o conteúdo antes de chamar o provedor
+func validate(msg Message) error {
+	if !strings.HasPrefix(msg.To, "+") || len(msg.To) < 9 || strings.Trim(msg.To[1:], "0123456789") != "" {
+		return ErrInvalidMessage
+	}
+	if msg.Template == "" && strings.TrimSpace(msg.Text) == "" {
+		return ErrInvalidMessage
+	}
+	return nil
+}